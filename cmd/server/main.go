@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// Embed the IANA zone database so @timezone works in minimal images.
+	_ "time/tzdata"
 
 	"tidb-graphql/internal/config"
 	"tidb-graphql/internal/serverapp"
@@ -0,0 +1,272 @@
+// Package apikey loads hashed API keys from a file and resolves presented keys
+// to their configured role, scopes and rate limits.
+package apikey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// HashPrefix marks the digest algorithm of a stored key hash.
+const HashPrefix = "sha256:"
+
+// Operation types a key can be scoped to.
+const (
+	OperationQuery    = "query"
+	OperationMutation = "mutation"
+)
+
+// Key is one configured API key. Only the hash of the secret is kept.
+type Key struct {
+	// ID names the key in logs, metrics and traces. It is not secret.
+	ID string `mapstructure:"id"`
+	// Hash is "sha256:" followed by the hex SHA-256 digest of the key.
+	Hash string `mapstructure:"hash"`
+	// Role is the database role requests made with this key run as.
+	Role string `mapstructure:"role"`
+	// Operations lists the allowed operation types (query, mutation). Empty allows all.
+	Operations []string `mapstructure:"operations"`
+	// Namespaces lists the database namespaces the key may access. Empty allows all.
+	Namespaces []string `mapstructure:"namespaces"`
+	// RateLimitRPS and RateLimitBurst configure a per-key token bucket. Zero disables it.
+	RateLimitRPS   float64 `mapstructure:"rate_limit_rps"`
+	RateLimitBurst int     `mapstructure:"rate_limit_burst"`
+	// Disabled keys are rejected without being removed from the file.
+	Disabled bool `mapstructure:"disabled"`
+}
+
+// AllowsOperation reports whether the key may run the given operation type.
+func (k *Key) AllowsOperation(operationType string) bool {
+	return len(k.Operations) == 0 || containsFold(k.Operations, operationType)
+}
+
+// AllowsNamespace reports whether the key may access the given namespace.
+func (k *Key) AllowsNamespace(namespace string) bool {
+	return len(k.Namespaces) == 0 || containsFold(k.Namespaces, namespace)
+}
+
+// RateLimited reports whether the key has its own rate limit.
+func (k *Key) RateLimited() bool {
+	return k.RateLimitRPS > 0 && k.RateLimitBurst > 0
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hash returns the stored form of a raw key.
+func Hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return HashPrefix + hex.EncodeToString(sum[:])
+}
+
+type keyFile struct {
+	Keys []Key `mapstructure:"keys"`
+}
+
+// Store holds the keys from a key file and reloads them when the file changes.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+
+	keys atomic.Pointer[map[[sha256.Size]byte]*Key]
+	// generation counts the key sets swapped in, so callers holding per-key
+	// state can tell when to prune it.
+	generation atomic.Uint64
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+	started  atomic.Bool
+}
+
+// Load reads a key file. YAML and JSON are supported, chosen by extension.
+func Load(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Lookup resolves a presented key. Unknown and disabled keys are not found.
+func (s *Store) Lookup(raw string) (*Key, bool) {
+	keys := s.keys.Load()
+	if keys == nil || raw == "" {
+		return nil, false
+	}
+	key, ok := (*keys)[sha256.Sum256([]byte(raw))]
+	if !ok || key.Disabled {
+		return nil, false
+	}
+	return key, true
+}
+
+// Len returns the number of loaded keys, including disabled ones.
+func (s *Store) Len() int {
+	if keys := s.keys.Load(); keys != nil {
+		return len(*keys)
+	}
+	return 0
+}
+
+// Generation increases every time a new key set is swapped in.
+func (s *Store) Generation() uint64 {
+	return s.generation.Load()
+}
+
+// ActiveIDs returns the IDs of the loaded keys that are not disabled.
+func (s *Store) ActiveIDs() map[string]struct{} {
+	ids := make(map[string]struct{})
+	if keys := s.keys.Load(); keys != nil {
+		for _, key := range *keys {
+			if !key.Disabled {
+				ids[key.ID] = struct{}{}
+			}
+		}
+	}
+	return ids
+}
+
+// Reload re-reads the key file if it changed since the last load. It reports
+// whether new keys were swapped in; on error the previous keys stay active.
+func (s *Store) Reload() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat API key file: %w", err)
+	}
+	if s.keys.Load() != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return false, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(s.path)
+	if err := v.ReadInConfig(); err != nil {
+		return false, fmt.Errorf("failed to read API key file: %w", err)
+	}
+	var file keyFile
+	if err := v.Unmarshal(&file); err != nil {
+		return false, fmt.Errorf("failed to parse API key file: %w", err)
+	}
+	keys, err := indexKeys(file.Keys)
+	if err != nil {
+		return false, fmt.Errorf("invalid API key file %s: %w", s.path, err)
+	}
+
+	s.keys.Store(&keys)
+	s.generation.Add(1)
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return true, nil
+}
+
+func indexKeys(entries []Key) (map[[sha256.Size]byte]*Key, error) {
+	keys := make(map[[sha256.Size]byte]*Key, len(entries))
+	ids := make(map[string]struct{}, len(entries))
+	for i := range entries {
+		key := entries[i]
+		if strings.TrimSpace(key.ID) == "" {
+			return nil, fmt.Errorf("keys[%d]: id is required", i)
+		}
+		if _, dup := ids[key.ID]; dup {
+			return nil, fmt.Errorf("keys[%d]: duplicate id %q", i, key.ID)
+		}
+		ids[key.ID] = struct{}{}
+
+		digest, err := parseHash(key.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		if _, dup := keys[digest]; dup {
+			return nil, fmt.Errorf("key %q: hash is already used by another key", key.ID)
+		}
+		for _, op := range key.Operations {
+			if !strings.EqualFold(op, OperationQuery) && !strings.EqualFold(op, OperationMutation) {
+				return nil, fmt.Errorf("key %q: unsupported operation %q (use query or mutation)", key.ID, op)
+			}
+		}
+		if key.RateLimitRPS < 0 || key.RateLimitBurst < 0 {
+			return nil, fmt.Errorf("key %q: rate limits must not be negative", key.ID)
+		}
+		if (key.RateLimitRPS > 0) != (key.RateLimitBurst > 0) {
+			return nil, fmt.Errorf("key %q: rate_limit_rps and rate_limit_burst must be set together", key.ID)
+		}
+		keys[digest] = &key
+	}
+	return keys, nil
+}
+
+func parseHash(value string) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, HashPrefix) {
+		return digest, errors.New(`hash must start with "sha256:"`)
+	}
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, HashPrefix))
+	if err != nil || len(decoded) != sha256.Size {
+		return digest, errors.New("hash must be a hex-encoded SHA-256 digest")
+	}
+	copy(digest[:], decoded)
+	return digest, nil
+}
+
+// Start polls the key file for changes. A non-positive interval disables reloading.
+func (s *Store) Start(interval time.Duration, logger *slog.Logger) {
+	if interval <= 0 || !s.started.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				changed, err := s.Reload()
+				if logger == nil {
+					continue
+				}
+				if err != nil {
+					logger.Error("API key reload failed; keeping previous keys", slog.String("error", err.Error()))
+				} else if changed {
+					logger.Info("API keys reloaded", slog.Int("keys", s.Len()))
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends polling. It is safe to call multiple times and without Start.
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	if s.started.Load() {
+		<-s.done
+	}
+}
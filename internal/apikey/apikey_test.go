@@ -0,0 +1,115 @@
+package apikey
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeKeyFile(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+}
+
+func TestStoreLookupAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeyFile(t, path, `
+keys:
+  - id: billing-sync
+    hash: `+Hash("secret-one")+`
+    role: app_billing
+    operations: [query]
+    namespaces: [sales]
+    rate_limit_rps: 5
+    rate_limit_burst: 10
+  - id: retired
+    hash: `+Hash("secret-old")+`
+    disabled: true
+`, time.Now().Add(-time.Hour))
+
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	key, ok := store.Lookup("secret-one")
+	if !ok {
+		t.Fatal("expected key to be found")
+	}
+	if key.ID != "billing-sync" || key.Role != "app_billing" || !key.RateLimited() {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+	if !key.AllowsOperation("query") || key.AllowsOperation("mutation") {
+		t.Fatalf("unexpected operation scope: %v", key.Operations)
+	}
+	if !key.AllowsNamespace("Sales") || key.AllowsNamespace("hr") {
+		t.Fatalf("unexpected namespace scope: %v", key.Namespaces)
+	}
+	if _, ok := store.Lookup("secret-old"); ok {
+		t.Fatal("disabled key must not be found")
+	}
+	if _, ok := store.Lookup("wrong"); ok {
+		t.Fatal("unknown key must not be found")
+	}
+
+	if changed, err := store.Reload(); err != nil || changed {
+		t.Fatalf("Reload() = %v, %v; want unchanged", changed, err)
+	}
+
+	writeKeyFile(t, path, `
+keys:
+  - id: billing-sync
+    hash: `+Hash("secret-two")+`
+    role: app_billing
+`, time.Now())
+	if changed, err := store.Reload(); err != nil || !changed {
+		t.Fatalf("Reload() = %v, %v; want changed", changed, err)
+	}
+	if _, ok := store.Lookup("secret-one"); ok {
+		t.Fatal("rotated-out key must not be found")
+	}
+	if key, ok := store.Lookup("secret-two"); !ok || !key.AllowsOperation("mutation") {
+		t.Fatal("expected rotated key with all operations allowed")
+	}
+
+	writeKeyFile(t, path, "keys:\n  - id: broken\n    hash: plain\n", time.Now().Add(time.Minute))
+	if _, err := store.Reload(); err == nil {
+		t.Fatal("expected invalid file to fail reload")
+	}
+	if _, ok := store.Lookup("secret-two"); !ok {
+		t.Fatal("a failed reload must keep the previous keys")
+	}
+}
+
+func TestLoadRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    string
+		wantErr string
+	}{
+		{name: "missing id", keys: "  - hash: " + Hash("a"), wantErr: "id is required"},
+		{name: "unprefixed hash", keys: "  - id: a\n    hash: abc", wantErr: `start with "sha256:"`},
+		{name: "short digest", keys: "  - id: a\n    hash: sha256:abcd", wantErr: "SHA-256 digest"},
+		{name: "duplicate id", keys: "  - id: a\n    hash: " + Hash("a") + "\n  - id: a\n    hash: " + Hash("b"), wantErr: "duplicate id"},
+		{name: "duplicate hash", keys: "  - id: a\n    hash: " + Hash("a") + "\n  - id: b\n    hash: " + Hash("a"), wantErr: "already used"},
+		{name: "unknown operation", keys: "  - id: a\n    hash: " + Hash("a") + "\n    operations: [subscription]", wantErr: "unsupported operation"},
+		{name: "rps without burst", keys: "  - id: a\n    hash: " + Hash("a") + "\n    rate_limit_rps: 2", wantErr: "set together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keys.yaml")
+			writeKeyFile(t, path, "keys:\n"+tt.keys+"\n", time.Now())
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"math"
 	"time"
 
+	"tidb-graphql/internal/scalars"
+
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/language/ast"
 )
@@ -37,12 +39,13 @@ func (s Spec) SessionValue() string {
 }
 
 // ResolveFieldDirective resolves the @asOf directive on a field when present.
-func ResolveFieldDirective(field *ast.Field, variables map[string]any, now time.Time) (*Spec, error) {
+// Times without a UTC offset are read in loc.
+func ResolveFieldDirective(field *ast.Field, variables map[string]any, now time.Time, loc *time.Location) (*Spec, error) {
 	directive := FindFieldDirective(field)
 	if directive == nil {
 		return nil, nil
 	}
-	return ResolveDirective(directive, variables, now)
+	return ResolveDirective(directive, variables, now, loc)
 }
 
 // FindFieldDirective returns the @asOf directive on the field when present.
@@ -59,7 +62,11 @@ func FindFieldDirective(field *ast.Field) *ast.Directive {
 }
 
 // ResolveDirective parses and validates an @asOf directive into an exact snapshot.
-func ResolveDirective(directive *ast.Directive, variables map[string]any, now time.Time) (*Spec, error) {
+// The time argument is parsed like the DateTime scalar, so a time without a UTC
+// offset is read in loc. A nil loc means the zone is not known yet: such a time
+// is only checked for its format, and the future check is left to the caller
+// that resolves it again with the request zone.
+func ResolveDirective(directive *ast.Directive, variables map[string]any, now time.Time, loc *time.Location) (*Spec, error) {
 	if directive == nil {
 		return nil, nil
 	}
@@ -83,8 +90,16 @@ func ResolveDirective(directive *ast.Directive, variables map[string]any, now ti
 		if !ok {
 			return nil, fmt.Errorf("@asOf time must be a valid DateTime")
 		}
-		parsed, ok := graphql.DateTime.ParseValue(resolved).(time.Time)
-		if !ok {
+		var parsed time.Time
+		switch v := scalars.DateTime().ParseValue(resolved).(type) {
+		case time.Time:
+			parsed = v
+		case scalars.LocalDateTime:
+			if loc == nil {
+				return &Spec{Time: v.Wall}, nil
+			}
+			parsed = v.In(loc)
+		default:
 			return nil, fmt.Errorf("@asOf time must be a valid DateTime")
 		}
 		if parsed.After(now) {
@@ -112,8 +127,9 @@ func ResolveDirective(directive *ast.Directive, variables map[string]any, now ti
 	return &Spec{Time: snapshot}, nil
 }
 
-// ValidateOperation validates @asOf usage for the selected operation.
-func ValidateOperation(op *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition, variables map[string]any, now time.Time) error {
+// ValidateOperation validates @asOf usage for the selected operation, reading
+// times without a UTC offset in loc (see ResolveDirective for a nil loc).
+func ValidateOperation(op *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition, variables map[string]any, now time.Time, loc *time.Location) error {
 	if op == nil {
 		return nil
 	}
@@ -121,10 +137,10 @@ func ValidateOperation(op *ast.OperationDefinition, fragments map[string]*ast.Fr
 		fragments = map[string]*ast.FragmentDefinition{}
 	}
 	visited := map[string]bool{}
-	return validateSelectionSet(op.GetSelectionSet(), string(op.Operation), rootFieldDepth, fragments, visited, variables, now)
+	return validateSelectionSet(op.GetSelectionSet(), string(op.Operation), rootFieldDepth, fragments, visited, variables, now, loc)
 }
 
-func validateSelectionSet(selectionSet *ast.SelectionSet, operationType string, depth int, fragments map[string]*ast.FragmentDefinition, visited map[string]bool, variables map[string]any, now time.Time) error {
+func validateSelectionSet(selectionSet *ast.SelectionSet, operationType string, depth int, fragments map[string]*ast.FragmentDefinition, visited map[string]bool, variables map[string]any, now time.Time, loc *time.Location) error {
 	if selectionSet == nil {
 		return nil
 	}
@@ -136,15 +152,15 @@ func validateSelectionSet(selectionSet *ast.SelectionSet, operationType string,
 				if operationType != "query" || depth != rootFieldDepth {
 					return fmt.Errorf("@asOf is only allowed on root query fields")
 				}
-				if _, err := ResolveDirective(directive, variables, now); err != nil {
+				if _, err := ResolveDirective(directive, variables, now, loc); err != nil {
 					return err
 				}
 			}
-			if err := validateSelectionSet(sel.SelectionSet, operationType, depth+1, fragments, visited, variables, now); err != nil {
+			if err := validateSelectionSet(sel.SelectionSet, operationType, depth+1, fragments, visited, variables, now, loc); err != nil {
 				return err
 			}
 		case *ast.InlineFragment:
-			if err := validateSelectionSet(sel.SelectionSet, operationType, depth, fragments, visited, variables, now); err != nil {
+			if err := validateSelectionSet(sel.SelectionSet, operationType, depth, fragments, visited, variables, now, loc); err != nil {
 				return err
 			}
 		case *ast.FragmentSpread:
@@ -160,7 +176,7 @@ func validateSelectionSet(selectionSet *ast.SelectionSet, operationType string,
 			if fragment == nil {
 				continue
 			}
-			if err := validateSelectionSet(fragment.SelectionSet, operationType, depth, fragments, visited, variables, now); err != nil {
+			if err := validateSelectionSet(fragment.SelectionSet, operationType, depth, fragments, visited, variables, now, loc); err != nil {
 				return err
 			}
 		}
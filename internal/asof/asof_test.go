@@ -20,7 +20,7 @@ func TestResolveDirective_Time(t *testing.T) {
 		},
 	}
 
-	spec, err := ResolveDirective(directive, nil, now)
+	spec, err := ResolveDirective(directive, nil, now, time.UTC)
 	if err != nil {
 		t.Fatalf("ResolveDirective() error = %v", err)
 	}
@@ -32,6 +32,42 @@ func TestResolveDirective_Time(t *testing.T) {
 	}
 }
 
+func TestResolveDirective_LocalTimeUsesZone(t *testing.T) {
+	now := time.Date(2026, 4, 7, 12, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	directive := &ast.Directive{
+		Name: &ast.Name{Value: DirectiveName},
+		Arguments: []*ast.Argument{
+			{
+				Name:  &ast.Name{Value: ArgTime},
+				Value: &ast.Variable{Name: &ast.Name{Value: "at"}},
+			},
+		},
+	}
+
+	spec, err := ResolveDirective(directive, map[string]any{"at": "2026-04-01T10:00:00"}, now, berlin)
+	if err != nil {
+		t.Fatalf("ResolveDirective() error = %v", err)
+	}
+	if got, want := spec.Time, time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("spec.Time = %v, want %v", got, want)
+	}
+
+	// 13:30 in Berlin is 11:30 UTC, before now; without a zone only the format is checked.
+	if _, err := ResolveDirective(directive, map[string]any{"at": "2026-04-07 13:30:00"}, now, berlin); err != nil {
+		t.Fatalf("ResolveDirective() error = %v", err)
+	}
+	if _, err := ResolveDirective(directive, map[string]any{"at": "2026-04-07 13:30:00"}, now, time.UTC); err == nil {
+		t.Fatal("expected a future time in UTC to be rejected")
+	}
+	if _, err := ResolveDirective(directive, map[string]any{"at": "2026-04-07 13:30:00"}, now, nil); err != nil {
+		t.Fatalf("ResolveDirective() without zone error = %v", err)
+	}
+}
+
 func TestResolveDirective_Offset(t *testing.T) {
 	now := time.Date(2026, 4, 7, 12, 0, 0, 0, time.UTC)
 	directive := &ast.Directive{
@@ -44,7 +80,7 @@ func TestResolveDirective_Offset(t *testing.T) {
 		},
 	}
 
-	spec, err := ResolveDirective(directive, nil, now)
+	spec, err := ResolveDirective(directive, nil, now, time.UTC)
 	if err != nil {
 		t.Fatalf("ResolveDirective() error = %v", err)
 	}
@@ -117,7 +153,7 @@ func TestResolveDirective_RejectsInvalidUsage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := ResolveDirective(tt.directive, tt.variables, now)
+			_, err := ResolveDirective(tt.directive, tt.variables, now, time.UTC)
 			if err == nil || err.Error() != tt.wantErr {
 				t.Fatalf("ResolveDirective() error = %v, want %q", err, tt.wantErr)
 			}
@@ -142,7 +178,7 @@ func TestValidateOperation_RootOnly(t *testing.T) {
 			},
 		},
 	}
-	if err := ValidateOperation(queryOp, nil, nil, now); err != nil {
+	if err := ValidateOperation(queryOp, nil, nil, now, time.UTC); err != nil {
 		t.Fatalf("ValidateOperation(query) error = %v", err)
 	}
 
@@ -161,7 +197,7 @@ func TestValidateOperation_RootOnly(t *testing.T) {
 			},
 		},
 	}
-	if err := ValidateOperation(nestedOp, nil, nil, now); err == nil || err.Error() != "@asOf is only allowed on root query fields" {
+	if err := ValidateOperation(nestedOp, nil, nil, now, time.UTC); err == nil || err.Error() != "@asOf is only allowed on root query fields" {
 		t.Fatalf("ValidateOperation(nested) error = %v", err)
 	}
 
@@ -173,7 +209,7 @@ func TestValidateOperation_RootOnly(t *testing.T) {
 			},
 		},
 	}
-	if err := ValidateOperation(mutationOp, nil, nil, now); err == nil || err.Error() != "@asOf is only allowed on root query fields" {
+	if err := ValidateOperation(mutationOp, nil, nil, now, time.UTC); err == nil || err.Error() != "@asOf is only allowed on root query fields" {
 		t.Fatalf("ValidateOperation(mutation) error = %v", err)
 	}
 }
@@ -0,0 +1,209 @@
+// Package authz evaluates field-level authorization rules against the
+// authenticated caller. Rules name GraphQL fields and require OAuth scopes,
+// a database role or claim values; the resolver enforces them per field.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Rule guards one GraphQL field. Every requirement that is set must hold:
+// all Scopes, one of Roles, and one of Values for Claim.
+type Rule struct {
+	// Name identifies the rule in errors. Defaults to the field.
+	Name string
+	// Field is "Type.field", or a bare root field name that matches the field
+	// on Query, Mutation and every namespace root.
+	Field  string
+	Scopes []string
+	Roles  []string
+	Claim  string
+	Values []string
+}
+
+// Principal is the caller as seen by authorization rules.
+type Principal struct {
+	Subject string
+	// Role is the database role chosen for the request, if any.
+	Role   string
+	Scopes []string
+	Claims map[string]interface{}
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal attaches the caller to ctx.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller attached to ctx.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	if ctx == nil {
+		return Principal{}, false
+	}
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
+
+// ScopesFromClaims reads OAuth scopes from the space-delimited "scope" claim
+// (RFC 8693) or the "scp" claim, which some providers emit as a list.
+func ScopesFromClaims(claims map[string]interface{}) []string {
+	var scopes []string
+	for _, name := range []string{"scope", "scp"} {
+		switch value := claims[name].(type) {
+		case string:
+			scopes = append(scopes, strings.Fields(value)...)
+		case []string:
+			scopes = append(scopes, value...)
+		case []interface{}:
+			for _, item := range value {
+				if scope, ok := item.(string); ok {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+	return scopes
+}
+
+// Error is returned for fields the caller may not access.
+type Error struct {
+	Field string
+	Rule  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("not authorized to access %s", e.Field)
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code": "FORBIDDEN",
+		"rule": e.Rule,
+	}
+}
+
+// Policy indexes rules by the field they guard.
+type Policy struct {
+	rules  []Rule
+	fields map[string][]Rule
+	roots  map[string][]Rule
+}
+
+// NewPolicy validates rules and indexes them. A nil policy allows everything.
+func NewPolicy(rules []Rule) (*Policy, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	policy := &Policy{
+		fields: make(map[string][]Rule),
+		roots:  make(map[string][]Rule),
+	}
+	for i, rule := range rules {
+		rule.Field = strings.TrimSpace(rule.Field)
+		if rule.Field == "" {
+			return nil, fmt.Errorf("authorization rule %d has no field", i)
+		}
+		if rule.Name == "" {
+			rule.Name = rule.Field
+		}
+		if len(rule.Scopes) == 0 && len(rule.Roles) == 0 && rule.Claim == "" {
+			return nil, fmt.Errorf("authorization rule %q requires scopes, roles or a claim", rule.Name)
+		}
+		if (rule.Claim == "") != (len(rule.Values) == 0) {
+			return nil, fmt.Errorf("authorization rule %q needs both claim and values", rule.Name)
+		}
+		policy.rules = append(policy.rules, rule)
+		typeName, fieldName, qualified := strings.Cut(rule.Field, ".")
+		if !qualified {
+			policy.roots[rule.Field] = append(policy.roots[rule.Field], rule)
+			continue
+		}
+		if typeName == "" || fieldName == "" || strings.Contains(fieldName, ".") {
+			return nil, fmt.Errorf("authorization rule %q: field must be Type.field or a root field name", rule.Name)
+		}
+		policy.fields[rule.Field] = append(policy.fields[rule.Field], rule)
+	}
+	return policy, nil
+}
+
+// Rules returns the validated rules in configuration order.
+func (p *Policy) Rules() []Rule {
+	if p == nil {
+		return nil
+	}
+	return slices.Clone(p.rules)
+}
+
+// RulesFor returns the rules guarding typeName.fieldName. root reports whether
+// typeName is Query, Mutation or a namespace root, where bare rules also apply.
+func (p *Policy) RulesFor(typeName, fieldName string, root bool) []Rule {
+	if p == nil {
+		return nil
+	}
+	rules := p.fields[typeName+"."+fieldName]
+	if root {
+		rules = append(slices.Clip(rules), p.roots[fieldName]...)
+	}
+	return rules
+}
+
+// Check returns an *Error for the first rule the caller in ctx does not
+// satisfy. Callers without a principal satisfy no rule.
+func Check(ctx context.Context, field string, rules []Rule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	principal, _ := PrincipalFromContext(ctx)
+	for _, rule := range rules {
+		if !rule.allows(principal) {
+			return &Error{Field: field, Rule: rule.Name}
+		}
+	}
+	return nil
+}
+
+func (r Rule) allows(principal Principal) bool {
+	for _, scope := range r.Scopes {
+		if !slices.Contains(principal.Scopes, scope) {
+			return false
+		}
+	}
+	if len(r.Roles) > 0 && (principal.Role == "" || !slices.Contains(r.Roles, principal.Role)) {
+		return false
+	}
+	if r.Claim != "" && !claimHasValue(principal.Claims[r.Claim], r.Values) {
+		return false
+	}
+	return true
+}
+
+func claimHasValue(raw interface{}, values []string) bool {
+	switch value := raw.(type) {
+	case string:
+		return slices.Contains(values, value)
+	case bool:
+		return slices.Contains(values, fmt.Sprint(value))
+	case []string:
+		for _, item := range value {
+			if slices.Contains(values, item) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if str, ok := item.(string); ok && slices.Contains(values, str) {
+				return true
+			}
+		}
+	}
+	return false
+}
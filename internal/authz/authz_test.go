@@ -0,0 +1,98 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	policy, err := NewPolicy([]Rule{
+		{Field: "User.salary", Scopes: []string{"hr:read"}},
+		{Name: "admins-delete", Field: "deleteOrder", Roles: []string{"app_admin"}},
+		{Field: "Order.margin", Claim: "groups", Values: []string{"finance"}},
+		{Field: "Order.margin", Scopes: []string{"orders:read"}},
+	})
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		typeName  string
+		fieldName string
+		root      bool
+		principal *Principal
+		wantRule  string
+	}{
+		{name: "unguarded field", typeName: "User", fieldName: "name"},
+		{name: "scope granted", typeName: "User", fieldName: "salary",
+			principal: &Principal{Scopes: ScopesFromClaims(map[string]interface{}{"scope": "openid hr:read"})}},
+		{name: "scope missing", typeName: "User", fieldName: "salary",
+			principal: &Principal{Scopes: []string{"openid"}}, wantRule: "User.salary"},
+		{name: "anonymous", typeName: "User", fieldName: "salary", wantRule: "User.salary"},
+		{name: "root rule on namespace root", typeName: "Sales_Mutation", fieldName: "deleteOrder", root: true,
+			principal: &Principal{Role: "app_viewer"}, wantRule: "admins-delete"},
+		{name: "root rule with role", typeName: "Mutation", fieldName: "deleteOrder", root: true,
+			principal: &Principal{Role: "app_admin"}},
+		{name: "root rule ignored off root", typeName: "Order", fieldName: "deleteOrder",
+			principal: &Principal{Role: "app_viewer"}},
+		{name: "all rules must pass", typeName: "Order", fieldName: "margin",
+			principal: &Principal{Claims: map[string]interface{}{"groups": []interface{}{"finance"}}}, wantRule: "Order.margin"},
+		{name: "claim and scope", typeName: "Order", fieldName: "margin",
+			principal: &Principal{Scopes: []string{"orders:read"}, Claims: map[string]interface{}{"groups": []interface{}{"staff", "finance"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = WithPrincipal(ctx, *tt.principal)
+			}
+			field := tt.typeName + "." + tt.fieldName
+			err := Check(ctx, field, policy.RulesFor(tt.typeName, tt.fieldName, tt.root))
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+				return
+			}
+			var authzErr *Error
+			if !errors.As(err, &authzErr) || authzErr.Rule != tt.wantRule || authzErr.Extensions()["code"] != "FORBIDDEN" {
+				t.Fatalf("Check() error = %#v, want rule %q", err, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	for _, rules := range [][]Rule{
+		{{Scopes: []string{"a"}}},
+		{{Field: "User.salary"}},
+		{{Field: "User.salary", Claim: "groups"}},
+		{{Field: "User.", Scopes: []string{"a"}}},
+		{{Field: "a.b.c", Scopes: []string{"a"}}},
+	} {
+		if _, err := NewPolicy(rules); err == nil {
+			t.Fatalf("expected error for %+v", rules[0])
+		}
+	}
+	if policy, err := NewPolicy(nil); err != nil || policy != nil {
+		t.Fatalf("NewPolicy(nil) = %v, %v; want nil policy", policy, err)
+	}
+}
+
+func TestScopesFromClaims(t *testing.T) {
+	got := ScopesFromClaims(map[string]interface{}{
+		"scope": "openid  hr:read",
+		"scp":   []interface{}{"orders:write", 7},
+	})
+	want := []string{"openid", "hr:read", "orders:write"}
+	if len(got) != len(want) {
+		t.Fatalf("ScopesFromClaims() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ScopesFromClaims() = %v, want %v", got, want)
+		}
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"tidb-graphql/internal/naming"
 )
 
 func TestDatabaseConfig_DSN(t *testing.T) {
@@ -24,7 +26,7 @@ func TestDatabaseConfig_DSN(t *testing.T) {
 				Password: "password",
 				Database: "test",
 			},
-			expected: "root:password@tcp(localhost:4000)/test?parseTime=true&loc=UTC",
+			expected: "root:password@tcp(localhost:4000)/test?parseTime=true&loc=UTC&time_zone=%27%2B00%3A00%27",
 		},
 		{
 			name: "with special characters in password",
@@ -35,7 +37,7 @@ func TestDatabaseConfig_DSN(t *testing.T) {
 				Password: "p@ss:w0rd!",
 				Database: "mydb",
 			},
-			expected: "admin:p@ss:w0rd!@tcp(db.example.com:3306)/mydb?parseTime=true&loc=UTC",
+			expected: "admin:p@ss:w0rd!@tcp(db.example.com:3306)/mydb?parseTime=true&loc=UTC&time_zone=%27%2B00%3A00%27",
 		},
 		{
 			name: "empty password",
@@ -46,7 +48,7 @@ func TestDatabaseConfig_DSN(t *testing.T) {
 				Password: "",
 				Database: "test",
 			},
-			expected: "root:@tcp(localhost:4000)/test?parseTime=true&loc=UTC",
+			expected: "root:@tcp(localhost:4000)/test?parseTime=true&loc=UTC&time_zone=%27%2B00%3A00%27",
 		},
 		{
 			name: "dsn with existing loc",
@@ -65,6 +67,37 @@ func TestDatabaseConfig_DSN(t *testing.T) {
 	}
 }
 
+// TestDatabaseConfig_TLSServerName tests which host verify-full checks against
+func TestDatabaseConfig_TLSServerName(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   DatabaseConfig
+		expected string
+	}{
+		{
+			name:     "explicit server name",
+			config:   DatabaseConfig{Host: "10.0.0.5", TLS: DatabaseTLSConfig{ServerName: "tidb.internal"}},
+			expected: "tidb.internal",
+		},
+		{
+			name:     "host from connection string",
+			config:   DatabaseConfig{Host: "localhost", ConnectionString: "user:pass@tcp(gateway.tidbcloud.com:4000)/app"},
+			expected: "gateway.tidbcloud.com",
+		},
+		{
+			name:     "host field",
+			config:   DatabaseConfig{Host: "tidb.internal", Port: 4000},
+			expected: "tidb.internal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.tlsServerName())
+		})
+	}
+}
+
 // TestLoad_WithEnvVars tests configuration loading from environment variables
 func TestLoad_WithEnvVars(t *testing.T) {
 	// Save original env vars
@@ -202,6 +235,116 @@ func TestConfig_Validate(t *testing.T) {
 		assert.Contains(t, result.Error(), "type_mappings.uuid_columns")
 	})
 
+	t.Run("scalar column mappings", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.TypeMappings.ScalarColumns = []ScalarColumnMappingConfig{
+			{Scalar: "Email", Table: "*", Columns: []string{"*_email"}},
+			{Scalar: "Money", Table: "orders", Columns: []string{"total"}, CurrencyColumn: "currency"},
+			{Scalar: "Duration", Table: "jobs", Columns: []string{"timeout_ms"}, Unit: "ms"},
+		}
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.TypeMappings.ScalarColumns = []ScalarColumnMappingConfig{
+			{Scalar: "Phone", Table: "users", Columns: []string{"phone"}},
+			{Scalar: "Money", Table: "orders", Columns: []string{"total"}},
+			{Scalar: "Email", Table: "users", Columns: []string{"email"}, Unit: "s"},
+			{Scalar: "URL", Table: "[bad", Columns: []string{"homepage"}},
+			{Scalar: "JSON", Table: "events"},
+		}
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "type_mappings.scalar_columns[0].scalar")
+		assert.Contains(t, result.Error(), "type_mappings.scalar_columns[1].currency_column")
+		assert.Contains(t, result.Error(), "type_mappings.scalar_columns[2].unit")
+		assert.Contains(t, result.Error(), "type_mappings.scalar_columns[3].table")
+		assert.Contains(t, result.Error(), "type_mappings.scalar_columns[4]")
+	})
+
+	t.Run("time zones", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.GraphQLTimeZone = "Europe/Berlin"
+		cfg.TypeMappings.DateTimeTimeZone = "Europe/Berlin"
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.Server.GraphQLTimeZone = "Local"
+		cfg.TypeMappings.DateTimeTimeZone = "Europe/Atlantis"
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.graphql_time_zone")
+		assert.Contains(t, result.Error(), "type_mappings.datetime_time_zone")
+	})
+
+	t.Run("json schema column mappings", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.TypeMappings.JSONSchemaColumns = []JSONSchemaColumnConfig{
+			{Table: "users", Column: "settings", SchemaFile: "schemas/settings.json"},
+		}
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.TypeMappings.JSONSchemaColumns = []JSONSchemaColumnConfig{
+			{Table: "users", Column: "settings"},
+			{Table: "users", Column: "address", SchemaFile: "a.json"},
+			{Table: "USERS", Column: "Address", SchemaFile: "b.json"},
+		}
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "type_mappings.json_schema_columns[0]")
+		assert.Contains(t, result.Error(), "duplicate JSON schema mapping")
+	})
+
+	t.Run("deprecations", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Deprecations = []DeprecationConfig{
+			{Table: "users", Column: "name", Reason: "Use fullName."},
+			{Table: "legacy_notes"},
+		}
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.Deprecations = []DeprecationConfig{
+			{Column: "name"},
+			{Table: "users", Column: "name"},
+			{Table: "USERS", Column: "NAME"},
+		}
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "deprecations[0].table")
+		assert.Contains(t, result.Error(), "duplicate deprecation for USERS.NAME")
+	})
+
+	t.Run("enum value mappings and lookup enums", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.TypeMappings.EnumValues = []EnumValuesConfig{
+			{Table: "orders", Column: "status", Values: []EnumValueConfig{
+				{Value: "in_progress", Name: "IN_PROGRESS", Description: "Being packed"},
+				{Value: "legacy", DeprecationReason: "No longer used."},
+			}},
+		}
+		cfg.TypeMappings.LookupEnums = []LookupEnumConfig{
+			{Table: "order_statuses", ValueColumn: "code", DescriptionColumn: "label", TypeName: "OrderStatusCode"},
+		}
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.TypeMappings.EnumValues = []EnumValuesConfig{
+			{Table: "orders", Column: "status", Values: []EnumValueConfig{
+				{Value: "a", Name: "1ST"},
+				{Value: "b", Name: "DONE"},
+				{Value: "c", Name: "DONE"},
+			}},
+			{Table: "orders"},
+		}
+		cfg.TypeMappings.LookupEnums = []LookupEnumConfig{
+			{Table: "order_statuses", ValueColumn: "code", TypeName: "order_status"},
+			{Table: "regions"},
+		}
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "type_mappings.enum_values[0].values[0].name")
+		assert.Contains(t, result.Error(), "duplicate GraphQL enum value name")
+		assert.Contains(t, result.Error(), "type_mappings.enum_values[1]")
+		assert.Contains(t, result.Error(), "type_mappings.lookup_enums[0].type_name")
+		assert.Contains(t, result.Error(), "type_mappings.lookup_enums[1]")
+	})
+
 	t.Run("valid tinyint1 mapping patterns", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.TypeMappings.TinyInt1BooleanColumns = map[string][]string{
@@ -475,6 +618,25 @@ func TestConfig_Validate(t *testing.T) {
 		assert.Contains(t, result.Error(), "tls_key_file")
 	})
 
+	t.Run("TLS file mode warns when reloading is disabled", func(t *testing.T) {
+		hasWarning := func(cfg *Config) bool {
+			for _, w := range cfg.Validate().Warnings {
+				if w.Field == "server.tls_reload_interval" {
+					return true
+				}
+			}
+			return false
+		}
+		cfg := validConfig()
+		cfg.Server.TLSMode = "file"
+		cfg.Server.TLSCertFile = "server.crt"
+		cfg.Server.TLSKeyFile = "server.key"
+		assert.True(t, hasWarning(cfg))
+
+		cfg.Server.TLSReloadInterval = time.Minute
+		assert.False(t, hasWarning(cfg))
+	})
+
 	t.Run("TLS auto mode valid", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Server.TLSMode = "auto"
@@ -502,6 +664,60 @@ func TestConfig_Validate(t *testing.T) {
 		assert.Contains(t, result.Error(), "db_role_enabled")
 	})
 
+	t.Run("db role enabled with client certificate role mappings", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.DBRoleEnabled = true
+		cfg.Server.Auth.OIDCEnabled = false
+		cfg.Server.Auth.DBRoleIntrospectionRole = "app_introspect"
+		cfg.Server.TLSMode = "auto"
+		cfg.Server.TLSClientAuth = "require"
+		cfg.Server.TLSClientCAFile = "/etc/tidb-graphql/client-ca.pem"
+		cfg.Server.Auth.RoleSchemaMaxRoles = 8
+		cfg.Server.Auth.ClientCertRoles = []ClientCertRoleConfig{{Identity: "billing-worker", Role: "app_billing"}}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+
+		cfg.Server.Auth.ClientCertRoles = []ClientCertRoleConfig{{Identity: "billing-worker"}}
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.auth.client_cert_roles[0]")
+	})
+
+	t.Run("db role enabled with API keys", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.DBRoleEnabled = true
+		cfg.Server.Auth.OIDCEnabled = false
+		cfg.Server.Auth.DBRoleIntrospectionRole = "app_introspect"
+		cfg.Server.Auth.RoleSchemaMaxRoles = 8
+		cfg.Server.Auth.APIKeyFile = "/etc/tidb-graphql/api-keys.yaml"
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+		warned := false
+		for _, warning := range result.Warnings {
+			warned = warned || warning.Field == "server.auth.api_key_file"
+		}
+		assert.True(t, warned, "expected plain HTTP warning for API keys")
+
+		cfg.Server.Auth.APIKeyReloadInterval = -time.Second
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.auth.api_key_reload_interval")
+	})
+
+	t.Run("client auth validation", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.TLSClientAuth = "optional"
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "invalid client auth mode")
+
+		cfg.Server.TLSClientAuth = "request"
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "client certificate verification requires TLS")
+		assert.Contains(t, result.Error(), "server.tls_client_ca_file")
+	})
+
 	t.Run("db role enabled requires introspection role", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Server.Auth.DBRoleEnabled = true
@@ -566,6 +782,167 @@ func TestConfig_Validate(t *testing.T) {
 		assert.Contains(t, result.Error(), "oidc_audience")
 	})
 
+	t.Run("db role rules", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.OIDCEnabled = true
+		cfg.Server.Auth.OIDCIssuerURL = "https://issuer.test"
+		cfg.Server.Auth.OIDCAudience = "tidb-graphql"
+		cfg.Server.Auth.DBRoleEnabled = true
+		cfg.Server.Auth.DBRoleIntrospectionRole = "app_introspect"
+		cfg.Server.Auth.RoleSchemaMaxRoles = 8
+		cfg.Server.Auth.DBRoleRules = []DBRoleRuleConfig{
+			{Name: "admins", Claim: "groups", Values: []string{"tidb-admins"}, Role: "app_admin", Priority: 10},
+			{Name: "services", Claim: "sub", Pattern: "^svc-", Role: "app_service"},
+			{Name: "default", Role: "app_viewer"},
+		}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+
+		cfg.Server.Auth.DBRoleRules = []DBRoleRuleConfig{
+			{Name: "admins", Claim: "groups", Role: "app_admin"},
+			{Name: "admins", Claim: "sub", Pattern: "(", Role: "app_service"},
+			{Values: []string{"x"}},
+		}
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), `db role rule for claim "groups" requires values or a pattern`)
+		assert.Contains(t, result.Error(), `duplicate db role rule name "admins"`)
+		assert.Contains(t, result.Error(), "server.auth.db_role_rules[1].pattern")
+		assert.Contains(t, result.Error(), "server.auth.db_role_rules[2].name")
+		assert.Contains(t, result.Error(), "server.auth.db_role_rules[2].role")
+		assert.Contains(t, result.Error(), "server.auth.db_role_rules[2].claim")
+	})
+
+	t.Run("token introspection", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.IntrospectionEnabled = true
+		cfg.Server.Auth.IntrospectionURL = "https://auth.partner.test/oauth2/introspect"
+		cfg.Server.Auth.IntrospectionClientID = "tidb-graphql"
+		cfg.Server.Auth.IntrospectionClientSecret = "secret"
+		cfg.Server.Auth.IntrospectionCacheTTL = time.Minute
+		cfg.Server.Auth.DBRoleEnabled = true
+		cfg.Server.Auth.DBRoleIntrospectionRole = "app_introspect"
+		cfg.Server.Auth.RoleSchemaMaxRoles = 8
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+
+		cfg.Server.Auth.IntrospectionURL = "http://auth.partner.test/oauth2/introspect"
+		cfg.Server.Auth.IntrospectionClientID = ""
+		cfg.Server.Auth.IntrospectionCacheTTL = -time.Second
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "introspection URL must use https")
+		assert.Contains(t, result.Error(), "server.auth.introspection_client_id")
+		assert.Contains(t, result.Error(), "server.auth.introspection_cache_ttl")
+	})
+
+	t.Run("field rules", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.FieldRules = []FieldRuleConfig{
+			{Field: "User.salary", Scopes: []string{"hr:read"}},
+			{Name: "finance-only", Field: "Order.margin", Claim: "groups", Values: []string{"finance"}},
+		}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+
+		cfg.Server.Auth.FieldRules = []FieldRuleConfig{
+			{Field: "User.", Scopes: []string{"hr:read"}},
+			{Field: "deleteOrder"},
+			{Field: "Order.margin", Claim: "groups"},
+			{Field: "deleteOrder", Roles: []string{"app_admin"}},
+		}
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.auth.field_rules[0].field")
+		assert.Contains(t, result.Error(), "field rule requires scopes, roles or a claim")
+		assert.Contains(t, result.Error(), "server.auth.field_rules[2].values")
+		assert.Contains(t, result.Warnings, ValidationWarning{
+			Field:   "server.auth.field_rules[3].roles",
+			Message: "role requirements never match unless db_role_enabled is set",
+		})
+	})
+
+	t.Run("graphql limit overrides", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.GraphQLMaxDepth = 5
+		cfg.Server.GraphQLLimitOverrides = []GraphQLLimitOverrideConfig{
+			{Name: "reporting", Claim: "groups", Values: []string{"analysts"}, MaxDepth: 12, MaxRows: 100000},
+			{Name: "public-search", Operations: []string{"SearchProducts"}, MaxDepth: 3},
+			{Name: "export", Operations: []string{"Export"}, OperationHashes: []string{"3f2a"}, MaxDepth: 20},
+			{Name: "pinned", OperationHashes: []string{"9c1e"}, MaxRows: 50000},
+		}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+		assert.Empty(t, result.Warnings)
+
+		cfg.Server.GraphQLLimitOverrides = []GraphQLLimitOverrideConfig{
+			{MaxDepth: 10, Roles: []string{"app_reporting"}},
+			{Name: "a", MaxDepth: 10},
+			{Name: "a", Claim: "groups", MaxDepth: -1},
+			{Name: "b", Operations: []string{"Report"}},
+			{Name: "c", Operations: []string{"Report"}, MaxDepth: 10},
+		}
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.graphql_limit_overrides[0].name")
+		assert.Contains(t, result.Error(), "limit override requires roles, a claim, operations or operation_hashes")
+		assert.Contains(t, result.Error(), `duplicate limit override name "a"`)
+		assert.Contains(t, result.Error(), "server.graphql_limit_overrides[2].values")
+		assert.Contains(t, result.Error(), "limit override values cannot be negative")
+		assert.Contains(t, result.Error(), "limit override requires max_depth, max_complexity or max_rows")
+		assert.Contains(t, result.Warnings, ValidationWarning{
+			Field:   "server.graphql_limit_overrides[0].roles",
+			Message: "role matchers never match unless db_role_enabled is set",
+		})
+		assert.Contains(t, result.Errors, ValidationError{
+			Field:   "server.graphql_limit_overrides[4].operations",
+			Message: "operation names are chosen by the client, so an override matching only operations cannot raise limits",
+			Hint:    "add roles or a claim, or pin the documents with operation_hashes",
+		})
+	})
+
+	t.Run("OIDC issuer list", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.OIDCEnabled = true
+		cfg.Server.Auth.OIDCIssuerURL = ""
+		cfg.Server.Auth.OIDCAudience = ""
+		cfg.Server.Auth.OIDCIssuers = []OIDCIssuerConfig{
+			{IssuerURL: "https://workforce.example", Audience: "tidb-graphql"},
+			{IssuerURL: "https://customers.example", Audience: "graphql-api", JWKSFile: "/etc/jwks.json", DBRoleClaimName: "tenant_role"},
+		}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+
+		cfg.Server.Auth.OIDCIssuerURL = "https://workforce.example"
+		cfg.Server.Auth.OIDCAudience = "tidb-graphql"
+		cfg.Server.Auth.OIDCIssuers[1].JWKSURL = "https://customers.example/keys"
+		cfg.Server.Auth.OIDCIssuers = append(cfg.Server.Auth.OIDCIssuers, OIDCIssuerConfig{IssuerURL: "https://partners.example"})
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.auth.oidc_issuers[0].issuer_url")
+		assert.Contains(t, result.Error(), "server.auth.oidc_issuers[1].jwks_file")
+		assert.Contains(t, result.Error(), "server.auth.oidc_issuers[2]: OIDC issuer requires issuer_url and audience")
+	})
+
+	t.Run("OIDC claim mappings cannot target registered claims", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Auth.OIDCEnabled = true
+		cfg.Server.Auth.OIDCIssuers = []OIDCIssuerConfig{{
+			IssuerURL:       "https://customers.example",
+			Audience:        "graphql-api",
+			DBRoleClaimName: "tenant_role",
+			ClaimMappings:   map[string]string{"tenant_role": "https://customers.example/role"},
+		}}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors(), result.Error())
+
+		cfg.Server.Auth.OIDCIssuers[0].ClaimMappings = map[string]string{"exp": "session_expiry", "sub": "customer_id"}
+		result = cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), `server.auth.oidc_issuers[0].claim_mappings.exp: claim mapping cannot target the registered claim "exp"`)
+		assert.Contains(t, result.Error(), "server.auth.oidc_issuers[0].claim_mappings.sub")
+	})
+
 	t.Run("admin schema reload enabled without OIDC requires token", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Server.Admin.SchemaReloadEnabled = true
@@ -596,6 +973,61 @@ func TestConfig_Validate(t *testing.T) {
 		assert.False(t, result.HasErrors())
 	})
 
+	t.Run("admin explain enabled without OIDC requires token", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Admin.ExplainEnabled = true
+		cfg.Server.Auth.OIDCEnabled = false
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "admin explain, status and drain endpoints require auth token")
+
+		cfg.Server.Admin.AuthToken = "secret-token"
+		result = cfg.Validate()
+		assert.False(t, result.HasErrors())
+		for _, warning := range result.Warnings {
+			assert.NotEqual(t, "server.admin.schema_reload_enabled", warning.Field)
+		}
+	})
+
+	t.Run("admin status enabled without OIDC requires token", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Admin.StatusEnabled = true
+		cfg.Server.Auth.OIDCEnabled = false
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.admin.auth_token")
+	})
+
+	t.Run("admin drain enabled without OIDC requires token", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Admin.DrainEnabled = true
+		cfg.Server.Auth.OIDCEnabled = false
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.admin.auth_token")
+	})
+
+	t.Run("drain timeout validation", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.DrainTimeout = -time.Second
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.drain_timeout")
+
+		cfg.Server.DrainTimeout = 30 * time.Second
+		cfg.Server.ShutdownTimeout = 30 * time.Second
+		result = cfg.Validate()
+		assert.False(t, result.HasErrors())
+		found := false
+		for _, warning := range result.Warnings {
+			if warning.Field == "server.drain_timeout" {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "expected warning when drain timeout leaves no shutdown budget")
+	})
+
 	t.Run("disabled admin schema reload with token warns", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Server.Admin.SchemaReloadEnabled = false
@@ -665,6 +1097,51 @@ func TestConfig_Validate(t *testing.T) {
 		assert.Contains(t, result.Error(), "vector_max_top_k")
 	})
 
+	t.Run("interactive transactions require positive limits", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Transactions.Enabled = true
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "server.transactions.idle_timeout")
+		assert.Contains(t, result.Error(), "server.transactions.max_lifetime")
+		assert.Contains(t, result.Error(), "server.transactions.max_per_subject")
+		assert.Contains(t, result.Error(), "server.transactions.max_open")
+	})
+
+	t.Run("interactive transactions with limits are valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Server.Transactions = TransactionsConfig{
+			Enabled:       true,
+			IdleTimeout:   30 * time.Second,
+			MaxLifetime:   2 * time.Minute,
+			MaxPerSubject: 2,
+			MaxOpen:       10,
+		}
+		result := cfg.Validate()
+		assert.False(t, result.HasErrors())
+	})
+
+	t.Run("interactive transactions warn only for anonymous callers", func(t *testing.T) {
+		hasWarning := func(cfg *Config) bool {
+			for _, w := range cfg.Validate().Warnings {
+				if w.Field == "server.transactions.enabled" {
+					return true
+				}
+			}
+			return false
+		}
+		cfg := validConfig()
+		cfg.Server.Transactions = TransactionsConfig{Enabled: true, IdleTimeout: 30 * time.Second, MaxLifetime: 2 * time.Minute, MaxPerSubject: 2, MaxOpen: 10}
+		assert.True(t, hasWarning(cfg))
+
+		cfg.Server.Auth.APIKeyFile = "keys.yaml"
+		assert.False(t, hasWarning(cfg))
+
+		cfg.Server.Auth.APIKeyFile = ""
+		cfg.Server.TLSClientAuth = "require"
+		assert.False(t, hasWarning(cfg))
+	})
+
 	t.Run("multiple errors collected", func(t *testing.T) {
 		cfg := validConfig()
 		cfg.Database.Port = 0
@@ -964,6 +1441,37 @@ func TestConfigValidate_NamingTypeOverrides(t *testing.T) {
 		assert.True(t, result.HasErrors())
 		assert.Contains(t, result.Error(), "reserved for mutation error/result types")
 	})
+
+	t.Run("field case and table prefixes", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Naming.FieldCase = "snake"
+		cfg.Naming.StripTablePrefixes = []string{"tbl_", "app_"}
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.Naming.FieldCase = "kebab"
+		cfg.Naming.StripTablePrefixes = []string{" "}
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "naming.field_case")
+		assert.Contains(t, result.Error(), "naming.strip_table_prefixes")
+	})
+
+	t.Run("relationship overrides", func(t *testing.T) {
+		cfg := newValidConfig()
+		cfg.Naming.RelationshipOverrides = map[string]map[string]naming.RelationshipOverride{
+			"orders": {
+				"orders_ibfk_2": {Forward: "shippingAddress", Reverse: "shippedOrders"},
+				"orders_ibfk_3": {HideForward: true, HideReverse: true},
+			},
+		}
+		assert.False(t, cfg.Validate().HasErrors())
+
+		cfg.Naming.RelationshipOverrides["orders"]["orders_ibfk_2"] = naming.RelationshipOverride{Forward: "shipping-address", Reverse: "shippedOrders", HideReverse: true}
+		result := cfg.Validate()
+		assert.True(t, result.HasErrors())
+		assert.Contains(t, result.Error(), "naming.relationship_overrides.orders.orders_ibfk_2.forward")
+		assert.Contains(t, result.Error(), "reverse relationship is both named and hidden")
+	})
 }
 
 func TestParseMyCnf(t *testing.T) {
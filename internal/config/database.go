@@ -2,11 +2,13 @@ package config
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 
+	"tidb-graphql/internal/tlscert"
+
 	"github.com/go-sql-driver/mysql"
 )
 
@@ -29,12 +31,9 @@ func (d *DatabaseConfig) DSN() string {
 				dsn += "?parseTime=true"
 			}
 		}
-		if !strings.Contains(dsn, "loc=") {
-			dsn += "&loc=UTC"
-		}
 	} else {
 		dsn = fmt.Sprintf(
-			"%s:%s@tcp(%s:%d)/%s?parseTime=true&loc=UTC",
+			"%s:%s@tcp(%s:%d)/%s?parseTime=true",
 			d.User,
 			d.Password,
 			d.Host,
@@ -43,6 +42,8 @@ func (d *DatabaseConfig) DSN() string {
 		)
 	}
 
+	dsn = withUTCSession(dsn)
+
 	// Add TLS parameter
 	tlsParam := d.effectiveTLSParam()
 	if tlsParam != "" && !strings.Contains(dsn, "tls=") {
@@ -52,6 +53,21 @@ func (d *DatabaseConfig) DSN() string {
 	return dsn
 }
 
+// withUTCSession reads times in UTC and pins the session time_zone to UTC, so
+// TIMESTAMP values arrive as real instants and DATETIME values as unshifted
+// wall clocks. A DSN that sets its own loc is left alone, since pinning the
+// session zone only helps when it matches loc.
+func withUTCSession(dsn string) string {
+	if strings.Contains(dsn, "loc=") {
+		return dsn
+	}
+	dsn += "&loc=UTC"
+	if !strings.Contains(dsn, "time_zone=") {
+		dsn += "&time_zone=%27%2B00%3A00%27"
+	}
+	return dsn
+}
+
 // DSNWithoutDatabase returns a DSN that omits the default database.
 // Useful for role-based auth where database access is granted via SET ROLE.
 func (d *DatabaseConfig) DSNWithoutDatabase() string {
@@ -69,12 +85,9 @@ func (d *DatabaseConfig) DSNWithoutDatabase() string {
 				dsn += "?parseTime=true"
 			}
 		}
-		if !strings.Contains(dsn, "loc=") {
-			dsn += "&loc=UTC"
-		}
 	} else {
 		dsn = fmt.Sprintf(
-			"%s:%s@tcp(%s:%d)/?parseTime=true&loc=UTC",
+			"%s:%s@tcp(%s:%d)/?parseTime=true",
 			d.User,
 			d.Password,
 			d.Host,
@@ -82,6 +95,8 @@ func (d *DatabaseConfig) DSNWithoutDatabase() string {
 		)
 	}
 
+	dsn = withUTCSession(dsn)
+
 	// Add TLS parameter
 	tlsParam := d.effectiveTLSParam()
 	if tlsParam != "" && !strings.Contains(dsn, "tls=") {
@@ -224,32 +239,38 @@ func (d *DatabaseConfig) effectiveTLSParam() string {
 
 // RegisterTLS registers a custom TLS configuration with the MySQL driver.
 // Must be called before opening the database connection when using verify-ca or verify-full modes.
+// It returns the CA bundle and client certificate so callers can watch them for
+// rotation; new connections always use the latest loaded files.
 // Returns nil if no custom TLS configuration is needed.
-func (d *DatabaseConfig) RegisterTLS() error {
+func (d *DatabaseConfig) RegisterTLS() ([]tlscert.Reloadable, error) {
 	mode := d.TLS.Mode
 
 	// Only register custom config for modes that need it
 	if mode != "verify-ca" && mode != "verify-full" {
-		return nil
+		return nil, nil
 	}
 
-	tlsCfg, err := d.buildTLSConfig()
+	tlsCfg, reloadables, err := d.buildTLSConfig()
 	if err != nil {
-		return fmt.Errorf("failed to build TLS config: %w", err)
+		return nil, fmt.Errorf("failed to build TLS config: %w", err)
 	}
 
 	if err := mysql.RegisterTLSConfig(tlsConfigName, tlsCfg); err != nil {
-		return fmt.Errorf("failed to register TLS config: %w", err)
+		return nil, fmt.Errorf("failed to register TLS config: %w", err)
 	}
 
-	return nil
+	return reloadables, nil
 }
 
 // buildTLSConfig creates a tls.Config based on the DatabaseTLSConfig settings.
-func (d *DatabaseConfig) buildTLSConfig() (*tls.Config, error) {
+// The driver clones the registered config once when parsing the DSN, so the CA
+// bundle and client certificate are served through callbacks that read the
+// latest reloaded files instead of static fields.
+func (d *DatabaseConfig) buildTLSConfig() (*tls.Config, []tlscert.Reloadable, error) {
 	tlsCfg := &tls.Config{
 		MinVersion: tls.VersionTLS12,
 	}
+	var reloadables []tlscert.Reloadable
 
 	mode := d.TLS.Mode
 
@@ -260,43 +281,56 @@ func (d *DatabaseConfig) buildTLSConfig() (*tls.Config, error) {
 
 	// Load CA certificate for server verification
 	if caFile != "" {
-		caCert, err := os.ReadFile(caFile)
+		caPool, err := tlscert.LoadCAPool("database_ca", caFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read CA file %q: %w", caFile, err)
+			return nil, nil, fmt.Errorf("failed to load CA file %q: %w", caFile, err)
 		}
-
-		certPool := x509.NewCertPool()
-		if !certPool.AppendCertsFromPEM(caCert) {
-			return nil, fmt.Errorf("failed to parse CA certificate from %q", caFile)
+		reloadables = append(reloadables, caPool)
+
+		// Verification runs in VerifyConnection against the current bundle;
+		// Go's built-in verification would pin the bundle loaded at startup.
+		serverName := ""
+		if mode == "verify-full" {
+			serverName = d.tlsServerName()
+			if serverName == "" {
+				return nil, nil, fmt.Errorf("verify-full requires database.tls.server_name or a database host")
+			}
 		}
-		tlsCfg.RootCAs = certPool
+		tlsCfg.InsecureSkipVerify = true
+		tlsCfg.ServerName = serverName
+		tlsCfg.VerifyConnection = caPool.VerifyConnection(serverName)
 	}
 
 	// Load client certificate for mTLS
 	if certFile != "" && keyFile != "" {
-		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		keyPair, err := tlscert.LoadKeyPair("database_client", certFile, keyFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			return nil, nil, fmt.Errorf("failed to load client certificate: %w", err)
 		}
-		tlsCfg.Certificates = []tls.Certificate{cert}
+		reloadables = append(reloadables, keyPair)
+		tlsCfg.GetClientCertificate = keyPair.GetClientCertificate
 	} else if certFile != "" || keyFile != "" {
-		return nil, fmt.Errorf("both cert_file and key_file must be specified for client certificate authentication")
+		return nil, nil, fmt.Errorf("both cert_file and key_file must be specified for client certificate authentication")
 	}
 
-	// Configure verification mode
-	switch mode {
-	case "verify-ca":
-		// Verify the server certificate against CA, but don't check hostname
-		tlsCfg.InsecureSkipVerify = false
-		// Note: go-sql-driver/mysql handles the CA verification
-	case "verify-full":
-		tlsCfg.InsecureSkipVerify = false
-		if d.TLS.ServerName != "" {
-			tlsCfg.ServerName = d.TLS.ServerName
+	return tlsCfg, reloadables, nil
+}
+
+// tlsServerName returns the host name verify-full checks the server
+// certificate against: server_name when set, otherwise the connection host.
+func (d *DatabaseConfig) tlsServerName() string {
+	if d.TLS.ServerName != "" {
+		return d.TLS.ServerName
+	}
+	if d.ConnectionString != "" {
+		if parsed, err := mysql.ParseDSN(d.ConnectionString); err == nil {
+			if host, _, err := net.SplitHostPort(parsed.Addr); err == nil {
+				return host
+			}
+			return parsed.Addr
 		}
 	}
-
-	return tlsCfg, nil
+	return d.Host
 }
 
 // resolveCAFile returns the effective CA file path, checking env var indirection.
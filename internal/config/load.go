@@ -142,6 +142,19 @@ func Load() (*Config, error) {
 		v.Set("server.admin.auth_token", token)
 	}
 
+	// --- Introspection client secret from file (explicit override) ---
+	if v.GetString("server.auth.introspection_client_secret") == "" && v.GetString("server.auth.introspection_client_secret_file") != "" {
+		secretPath := v.GetString("server.auth.introspection_client_secret_file")
+		secret, err := readPasswordFile(secretPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read introspection client secret file: %w", err)
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("introspection client secret file %q is empty", secretPath)
+		}
+		v.Set("server.auth.introspection_client_secret", secret)
+	}
+
 	// --- Effective database normalization ---
 	// Skipped when database.databases is explicitly configured: the Databases
 	// array takes precedence and validate() will sync Database from Databases[0].
@@ -264,6 +277,7 @@ func defineFlags() {
 		pflag.Int("server.graphql_max_complexity", 0, "Maximum GraphQL query complexity limit")
 		pflag.Int("server.graphql_max_rows", 0, "Maximum estimated GraphQL rows per request")
 		pflag.Int("server.graphql_default_limit", 0, "Default page size for GraphQL connection collection queries")
+		pflag.String("server.graphql_time_zone", "", "Default IANA time zone for rendering DateTime values and reading offset-less DateTime inputs")
 		pflag.Bool("server.search.vector_require_index", false, "Require vector-search-capable indexes before exposing vector search fields")
 		pflag.Int("server.search.vector_max_top_k", 0, "Maximum allowed page size (first) for vector search connection fields")
 		pflag.Duration("server.schema_refresh_min_interval", 0, "Minimum interval between schema refresh checks")
@@ -273,6 +287,8 @@ func defineFlags() {
 		pflag.String("server.auth.oidc_issuer_url", "", "OIDC issuer URL (for discovery and JWKS)")
 		pflag.String("server.auth.oidc_audience", "", "Expected JWT audience (client ID)")
 		pflag.String("server.auth.oidc_ca_file", "", "Path to CA bundle for OIDC provider TLS verification")
+		pflag.String("server.auth.oidc_jwks_url", "", "JWKS URL for the OIDC issuer; skips discovery")
+		pflag.String("server.auth.oidc_jwks_file", "", "Path to a local JWKS file for the OIDC issuer; skips discovery")
 		pflag.Duration("server.auth.oidc_clock_skew", 0, "Allowed JWT clock skew (e.g. 2m)")
 		pflag.Bool("server.auth.db_role_enabled", false, "Enable database role-based authorization (SET ROLE)")
 		pflag.String("server.auth.db_role_claim_name", "", "JWT claim name containing database role (default: db_role)")
@@ -280,9 +296,28 @@ func defineFlags() {
 		pflag.StringSlice("server.auth.role_schema_include", nil, "Role glob patterns to include for role-specific schema snapshots (default: [*])")
 		pflag.StringSlice("server.auth.role_schema_exclude", nil, "Role glob patterns to exclude from role-specific schema snapshots")
 		pflag.Int("server.auth.role_schema_max_roles", 0, "Maximum number of role-specific schemas to build when db_role_enabled is true")
+		pflag.String("server.auth.api_key_file", "", "Path to YAML/JSON file of hashed API keys accepted in the X-API-Key header")
+		pflag.Duration("server.auth.api_key_reload_interval", 0, "How often to check the API key file for changes (0 disables reloading)")
+		pflag.Bool("server.auth.introspection_enabled", false, "Validate opaque bearer tokens with an OAuth2 token introspection endpoint")
+		pflag.String("server.auth.introspection_url", "", "OAuth2 token introspection endpoint URL (RFC 7662)")
+		pflag.String("server.auth.introspection_client_id", "", "Client ID used to authenticate to the introspection endpoint")
+		pflag.String("server.auth.introspection_client_secret", "", "Client secret used to authenticate to the introspection endpoint")
+		pflag.String("server.auth.introspection_client_secret_file", "", "Path to file containing the introspection client secret (use @- for stdin)")
+		pflag.String("server.auth.introspection_ca_file", "", "Path to CA bundle for introspection endpoint TLS verification")
+		pflag.String("server.auth.introspection_audience", "", "Audience required in introspection responses (optional)")
+		pflag.Duration("server.auth.introspection_cache_ttl", 0, "How long to cache active introspection results (0 disables caching)")
+		pflag.Int("server.auth.introspection_cache_max_entries", 0, "Maximum number of cached introspection results")
 		pflag.Bool("server.admin.schema_reload_enabled", false, "Enable /admin/reload-schema endpoint")
+		pflag.Bool("server.admin.explain_enabled", false, "Enable /admin/explain endpoint")
+		pflag.Bool("server.admin.status_enabled", false, "Enable /admin/status endpoint")
+		pflag.Bool("server.admin.drain_enabled", false, "Enable /admin/drain endpoint")
 		pflag.String("server.admin.auth_token", "", "Shared secret required in X-Admin-Token header when admin endpoint is enabled without OIDC")
 		pflag.String("server.admin.auth_token_file", "", "Path to file containing admin auth token (use @- for stdin)")
+		pflag.Bool("server.transactions.enabled", false, "Enable interactive multi-request transactions (beginTransaction/commitTransaction/rollbackTransaction)")
+		pflag.Duration("server.transactions.idle_timeout", 0, "Roll back interactive transactions idle for longer than this")
+		pflag.Duration("server.transactions.max_lifetime", 0, "Hard upper bound on interactive transaction lifetime")
+		pflag.Int("server.transactions.max_per_subject", 0, "Maximum open interactive transactions per authenticated subject")
+		pflag.Int("server.transactions.max_open", 0, "Maximum open interactive transactions across all subjects")
 		pflag.Bool("server.rate_limit_enabled", false, "Enable global rate limiting for all HTTP endpoints")
 		pflag.Float64("server.rate_limit_rps", 0, "Global rate limit requests per second")
 		pflag.Int("server.rate_limit_burst", 0, "Global rate limit burst size")
@@ -297,6 +332,7 @@ func defineFlags() {
 		pflag.Duration("server.write_timeout", 0, "HTTP server write timeout")
 		pflag.Duration("server.idle_timeout", 0, "HTTP server idle timeout")
 		pflag.Duration("server.shutdown_timeout", 0, "HTTP server graceful shutdown timeout")
+		pflag.Duration("server.drain_timeout", 0, "Maximum time to wait for in-flight mutations and interactive transactions during shutdown")
 		pflag.Duration("server.health_check_timeout", 0, "Health check timeout")
 
 		// TLS flags
@@ -304,6 +340,9 @@ func defineFlags() {
 		pflag.String("server.tls_cert_file", "", "Path to TLS certificate file (for file mode)")
 		pflag.String("server.tls_key_file", "", "Path to TLS private key file (for file mode)")
 		pflag.String("server.tls_auto_cert_dir", "", "Directory for auto-generated certificates (default: .tls)")
+		pflag.Duration("server.tls_reload_interval", 0, "How often to check file-based server and database certificates for rotation (0 disables)")
+		pflag.String("server.tls_client_auth", "", "Client certificate verification: off, request, require (default: off)")
+		pflag.String("server.tls_client_ca_file", "", "Path to CA bundle used to verify client certificates")
 
 		// Observability flags
 		pflag.String("observability.service_name", "", "Service name for observability")
@@ -392,6 +431,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.graphql_max_complexity", 0)
 	v.SetDefault("server.graphql_max_rows", 0)
 	v.SetDefault("server.graphql_default_limit", 100)
+	v.SetDefault("server.graphql_time_zone", "UTC")
 	v.SetDefault("server.search.vector_require_index", true)
 	v.SetDefault("server.search.vector_max_top_k", 100)
 	v.SetDefault("server.schema_refresh_min_interval", 30*time.Second)
@@ -401,6 +441,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.auth.oidc_issuer_url", "")
 	v.SetDefault("server.auth.oidc_audience", "")
 	v.SetDefault("server.auth.oidc_ca_file", "")
+	v.SetDefault("server.auth.oidc_jwks_url", "")
+	v.SetDefault("server.auth.oidc_jwks_file", "")
 	v.SetDefault("server.auth.oidc_clock_skew", 2*time.Minute)
 	v.SetDefault("server.auth.db_role_enabled", false)
 	v.SetDefault("server.auth.db_role_claim_name", "db_role")
@@ -408,9 +450,28 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.auth.role_schema_include", []string{"*"})
 	v.SetDefault("server.auth.role_schema_exclude", []string{})
 	v.SetDefault("server.auth.role_schema_max_roles", 64)
+	v.SetDefault("server.auth.api_key_file", "")
+	v.SetDefault("server.auth.api_key_reload_interval", 30*time.Second)
+	v.SetDefault("server.auth.introspection_enabled", false)
+	v.SetDefault("server.auth.introspection_url", "")
+	v.SetDefault("server.auth.introspection_client_id", "")
+	v.SetDefault("server.auth.introspection_client_secret", "")
+	v.SetDefault("server.auth.introspection_client_secret_file", "")
+	v.SetDefault("server.auth.introspection_ca_file", "")
+	v.SetDefault("server.auth.introspection_audience", "")
+	v.SetDefault("server.auth.introspection_cache_ttl", time.Minute)
+	v.SetDefault("server.auth.introspection_cache_max_entries", 10000)
 	v.SetDefault("server.admin.schema_reload_enabled", false)
+	v.SetDefault("server.admin.explain_enabled", false)
+	v.SetDefault("server.admin.status_enabled", false)
+	v.SetDefault("server.admin.drain_enabled", false)
 	v.SetDefault("server.admin.auth_token", "")
 	v.SetDefault("server.admin.auth_token_file", "")
+	v.SetDefault("server.transactions.enabled", false)
+	v.SetDefault("server.transactions.idle_timeout", 30*time.Second)
+	v.SetDefault("server.transactions.max_lifetime", 2*time.Minute)
+	v.SetDefault("server.transactions.max_per_subject", 2)
+	v.SetDefault("server.transactions.max_open", 20)
 	v.SetDefault("server.rate_limit_enabled", false)
 	v.SetDefault("server.rate_limit_rps", 0.0)
 	v.SetDefault("server.rate_limit_burst", 0)
@@ -425,6 +486,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.write_timeout", 15*time.Second)
 	v.SetDefault("server.idle_timeout", 60*time.Second)
 	v.SetDefault("server.shutdown_timeout", 30*time.Second)
+	v.SetDefault("server.drain_timeout", 20*time.Second)
 	v.SetDefault("server.health_check_timeout", 2*time.Second)
 
 	// TLS defaults
@@ -432,6 +494,9 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.tls_cert_file", "")
 	v.SetDefault("server.tls_key_file", "")
 	v.SetDefault("server.tls_auto_cert_dir", ".tls")
+	v.SetDefault("server.tls_reload_interval", time.Minute)
+	v.SetDefault("server.tls_client_auth", "off")
+	v.SetDefault("server.tls_client_ca_file", "")
 
 	// Observability defaults
 	v.SetDefault("observability.service_name", "tidb-graphql")
@@ -472,6 +537,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("type_mappings.uuid_columns", map[string][]string{})
 	v.SetDefault("type_mappings.tinyint1_boolean_columns", map[string][]string{})
 	v.SetDefault("type_mappings.tinyint1_int_columns", map[string][]string{})
+	v.SetDefault("type_mappings.datetime_time_zone", "UTC")
 
 	// Naming defaults
 	v.SetDefault("naming.plural_overrides", map[string]string{})
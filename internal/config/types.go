@@ -15,6 +15,17 @@ type Config struct {
 	SchemaFilters schemafilter.Config `mapstructure:"schema_filters"`
 	TypeMappings  TypeMappingsConfig  `mapstructure:"type_mappings"`
 	Naming        naming.Config       `mapstructure:"naming"`
+	// Deprecations marks tables and columns as deprecated in GraphQL, in
+	// addition to "@deprecated" markers in SQL comments.
+	Deprecations []DeprecationConfig `mapstructure:"deprecations"`
+}
+
+// DeprecationConfig deprecates a table's root fields, or one column's field
+// when Column is set.
+type DeprecationConfig struct {
+	Table  string `mapstructure:"table"`
+	Column string `mapstructure:"column"`
+	Reason string `mapstructure:"reason"`
 }
 
 // TypeMappingsConfig controls explicit SQL-to-GraphQL type overrides.
@@ -27,6 +38,61 @@ type TypeMappingsConfig struct {
 	// TinyInt1IntColumns maps table glob patterns to tinyint(1) column glob patterns
 	// that should be treated as GraphQL Int (escape hatch when tinyint(1) is not semantic boolean).
 	TinyInt1IntColumns map[string][]string `mapstructure:"tinyint1_int_columns"`
+	// ScalarColumns exposes columns stored in generic SQL types as semantic
+	// scalars such as Email, IPAddress or Money.
+	ScalarColumns []ScalarColumnMappingConfig `mapstructure:"scalar_columns"`
+	// DateTimeTimeZone is the IANA zone of the wall-clock values stored in DATETIME columns.
+	DateTimeTimeZone string `mapstructure:"datetime_time_zone"`
+	// JSONSchemaColumns exposes JSON columns as typed objects described by a JSON Schema file.
+	JSONSchemaColumns []JSONSchemaColumnConfig `mapstructure:"json_schema_columns"`
+	// EnumValues renames, describes and deprecates the values of enum, SET
+	// and lookup enum columns.
+	EnumValues []EnumValuesConfig `mapstructure:"enum_values"`
+	// LookupEnums generates GraphQL enums from small lookup tables referenced by foreign keys.
+	LookupEnums []LookupEnumConfig `mapstructure:"lookup_enums"`
+}
+
+// ScalarColumnMappingConfig maps columns matching Table/Columns glob patterns
+// to one semantic scalar.
+type ScalarColumnMappingConfig struct {
+	Scalar         string   `mapstructure:"scalar"` // Email, URL, IPAddress, Money, Duration, Timestamp or JSON
+	Table          string   `mapstructure:"table"`
+	Columns        []string `mapstructure:"columns"`
+	CurrencyColumn string   `mapstructure:"currency_column"` // Money only
+	Unit           string   `mapstructure:"unit"`            // "s" or "ms"; Duration and integer Timestamp columns
+}
+
+// JSONSchemaColumnConfig attaches a JSON Schema file to one JSON column.
+type JSONSchemaColumnConfig struct {
+	Table      string `mapstructure:"table"`
+	Column     string `mapstructure:"column"`
+	SchemaFile string `mapstructure:"schema_file"`
+}
+
+// EnumValuesConfig customizes the GraphQL values of one enum column. For
+// lookup enums, Table and Column name the lookup table and its value column.
+type EnumValuesConfig struct {
+	Table  string            `mapstructure:"table"`
+	Column string            `mapstructure:"column"`
+	Values []EnumValueConfig `mapstructure:"values"`
+}
+
+// EnumValueConfig customizes one stored enum value.
+type EnumValueConfig struct {
+	Value             string `mapstructure:"value"`
+	Name              string `mapstructure:"name"`
+	Description       string `mapstructure:"description"`
+	DeprecationReason string `mapstructure:"deprecation_reason"`
+}
+
+// LookupEnumConfig exposes foreign keys referencing ValueColumn of a lookup
+// table as a GraphQL enum built from the table rows.
+type LookupEnumConfig struct {
+	Table             string `mapstructure:"table"`
+	ValueColumn       string `mapstructure:"value_column"`
+	NameColumn        string `mapstructure:"name_column"`        // optional; derived from the value otherwise
+	DescriptionColumn string `mapstructure:"description_column"` // optional
+	TypeName          string `mapstructure:"type_name"`          // optional GraphQL enum type name
 }
 
 // PoolConfig holds connection pool parameters.
@@ -169,6 +235,8 @@ type AuthConfig struct {
 	OIDCIssuerURL           string        `mapstructure:"oidc_issuer_url"`
 	OIDCAudience            string        `mapstructure:"oidc_audience"`
 	OIDCCAFile              string        `mapstructure:"oidc_ca_file"`
+	OIDCJWKSURL             string        `mapstructure:"oidc_jwks_url"`  // Skip discovery and fetch keys from this URL
+	OIDCJWKSFile            string        `mapstructure:"oidc_jwks_file"` // Skip discovery and verify against this local JWKS
 	OIDCClockSkew           time.Duration `mapstructure:"oidc_clock_skew"`
 	DBRoleEnabled           bool          `mapstructure:"db_role_enabled"`
 	DBRoleClaimName         string        `mapstructure:"db_role_claim_name"`
@@ -176,6 +244,84 @@ type AuthConfig struct {
 	RoleSchemaInclude       []string      `mapstructure:"role_schema_include"`
 	RoleSchemaExclude       []string      `mapstructure:"role_schema_exclude"`
 	RoleSchemaMaxRoles      int           `mapstructure:"role_schema_max_roles"`
+
+	// ClientCertRoles maps verified client certificate identities to database
+	// roles for callers that authenticate with mTLS instead of a JWT.
+	ClientCertRoles []ClientCertRoleConfig `mapstructure:"client_cert_roles"`
+
+	// DBRoleRules derive the database role of JWT callers from their claims,
+	// replacing the single db_role_claim_name lookup when set.
+	DBRoleRules []DBRoleRuleConfig `mapstructure:"db_role_rules"`
+
+	// OIDCIssuers lists further trusted token issuers next to oidc_issuer_url.
+	// Tokens are matched to an issuer by their "iss" claim.
+	OIDCIssuers []OIDCIssuerConfig `mapstructure:"oidc_issuers"`
+
+	// APIKeyFile is a YAML or JSON file of hashed API keys accepted in the
+	// X-API-Key header. Each key carries its own role, scopes and rate limit.
+	APIKeyFile           string        `mapstructure:"api_key_file"`
+	APIKeyReloadInterval time.Duration `mapstructure:"api_key_reload_interval"` // 0 disables reloading
+
+	// Introspection validates opaque bearer tokens against an OAuth2 token
+	// introspection endpoint (RFC 7662). With OIDC also enabled, JWTs keep
+	// going through OIDC verification.
+	IntrospectionEnabled          bool          `mapstructure:"introspection_enabled"`
+	IntrospectionURL              string        `mapstructure:"introspection_url"`
+	IntrospectionClientID         string        `mapstructure:"introspection_client_id"`
+	IntrospectionClientSecret     string        `mapstructure:"introspection_client_secret"`
+	IntrospectionClientSecretFile string        `mapstructure:"introspection_client_secret_file"`
+	IntrospectionCAFile           string        `mapstructure:"introspection_ca_file"`
+	IntrospectionAudience         string        `mapstructure:"introspection_audience"`  // Optional; required "aud" value
+	IntrospectionCacheTTL         time.Duration `mapstructure:"introspection_cache_ttl"` // 0 disables caching; never beyond the token's exp
+	IntrospectionCacheMaxEntries  int           `mapstructure:"introspection_cache_max_entries"`
+
+	// FieldRules guard GraphQL fields with OAuth scopes, database roles or
+	// claim values, checked per field at resolve time.
+	FieldRules []FieldRuleConfig `mapstructure:"field_rules"`
+}
+
+// FieldRuleConfig requires callers of one GraphQL field to hold all Scopes,
+// one of Roles and one of Values for Claim, whichever are set.
+type FieldRuleConfig struct {
+	Name   string   `mapstructure:"name"`
+	Field  string   `mapstructure:"field"` // "Type.field" or a root field name such as "deleteOrder"
+	Scopes []string `mapstructure:"scopes"`
+	Roles  []string `mapstructure:"roles"` // Matched against the request's database role
+	Claim  string   `mapstructure:"claim"`
+	Values []string `mapstructure:"values"`
+}
+
+// ClientCertRoleConfig maps one client certificate identity to a database role.
+type ClientCertRoleConfig struct {
+	Identity string `mapstructure:"identity"` // Subject DN, common name, or DNS/URI/email/IP SAN
+	Role     string `mapstructure:"role"`
+}
+
+// DBRoleRuleConfig maps JWT claims to a database role. The rule matches when
+// the claim (or any element of a list claim) equals one of Values or matches
+// Pattern. A rule without a claim matches every token and acts as a default.
+type DBRoleRuleConfig struct {
+	Name     string   `mapstructure:"name"`
+	Issuer   string   `mapstructure:"issuer"` // Optional; limit the rule to one issuer
+	Claim    string   `mapstructure:"claim"`
+	Values   []string `mapstructure:"values"`
+	Pattern  string   `mapstructure:"pattern"` // Go regular expression
+	Role     string   `mapstructure:"role"`
+	Priority int      `mapstructure:"priority"` // Higher runs first; ties keep list order
+}
+
+// OIDCIssuerConfig is one trusted OIDC issuer.
+type OIDCIssuerConfig struct {
+	IssuerURL string `mapstructure:"issuer_url"`
+	Audience  string `mapstructure:"audience"`
+	CAFile    string `mapstructure:"ca_file"`
+	JWKSURL   string `mapstructure:"jwks_url"`  // Skip discovery and fetch keys from this URL
+	JWKSFile  string `mapstructure:"jwks_file"` // Skip discovery and verify against this local JWKS
+	// DBRoleClaimName overrides server.auth.db_role_claim_name for this issuer's tokens.
+	DBRoleClaimName string `mapstructure:"db_role_claim_name"`
+	// ClaimMappings copies token claims before use: target claim -> source claim.
+	// Registered JWT claims (sub, exp, ...) cannot be targets.
+	ClaimMappings map[string]string `mapstructure:"claim_mappings"`
 }
 
 // SearchConfig holds vector search configuration.
@@ -187,44 +333,88 @@ type SearchConfig struct {
 // AdminConfig controls administrative endpoint exposure and authentication.
 type AdminConfig struct {
 	SchemaReloadEnabled bool   `mapstructure:"schema_reload_enabled"`
+	ExplainEnabled      bool   `mapstructure:"explain_enabled"`
+	StatusEnabled       bool   `mapstructure:"status_enabled"`
+	DrainEnabled        bool   `mapstructure:"drain_enabled"`
 	AuthToken           string `mapstructure:"auth_token"`
 	AuthTokenFile       string `mapstructure:"auth_token_file"`
 }
 
+// TransactionsConfig controls interactive multi-request transactions.
+type TransactionsConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	IdleTimeout   time.Duration `mapstructure:"idle_timeout"`
+	MaxLifetime   time.Duration `mapstructure:"max_lifetime"`
+	MaxPerSubject int           `mapstructure:"max_per_subject"`
+	MaxOpen       int           `mapstructure:"max_open"`
+}
+
 // ServerConfig holds HTTP server parameters.
 type ServerConfig struct {
-	Port                     int           `mapstructure:"port"`
-	GraphQLMaxDepth          int           `mapstructure:"graphql_max_depth"`
-	GraphQLMaxComplexity     int           `mapstructure:"graphql_max_complexity"`
-	GraphQLMaxRows           int           `mapstructure:"graphql_max_rows"`
-	GraphQLDefaultLimit      int           `mapstructure:"graphql_default_limit"`
-	SchemaRefreshMinInterval time.Duration `mapstructure:"schema_refresh_min_interval"`
-	SchemaRefreshMaxInterval time.Duration `mapstructure:"schema_refresh_max_interval"`
-	GraphiQLEnabled          bool          `mapstructure:"graphiql_enabled"`
-	Search                   SearchConfig  `mapstructure:"search"`
-	Auth                     AuthConfig    `mapstructure:"auth"`
-	Admin                    AdminConfig   `mapstructure:"admin"`
-	RateLimitEnabled         bool          `mapstructure:"rate_limit_enabled"`
-	RateLimitRPS             float64       `mapstructure:"rate_limit_rps"`
-	RateLimitBurst           int           `mapstructure:"rate_limit_burst"`
-	CORSEnabled              bool          `mapstructure:"cors_enabled"`
-	CORSAllowedOrigins       []string      `mapstructure:"cors_allowed_origins"`
-	CORSAllowedMethods       []string      `mapstructure:"cors_allowed_methods"`
-	CORSAllowedHeaders       []string      `mapstructure:"cors_allowed_headers"`
-	CORSExposeHeaders        []string      `mapstructure:"cors_expose_headers"`
-	CORSAllowCredentials     bool          `mapstructure:"cors_allow_credentials"`
-	CORSMaxAge               int           `mapstructure:"cors_max_age"`
-	ReadTimeout              time.Duration `mapstructure:"read_timeout"`
-	WriteTimeout             time.Duration `mapstructure:"write_timeout"`
-	IdleTimeout              time.Duration `mapstructure:"idle_timeout"`
-	ShutdownTimeout          time.Duration `mapstructure:"shutdown_timeout"`
-	HealthCheckTimeout       time.Duration `mapstructure:"health_check_timeout"`
+	Port                     int                `mapstructure:"port"`
+	GraphQLMaxDepth          int                `mapstructure:"graphql_max_depth"`
+	GraphQLMaxComplexity     int                `mapstructure:"graphql_max_complexity"`
+	GraphQLMaxRows           int                `mapstructure:"graphql_max_rows"`
+	GraphQLDefaultLimit      int                `mapstructure:"graphql_default_limit"`
+	GraphQLTimeZone          string             `mapstructure:"graphql_time_zone"`
+	SchemaRefreshMinInterval time.Duration      `mapstructure:"schema_refresh_min_interval"`
+	SchemaRefreshMaxInterval time.Duration      `mapstructure:"schema_refresh_max_interval"`
+	GraphiQLEnabled          bool               `mapstructure:"graphiql_enabled"`
+	Search                   SearchConfig       `mapstructure:"search"`
+	Auth                     AuthConfig         `mapstructure:"auth"`
+	Admin                    AdminConfig        `mapstructure:"admin"`
+	Transactions             TransactionsConfig `mapstructure:"transactions"`
+	RateLimitEnabled         bool               `mapstructure:"rate_limit_enabled"`
+	RateLimitRPS             float64            `mapstructure:"rate_limit_rps"`
+	RateLimitBurst           int                `mapstructure:"rate_limit_burst"`
+	CORSEnabled              bool               `mapstructure:"cors_enabled"`
+	CORSAllowedOrigins       []string           `mapstructure:"cors_allowed_origins"`
+	CORSAllowedMethods       []string           `mapstructure:"cors_allowed_methods"`
+	CORSAllowedHeaders       []string           `mapstructure:"cors_allowed_headers"`
+	CORSExposeHeaders        []string           `mapstructure:"cors_expose_headers"`
+	CORSAllowCredentials     bool               `mapstructure:"cors_allow_credentials"`
+	CORSMaxAge               int                `mapstructure:"cors_max_age"`
+	ReadTimeout              time.Duration      `mapstructure:"read_timeout"`
+	WriteTimeout             time.Duration      `mapstructure:"write_timeout"`
+	IdleTimeout              time.Duration      `mapstructure:"idle_timeout"`
+	ShutdownTimeout          time.Duration      `mapstructure:"shutdown_timeout"`
+	DrainTimeout             time.Duration      `mapstructure:"drain_timeout"` // Wait for in-flight mutations before shutdown
+	HealthCheckTimeout       time.Duration      `mapstructure:"health_check_timeout"`
 
 	// TLS Configuration
 	TLSMode        string `mapstructure:"tls_mode"`          // "off", "auto", or "file" (default: "off")
 	TLSCertFile    string `mapstructure:"tls_cert_file"`     // Path to certificate file (for "file" mode)
 	TLSKeyFile     string `mapstructure:"tls_key_file"`      // Path to private key file (for "file" mode)
 	TLSAutoCertDir string `mapstructure:"tls_auto_cert_dir"` // Directory for auto-generated certs (default: ".tls")
+	// TLSReloadInterval is how often file-based server and database certificates
+	// are checked for rotation (0 disables reloading).
+	TLSReloadInterval time.Duration `mapstructure:"tls_reload_interval"`
+
+	// Client certificate (mTLS) verification
+	TLSClientAuth   string `mapstructure:"tls_client_auth"`    // "off", "request", or "require" (default: "off")
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file"` // CA bundle used to verify client certificates
+
+	// GraphQLLimitOverrides replace graphql_max_* for matching requests. The
+	// first override whose matchers all hold applies.
+	GraphQLLimitOverrides []GraphQLLimitOverrideConfig `mapstructure:"graphql_limit_overrides"`
+}
+
+// GraphQLLimitOverrideConfig sets query limits for requests made with one of
+// Roles, carrying one of Values for Claim, naming one of Operations, or sending
+// a document whose operation hash is one of OperationHashes. Zero limits
+// inherit the server-wide value.
+type GraphQLLimitOverrideConfig struct {
+	Name       string   `mapstructure:"name"`
+	Roles      []string `mapstructure:"roles"` // Matched against the request's database role
+	Claim      string   `mapstructure:"claim"`
+	Values     []string `mapstructure:"values"`
+	Operations []string `mapstructure:"operations"` // GraphQL operation names
+	// OperationHashes pin the override to vetted documents by the
+	// operation_hash reported in request logs.
+	OperationHashes []string `mapstructure:"operation_hashes"`
+	MaxDepth        int      `mapstructure:"max_depth"`
+	MaxComplexity   int      `mapstructure:"max_complexity"`
+	MaxRows         int      `mapstructure:"max_rows"`
 }
 
 // LoggingConfig holds logging parameters.
@@ -2,12 +2,16 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"net"
 	"net/url"
 	"path"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"tidb-graphql/internal/introspection"
 	"tidb-graphql/internal/naming"
 	"tidb-graphql/internal/schemafilter"
 )
@@ -79,6 +83,9 @@ func (c *Config) Validate() *ValidationResult {
 	// Validate naming config
 	validateNamingConfig(result, c.Naming)
 
+	// Validate deprecations
+	validateDeprecations(result, c.Deprecations)
+
 	return result
 }
 
@@ -86,6 +93,165 @@ func (t *TypeMappingsConfig) validate(result *ValidationResult) {
 	validatePatternMap(result, "type_mappings.uuid_columns", t.UUIDColumns)
 	validatePatternMap(result, "type_mappings.tinyint1_boolean_columns", t.TinyInt1BooleanColumns)
 	validatePatternMap(result, "type_mappings.tinyint1_int_columns", t.TinyInt1IntColumns)
+	validateTimeZone(result, "type_mappings.datetime_time_zone", t.DateTimeTimeZone)
+	for i, mapping := range t.ScalarColumns {
+		field := fmt.Sprintf("type_mappings.scalar_columns[%d]", i)
+		if _, ok := introspection.ScalarMappingTypes[mapping.Scalar]; !ok {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".scalar",
+				Message: fmt.Sprintf("unknown scalar %q", mapping.Scalar),
+				Hint:    "use Email, URL, IPAddress, Money, Duration, Timestamp or JSON",
+			})
+		}
+		if strings.TrimSpace(mapping.Table) == "" || len(mapping.Columns) == 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "scalar mapping requires table and columns",
+			})
+		} else {
+			validateGlobList(result, field+".table", []string{mapping.Table})
+			validateGlobList(result, field+".columns", mapping.Columns)
+		}
+		if (mapping.Scalar == "Money") != (strings.TrimSpace(mapping.CurrencyColumn) != "") {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".currency_column",
+				Message: "currency_column is required for Money and only allowed for Money",
+			})
+		}
+		if mapping.Unit != "" && (mapping.Scalar != "Duration" && mapping.Scalar != "Timestamp" || mapping.Unit != "s" && mapping.Unit != "ms") {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".unit",
+				Message: fmt.Sprintf("invalid unit %q", mapping.Unit),
+				Hint:    `unit is "s" or "ms" and applies to Duration and Timestamp mappings`,
+			})
+		}
+	}
+	seen := make(map[string]bool, len(t.JSONSchemaColumns))
+	for i, mapping := range t.JSONSchemaColumns {
+		field := fmt.Sprintf("type_mappings.json_schema_columns[%d]", i)
+		if strings.TrimSpace(mapping.Table) == "" || strings.TrimSpace(mapping.Column) == "" || strings.TrimSpace(mapping.SchemaFile) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "JSON schema mapping requires table, column and schema_file",
+			})
+			continue
+		}
+		key := strings.ToLower(mapping.Table + "." + mapping.Column)
+		if seen[key] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("duplicate JSON schema mapping for %s.%s", mapping.Table, mapping.Column),
+			})
+		}
+		seen[key] = true
+	}
+	validateEnumValues(result, t.EnumValues)
+	validateLookupEnums(result, t.LookupEnums)
+}
+
+var graphQLNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+func validateEnumValues(result *ValidationResult, mappings []EnumValuesConfig) {
+	seen := make(map[string]bool, len(mappings))
+	for i, mapping := range mappings {
+		field := fmt.Sprintf("type_mappings.enum_values[%d]", i)
+		if strings.TrimSpace(mapping.Table) == "" || strings.TrimSpace(mapping.Column) == "" || len(mapping.Values) == 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "enum value mapping requires table, column and values",
+			})
+			continue
+		}
+		key := strings.ToLower(mapping.Table + "." + mapping.Column)
+		if seen[key] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("duplicate enum value mapping for %s.%s", mapping.Table, mapping.Column),
+			})
+		}
+		seen[key] = true
+
+		values := make(map[string]bool, len(mapping.Values))
+		names := make(map[string]bool, len(mapping.Values))
+		for j, value := range mapping.Values {
+			valueField := fmt.Sprintf("%s.values[%d]", field, j)
+			if values[value.Value] {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   valueField + ".value",
+					Message: fmt.Sprintf("duplicate enum value %q", value.Value),
+				})
+			}
+			values[value.Value] = true
+			if value.Name == "" {
+				continue
+			}
+			if !graphQLNamePattern.MatchString(value.Name) || value.Name == "true" || value.Name == "false" || value.Name == "null" {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   valueField + ".name",
+					Message: fmt.Sprintf("invalid GraphQL enum value name %q", value.Name),
+					Hint:    "use letters, digits and underscores, not starting with a digit",
+				})
+			}
+			if names[value.Name] {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   valueField + ".name",
+					Message: fmt.Sprintf("duplicate GraphQL enum value name %q", value.Name),
+				})
+			}
+			names[value.Name] = true
+		}
+	}
+}
+
+func validateLookupEnums(result *ValidationResult, lookups []LookupEnumConfig) {
+	seen := make(map[string]bool, len(lookups))
+	for i, lookup := range lookups {
+		field := fmt.Sprintf("type_mappings.lookup_enums[%d]", i)
+		if strings.TrimSpace(lookup.Table) == "" || strings.TrimSpace(lookup.ValueColumn) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "lookup enum requires table and value_column",
+			})
+			continue
+		}
+		key := strings.ToLower(lookup.Table)
+		if seen[key] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("duplicate lookup enum for %s", lookup.Table),
+			})
+		}
+		seen[key] = true
+		if lookup.TypeName != "" && !pascalCaseTypePattern.MatchString(lookup.TypeName) {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".type_name",
+				Message: fmt.Sprintf("invalid GraphQL type name %q", lookup.TypeName),
+				Hint:    "use PascalCase letters and digits",
+			})
+		}
+	}
+}
+
+func validateDeprecations(result *ValidationResult, deprecations []DeprecationConfig) {
+	seen := make(map[string]bool, len(deprecations))
+	for i, deprecation := range deprecations {
+		field := fmt.Sprintf("deprecations[%d]", i)
+		if strings.TrimSpace(deprecation.Table) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".table",
+				Message: "deprecation requires a table",
+			})
+			continue
+		}
+		key := strings.ToLower(deprecation.Table + "." + deprecation.Column)
+		if seen[key] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("duplicate deprecation for %s", strings.TrimSuffix(deprecation.Table+"."+deprecation.Column, ".")),
+			})
+		}
+		seen[key] = true
+	}
 }
 
 func validateSchemaFilters(result *ValidationResult, filters schemafilter.Config) {
@@ -110,6 +276,53 @@ var mutationReservedTypeNames = map[string]bool{
 var pascalCaseTypePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
 
 func validateNamingConfig(result *ValidationResult, cfg naming.Config) {
+	switch cfg.FieldCase {
+	case "", naming.FieldCaseCamel, naming.FieldCaseSnake, naming.FieldCasePreserve:
+	default:
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "naming.field_case",
+			Message: fmt.Sprintf("unknown field case %q", cfg.FieldCase),
+			Hint:    "use camel, snake or preserve",
+		})
+	}
+	for _, prefix := range cfg.StripTablePrefixes {
+		if strings.TrimSpace(prefix) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "naming.strip_table_prefixes",
+				Message: "table prefix cannot be empty",
+			})
+		}
+	}
+	for tableName, constraints := range cfg.RelationshipOverrides {
+		for constraintName, override := range constraints {
+			field := fmt.Sprintf("naming.relationship_overrides.%s.%s", tableName, constraintName)
+			for _, dir := range []struct {
+				name   string
+				value  string
+				hidden bool
+			}{
+				{"forward", override.Forward, override.HideForward},
+				{"reverse", override.Reverse, override.HideReverse},
+			} {
+				if dir.value == "" {
+					continue
+				}
+				if !graphQLNamePattern.MatchString(dir.value) {
+					result.Errors = append(result.Errors, ValidationError{
+						Field:   field + "." + dir.name,
+						Message: fmt.Sprintf("%q is not a valid GraphQL field name", dir.value),
+					})
+				}
+				if dir.hidden {
+					result.Errors = append(result.Errors, ValidationError{
+						Field:   field + "." + dir.name,
+						Message: fmt.Sprintf("%s relationship is both named and hidden", dir.name),
+						Hint:    fmt.Sprintf("remove %s or hide_%s", dir.name, dir.name),
+					})
+				}
+			}
+		}
+	}
 	for tableName, typeName := range cfg.TypeOverrides {
 		tableName = strings.TrimSpace(tableName)
 		typeName = strings.TrimSpace(typeName)
@@ -393,6 +606,13 @@ func validateDatabasesArray(result *ValidationResult, d *DatabaseConfig) {
 		}
 		if entry.Naming != nil {
 			validateNamingConfig(result, *entry.Naming)
+			if entry.Naming.FieldCase != "" || len(entry.Naming.StripTablePrefixes) > 0 || len(entry.Naming.RelationshipOverrides) > 0 {
+				result.Warnings = append(result.Warnings, ValidationWarning{
+					Field:   "database.databases.naming",
+					Message: "field_case, strip_table_prefixes and relationship_overrides are ignored per database",
+					Hint:    "set them under the top-level naming section",
+				})
+			}
 		}
 	}
 }
@@ -490,12 +710,14 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 			Message: "graphql_max_rows cannot be negative",
 		})
 	}
+	validateLimitOverrides(result, s)
 	if s.GraphQLDefaultLimit < 0 {
 		result.Errors = append(result.Errors, ValidationError{
 			Field:   "server.graphql_default_limit",
 			Message: "graphql_default_limit cannot be negative",
 		})
 	}
+	validateTimeZone(result, "server.graphql_time_zone", s.GraphQLTimeZone)
 	if s.Search.VectorMaxTopK < 0 {
 		result.Errors = append(result.Errors, ValidationError{
 			Field:   "server.search.vector_max_top_k",
@@ -503,6 +725,61 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 		})
 	}
 
+	if s.DrainTimeout < 0 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.drain_timeout",
+			Message: "drain_timeout cannot be negative",
+		})
+	} else if s.ShutdownTimeout > 0 && s.DrainTimeout >= s.ShutdownTimeout {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.drain_timeout",
+			Message: "drain_timeout is not shorter than shutdown_timeout; the HTTP server may have no time left to close connections",
+			Hint:    "set server.drain_timeout below server.shutdown_timeout",
+		})
+	}
+
+	if s.Transactions.Enabled {
+		if s.Transactions.IdleTimeout <= 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "server.transactions.idle_timeout",
+				Message: "idle_timeout must be greater than 0 when interactive transactions are enabled",
+			})
+		}
+		if s.Transactions.MaxLifetime <= 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "server.transactions.max_lifetime",
+				Message: "max_lifetime must be greater than 0 when interactive transactions are enabled",
+			})
+		} else if s.Transactions.IdleTimeout > s.Transactions.MaxLifetime {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   "server.transactions.idle_timeout",
+				Message: "idle_timeout exceeds max_lifetime; transactions will hit max_lifetime first",
+			})
+		}
+		if s.Transactions.MaxPerSubject <= 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "server.transactions.max_per_subject",
+				Message: "max_per_subject must be greater than 0 when interactive transactions are enabled",
+			})
+		}
+		if s.Transactions.MaxOpen <= 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "server.transactions.max_open",
+				Message: "max_open must be greater than 0 when interactive transactions are enabled",
+				Hint:    "each open transaction holds a database connection; keep this below database.pool.max_open",
+			})
+		}
+		clientCertAuth := s.TLSClientAuth == "request" || s.TLSClientAuth == "require"
+		apiKeysEnabled := strings.TrimSpace(s.Auth.APIKeyFile) != ""
+		if !s.Auth.OIDCEnabled && !s.Auth.IntrospectionEnabled && !apiKeysEnabled && !clientCertAuth {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   "server.transactions.enabled",
+				Message: "interactive transactions are enabled without caller authentication",
+				Hint:    "all anonymous callers share one subject and its max_per_subject quota; enable OIDC, token introspection, API keys or client certificates",
+			})
+		}
+	}
+
 	// CORS validation
 	if s.CORSEnabled {
 		if len(s.CORSAllowedOrigins) == 0 {
@@ -565,13 +842,47 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 		}
 	}
 
-	if s.Auth.DBRoleEnabled && !s.Auth.OIDCEnabled {
+	clientCertAuth := s.TLSClientAuth == "request" || s.TLSClientAuth == "require"
+	apiKeysEnabled := strings.TrimSpace(s.Auth.APIKeyFile) != ""
+	if s.Auth.DBRoleEnabled && !s.Auth.OIDCEnabled && !s.Auth.IntrospectionEnabled && !apiKeysEnabled && (!clientCertAuth || len(s.Auth.ClientCertRoles) == 0) {
 		result.Errors = append(result.Errors, ValidationError{
 			Field:   "server.auth.db_role_enabled",
-			Message: "db_role_enabled requires OIDC to be enabled",
-			Hint:    "set server.auth.oidc_enabled=true or disable db_role_enabled",
+			Message: "db_role_enabled requires OIDC, token introspection, API keys or client certificate role mappings",
+			Hint:    "set server.auth.oidc_enabled=true, server.auth.introspection_enabled=true, server.auth.api_key_file, or server.tls_client_auth and server.auth.client_cert_roles, or disable db_role_enabled",
+		})
+	}
+	if s.Auth.APIKeyReloadInterval < 0 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.api_key_reload_interval",
+			Message: "api_key_reload_interval must not be negative",
+			Hint:    "use 0 to disable reloading",
+		})
+	}
+	if apiKeysEnabled && !tlsEnabled {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.auth.api_key_file",
+			Message: "API keys are accepted over plain HTTP",
+			Hint:    "enable server.tls_mode or terminate TLS in front of the server",
 		})
 	}
+	for i, mapping := range s.Auth.ClientCertRoles {
+		if strings.TrimSpace(mapping.Identity) == "" || strings.TrimSpace(mapping.Role) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   fmt.Sprintf("server.auth.client_cert_roles[%d]", i),
+				Message: "client certificate role mapping requires identity and role",
+			})
+		}
+	}
+	if len(s.Auth.ClientCertRoles) > 0 && (!clientCertAuth || !s.Auth.DBRoleEnabled) {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.auth.client_cert_roles",
+			Message: "client certificate role mappings are ignored unless tls_client_auth and db_role_enabled are both set",
+		})
+	}
+
+	validateDBRoleRules(result, s.Auth)
+	validateIntrospection(result, s.Auth, tlsEnabled)
+	validateFieldRules(result, s.Auth)
 
 	if s.Auth.DBRoleEnabled && s.Auth.DBRoleIntrospectionRole == "" {
 		result.Errors = append(result.Errors, ValidationError{
@@ -592,18 +903,20 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 	}
 
 	if s.Auth.OIDCEnabled {
-		if s.Auth.OIDCIssuerURL == "" {
+		if s.Auth.OIDCIssuerURL == "" && len(s.Auth.OIDCIssuers) == 0 {
 			result.Errors = append(result.Errors, ValidationError{
 				Field:   "server.auth.oidc_issuer_url",
 				Message: "issuer URL is required when OIDC is enabled",
+				Hint:    "set server.auth.oidc_issuer_url or list issuers in server.auth.oidc_issuers",
 			})
 		}
-		if s.Auth.OIDCAudience == "" {
+		if (s.Auth.OIDCIssuerURL != "" || len(s.Auth.OIDCIssuers) == 0) && s.Auth.OIDCAudience == "" {
 			result.Errors = append(result.Errors, ValidationError{
 				Field:   "server.auth.oidc_audience",
 				Message: "audience is required when OIDC is enabled",
 			})
 		}
+		validateOIDCIssuers(result, s.Auth)
 	}
 
 	adminTokenConfigured := strings.TrimSpace(s.Admin.AuthToken) != "" || strings.TrimSpace(s.Admin.AuthTokenFile) != ""
@@ -615,7 +928,7 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 				Hint:    "set server.admin.auth_token or server.admin.auth_token_file, or enable server.auth.oidc_enabled",
 			})
 		}
-	} else if adminTokenConfigured {
+	} else if adminTokenConfigured && !s.Admin.ExplainEnabled && !s.Admin.StatusEnabled && !s.Admin.DrainEnabled {
 		result.Warnings = append(result.Warnings, ValidationWarning{
 			Field:   "server.admin.schema_reload_enabled",
 			Message: "admin auth token is configured but schema reload endpoint is disabled",
@@ -623,6 +936,14 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 		})
 	}
 
+	if !s.Admin.SchemaReloadEnabled && (s.Admin.ExplainEnabled || s.Admin.StatusEnabled || s.Admin.DrainEnabled) && !s.Auth.OIDCEnabled && !adminTokenConfigured {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.admin.auth_token",
+			Message: "admin explain, status and drain endpoints require auth token when OIDC is disabled",
+			Hint:    "set server.admin.auth_token or server.admin.auth_token_file, or enable server.auth.oidc_enabled",
+		})
+	}
+
 	if s.Auth.OIDCEnabled && adminTokenConfigured {
 		result.Warnings = append(result.Warnings, ValidationWarning{
 			Field:   "server.admin.auth_token",
@@ -649,6 +970,43 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 		})
 	}
 
+	if s.TLSReloadInterval < 0 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.tls_reload_interval",
+			Message: "tls_reload_interval cannot be negative",
+		})
+	} else if s.TLSReloadInterval == 0 && s.TLSMode == "file" {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.tls_reload_interval",
+			Message: "certificate reloading is disabled; rotated tls_cert_file/tls_key_file are only picked up on restart",
+			Hint:    "set a positive interval such as 1m to swap in rotated certificates",
+		})
+	}
+
+	validClientAuthModes := map[string]bool{"": true, "off": true, "request": true, "require": true}
+	if !validClientAuthModes[s.TLSClientAuth] {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.tls_client_auth",
+			Message: fmt.Sprintf("invalid client auth mode %q", s.TLSClientAuth),
+			Hint:    "valid values are: off, request, require",
+		})
+	}
+	if clientCertAuth {
+		if !tlsEnabled {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "server.tls_client_auth",
+				Message: "client certificate verification requires TLS",
+				Hint:    "set server.tls_mode to auto or file",
+			})
+		}
+		if strings.TrimSpace(s.TLSClientCAFile) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   "server.tls_client_ca_file",
+				Message: fmt.Sprintf("client CA file required when tls_client_auth is '%s'", s.TLSClientAuth),
+			})
+		}
+	}
+
 	if s.TLSMode == "file" {
 		if s.TLSCertFile == "" {
 			result.Errors = append(result.Errors, ValidationError{
@@ -665,6 +1023,295 @@ func (s *ServerConfig) validate(result *ValidationResult) {
 	}
 }
 
+// validateDBRoleRules checks that each claim-to-role rule is named, has a role
+// and a usable matcher.
+func validateDBRoleRules(result *ValidationResult, auth AuthConfig) {
+	names := map[string]bool{}
+	for i, rule := range auth.DBRoleRules {
+		field := fmt.Sprintf("server.auth.db_role_rules[%d]", i)
+		if strings.TrimSpace(rule.Name) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".name",
+				Message: "db role rule requires a name",
+				Hint:    "the name is reported in logs and metrics when the rule matches",
+			})
+		} else if names[rule.Name] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".name",
+				Message: fmt.Sprintf("duplicate db role rule name %q", rule.Name),
+			})
+		}
+		names[rule.Name] = true
+		if strings.TrimSpace(rule.Role) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".role",
+				Message: "db role rule requires a role",
+			})
+		}
+		hasMatcher := len(rule.Values) > 0 || rule.Pattern != ""
+		if rule.Claim == "" && hasMatcher {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".claim",
+				Message: "values and pattern require a claim",
+				Hint:    "omit values and pattern for a default rule",
+			})
+		}
+		if rule.Claim != "" && !hasMatcher {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("db role rule for claim %q requires values or a pattern", rule.Claim),
+			})
+		}
+		if rule.Pattern != "" {
+			if _, err := regexp.Compile(rule.Pattern); err != nil {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   field + ".pattern",
+					Message: fmt.Sprintf("invalid pattern: %v", err),
+				})
+			}
+		}
+	}
+	if len(auth.DBRoleRules) > 0 && (!auth.DBRoleEnabled || (!auth.OIDCEnabled && !auth.IntrospectionEnabled)) {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.auth.db_role_rules",
+			Message: "db role rules only apply to bearer token callers when db_role_enabled is set",
+		})
+	}
+}
+
+// validateFieldRules checks that each field authorization rule names a field
+// and has at least one requirement.
+func validateFieldRules(result *ValidationResult, auth AuthConfig) {
+	for i, rule := range auth.FieldRules {
+		field := fmt.Sprintf("server.auth.field_rules[%d]", i)
+		target := strings.TrimSpace(rule.Field)
+		typeName, fieldName, qualified := strings.Cut(target, ".")
+		if target == "" || (qualified && (typeName == "" || fieldName == "" || strings.Contains(fieldName, "."))) {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".field",
+				Message: fmt.Sprintf("invalid field %q", rule.Field),
+				Hint:    `use "Type.field" (e.g. "User.salary") or a root field name (e.g. "deleteOrder")`,
+			})
+		}
+		if len(rule.Scopes) == 0 && len(rule.Roles) == 0 && strings.TrimSpace(rule.Claim) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "field rule requires scopes, roles or a claim",
+			})
+		}
+		if (strings.TrimSpace(rule.Claim) == "") != (len(rule.Values) == 0) {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".values",
+				Message: "claim and values must be set together",
+			})
+		}
+		if len(rule.Roles) > 0 && !auth.DBRoleEnabled {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   field + ".roles",
+				Message: "role requirements never match unless db_role_enabled is set",
+			})
+		}
+	}
+}
+
+// validateLimitOverrides checks that each query limit override is named, has
+// a matcher and sets at least one limit, and that overrides raising limits do
+// not match on client-chosen operation names alone.
+func validateLimitOverrides(result *ValidationResult, s *ServerConfig) {
+	names := map[string]bool{}
+	for i, override := range s.GraphQLLimitOverrides {
+		field := fmt.Sprintf("server.graphql_limit_overrides[%d]", i)
+		if strings.TrimSpace(override.Name) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".name",
+				Message: "limit override requires a name",
+				Hint:    "the name is reported in limit errors and logs",
+			})
+		} else if names[override.Name] {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".name",
+				Message: fmt.Sprintf("duplicate limit override name %q", override.Name),
+			})
+		}
+		names[override.Name] = true
+		hasClaim := strings.TrimSpace(override.Claim) != ""
+		if len(override.Roles) == 0 && !hasClaim && len(override.Operations) == 0 && len(override.OperationHashes) == 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "limit override requires roles, a claim, operations or operation_hashes",
+			})
+		}
+		if hasClaim != (len(override.Values) > 0) {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".values",
+				Message: "claim and values must be set together",
+			})
+		}
+		if override.MaxDepth < 0 || override.MaxComplexity < 0 || override.MaxRows < 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "limit override values cannot be negative",
+			})
+		}
+		if override.MaxDepth == 0 && override.MaxComplexity == 0 && override.MaxRows == 0 {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "limit override requires max_depth, max_complexity or max_rows",
+			})
+		}
+		if len(override.Roles) > 0 && !s.Auth.DBRoleEnabled {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   field + ".roles",
+				Message: "role matchers never match unless db_role_enabled is set",
+			})
+		}
+		if len(override.Operations) > 0 && len(override.Roles) == 0 && !hasClaim && len(override.OperationHashes) == 0 && raisesLimit(override, s) {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".operations",
+				Message: "operation names are chosen by the client, so an override matching only operations cannot raise limits",
+				Hint:    "add roles or a claim, or pin the documents with operation_hashes",
+			})
+		}
+	}
+}
+
+// raisesLimit reports whether override loosens any server-wide limit.
+func raisesLimit(override GraphQLLimitOverrideConfig, s *ServerConfig) bool {
+	raises := func(value, global int) bool {
+		return global > 0 && value > global
+	}
+	return raises(override.MaxDepth, s.GraphQLMaxDepth) ||
+		raises(override.MaxComplexity, s.GraphQLMaxComplexity) ||
+		raises(override.MaxRows, s.GraphQLMaxRows)
+}
+
+// validateIntrospection checks the token introspection endpoint settings.
+func validateIntrospection(result *ValidationResult, auth AuthConfig, tlsEnabled bool) {
+	if !auth.IntrospectionEnabled {
+		return
+	}
+	if strings.TrimSpace(auth.IntrospectionURL) == "" {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.introspection_url",
+			Message: "introspection URL is required when token introspection is enabled",
+		})
+	} else if !strings.HasPrefix(auth.IntrospectionURL, "https://") {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.introspection_url",
+			Message: "introspection URL must use https",
+			Hint:    "use server.auth.introspection_ca_file for endpoints with a private CA",
+		})
+	}
+	if strings.TrimSpace(auth.IntrospectionClientID) == "" {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.introspection_client_id",
+			Message: "client ID is required when token introspection is enabled",
+			Hint:    "introspection endpoints authenticate the resource server calling them",
+		})
+	}
+	if strings.TrimSpace(auth.IntrospectionClientSecret) != "" && strings.TrimSpace(auth.IntrospectionClientSecretFile) != "" {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.auth.introspection_client_secret",
+			Message: "both introspection_client_secret and introspection_client_secret_file are set; inline secret takes precedence",
+			Hint:    "remove one of the values to avoid ambiguity",
+		})
+	}
+	if auth.IntrospectionCacheTTL < 0 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.introspection_cache_ttl",
+			Message: "introspection_cache_ttl must not be negative",
+			Hint:    "use 0 to disable caching",
+		})
+	}
+	if auth.IntrospectionCacheMaxEntries < 0 {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.introspection_cache_max_entries",
+			Message: "introspection_cache_max_entries must not be negative",
+		})
+	}
+	if !tlsEnabled {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "server.auth.introspection_enabled",
+			Message: "opaque bearer tokens are accepted over plain HTTP",
+			Hint:    "enable server.tls_mode or terminate TLS in front of the server",
+		})
+	}
+}
+
+// validateOIDCIssuers checks the trusted issuer list, including the top-level
+// oidc_issuer_url, for missing fields, conflicting key sources and duplicates.
+func validateOIDCIssuers(result *ValidationResult, auth AuthConfig) {
+	if auth.OIDCJWKSURL != "" && auth.OIDCJWKSFile != "" {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.oidc_jwks_file",
+			Message: "oidc_jwks_url and oidc_jwks_file are mutually exclusive",
+		})
+	}
+	if auth.OIDCIssuerURL == "" && (auth.OIDCJWKSURL != "" || auth.OIDCJWKSFile != "") {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   "server.auth.oidc_issuer_url",
+			Message: "oidc_jwks_url and oidc_jwks_file require oidc_issuer_url",
+			Hint:    "set oidc_issuer_url to the \"iss\" claim of the tokens",
+		})
+	}
+
+	seen := map[string]string{}
+	if auth.OIDCIssuerURL != "" {
+		seen[auth.OIDCIssuerURL] = "server.auth.oidc_issuer_url"
+	}
+	for i, issuer := range auth.OIDCIssuers {
+		field := fmt.Sprintf("server.auth.oidc_issuers[%d]", i)
+		if strings.TrimSpace(issuer.IssuerURL) == "" || strings.TrimSpace(issuer.Audience) == "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field,
+				Message: "OIDC issuer requires issuer_url and audience",
+			})
+			continue
+		}
+		if issuer.JWKSURL != "" && issuer.JWKSFile != "" {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".jwks_file",
+				Message: "jwks_url and jwks_file are mutually exclusive",
+			})
+		}
+		if prev, dup := seen[issuer.IssuerURL]; dup {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   field + ".issuer_url",
+				Message: fmt.Sprintf("issuer %q is already configured by %s", issuer.IssuerURL, prev),
+			})
+			continue
+		}
+		seen[issuer.IssuerURL] = field
+		for _, target := range slices.Sorted(maps.Keys(issuer.ClaimMappings)) {
+			if name := strings.ToLower(strings.TrimSpace(target)); slices.Contains(registeredJWTClaims, name) {
+				result.Errors = append(result.Errors, ValidationError{
+					Field:   field + ".claim_mappings." + name,
+					Message: fmt.Sprintf("claim mapping cannot target the registered claim %q", name),
+					Hint:    "map to a custom claim name, such as the issuer's db_role_claim_name",
+				})
+			}
+		}
+	}
+}
+
+// registeredJWTClaims are set and checked by the issuer, so claim mappings may
+// not overwrite them.
+var registeredJWTClaims = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "azp", "auth_time", "nonce"}
+
+// validateTimeZone checks an optional IANA time zone name; empty means UTC.
+func validateTimeZone(result *ValidationResult, field, name string) {
+	if name == "" {
+		return
+	}
+	if _, err := time.LoadLocation(name); err != nil || name == "Local" {
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("unknown time zone %q", name),
+			Hint:    `use an IANA time zone name such as "UTC" or "Europe/Berlin"`,
+		})
+	}
+}
+
 func validateGlobList(result *ValidationResult, field string, patterns []string) {
 	for _, pattern := range patterns {
 		if strings.TrimSpace(pattern) == "" {
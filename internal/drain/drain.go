@@ -0,0 +1,116 @@
+// Package drain tracks graceful drain state: once draining starts the server
+// fails readiness, refuses new mutations and waits for in-flight writes to
+// commit or roll back before shutting down.
+package drain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultPollInterval is how often Wait re-checks outstanding work.
+const defaultPollInterval = 50 * time.Millisecond
+
+// Status is a point-in-time view of the drain state.
+type Status struct {
+	Draining          bool      `json:"draining"`
+	Since             time.Time `json:"since,omitempty"`
+	InFlightMutations int64     `json:"inFlightMutations"`
+	OpenTransactions  int       `json:"openTransactions"`
+}
+
+// Controller holds the drain flag and counts mutations that are still running.
+// The zero value is not usable; create one with New.
+type Controller struct {
+	draining atomic.Bool
+	inFlight atomic.Int64
+
+	mu               sync.Mutex
+	since            time.Time
+	openTransactions func() int
+
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// New creates a controller. openTransactions reports how many interactive
+// transactions are still open and may be nil when they are disabled.
+func New(openTransactions func() int) *Controller {
+	return &Controller{
+		openTransactions: openTransactions,
+		pollInterval:     defaultPollInterval,
+		now:              time.Now,
+	}
+}
+
+// Start switches the controller into drain mode. It reports whether this call
+// started the drain; later calls are no-ops.
+func (c *Controller) Start() bool {
+	if !c.draining.CompareAndSwap(false, true) {
+		return false
+	}
+	c.mu.Lock()
+	c.since = c.now()
+	c.mu.Unlock()
+	return true
+}
+
+// Draining reports whether drain mode is active.
+func (c *Controller) Draining() bool {
+	return c.draining.Load()
+}
+
+// Begin counts a mutation as in flight. Callers must invoke the returned
+// function exactly once when the mutation's transaction has finished.
+func (c *Controller) Begin() func() {
+	c.inFlight.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() { c.inFlight.Add(-1) })
+	}
+}
+
+// InFlight returns the number of mutations that have not finished yet.
+func (c *Controller) InFlight() int64 {
+	return c.inFlight.Load()
+}
+
+// OpenTransactions returns the number of open interactive transactions.
+func (c *Controller) OpenTransactions() int {
+	if c.openTransactions == nil {
+		return 0
+	}
+	return c.openTransactions()
+}
+
+// Status returns the current drain state.
+func (c *Controller) Status() Status {
+	c.mu.Lock()
+	since := c.since
+	c.mu.Unlock()
+	return Status{
+		Draining:          c.Draining(),
+		Since:             since,
+		InFlightMutations: c.InFlight(),
+		OpenTransactions:  c.OpenTransactions(),
+	}
+}
+
+// Wait blocks until no mutation is in flight and no interactive transaction
+// is open, or until ctx is done. It does not start the drain by itself.
+func (c *Controller) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+	for {
+		if c.InFlight() == 0 && c.OpenTransactions() == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
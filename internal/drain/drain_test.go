@@ -0,0 +1,69 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStartIsIdempotent(t *testing.T) {
+	c := New(nil)
+	if c.Draining() {
+		t.Fatal("expected controller to start out of drain mode")
+	}
+	if !c.Start() {
+		t.Fatal("expected first Start to begin draining")
+	}
+	if c.Start() {
+		t.Fatal("expected second Start to be a no-op")
+	}
+	status := c.Status()
+	if !status.Draining || status.Since.IsZero() {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestWaitForInFlightMutations(t *testing.T) {
+	c := New(nil)
+	c.pollInterval = time.Millisecond
+	release := c.Begin()
+	c.Start()
+
+	done := make(chan error, 1)
+	go func() { done <- c.Wait(context.Background()) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Wait returned early: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	release()
+	release()
+	if err := <-done; err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if c.InFlight() != 0 {
+		t.Fatalf("in flight = %d, want 0 after double release", c.InFlight())
+	}
+}
+
+func TestWaitHonorsDeadlineForOpenTransactions(t *testing.T) {
+	var open atomic.Int64
+	open.Store(1)
+	c := New(func() int { return int(open.Load()) })
+	c.pollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait() error = %v, want deadline exceeded", err)
+	}
+
+	open.Store(0)
+	if err := c.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+}
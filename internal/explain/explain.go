@@ -0,0 +1,240 @@
+// Package explain records the SQL issued while resolving a GraphQL query and
+// attaches TiDB execution plans to it for the admin explain endpoint.
+package explain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"tidb-graphql/internal/dbexec"
+)
+
+// Batching decisions recorded per field.
+const (
+	BatchBatched  = "batched"
+	BatchCacheHit = "batch_cache_hit"
+	// BatchSkippedPrefix is followed by the skip reason, e.g. "skipped:no_primary_key".
+	BatchSkippedPrefix = "skipped:"
+)
+
+// Executor runs the EXPLAIN statements. It should be the same role-aware
+// executor the resolvers use so plans reflect the caller's privileges.
+type Executor interface {
+	QueryContext(ctx context.Context, query string, args ...any) (dbexec.Rows, error)
+}
+
+// Plan is the tabular EXPLAIN output returned by TiDB.
+type Plan struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// Query is one SQL statement executed while resolving a field.
+type Query struct {
+	SQL       string `json:"sql"`
+	Args      []any  `json:"args"`
+	Plan      *Plan  `json:"plan,omitempty"`
+	PlanError string `json:"planError,omitempty"`
+
+	snapshot *dbexec.SnapshotRead
+}
+
+// Field groups the statements and batching decisions of one response path.
+// List indexes are dropped from the path so sibling items share an entry.
+type Field struct {
+	Path     string         `json:"path"`
+	Batching map[string]int `json:"batching,omitempty"`
+	Queries  []*Query       `json:"queries"`
+}
+
+// Recorder collects fields in the order they were first resolved.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	fields []*Field
+	byPath map[string]*Field
+	total  int
+	max    int
+}
+
+// NewRecorder returns a recorder that keeps at most maxQueries statements
+// (0 means unlimited); statements past the limit are counted but dropped.
+func NewRecorder(maxQueries int) *Recorder {
+	return &Recorder{byPath: make(map[string]*Field), max: maxQueries}
+}
+
+type recorderKey struct{}
+type pathKey struct{}
+
+// WithRecorder enables recording for the request.
+func WithRecorder(ctx context.Context, rec *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, rec)
+}
+
+// RecorderFromContext returns the active recorder, or nil when explain is off.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return rec
+}
+
+// WithPath sets the response path statements issued under ctx belong to.
+func WithPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, pathKey{}, path)
+}
+
+// PathFromContext returns the response path set by WithPath.
+func PathFromContext(ctx context.Context) string {
+	path, _ := ctx.Value(pathKey{}).(string)
+	return path
+}
+
+// RecordQuery records a statement for the field in ctx. It is a no-op when no
+// recorder is active.
+func RecordQuery(ctx context.Context, query string, args []any) {
+	rec := RecorderFromContext(ctx)
+	if rec == nil {
+		return
+	}
+	q := &Query{SQL: query, Args: append([]any(nil), args...)}
+	if snapshot, ok := dbexec.SnapshotReadFromContext(ctx); ok {
+		q.snapshot = &snapshot
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.total++
+	if rec.max > 0 && rec.total > rec.max {
+		return
+	}
+	field := rec.fieldLocked(PathFromContext(ctx))
+	field.Queries = append(field.Queries, q)
+}
+
+// RecordBatching counts a batching decision for the field in ctx. It is a
+// no-op when no recorder is active.
+func RecordBatching(ctx context.Context, decision string) {
+	rec := RecorderFromContext(ctx)
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	field := rec.fieldLocked(PathFromContext(ctx))
+	if field.Batching == nil {
+		field.Batching = make(map[string]int)
+	}
+	field.Batching[decision]++
+}
+
+func (r *Recorder) fieldLocked(path string) *Field {
+	if field, ok := r.byPath[path]; ok {
+		return field
+	}
+	field := &Field{Path: path, Queries: []*Query{}}
+	r.byPath[path] = field
+	r.fields = append(r.fields, field)
+	return field
+}
+
+// Fields returns the recorded fields in resolution order.
+func (r *Recorder) Fields() []*Field {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Field(nil), r.fields...)
+}
+
+// Dropped reports how many statements exceeded the recorder limit.
+func (r *Recorder) Dropped() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.max > 0 && r.total > r.max {
+		return r.total - r.max
+	}
+	return 0
+}
+
+// AttachPlans runs EXPLAIN (or EXPLAIN ANALYZE) for every recorded statement.
+// Per-statement failures are reported on the statement; only context
+// cancellation aborts the whole run.
+func (r *Recorder) AttachPlans(ctx context.Context, executor Executor, analyze bool) error {
+	prefix := "EXPLAIN "
+	if analyze {
+		prefix = "EXPLAIN ANALYZE "
+	}
+	for _, field := range r.Fields() {
+		for _, q := range field.Queries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			planCtx := ctx
+			if q.snapshot != nil {
+				planCtx = dbexec.WithSnapshotRead(ctx, *q.snapshot)
+			}
+			plan, err := runExplain(planCtx, executor, prefix+q.SQL, q.Args)
+			if err != nil {
+				q.PlanError = err.Error()
+				continue
+			}
+			q.Plan = plan
+		}
+	}
+	return nil
+}
+
+type columnRows interface {
+	Columns() ([]string, error)
+}
+
+func runExplain(ctx context.Context, executor Executor, query string, args []any) (*Plan, error) {
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	withColumns, ok := rows.(columnRows)
+	if !ok {
+		return nil, errors.New("executor does not expose result columns")
+	}
+	columns, err := withColumns.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Columns: columns, Rows: [][]string{}}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan plan row: %w", err)
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		plan.Rows = append(plan.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// PathString renders a GraphQL response path without list indexes,
+// e.g. ["users", "nodes", 0, "posts"] becomes "users.nodes.posts".
+func PathString(keys []any) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if s, ok := key.(string); ok {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,86 @@
+package explain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	"tidb-graphql/internal/dbexec"
+)
+
+func TestRecorderGroupsByPath(t *testing.T) {
+	rec := NewRecorder(2)
+	ctx := WithRecorder(context.Background(), rec)
+	users := WithPath(ctx, "users")
+	posts := WithPath(ctx, PathString([]any{"users", "nodes", 3, "posts"}))
+
+	RecordQuery(users, "SELECT 1", []any{1})
+	RecordBatching(posts, BatchBatched)
+	RecordQuery(posts, "SELECT 2", nil)
+	RecordBatching(posts, BatchCacheHit)
+	RecordBatching(posts, BatchCacheHit)
+	RecordQuery(posts, "SELECT 3", nil)
+
+	fields := rec.Fields()
+	if len(fields) != 2 {
+		t.Fatalf("fields = %d, want 2", len(fields))
+	}
+	if fields[0].Path != "users" || len(fields[0].Queries) != 1 {
+		t.Fatalf("unexpected first field: %+v", fields[0])
+	}
+	if fields[1].Path != "users.nodes.posts" {
+		t.Fatalf("path = %q, want users.nodes.posts", fields[1].Path)
+	}
+	if fields[1].Batching[BatchBatched] != 1 || fields[1].Batching[BatchCacheHit] != 2 {
+		t.Fatalf("unexpected batching: %v", fields[1].Batching)
+	}
+	if len(fields[1].Queries) != 1 || rec.Dropped() != 1 {
+		t.Fatalf("expected the third statement to be dropped, queries=%d dropped=%d", len(fields[1].Queries), rec.Dropped())
+	}
+}
+
+func TestRecordWithoutRecorderIsNoop(t *testing.T) {
+	ctx := WithPath(context.Background(), "users")
+	RecordQuery(ctx, "SELECT 1", nil)
+	RecordBatching(ctx, BatchBatched)
+	if RecorderFromContext(ctx) != nil {
+		t.Fatal("expected no recorder")
+	}
+}
+
+func TestAttachPlans(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	rec := NewRecorder(0)
+	ctx := WithPath(WithRecorder(context.Background(), rec), "users")
+	RecordQuery(ctx, "SELECT id FROM users WHERE id = ?", []any{7})
+	RecordQuery(ctx, "SELECT secret FROM users", nil)
+
+	mock.ExpectQuery("EXPLAIN ANALYZE SELECT id FROM users WHERE id = ?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "estRows", "task"}).
+			AddRow("Point_Get_1", "1.00", "root"))
+	mock.ExpectQuery("EXPLAIN ANALYZE SELECT secret FROM users").
+		WillReturnError(errors.New("SELECT command denied"))
+
+	if err := rec.AttachPlans(context.Background(), dbexec.NewStandardExecutor(db), true); err != nil {
+		t.Fatalf("AttachPlans() error = %v", err)
+	}
+	queries := rec.Fields()[0].Queries
+	plan := queries[0].Plan
+	if plan == nil || len(plan.Rows) != 1 || plan.Columns[0] != "id" || plan.Rows[0][0] != "Point_Get_1" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if queries[1].Plan != nil || queries[1].PlanError == "" {
+		t.Fatalf("expected per-statement plan error, got %+v", queries[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,520 @@
+// Package geojson converts between GeoJSON geometry objects (RFC 7946) and
+// the WKB-based storage format of MySQL-compatible GEOMETRY columns.
+//
+// Only two-dimensional geometries are supported. Positions are written as
+// [x, y] in the axis order stored by the column; the package never reorders
+// or reprojects coordinates.
+package geojson
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Geometry type names.
+const (
+	TypePoint              = "Point"
+	TypeLineString         = "LineString"
+	TypePolygon            = "Polygon"
+	TypeMultiPoint         = "MultiPoint"
+	TypeMultiLineString    = "MultiLineString"
+	TypeMultiPolygon       = "MultiPolygon"
+	TypeGeometryCollection = "GeometryCollection"
+)
+
+// wkbTypes maps geometry type names to their WKB type codes.
+var wkbTypes = map[string]uint32{
+	TypePoint:              1,
+	TypeLineString:         2,
+	TypePolygon:            3,
+	TypeMultiPoint:         4,
+	TypeMultiLineString:    5,
+	TypeMultiPolygon:       6,
+	TypeGeometryCollection: 7,
+}
+
+// maxNesting bounds GeometryCollection depth while decoding untrusted input.
+const maxNesting = 8
+
+// Position is an [x, y] coordinate pair.
+type Position [2]float64
+
+// Geometry is a parsed GeoJSON geometry. Exactly one coordinate field is used,
+// depending on Type.
+type Geometry struct {
+	Type string
+	// Point holds the coordinates of a Point.
+	Point Position
+	// Line holds the positions of a LineString or MultiPoint.
+	Line []Position
+	// Lines holds the rings of a Polygon or the lines of a MultiLineString.
+	Lines [][]Position
+	// Polygons holds the polygons of a MultiPolygon.
+	Polygons [][][]Position
+	// Geometries holds the members of a GeometryCollection.
+	Geometries []Geometry
+}
+
+// Box returns the rectangular polygon spanning the given bounds.
+func Box(minX, minY, maxX, maxY float64) Geometry {
+	return Geometry{
+		Type: TypePolygon,
+		Lines: [][]Position{{
+			{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}, {minX, minY},
+		}},
+	}
+}
+
+// Parse validates a decoded GeoJSON geometry object. A JSON string holding an
+// object is decoded first.
+func Parse(value interface{}) (Geometry, error) {
+	if raw, ok := value.(string); ok {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+			return Geometry{}, fmt.Errorf("GeoJSON must be an object")
+		}
+		value = decoded
+	}
+	return parseGeometry(value, 0)
+}
+
+func parseGeometry(value interface{}, depth int) (Geometry, error) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return Geometry{}, fmt.Errorf("GeoJSON must be an object")
+	}
+	typ, _ := obj["type"].(string)
+	g := Geometry{Type: typ}
+	if typ == TypeGeometryCollection {
+		if depth >= maxNesting {
+			return Geometry{}, fmt.Errorf("GeometryCollection nesting is too deep")
+		}
+		members, ok := obj["geometries"].([]interface{})
+		if !ok {
+			return Geometry{}, fmt.Errorf("GeometryCollection requires geometries")
+		}
+		for _, member := range members {
+			child, err := parseGeometry(member, depth+1)
+			if err != nil {
+				return Geometry{}, err
+			}
+			g.Geometries = append(g.Geometries, child)
+		}
+		return g, nil
+	}
+
+	coords, ok := obj["coordinates"]
+	if !ok {
+		return Geometry{}, fmt.Errorf("%s requires coordinates", describeType(typ))
+	}
+	var err error
+	switch typ {
+	case TypePoint:
+		g.Point, err = parsePosition(coords)
+	case TypeLineString:
+		g.Line, err = parsePositions(coords, 2)
+	case TypeMultiPoint:
+		g.Line, err = parsePositions(coords, 1)
+	case TypePolygon:
+		g.Lines, err = parseRings(coords)
+	case TypeMultiLineString:
+		g.Lines, err = parseLines(coords, 2)
+	case TypeMultiPolygon:
+		items, ok := coords.([]interface{})
+		if !ok || len(items) == 0 {
+			return Geometry{}, fmt.Errorf("MultiPolygon coordinates must be a non-empty array")
+		}
+		for _, item := range items {
+			rings, err := parseRings(item)
+			if err != nil {
+				return Geometry{}, err
+			}
+			g.Polygons = append(g.Polygons, rings)
+		}
+	default:
+		return Geometry{}, fmt.Errorf("unsupported geometry type %s", describeType(typ))
+	}
+	if err != nil {
+		return Geometry{}, fmt.Errorf("invalid %s: %w", typ, err)
+	}
+	return g, nil
+}
+
+func describeType(typ string) string {
+	if typ == "" {
+		return "(missing)"
+	}
+	return fmt.Sprintf("%q", typ)
+}
+
+func parsePosition(value interface{}) (Position, error) {
+	items, ok := value.([]interface{})
+	if !ok || len(items) != 2 {
+		return Position{}, fmt.Errorf("positions must be [x, y]")
+	}
+	var pos Position
+	for i, item := range items {
+		n, ok := toFloat(item)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return Position{}, fmt.Errorf("coordinates must be finite numbers")
+		}
+		pos[i] = n
+	}
+	return pos, nil
+}
+
+func parsePositions(value interface{}, minLen int) ([]Position, error) {
+	items, ok := value.([]interface{})
+	if !ok || len(items) < minLen {
+		return nil, fmt.Errorf("expected at least %d positions", minLen)
+	}
+	out := make([]Position, 0, len(items))
+	for _, item := range items {
+		pos, err := parsePosition(item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, pos)
+	}
+	return out, nil
+}
+
+func parseLines(value interface{}, minLen int) ([][]Position, error) {
+	items, ok := value.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("coordinates must be a non-empty array")
+	}
+	out := make([][]Position, 0, len(items))
+	for _, item := range items {
+		line, err := parsePositions(item, minLen)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, line)
+	}
+	return out, nil
+}
+
+func parseRings(value interface{}) ([][]Position, error) {
+	rings, err := parseLines(value, 4)
+	if err != nil {
+		return nil, err
+	}
+	for _, ring := range rings {
+		if ring[0] != ring[len(ring)-1] {
+			return nil, fmt.Errorf("polygon rings must be closed")
+		}
+	}
+	return rings, nil
+}
+
+// Object returns the GeoJSON object for g, ready for JSON serialization.
+func (g Geometry) Object() map[string]interface{} {
+	obj := map[string]interface{}{"type": g.Type}
+	switch g.Type {
+	case TypePoint:
+		obj["coordinates"] = positionArray(g.Point)
+	case TypeLineString, TypeMultiPoint:
+		obj["coordinates"] = positionsArray(g.Line)
+	case TypePolygon, TypeMultiLineString:
+		obj["coordinates"] = linesArray(g.Lines)
+	case TypeMultiPolygon:
+		polygons := make([]interface{}, 0, len(g.Polygons))
+		for _, rings := range g.Polygons {
+			polygons = append(polygons, linesArray(rings))
+		}
+		obj["coordinates"] = polygons
+	case TypeGeometryCollection:
+		members := make([]interface{}, 0, len(g.Geometries))
+		for _, member := range g.Geometries {
+			members = append(members, member.Object())
+		}
+		obj["geometries"] = members
+	}
+	return obj
+}
+
+func positionArray(p Position) []interface{} {
+	return []interface{}{p[0], p[1]}
+}
+
+func positionsArray(line []Position) []interface{} {
+	out := make([]interface{}, 0, len(line))
+	for _, p := range line {
+		out = append(out, positionArray(p))
+	}
+	return out
+}
+
+func linesArray(lines [][]Position) []interface{} {
+	out := make([]interface{}, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, positionsArray(line))
+	}
+	return out
+}
+
+// WKB encodes g as little-endian well-known binary.
+func (g Geometry) WKB() []byte {
+	var buf bytes.Buffer
+	g.writeWKB(&buf)
+	return buf.Bytes()
+}
+
+func (g Geometry) writeWKB(buf *bytes.Buffer) {
+	buf.WriteByte(1)
+	writeUint32(buf, wkbTypes[g.Type])
+	switch g.Type {
+	case TypePoint:
+		writePosition(buf, g.Point)
+	case TypeLineString:
+		writePositions(buf, g.Line)
+	case TypePolygon:
+		writeLines(buf, g.Lines)
+	case TypeMultiPoint:
+		writeUint32(buf, uint32(len(g.Line)))
+		for _, p := range g.Line {
+			Geometry{Type: TypePoint, Point: p}.writeWKB(buf)
+		}
+	case TypeMultiLineString:
+		writeUint32(buf, uint32(len(g.Lines)))
+		for _, line := range g.Lines {
+			Geometry{Type: TypeLineString, Line: line}.writeWKB(buf)
+		}
+	case TypeMultiPolygon:
+		writeUint32(buf, uint32(len(g.Polygons)))
+		for _, rings := range g.Polygons {
+			Geometry{Type: TypePolygon, Lines: rings}.writeWKB(buf)
+		}
+	case TypeGeometryCollection:
+		writeUint32(buf, uint32(len(g.Geometries)))
+		for _, member := range g.Geometries {
+			member.writeWKB(buf)
+		}
+	}
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func writePosition(buf *bytes.Buffer, p Position) {
+	var b [8]byte
+	for _, v := range p {
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		buf.Write(b[:])
+	}
+}
+
+func writePositions(buf *bytes.Buffer, line []Position) {
+	writeUint32(buf, uint32(len(line)))
+	for _, p := range line {
+		writePosition(buf, p)
+	}
+}
+
+func writeLines(buf *bytes.Buffer, lines [][]Position) {
+	writeUint32(buf, uint32(len(lines)))
+	for _, line := range lines {
+		writePositions(buf, line)
+	}
+}
+
+// Stored encodes g in the column storage format: a little-endian SRID
+// followed by WKB.
+func (g Geometry) Stored(srid uint32) []byte {
+	var buf bytes.Buffer
+	writeUint32(&buf, srid)
+	g.writeWKB(&buf)
+	return buf.Bytes()
+}
+
+// FromStored decodes a value read from a GEOMETRY column.
+func FromStored(data []byte) (Geometry, uint32, error) {
+	if len(data) < 4 {
+		return Geometry{}, 0, fmt.Errorf("geometry value is too short")
+	}
+	srid := binary.LittleEndian.Uint32(data[:4])
+	g, err := FromWKB(data[4:])
+	return g, srid, err
+}
+
+// FromWKB decodes well-known binary in either byte order.
+func FromWKB(data []byte) (Geometry, error) {
+	r := &wkbReader{data: data}
+	g := r.geometry(0)
+	if r.err != nil {
+		return Geometry{}, r.err
+	}
+	if r.pos != len(r.data) {
+		return Geometry{}, fmt.Errorf("unexpected trailing bytes in WKB")
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+	err   error
+}
+
+func (r *wkbReader) fail(format string, args ...interface{}) {
+	if r.err == nil {
+		r.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (r *wkbReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data)-r.pos < n {
+		r.fail("truncated WKB")
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *wkbReader) uint32() uint32 {
+	b := r.take(4)
+	if b == nil {
+		return 0
+	}
+	return r.order.Uint32(b)
+}
+
+// count reads an element count and rejects counts the remaining bytes cannot hold.
+func (r *wkbReader) count(minSize int) int {
+	n := int(r.uint32())
+	if r.err == nil && n*minSize > len(r.data)-r.pos {
+		r.fail("truncated WKB")
+		return 0
+	}
+	return n
+}
+
+func (r *wkbReader) position() Position {
+	b := r.take(16)
+	if b == nil {
+		return Position{}
+	}
+	return Position{
+		math.Float64frombits(r.order.Uint64(b[:8])),
+		math.Float64frombits(r.order.Uint64(b[8:])),
+	}
+}
+
+func (r *wkbReader) positions() []Position {
+	n := r.count(16)
+	out := make([]Position, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		out = append(out, r.position())
+	}
+	return out
+}
+
+func (r *wkbReader) lines() [][]Position {
+	n := r.count(4)
+	out := make([][]Position, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		out = append(out, r.positions())
+	}
+	return out
+}
+
+func (r *wkbReader) geometry(depth int) Geometry {
+	if depth > maxNesting {
+		r.fail("WKB nesting is too deep")
+		return Geometry{}
+	}
+	orderByte := r.take(1)
+	if orderByte == nil {
+		return Geometry{}
+	}
+	switch orderByte[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		r.fail("invalid WKB byte order %d", orderByte[0])
+		return Geometry{}
+	}
+
+	code := r.uint32()
+	var g Geometry
+	for name, c := range wkbTypes {
+		if c == code {
+			g.Type = name
+		}
+	}
+	if g.Type == "" && r.err == nil {
+		r.fail("unsupported WKB geometry type %d", code)
+		return Geometry{}
+	}
+
+	switch g.Type {
+	case TypePoint:
+		g.Point = r.position()
+	case TypeLineString:
+		g.Line = r.positions()
+	case TypePolygon:
+		g.Lines = r.lines()
+	case TypeMultiPoint:
+		for _, member := range r.members(depth, TypePoint) {
+			g.Line = append(g.Line, member.Point)
+		}
+	case TypeMultiLineString:
+		for _, member := range r.members(depth, TypeLineString) {
+			g.Lines = append(g.Lines, member.Line)
+		}
+	case TypeMultiPolygon:
+		for _, member := range r.members(depth, TypePolygon) {
+			g.Polygons = append(g.Polygons, member.Lines)
+		}
+	case TypeGeometryCollection:
+		g.Geometries = r.members(depth, "")
+	}
+	return g
+}
+
+// members reads the nested geometries of a multi-geometry or collection. Each
+// member carries its own byte order; want restricts the member type unless empty.
+func (r *wkbReader) members(depth int, want string) []Geometry {
+	n := r.count(5)
+	out := make([]Geometry, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		member := r.geometry(depth + 1)
+		if want != "" && member.Type != want && r.err == nil {
+			r.fail("unexpected %s member in WKB multi-geometry", member.Type)
+		}
+		out = append(out, member)
+	}
+	return out
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,96 @@
+package geojson
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndObjectRoundTrip(t *testing.T) {
+	inputs := []string{
+		`{"type":"Point","coordinates":[13.4,52.5]}`,
+		`{"type":"LineString","coordinates":[[0,0],[1,1]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		`{"type":"MultiPoint","coordinates":[[0,0],[2,2]]}`,
+		`{"type":"MultiLineString","coordinates":[[[0,0],[1,1]],[[2,2],[3,3]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]}]}`,
+	}
+	for _, input := range inputs {
+		g, err := Parse(input)
+		require.NoError(t, err, input)
+
+		decoded, err := FromWKB(g.WKB())
+		require.NoError(t, err, input)
+		assert.Equal(t, g.Object(), decoded.Object(), input)
+
+		reparsed, err := Parse(g.Object())
+		require.NoError(t, err, input)
+		assert.Equal(t, g, reparsed, input)
+	}
+}
+
+func TestWKBEncoding(t *testing.T) {
+	g, err := Parse(map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.0, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, "0101000000000000000000f03f0000000000000040", hex.EncodeToString(g.WKB()))
+	assert.Equal(t, "e6100000"+"0101000000000000000000f03f0000000000000040", hex.EncodeToString(g.Stored(4326)))
+
+	bigEndian, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	decoded, err := FromWKB(bigEndian)
+	require.NoError(t, err)
+	assert.Equal(t, Position{1, 2}, decoded.Point)
+
+	stored, srid, err := FromStored(g.Stored(4326))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4326), srid)
+	assert.Equal(t, g, stored)
+}
+
+func TestParseRejectsInvalidGeometries(t *testing.T) {
+	cases := map[string]interface{}{
+		"missing type":        `{"coordinates":[0,0]}`,
+		"unsupported type":    `{"type":"Feature","geometry":null}`,
+		"three dimensions":    `{"type":"Point","coordinates":[0,0,0]}`,
+		"short line":          `{"type":"LineString","coordinates":[[0,0]]}`,
+		"open ring":           `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`,
+		"non numeric":         `{"type":"Point","coordinates":["a",0]}`,
+		"not an object":       `[0,0]`,
+		"collection members":  `{"type":"GeometryCollection"}`,
+		"non-object argument": 42,
+	}
+	for name, input := range cases {
+		_, err := Parse(input)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestFromWKBRejectsMalformedInput(t *testing.T) {
+	valid := Geometry{Type: TypeLineString, Line: []Position{{0, 0}, {1, 1}}}.WKB()
+
+	_, err := FromWKB(valid[:len(valid)-1])
+	assert.Error(t, err)
+	_, err = FromWKB(append(append([]byte{}, valid...), 0))
+	assert.Error(t, err)
+	_, err = FromWKB([]byte{2, 1, 0, 0, 0})
+	assert.Error(t, err)
+
+	// A count larger than the remaining data must not allocate or loop.
+	huge, _ := hex.DecodeString("0102000000ffffffff")
+	_, err = FromWKB(huge)
+	assert.Error(t, err)
+
+	// MultiPoint members must be points.
+	multi := Geometry{Type: TypeMultiLineString, Lines: [][]Position{{{0, 0}, {1, 1}}}}.WKB()
+	multi[1] = 4
+	_, err = FromWKB(multi)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "LineString"))
+
+	_, _, err = FromStored([]byte{0, 0})
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package gqlrequest
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,6 +25,12 @@ type Analysis struct {
 
 	OperationName string
 	OperationType string
+	// DryRun is set for mutations requested with @dryRun or DryRunHeader.
+	// Their writes are rolled back after the mutation runs.
+	DryRun bool
+	// TimeZone is the request time zone selected by @timezone or TimeZoneHeader.
+	// Nil means the server default.
+	TimeZone *time.Location
 
 	FieldCount     int
 	SelectionDepth int
@@ -40,6 +47,13 @@ type Analysis struct {
 	CanonicalizeErr error
 }
 
+const (
+	// DryRunDirectiveName is the operation directive that requests a dry-run mutation.
+	DryRunDirectiveName = "dryRun"
+	// DryRunHeader requests a dry-run mutation without changing the document.
+	DryRunHeader = "X-Dry-Run"
+)
+
 // AnalyzeRequest decodes and analyzes a GraphQL request payload.
 func AnalyzeRequest(r *http.Request) *Analysis {
 	envelope, err := DecodeEnvelope(r)
@@ -47,9 +61,39 @@ func AnalyzeRequest(r *http.Request) *Analysis {
 	if err != nil {
 		analysis.DecodeError = err
 	}
+	if analysis.OperationType == "mutation" && dryRunHeaderSet(r.Header.Get(DryRunHeader)) {
+		analysis.DryRun = true
+	}
+	if header := r.Header.Get(TimeZoneHeader); header != "" && analysis.TimeZone == nil && analysis.ValidationError == nil {
+		loc, err := LoadTimeZone(header)
+		if err != nil {
+			analysis.ValidationError = fmt.Errorf("%s: %w", TimeZoneHeader, err)
+		} else {
+			analysis.TimeZone = loc
+			// Re-check @asOf times without an offset now that the zone is known.
+			variables, _ := asof.DecodeVariables(analysis.Envelope.VariablesRaw)
+			if err := asof.ValidateOperation(analysis.Operation, analysis.Fragments, variables, analysis.ValidationTime, loc); err != nil {
+				analysis.ValidationError = err
+			}
+		}
+	}
 	return analysis
 }
 
+func dryRunHeaderSet(value string) bool {
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	return err == nil && enabled
+}
+
+func hasOperationDirective(op *ast.OperationDefinition, name string) bool {
+	for _, directive := range op.Directives {
+		if directive != nil && directive.Name != nil && directive.Name.Value == name {
+			return true
+		}
+	}
+	return false
+}
+
 // AnalyzeEnvelope parses and analyzes a normalized request envelope.
 func AnalyzeEnvelope(env Envelope) *Analysis {
 	analysis := &Analysis{
@@ -89,6 +133,7 @@ func AnalyzeEnvelope(env Envelope) *Analysis {
 	analysis.Operation = op
 	analysis.OperationName = effectiveOperationName(op)
 	analysis.OperationType = string(op.Operation)
+	analysis.DryRun = analysis.OperationType == "mutation" && hasOperationDirective(op, DryRunDirectiveName)
 	analysis.VariableCount = len(op.VariableDefinitions)
 	analysis.ValidationTime = time.Now().UTC()
 
@@ -97,7 +142,11 @@ func AnalyzeEnvelope(env Envelope) *Analysis {
 		analysis.ValidationError = err
 		return analysis
 	}
-	if err := asof.ValidateOperation(op, analysis.Fragments, variables, analysis.ValidationTime); err != nil {
+	if analysis.TimeZone, err = operationTimeZone(op, variables); err != nil {
+		analysis.ValidationError = err
+		return analysis
+	}
+	if err := asof.ValidateOperation(op, analysis.Fragments, variables, analysis.ValidationTime, analysis.TimeZone); err != nil {
 		analysis.ValidationError = err
 		return analysis
 	}
@@ -1,6 +1,12 @@
 package gqlrequest
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
 
 func TestAnalyzeEnvelope_Metadata(t *testing.T) {
 	tests := []struct {
@@ -230,3 +236,114 @@ func TestFramedHashDisambiguatesTuples(t *testing.T) {
 		t.Fatalf("expected framed hash to disambiguate tuple boundaries")
 	}
 }
+
+func TestAnalyzeEnvelope_DryRunDirective(t *testing.T) {
+	dry := AnalyzeEnvelope(Envelope{Query: `mutation M @dryRun { createUser(input: {}) { id } }`})
+	if !dry.DryRun {
+		t.Fatalf("expected @dryRun mutation to be marked as dry run")
+	}
+	wet := AnalyzeEnvelope(Envelope{Query: `mutation M { createUser(input: {}) { id } }`})
+	if wet.DryRun {
+		t.Fatalf("expected mutation without @dryRun to run normally")
+	}
+}
+
+func TestAnalyzeRequest_DryRunHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		header string
+		want   bool
+	}{
+		{name: "mutation with true header", query: `mutation { createUser(input: {}) { id } }`, header: "true", want: true},
+		{name: "mutation with false header", query: `mutation { createUser(input: {}) { id } }`, header: "false", want: false},
+		{name: "mutation with invalid header", query: `mutation { createUser(input: {}) { id } }`, header: "yes please", want: false},
+		{name: "query ignores header", query: `query { users { id } }`, header: "1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"query": tt.query})
+			req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(DryRunHeader, tt.header)
+			if got := AnalyzeRequest(req).DryRun; got != tt.want {
+				t.Fatalf("DryRun = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeEnvelope_TimeZoneDirective(t *testing.T) {
+	literal := AnalyzeEnvelope(Envelope{Query: `query Q @timezone(name: "Europe/Berlin") { users { id } }`})
+	if literal.ValidationError != nil {
+		t.Fatalf("unexpected validation error: %v", literal.ValidationError)
+	}
+	if literal.TimeZone == nil || literal.TimeZone.String() != "Europe/Berlin" {
+		t.Fatalf("TimeZone = %v, want Europe/Berlin", literal.TimeZone)
+	}
+
+	variable := AnalyzeEnvelope(Envelope{
+		Query:        `query Q($tz: String!) @timezone(name: $tz) { users { id } }`,
+		VariablesRaw: []byte(`{"tz":"America/New_York"}`),
+	})
+	if variable.TimeZone == nil || variable.TimeZone.String() != "America/New_York" {
+		t.Fatalf("TimeZone = %v, want America/New_York", variable.TimeZone)
+	}
+
+	none := AnalyzeEnvelope(Envelope{Query: `query Q { users { id } }`})
+	if none.TimeZone != nil {
+		t.Fatalf("expected no time zone without @timezone, got %v", none.TimeZone)
+	}
+
+	for _, query := range []string{
+		`query Q @timezone(name: "Mars/Olympus") { users { id } }`,
+		`query Q @timezone(name: "Local") { users { id } }`,
+	} {
+		if got := AnalyzeEnvelope(Envelope{Query: query}); got.ValidationError == nil {
+			t.Fatalf("expected validation error for %s", query)
+		}
+	}
+}
+
+func TestAnalyzeEnvelope_AsOfLocalTime(t *testing.T) {
+	past := AnalyzeEnvelope(Envelope{Query: `query @timezone(name: "Europe/Berlin") { users @asOf(time: "2020-01-01T00:00:00") { id } }`})
+	if past.ValidationError != nil {
+		t.Fatalf("unexpected validation error: %v", past.ValidationError)
+	}
+	future := AnalyzeEnvelope(Envelope{Query: `query @timezone(name: "Europe/Berlin") { users @asOf(time: "2999-01-01 00:00:00") { id } }`})
+	if future.ValidationError == nil {
+		t.Fatal("expected a future @asOf time to be rejected")
+	}
+}
+
+func TestAnalyzeRequest_TimeZoneHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		header    string
+		want      string
+		wantError bool
+	}{
+		{name: "header selects zone", query: `query { users { id } }`, header: "Asia/Tokyo", want: "Asia/Tokyo"},
+		{name: "directive wins over header", query: `query @timezone(name: "Europe/Paris") { users { id } }`, header: "Asia/Tokyo", want: "Europe/Paris"},
+		{name: "invalid header", query: `query { users { id } }`, header: "Nowhere/Special", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"query": tt.query})
+			req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(TimeZoneHeader, tt.header)
+			analysis := AnalyzeRequest(req)
+			if tt.wantError {
+				if analysis.ValidationError == nil {
+					t.Fatalf("expected validation error for header %q", tt.header)
+				}
+				return
+			}
+			if analysis.TimeZone == nil || analysis.TimeZone.String() != tt.want {
+				t.Fatalf("TimeZone = %v, want %s", analysis.TimeZone, tt.want)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ type ExecMeta struct {
 	OperationName string
 	OperationType string
 	OperationHash string
+	DryRun        bool
 }
 
 // WithAnalysis stores GraphQL request analysis in context.
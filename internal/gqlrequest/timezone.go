@@ -0,0 +1,64 @@
+package gqlrequest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// TimeZoneDirectiveName is the operation directive that selects the request time zone.
+	TimeZoneDirectiveName = "timezone"
+	// TimeZoneDirectiveArg names the IANA zone argument of @timezone.
+	TimeZoneDirectiveArg = "name"
+	// TimeZoneHeader selects the request time zone without changing the document.
+	// The @timezone directive takes precedence when both are present.
+	TimeZoneHeader = "X-Timezone"
+)
+
+// LoadTimeZone resolves an IANA time zone name such as "Europe/Berlin".
+// "Local" is rejected so results never depend on the server host.
+func LoadTimeZone(name string) (*time.Location, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" || trimmed == "Local" {
+		return nil, fmt.Errorf("invalid time zone %q", name)
+	}
+	loc, err := time.LoadLocation(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", name)
+	}
+	return loc, nil
+}
+
+// operationTimeZone returns the zone named by the operation's @timezone
+// directive, or nil when the directive is absent.
+func operationTimeZone(op *ast.OperationDefinition, variables map[string]any) (*time.Location, error) {
+	for _, directive := range op.Directives {
+		if directive == nil || directive.Name == nil || directive.Name.Value != TimeZoneDirectiveName {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg == nil || arg.Name == nil || arg.Name.Value != TimeZoneDirectiveArg {
+				continue
+			}
+			var name any
+			switch value := arg.Value.(type) {
+			case *ast.StringValue:
+				name = value.Value
+			case *ast.Variable:
+				if value.Name != nil {
+					name = variables[value.Name.Value]
+				}
+			}
+			str, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("@timezone name must be a String")
+			}
+			return LoadTimeZone(str)
+		}
+		return nil, fmt.Errorf("@timezone requires a name argument")
+	}
+	return nil, nil
+}
@@ -0,0 +1,63 @@
+package introspection
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultDeprecationReason is used when a marker or mapping gives no reason.
+const DefaultDeprecationReason = "No longer supported"
+
+var deprecationMarkerPattern = regexp.MustCompile(`(?is)@deprecated\b:?(.*)$`)
+
+// ParseDeprecationComment splits a SQL comment at an "@deprecated" marker,
+// e.g. "Legal name. @deprecated: use full_name". The text before the marker
+// stays the description; the text after it is the deprecation reason.
+func ParseDeprecationComment(comment string) (description, reason string) {
+	loc := deprecationMarkerPattern.FindStringSubmatchIndex(comment)
+	if loc == nil {
+		return comment, ""
+	}
+	description = strings.TrimSpace(comment[:loc[0]])
+	reason = strings.TrimSpace(comment[loc[2]:loc[3]])
+	if reason == "" {
+		reason = DefaultDeprecationReason
+	}
+	return description, reason
+}
+
+// Deprecation marks a table, or one of its columns when Column is set, as
+// deprecated in GraphQL.
+type Deprecation struct {
+	Table  string
+	Column string
+	Reason string
+}
+
+// ApplyDeprecations sets configured deprecation reasons, taking precedence
+// over comment markers. Names are matched case-insensitively; tables or
+// columns absent from the schema are ignored.
+func ApplyDeprecations(schema *Schema, deprecations []Deprecation) {
+	if schema == nil {
+		return
+	}
+	for _, deprecation := range deprecations {
+		reason := deprecation.Reason
+		if reason == "" {
+			reason = DefaultDeprecationReason
+		}
+		for ti := range schema.Tables {
+			table := &schema.Tables[ti]
+			if !strings.EqualFold(table.Name, deprecation.Table) {
+				continue
+			}
+			if deprecation.Column == "" {
+				table.DeprecationReason = reason
+				continue
+			}
+			if col := findColumn(*table, deprecation.Column); col != nil {
+				col.DeprecationReason = reason
+			}
+		}
+	}
+}
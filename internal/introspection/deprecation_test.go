@@ -0,0 +1,47 @@
+package introspection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDeprecationComment(t *testing.T) {
+	tests := []struct {
+		comment     string
+		description string
+		reason      string
+	}{
+		{"Legal name", "Legal name", ""},
+		{"Legal name. @deprecated: use full_name", "Legal name.", "use full_name"},
+		{"@DEPRECATED Use full_name instead", "", "Use full_name instead"},
+		{"Legal name @deprecated", "Legal name", DefaultDeprecationReason},
+		{"See @deprecatedFields", "See @deprecatedFields", ""},
+	}
+	for _, tt := range tests {
+		description, reason := ParseDeprecationComment(tt.comment)
+		assert.Equal(t, tt.description, description, tt.comment)
+		assert.Equal(t, tt.reason, reason, tt.comment)
+	}
+}
+
+func TestApplyDeprecations(t *testing.T) {
+	schema := &Schema{Tables: []Table{
+		{Name: "users", Columns: []Column{
+			{Name: "name", DeprecationReason: "from comment"},
+			{Name: "full_name"},
+		}},
+		{Name: "legacy_orders"},
+	}}
+
+	ApplyDeprecations(schema, []Deprecation{
+		{Table: "USERS", Column: "name", Reason: "use fullName"},
+		{Table: "legacy_orders"},
+		{Table: "users", Column: "missing"},
+	})
+
+	assert.Equal(t, "use fullName", schema.Tables[0].Columns[0].DeprecationReason)
+	assert.Empty(t, schema.Tables[0].Columns[1].DeprecationReason)
+	assert.Empty(t, schema.Tables[0].DeprecationReason)
+	assert.Equal(t, DefaultDeprecationReason, schema.Tables[1].DeprecationReason)
+}
@@ -0,0 +1,237 @@
+package introspection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"tidb-graphql/internal/sqlutil"
+)
+
+// MaxLookupEnumValues bounds the rows loaded from one lookup table. Larger
+// tables are reference data rather than enums and fail the schema build.
+const MaxLookupEnumValues = 500
+
+// EnumValueMeta customizes how one stored enum value is exposed in GraphQL.
+type EnumValueMeta struct {
+	// Name replaces the derived GraphQL enum value name.
+	Name              string
+	Description       string
+	DeprecationReason string
+}
+
+// EnumLookup records the lookup table a foreign key column's enum values
+// were loaded from. Columns sharing a lookup share one GraphQL enum type.
+type EnumLookup struct {
+	Table  string
+	Column string
+	// TypeName overrides the derived GraphQL enum type name.
+	TypeName string
+}
+
+// EnumValueMapping customizes the values of one enum, SET or lookup enum column.
+type EnumValueMapping struct {
+	Table  string
+	Column string
+	Values map[string]EnumValueMeta
+}
+
+// LookupEnum generates a GraphQL enum from the rows of a small lookup table.
+// Foreign key columns referencing ValueColumn are exposed as that enum.
+type LookupEnum struct {
+	Table             string
+	ValueColumn       string
+	NameColumn        string
+	DescriptionColumn string
+	TypeName          string
+}
+
+// ApplyLookupEnums loads the rows of each lookup table in databaseName and
+// exposes single-column foreign keys referencing it as enums. The lookup
+// table itself is read even when schema filters hide it, and is re-read on
+// every schema build so the enum follows the table contents.
+func ApplyLookupEnums(ctx context.Context, db Queryer, schema *Schema, databaseName string, lookups []LookupEnum) error {
+	if schema == nil || len(lookups) == 0 {
+		return nil
+	}
+	for _, lookup := range lookups {
+		refs := lookupReferences(schema, databaseName, lookup)
+		if len(refs) == 0 {
+			continue
+		}
+		values, meta, err := loadLookupEnumValues(ctx, db, databaseName, lookup)
+		if err != nil {
+			return err
+		}
+		ref := &EnumLookup{Table: lookup.Table, Column: lookup.ValueColumn, TypeName: lookup.TypeName}
+		for _, col := range refs {
+			col.EnumValues = values
+			col.EnumValueMeta = meta
+			col.EnumLookup = ref
+		}
+	}
+	return nil
+}
+
+// lookupReferences returns the columns of single-column foreign keys that
+// reference the lookup table's value column within the same database.
+func lookupReferences(schema *Schema, databaseName string, lookup LookupEnum) []*Column {
+	var refs []*Column
+	for ti := range schema.Tables {
+		table := &schema.Tables[ti]
+		fkColumns := make(map[string]int, len(table.ForeignKeys))
+		for _, fk := range table.ForeignKeys {
+			fkColumns[fk.ConstraintName]++
+		}
+		for _, fk := range table.ForeignKeys {
+			if fkColumns[fk.ConstraintName] != 1 ||
+				!strings.EqualFold(fk.ReferencedTable, lookup.Table) ||
+				!strings.EqualFold(fk.ReferencedColumn, lookup.ValueColumn) ||
+				fk.ReferencedDatabase != "" && !strings.EqualFold(fk.ReferencedDatabase, databaseName) {
+				continue
+			}
+			if col := findColumn(*table, fk.ColumnName); col != nil {
+				refs = append(refs, col)
+			}
+		}
+	}
+	return refs
+}
+
+// LookupEnumQuery returns the query reading the rows of a lookup table. The
+// schema refresh fingerprint hashes its result so row changes trigger a rebuild.
+func LookupEnumQuery(databaseName string, lookup LookupEnum) string {
+	selectColumn := func(name string) string {
+		if name == "" {
+			return "NULL"
+		}
+		return sqlutil.QuoteIdentifier(name)
+	}
+	valueColumn := sqlutil.QuoteIdentifier(lookup.ValueColumn)
+	return fmt.Sprintf("SELECT %s, %s, %s FROM %s.%s ORDER BY %s LIMIT %d",
+		valueColumn,
+		selectColumn(lookup.NameColumn),
+		selectColumn(lookup.DescriptionColumn),
+		sqlutil.QuoteIdentifier(databaseName),
+		sqlutil.QuoteIdentifier(lookup.Table),
+		valueColumn,
+		MaxLookupEnumValues+1,
+	)
+}
+
+func loadLookupEnumValues(ctx context.Context, db Queryer, databaseName string, lookup LookupEnum) ([]string, map[string]EnumValueMeta, error) {
+	query := LookupEnumQuery(databaseName, lookup)
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load lookup enum %s: %w", lookup.Table, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var values []string
+	meta := make(map[string]EnumValueMeta)
+	for rows.Next() {
+		var value, name, description sql.NullString
+		if err := rows.Scan(&value, &name, &description); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan lookup enum %s: %w", lookup.Table, err)
+		}
+		if !value.Valid {
+			continue
+		}
+		values = append(values, value.String)
+		if name.String != "" || description.String != "" {
+			meta[value.String] = EnumValueMeta{Name: name.String, Description: description.String}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to load lookup enum %s: %w", lookup.Table, err)
+	}
+	if len(values) > MaxLookupEnumValues {
+		return nil, nil, fmt.Errorf("lookup enum %s has more than %d rows", lookup.Table, MaxLookupEnumValues)
+	}
+	if len(values) == 0 {
+		return nil, nil, fmt.Errorf("lookup enum %s has no rows", lookup.Table)
+	}
+	return values, meta, nil
+}
+
+// ApplyEnumValueMappings merges configured names, descriptions and
+// deprecations into enum columns. A mapping matches an ENUM or SET column by
+// its own table and column, and a lookup enum column by the lookup table and
+// value column. Values the column does not define are logged and ignored, as
+// lookup table contents change between refreshes; it runs after
+// ApplyLookupEnums.
+func ApplyEnumValueMappings(schema *Schema, mappings []EnumValueMapping) error {
+	if schema == nil || len(mappings) == 0 {
+		return nil
+	}
+	for _, mapping := range mappings {
+		var invalid *Column
+		applied := false
+		for ti := range schema.Tables {
+			table := &schema.Tables[ti]
+			for ci := range table.Columns {
+				col := &table.Columns[ci]
+				if !enumMappingMatches(*table, *col, mapping) {
+					continue
+				}
+				if len(col.EnumValues) == 0 {
+					invalid = col
+					continue
+				}
+				col.EnumValueMeta = mergeEnumValueMeta(col.EnumValueMeta, col.EnumValues, mapping)
+				applied = true
+			}
+		}
+		// A lookup table's own value column matches its mapping too; only
+		// report the mapping when it customizes no enum at all.
+		if invalid != nil && !applied {
+			return fmt.Errorf("invalid enum value mapping for %s.%s: column is not an enum", mapping.Table, invalid.Name)
+		}
+	}
+	return nil
+}
+
+func enumMappingMatches(table Table, col Column, mapping EnumValueMapping) bool {
+	if col.EnumLookup != nil {
+		return strings.EqualFold(col.EnumLookup.Table, mapping.Table) && strings.EqualFold(col.EnumLookup.Column, mapping.Column)
+	}
+	return strings.EqualFold(table.Name, mapping.Table) && strings.EqualFold(col.Name, mapping.Column)
+}
+
+// mergeEnumValueMeta returns a new map so lookup columns sharing the loaded
+// metadata are merged once per mapping without aliasing surprises.
+func mergeEnumValueMeta(existing map[string]EnumValueMeta, values []string, mapping EnumValueMapping) map[string]EnumValueMeta {
+	merged := make(map[string]EnumValueMeta, len(existing)+len(mapping.Values))
+	for value, meta := range existing {
+		merged[value] = meta
+	}
+	defined := make(map[string]bool, len(values))
+	for _, value := range values {
+		defined[value] = true
+	}
+	for value, override := range mapping.Values {
+		if !defined[value] {
+			slog.Default().Warn("enum value mapping names an unknown value; ignoring",
+				slog.String("table", mapping.Table),
+				slog.String("column", mapping.Column),
+				slog.String("value", value))
+			continue
+		}
+		meta := merged[value]
+		if override.Name != "" {
+			meta.Name = override.Name
+		}
+		if override.Description != "" {
+			meta.Description = override.Description
+		}
+		if override.DeprecationReason != "" {
+			meta.DeprecationReason = override.DeprecationReason
+		}
+		merged[value] = meta
+	}
+	return merged
+}
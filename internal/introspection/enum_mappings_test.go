@@ -0,0 +1,91 @@
+package introspection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupEnumTestSchema() *Schema {
+	return &Schema{Tables: []Table{
+		{
+			Name:    "order_statuses",
+			Columns: []Column{{Name: "code", DataType: "int"}, {Name: "label", DataType: "varchar"}},
+		},
+		{
+			Name: "orders",
+			Columns: []Column{
+				{Name: "id", DataType: "int"},
+				{Name: "status_code", DataType: "int"},
+				{Name: "priority", DataType: "enum", EnumValues: []string{"low", "high"}},
+			},
+			ForeignKeys: []ForeignKey{
+				{ColumnName: "status_code", ReferencedTable: "order_statuses", ReferencedColumn: "code", ConstraintName: "fk_status"},
+			},
+		},
+	}}
+}
+
+func TestApplyLookupEnums(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT `code`, NULL, `label` FROM `shop`.`order_statuses` ORDER BY `code` LIMIT 501").
+		WillReturnRows(sqlmock.NewRows([]string{"code", "name", "label"}).
+			AddRow(1, nil, "Open").
+			AddRow(2, nil, nil))
+
+	schema := lookupEnumTestSchema()
+	err = ApplyLookupEnums(context.Background(), db, schema, "shop", []LookupEnum{
+		{Table: "order_statuses", ValueColumn: "code", DescriptionColumn: "label"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	col := schema.Tables[1].Columns[1]
+	assert.Equal(t, []string{"1", "2"}, col.EnumValues)
+	assert.Equal(t, map[string]EnumValueMeta{"1": {Description: "Open"}}, col.EnumValueMeta)
+	assert.Equal(t, &EnumLookup{Table: "order_statuses", Column: "code"}, col.EnumLookup)
+	assert.Nil(t, schema.Tables[0].Columns[0].EnumLookup, "the lookup table's own column is unchanged")
+
+	// Unreferenced lookups are not queried; empty lookups fail the build.
+	require.NoError(t, ApplyLookupEnums(context.Background(), db, schema, "shop", []LookupEnum{{Table: "regions", ValueColumn: "code"}}))
+	mock.ExpectQuery("FROM `shop`.`order_statuses`").WillReturnRows(sqlmock.NewRows([]string{"code", "name", "label"}))
+	err = ApplyLookupEnums(context.Background(), db, lookupEnumTestSchema(), "shop", []LookupEnum{{Table: "order_statuses", ValueColumn: "code"}})
+	assert.ErrorContains(t, err, "has no rows")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyEnumValueMappings(t *testing.T) {
+	schema := lookupEnumTestSchema()
+	orders := &schema.Tables[1]
+	orders.Columns[1].EnumValues = []string{"1", "2"}
+	orders.Columns[1].EnumValueMeta = map[string]EnumValueMeta{"1": {Description: "Open"}}
+	orders.Columns[1].EnumLookup = &EnumLookup{Table: "order_statuses", Column: "code"}
+
+	err := ApplyEnumValueMappings(schema, []EnumValueMapping{
+		{Table: "orders", Column: "priority", Values: map[string]EnumValueMeta{
+			"low":     {Name: "LOW_PRIORITY", DeprecationReason: "Use HIGH."},
+			"missing": {Name: "MISSING"},
+		}},
+		{Table: "order_statuses", Column: "code", Values: map[string]EnumValueMeta{
+			"1": {Name: "OPEN"},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]EnumValueMeta{
+		"low": {Name: "LOW_PRIORITY", DeprecationReason: "Use HIGH."},
+	}, orders.Columns[2].EnumValueMeta)
+	assert.Equal(t, map[string]EnumValueMeta{
+		"1": {Name: "OPEN", Description: "Open"},
+	}, orders.Columns[1].EnumValueMeta)
+
+	err = ApplyEnumValueMappings(schema, []EnumValueMapping{
+		{Table: "orders", Column: "id", Values: map[string]EnumValueMeta{"1": {Name: "ONE"}}},
+	})
+	assert.ErrorContains(t, err, "column is not an enum")
+}
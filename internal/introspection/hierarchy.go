@@ -0,0 +1,47 @@
+package introspection
+
+// SelfReference describes a single-column foreign key from a table to its own
+// primary key, which arranges the table's rows into trees.
+type SelfReference struct {
+	ConstraintName string
+	KeyColumn      string // Referenced primary key column, e.g. "id"
+	ParentColumn   string // FK column holding the parent row's key, e.g. "parent_id"
+}
+
+// HierarchyReference returns the table's self-referencing foreign key. Tables
+// with a composite primary key, a composite self-FK, or more than one self-FK
+// have no unambiguous hierarchy and report false.
+func HierarchyReference(table Table) (SelfReference, bool) {
+	pkCols := PrimaryKeyColumns(table)
+	if len(pkCols) != 1 {
+		return SelfReference{}, false
+	}
+
+	widths := make(map[string]int)
+	for _, fk := range table.ForeignKeys {
+		widths[fk.ConstraintName]++
+	}
+
+	var found []SelfReference
+	for _, fk := range table.ForeignKeys {
+		if widths[fk.ConstraintName] != 1 {
+			continue
+		}
+		if fk.ReferencedTable != table.Name || fk.ReferencedColumn != pkCols[0].Name {
+			continue
+		}
+		if fk.ReferencedDatabase != "" && fk.ReferencedDatabase != table.Key.Database {
+			continue
+		}
+		found = append(found, SelfReference{
+			ConstraintName: fk.ConstraintName,
+			KeyColumn:      fk.ReferencedColumn,
+			ParentColumn:   fk.ColumnName,
+		})
+	}
+
+	if len(found) != 1 {
+		return SelfReference{}, false
+	}
+	return found[0], true
+}
@@ -0,0 +1,123 @@
+package introspection
+
+import (
+	"testing"
+
+	"tidb-graphql/internal/tablekey"
+)
+
+func TestHierarchyReference(t *testing.T) {
+	categoryColumns := []Column{
+		{Name: "id", DataType: "int", IsPrimaryKey: true},
+		{Name: "parent_id", DataType: "int", IsNullable: true},
+		{Name: "moved_from_id", DataType: "int", IsNullable: true},
+	}
+
+	tests := []struct {
+		name       string
+		table      Table
+		wantOK     bool
+		wantParent string
+	}{
+		{
+			name: "single self reference",
+			table: Table{
+				Name:    "categories",
+				Columns: categoryColumns,
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ConstraintName: "fk_parent"},
+				},
+			},
+			wantOK:     true,
+			wantParent: "parent_id",
+		},
+		{
+			name: "same database qualified reference",
+			table: Table{
+				Key:     tablekey.TableKey{Database: "shop", Table: "categories"},
+				Name:    "categories",
+				Columns: categoryColumns,
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ReferencedDatabase: "shop", ConstraintName: "fk_parent"},
+				},
+			},
+			wantOK:     true,
+			wantParent: "parent_id",
+		},
+		{
+			name: "other table",
+			table: Table{
+				Name:    "categories",
+				Columns: categoryColumns,
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "sections", ReferencedColumn: "id", ConstraintName: "fk_parent"},
+				},
+			},
+		},
+		{
+			name: "same name in another database",
+			table: Table{
+				Key:     tablekey.TableKey{Database: "shop", Table: "categories"},
+				Name:    "categories",
+				Columns: categoryColumns,
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ReferencedDatabase: "archive", ConstraintName: "fk_parent"},
+				},
+			},
+		},
+		{
+			name: "ambiguous self references",
+			table: Table{
+				Name:    "categories",
+				Columns: categoryColumns,
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ConstraintName: "fk_parent"},
+					{ColumnName: "moved_from_id", ReferencedTable: "categories", ReferencedColumn: "id", ConstraintName: "fk_moved_from"},
+				},
+			},
+		},
+		{
+			name: "composite primary key",
+			table: Table{
+				Name: "nodes",
+				Columns: []Column{
+					{Name: "tenant_id", IsPrimaryKey: true},
+					{Name: "id", IsPrimaryKey: true},
+					{Name: "parent_id"},
+				},
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "nodes", ReferencedColumn: "id", ConstraintName: "fk_parent"},
+				},
+			},
+		},
+		{
+			name: "composite self reference",
+			table: Table{
+				Name:    "categories",
+				Columns: categoryColumns,
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ConstraintName: "fk_parent", OrdinalPosition: 1},
+					{ColumnName: "moved_from_id", ReferencedTable: "categories", ReferencedColumn: "moved_from_id", ConstraintName: "fk_parent", OrdinalPosition: 2},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, ok := HierarchyReference(tt.table)
+			if ok != tt.wantOK {
+				t.Fatalf("HierarchyReference() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if ref.ParentColumn != tt.wantParent {
+				t.Errorf("ParentColumn = %q, want %q", ref.ParentColumn, tt.wantParent)
+			}
+			if ref.KeyColumn != "id" {
+				t.Errorf("KeyColumn = %q, want %q", ref.KeyColumn, "id")
+			}
+		})
+	}
+}
@@ -10,12 +10,14 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 
+	"tidb-graphql/internal/jsonschema"
 	"tidb-graphql/internal/naming"
 	"tidb-graphql/internal/sqltype"
 	"tidb-graphql/internal/tablekey"
@@ -37,10 +39,27 @@ type Column struct {
 	// GenerationExpression stores INFORMATION_SCHEMA.COLUMNS.GENERATION_EXPRESSION.
 	GenerationExpression string
 	EnumValues           []string
-	Comment              string
+	// EnumValueMeta holds configured GraphQL names, descriptions and
+	// deprecations keyed by stored enum value.
+	EnumValueMeta map[string]EnumValueMeta
+	// EnumLookup is set when EnumValues were loaded from a lookup table.
+	EnumLookup *EnumLookup
+	Comment    string
+	// DeprecationReason deprecates the column's GraphQL field.
+	DeprecationReason string
 	// OverrideType is an explicit GraphQL type override resolved during schema preparation.
 	OverrideType    sqltype.GraphQLType
 	HasOverrideType bool
+	// OverrideUnit is "s" or "ms" for Duration and integer Timestamp overrides.
+	OverrideUnit string
+	// CurrencyColumn names the SQL column holding the currency of a Money override.
+	CurrencyColumn string
+	// TimeZone is the zone of wall-clock DATETIME values; nil means UTC.
+	TimeZone *time.Location
+	// JSONSchema describes the document shape of a JSON column exposed as a typed object.
+	JSONSchema *jsonschema.Schema
+	// SRID is the spatial reference system a GEOMETRY column is restricted to (0 when unrestricted).
+	SRID uint32
 	// GraphQLFieldName is the resolved GraphQL field name for this column.
 	GraphQLFieldName string
 }
@@ -95,6 +114,9 @@ type Relationship struct {
 	// JunctionTableKey is the fully-qualified key for the junction table. Set alongside JunctionTable.
 	JunctionTableKey tablekey.TableKey
 	GraphQLFieldName string // e.g., "volume" or "books" or "departmentEmployees"
+	// NameOverridden is set when GraphQLFieldName comes from naming.relationship_overrides
+	// and must be used verbatim.
+	NameOverridden bool
 }
 
 // JunctionType indicates how a junction table should be handled.
@@ -138,6 +160,9 @@ type Table struct {
 	Name    string
 	IsView  bool
 	Comment string
+	// DeprecationReason deprecates the table's root fields and the
+	// relationship fields leading to it.
+	DeprecationReason string
 	// GraphQLTypeName is the resolved GraphQL type name for this table.
 	GraphQLTypeName string
 	// GraphQLQueryName is the resolved GraphQL root field name for this table.
@@ -158,6 +183,8 @@ type Schema struct {
 	Junctions JunctionMap
 	// NamesApplied marks whether GraphQL naming has been applied to this schema.
 	NamesApplied bool
+	// Spatial records the spatial functions available for GeoJSON filters.
+	Spatial SpatialFunctions
 }
 
 // Queryer provides query access for schema introspection.
@@ -198,6 +225,7 @@ func IntrospectDatabaseContext(ctx context.Context, db Queryer, databaseName str
 		if !tableInfo.IsView {
 			columns = applyAutoRandomColumns(ctx, db, databaseName, tableInfo.Name, columns)
 		}
+		columns = applySpatialReferenceIDs(ctx, db, databaseName, tableInfo.Name, columns)
 
 		var primaryKeys []string
 		var foreignKeys []ForeignKey
@@ -235,14 +263,16 @@ func IntrospectDatabaseContext(ctx context.Context, db Queryer, databaseName str
 			}
 		}
 
+		comment, deprecationReason := ParseDeprecationComment(tableInfo.Comment)
 		schema.Tables = append(schema.Tables, Table{
-			Key:         tablekey.TableKey{Database: databaseName, Table: tableInfo.Name},
-			Name:        tableInfo.Name,
-			IsView:      tableInfo.IsView,
-			Comment:     tableInfo.Comment,
-			Columns:     columns,
-			ForeignKeys: foreignKeys,
-			Indexes:     indexes,
+			Key:               tablekey.TableKey{Database: databaseName, Table: tableInfo.Name},
+			Name:              tableInfo.Name,
+			IsView:            tableInfo.IsView,
+			Comment:           comment,
+			DeprecationReason: deprecationReason,
+			Columns:           columns,
+			ForeignKeys:       foreignKeys,
+			Indexes:           indexes,
 		})
 	}
 
@@ -388,7 +418,7 @@ func getColumns(ctx context.Context, db Queryer, databaseName, tableName string)
 		col.ColumnType = columnType
 		col.VectorDimension = parseVectorDimension(columnType)
 		if columnComment.Valid {
-			col.Comment = strings.TrimSpace(columnComment.String)
+			col.Comment, col.DeprecationReason = ParseDeprecationComment(strings.TrimSpace(columnComment.String))
 		}
 		col.IsNullable = strings.ToUpper(isNullable) == "YES"
 		if columnDefault.Valid {
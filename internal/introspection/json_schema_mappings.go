@@ -0,0 +1,45 @@
+package introspection
+
+import (
+	"fmt"
+	"strings"
+
+	"tidb-graphql/internal/jsonschema"
+	"tidb-graphql/internal/sqltype"
+)
+
+// JSONSchemaMapping attaches a JSON Schema to one JSON column so it is exposed
+// as a typed GraphQL object instead of the JSON scalar.
+type JSONSchemaMapping struct {
+	Table  string
+	Column string
+	Schema *jsonschema.Schema
+}
+
+// ApplyJSONSchemaMappings attaches schemas to matching columns. Table and
+// column names are matched case-insensitively; mappings naming tables or
+// columns absent from the schema (for example, hidden by schema filters) are
+// ignored. Matched columns must be JSON columns, either native or mapped
+// through a JSON scalar mapping, so it runs after the scalar type overrides.
+func ApplyJSONSchemaMappings(schema *Schema, mappings []JSONSchemaMapping) error {
+	if schema == nil || len(mappings) == 0 {
+		return nil
+	}
+	for _, mapping := range mappings {
+		for ti := range schema.Tables {
+			table := &schema.Tables[ti]
+			if !strings.EqualFold(table.Name, mapping.Table) {
+				continue
+			}
+			col := findColumn(*table, mapping.Column)
+			if col == nil {
+				continue
+			}
+			if EffectiveGraphQLType(*col) != sqltype.TypeJSON {
+				return fmt.Errorf("invalid JSON schema mapping for %s.%s: column is not a JSON column", table.Name, col.Name)
+			}
+			col.JSONSchema = mapping.Schema
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package introspection
+
+import (
+	"testing"
+
+	"tidb-graphql/internal/jsonschema"
+	"tidb-graphql/internal/sqltype"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyJSONSchemaMappings(t *testing.T) {
+	settings := &jsonschema.Schema{Type: jsonschema.TypeObject}
+	schema := &Schema{
+		Tables: []Table{
+			{
+				Name: "Users",
+				Columns: []Column{
+					{Name: "settings", DataType: "json"},
+					{Name: "address", DataType: "text", OverrideType: sqltype.TypeJSON, HasOverrideType: true},
+					{Name: "name", DataType: "varchar"},
+				},
+			},
+		},
+	}
+
+	err := ApplyJSONSchemaMappings(schema, []JSONSchemaMapping{
+		{Table: "users", Column: "SETTINGS", Schema: settings},
+		{Table: "users", Column: "address", Schema: settings},
+		{Table: "users", Column: "missing", Schema: settings},
+		{Table: "orders", Column: "settings", Schema: settings},
+	})
+	require.NoError(t, err)
+	assert.Same(t, settings, schema.Tables[0].Columns[0].JSONSchema)
+	assert.Same(t, settings, schema.Tables[0].Columns[1].JSONSchema)
+	assert.Nil(t, schema.Tables[0].Columns[2].JSONSchema)
+
+	err = ApplyJSONSchemaMappings(schema, []JSONSchemaMapping{{Table: "users", Column: "name", Schema: settings}})
+	assert.ErrorContains(t, err, "column is not a JSON column")
+}
@@ -0,0 +1,68 @@
+package introspection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tidb-graphql/internal/naming"
+)
+
+func relationshipFieldNames(table Table) map[string]Relationship {
+	names := make(map[string]Relationship, len(table.Relationships))
+	for _, rel := range table.Relationships {
+		names[rel.GraphQLFieldName] = rel
+	}
+	return names
+}
+
+func TestRebuildRelationshipsWithNamer_RelationshipOverrides(t *testing.T) {
+	schema := &Schema{
+		Tables: []Table{
+			{
+				Name:    "users",
+				Columns: []Column{{Name: "id", IsPrimaryKey: true}},
+			},
+			{
+				Name: "documents",
+				Columns: []Column{
+					{Name: "id", IsPrimaryKey: true},
+					{Name: "created_by"},
+					{Name: "updated_by"},
+				},
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "created_by", ReferencedTable: "users", ReferencedColumn: "id", ConstraintName: "documents_ibfk_1"},
+					{ColumnName: "updated_by", ReferencedTable: "users", ReferencedColumn: "id", ConstraintName: "documents_ibfk_2"},
+				},
+			},
+		},
+	}
+
+	cfg := naming.DefaultConfig()
+	cfg.RelationshipOverrides = map[string]map[string]naming.RelationshipOverride{
+		"documents": {
+			"DOCUMENTS_IBFK_1": {Forward: "author", Reverse: "authoredDocuments"},
+			"documents_ibfk_2": {HideReverse: true},
+		},
+	}
+	require.NoError(t, RebuildRelationshipsWithNamer(context.Background(), schema, naming.New(cfg, nil)))
+
+	users := relationshipFieldNames(schema.Tables[0])
+	assert.Len(t, users, 1)
+	assert.Equal(t, []string{"created_by"}, users["authoredDocuments"].RemoteColumns)
+
+	documents := relationshipFieldNames(schema.Tables[1])
+	assert.Len(t, documents, 2)
+	assert.Equal(t, []string{"created_by"}, documents["author"].LocalColumns)
+	assert.Equal(t, []string{"updated_by"}, documents["updatedBy"].LocalColumns)
+	assert.True(t, documents["author"].NameOverridden)
+	assert.True(t, users["authoredDocuments"].NameOverridden)
+	assert.False(t, documents["updatedBy"].NameOverridden)
+
+	cfg.RelationshipOverrides["documents"]["documents_ibfk_2"] = naming.RelationshipOverride{HideForward: true, Reverse: "editedDocuments"}
+	require.NoError(t, RebuildRelationshipsWithNamer(context.Background(), schema, naming.New(cfg, nil)))
+
+	assert.Contains(t, relationshipFieldNames(schema.Tables[0]), "editedDocuments")
+	assert.NotContains(t, relationshipFieldNames(schema.Tables[1]), "updatedBy")
+}
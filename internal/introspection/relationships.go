@@ -98,10 +98,17 @@ func buildRelationships(ctx context.Context, schema *Schema, namer *naming.Namer
 				warnCompositeSkip("many_to_one", table.Name, fk.ConstraintName, fk.ColumnNames, fk.ReferencedTable, fk.ReferencedColumns, "invalid_foreign_key_mapping")
 				continue
 			}
+			override := namer.RelationshipOverride(table.Name, fk.ConstraintName)
+			if override.HideForward {
+				continue
+			}
 			fieldName := ""
-			if jType == JunctionTypeAttribute {
+			switch {
+			case override.Forward != "":
+				fieldName = override.Forward
+			case jType == JunctionTypeAttribute:
 				fieldName = namer.JunctionEdgeRefFieldName(fk.ReferencedTable)
-			} else {
+			default:
 				fieldName = namer.ManyToOneFieldName(fk.ColumnNames[0])
 			}
 			localColumns := append([]string(nil), fk.ColumnNames...)
@@ -124,6 +131,7 @@ func buildRelationships(ctx context.Context, schema *Schema, namer *naming.Namer
 				RemoteColumns:    remoteColumns,
 				RemoteTableKey:   tablekey.TableKey{Database: remoteDB, Table: fk.ReferencedTable},
 				GraphQLFieldName: fieldName,
+				NameOverridden:   override.Forward != "",
 			}
 			table.Relationships = append(table.Relationships, rel)
 		}
@@ -133,6 +141,7 @@ func buildRelationships(ctx context.Context, schema *Schema, namer *naming.Namer
 	// Skip if the source table is any junction (edge or pure)
 	// When single FK: use pluralized table name (e.g., "comments")
 	// When multiple FKs: prefix with FK column name (e.g., "authorPosts", "editorPosts")
+	// Composite FKs map every column positionally, e.g. (tenant_id, user_id) -> (tenant_id, id)
 	for i := range schema.Tables {
 		table := &schema.Tables[i]
 		if table.IsView {
@@ -159,20 +168,29 @@ func buildRelationships(ctx context.Context, schema *Schema, namer *naming.Namer
 				// REFERENCED_TABLE_SCHEMA is always populated, even for same-database references).
 				isIntraDB := fk.ReferencedDatabase == "" || fk.ReferencedDatabase == otherTable.Key.Database
 				if fk.ReferencedTable == table.Name && isIntraDB {
-					if len(fk.ColumnNames) != 1 || len(fk.ReferencedColumns) != 1 {
-						warnCompositeSkip("one_to_many", otherTable.Name, fk.ConstraintName, fk.ColumnNames, table.Name, fk.ReferencedColumns, "composite_one_to_many_not_supported_in_phase")
+					if len(fk.ColumnNames) == 0 || len(fk.ColumnNames) != len(fk.ReferencedColumns) {
+						warnCompositeSkip("one_to_many", otherTable.Name, fk.ConstraintName, fk.ColumnNames, table.Name, fk.ReferencedColumns, "invalid_foreign_key_mapping")
 						continue
 					}
-					srcKey := otherTable.MapKey()
-					dstKey := tablekey.TableKey{Database: otherTable.Key.Database, Table: fk.ReferencedTable}.MapKey()
-					isOnlyFK := fkCount[srcKey][dstKey] == 1
+					override := namer.RelationshipOverride(otherTable.Name, fk.ConstraintName)
+					if override.HideReverse {
+						continue
+					}
+					fieldName := override.Reverse
+					if fieldName == "" {
+						srcKey := otherTable.MapKey()
+						dstKey := tablekey.TableKey{Database: otherTable.Key.Database, Table: fk.ReferencedTable}.MapKey()
+						isOnlyFK := fkCount[srcKey][dstKey] == 1
+						fieldName = namer.OneToManyFieldName(otherTable.Name, oneToManyNamingColumn(fk.ColumnNames), isOnlyFK)
+					}
 					rel := Relationship{
 						IsOneToMany:      true,
 						LocalColumns:     append([]string(nil), fk.ReferencedColumns...),
 						RemoteTable:      otherTable.Name,
 						RemoteColumns:    append([]string(nil), fk.ColumnNames...),
 						RemoteTableKey:   tablekey.TableKey{Database: otherTable.Key.Database, Table: otherTable.Name},
-						GraphQLFieldName: namer.OneToManyFieldName(otherTable.Name, fk.ColumnNames[0], isOnlyFK),
+						GraphQLFieldName: fieldName,
+						NameOverridden:   override.Reverse != "",
 					}
 					table.Relationships = append(table.Relationships, rel)
 				}
@@ -313,8 +331,6 @@ func buildRelationships(ctx context.Context, schema *Schema, namer *naming.Namer
 // reverse one-to-many relationships on the referenced tables — but only when the
 // referenced database is present in the merged schema (i.e. a configured database).
 // FKs referencing unconfigured databases are silently skipped.
-//
-// Composite FK one-to-many are skipped, matching the intra-database limitation.
 func ResolveCrossDatabaseRelationships(schema *Schema, namer *naming.Namer) error {
 	// Build a full tableIndex across all databases, keyed by TableKey.MapKey().
 	tableIndex := make(map[string]*Table)
@@ -352,8 +368,7 @@ func ResolveCrossDatabaseRelationships(schema *Schema, namer *naming.Namer) erro
 			if fk.ReferencedDatabase == "" || fk.ReferencedDatabase == table.Key.Database {
 				continue // intra-db, already handled by buildRelationships
 			}
-			if len(fk.ColumnNames) != 1 || len(fk.ReferencedColumns) != 1 {
-				// Composite cross-db FK one-to-many not yet supported.
+			if len(fk.ColumnNames) == 0 || len(fk.ColumnNames) != len(fk.ReferencedColumns) {
 				continue
 			}
 
@@ -364,8 +379,16 @@ func ResolveCrossDatabaseRelationships(schema *Schema, namer *naming.Namer) erro
 				continue
 			}
 
-			srcKey := table.MapKey()
-			isOnlyFK := fkCount[srcKey][refKey] == 1
+			override := namer.RelationshipOverride(table.Name, fk.ConstraintName)
+			if override.HideReverse {
+				continue
+			}
+			fieldName := override.Reverse
+			if fieldName == "" {
+				srcKey := table.MapKey()
+				isOnlyFK := fkCount[srcKey][refKey] == 1
+				fieldName = namer.OneToManyFieldName(table.Name, oneToManyNamingColumn(fk.ColumnNames), isOnlyFK)
+			}
 			refTable.Relationships = append(refTable.Relationships, Relationship{
 				IsOneToMany:      true,
 				IsCrossDatabase:  true,
@@ -373,10 +396,19 @@ func ResolveCrossDatabaseRelationships(schema *Schema, namer *naming.Namer) erro
 				RemoteTable:      table.Name,
 				RemoteColumns:    append([]string(nil), fk.ColumnNames...),
 				RemoteTableKey:   table.Key,
-				GraphQLFieldName: namer.OneToManyFieldName(table.Name, fk.ColumnNames[0], isOnlyFK),
+				GraphQLFieldName: fieldName,
+				NameOverridden:   override.Reverse != "",
 			})
 		}
 	}
 
 	return nil
 }
+
+// oneToManyNamingColumn returns the FK column used to prefix one-to-many field
+// names when several FKs point at the same table. Composite keys usually lead
+// with shared partition columns such as tenant_id, so the last column is the
+// one that tells the references apart.
+func oneToManyNamingColumn(columns []string) string {
+	return columns[len(columns)-1]
+}
@@ -2,6 +2,7 @@ package introspection
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"tidb-graphql/internal/naming"
@@ -83,3 +84,59 @@ func TestRebuildRelationshipsWithJunctions_CompositeMappings(t *testing.T) {
 		t.Fatalf("junction local FK columns not populated correctly: %v", userRel.JunctionLocalFKColumns)
 	}
 }
+
+func TestRebuildRelationships_CompositeOneToMany(t *testing.T) {
+	schema := &Schema{
+		Tables: []Table{
+			{
+				Name: "customers",
+				Columns: []Column{
+					{Name: "tenant_id", IsPrimaryKey: true},
+					{Name: "id", IsPrimaryKey: true},
+				},
+			},
+			{
+				Name: "orders",
+				Columns: []Column{
+					{Name: "tenant_id", IsPrimaryKey: true},
+					{Name: "id", IsPrimaryKey: true},
+					{Name: "customer_id"},
+					{Name: "referrer_id"},
+				},
+				ForeignKeys: []ForeignKey{
+					{ColumnName: "tenant_id", ReferencedTable: "customers", ReferencedColumn: "tenant_id", ConstraintName: "fk_orders_customer", OrdinalPosition: 1},
+					{ColumnName: "customer_id", ReferencedTable: "customers", ReferencedColumn: "id", ConstraintName: "fk_orders_customer", OrdinalPosition: 2},
+					{ColumnName: "tenant_id", ReferencedTable: "customers", ReferencedColumn: "tenant_id", ConstraintName: "fk_orders_referrer", OrdinalPosition: 1},
+					{ColumnName: "referrer_id", ReferencedTable: "customers", ReferencedColumn: "id", ConstraintName: "fk_orders_referrer", OrdinalPosition: 2},
+				},
+			},
+		},
+	}
+
+	if err := RebuildRelationships(context.Background(), schema); err != nil {
+		t.Fatalf("failed to rebuild relationships: %v", err)
+	}
+
+	byName := make(map[string]Relationship)
+	for _, rel := range schema.Tables[0].Relationships {
+		if rel.IsOneToMany {
+			byName[rel.GraphQLFieldName] = rel
+		}
+	}
+	if len(byName) != 2 {
+		t.Fatalf("expected two composite one-to-many relationships, got %#v", byName)
+	}
+	customerOrders, ok := byName["customerOrders"]
+	if !ok {
+		t.Fatalf("expected customerOrders relationship, got %#v", byName)
+	}
+	if strings.Join(customerOrders.LocalColumns, ",") != "tenant_id,id" {
+		t.Fatalf("unexpected local columns: %#v", customerOrders.LocalColumns)
+	}
+	if strings.Join(customerOrders.RemoteColumns, ",") != "tenant_id,customer_id" {
+		t.Fatalf("unexpected remote columns: %#v", customerOrders.RemoteColumns)
+	}
+	if _, ok := byName["referrerOrders"]; !ok {
+		t.Fatalf("expected referrerOrders relationship, got %#v", byName)
+	}
+}
@@ -0,0 +1,168 @@
+package introspection
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"tidb-graphql/internal/sqltype"
+)
+
+// ScalarMapping exposes columns matching SQL table/column glob patterns as a
+// semantic GraphQL scalar.
+type ScalarMapping struct {
+	// Scalar is Email, URL, IPAddress, Money, Duration, Timestamp or JSON.
+	Scalar  string
+	Table   string
+	Columns []string
+	// CurrencyColumn is required for Money and names a text column of the same table.
+	CurrencyColumn string
+	// Unit is "s" or "ms" for Duration (default "s") and integer Timestamp columns (default "ms").
+	Unit string
+}
+
+// ScalarMappingTypes lists the scalar names accepted by ScalarMapping.
+var ScalarMappingTypes = map[string]sqltype.GraphQLType{
+	"Email":     sqltype.TypeEmail,
+	"URL":       sqltype.TypeURL,
+	"IPAddress": sqltype.TypeIPAddress,
+	"Money":     sqltype.TypeMoney,
+	"Duration":  sqltype.TypeDuration,
+	"Timestamp": sqltype.TypeTimestamp,
+	"JSON":      sqltype.TypeJSON,
+}
+
+// ApplyScalarTypeOverrides marks columns matched by mappings with their semantic
+// scalar type. Patterns are matched case-insensitively against SQL names. A
+// column matched by a mapping must have a compatible SQL type and must not
+// already carry another override.
+func ApplyScalarTypeOverrides(schema *Schema, mappings []ScalarMapping) error {
+	if schema == nil || len(mappings) == 0 {
+		return nil
+	}
+	for _, mapping := range mappings {
+		typ, ok := ScalarMappingTypes[mapping.Scalar]
+		if !ok {
+			return fmt.Errorf("unknown scalar %q in type mapping", mapping.Scalar)
+		}
+		tablePattern := strings.ToLower(strings.TrimSpace(mapping.Table))
+		for ti := range schema.Tables {
+			table := &schema.Tables[ti]
+			if matched, err := path.Match(tablePattern, strings.ToLower(table.Name)); err != nil || !matched {
+				continue
+			}
+			for ci := range table.Columns {
+				col := &table.Columns[ci]
+				if !matchesAny(col.Name, mapping.Columns) {
+					continue
+				}
+				if col.HasOverrideType && col.OverrideType != typ {
+					return fmt.Errorf("invalid %s mapping for %s.%s: column is already mapped to %s", mapping.Scalar, table.Name, col.Name, col.OverrideType)
+				}
+				if err := applyScalarMapping(*table, col, typ, mapping); err != nil {
+					return fmt.Errorf("invalid %s mapping for %s.%s: %w", mapping.Scalar, table.Name, col.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func applyScalarMapping(table Table, col *Column, typ sqltype.GraphQLType, mapping ScalarMapping) error {
+	baseType := strings.ToLower(strings.TrimSpace(col.DataType))
+	switch typ {
+	case sqltype.TypeEmail, sqltype.TypeURL, sqltype.TypeJSON:
+		if !isTextType(baseType) {
+			return fmt.Errorf("unsupported SQL type %q, expected a CHAR, VARCHAR or TEXT column", col.DataType)
+		}
+	case sqltype.TypeIPAddress:
+		// INET6_ATON returns 4 bytes for IPv4 and 16 for IPv6; BINARY would pad
+		// IPv4 values and make them indistinguishable from IPv6.
+		if baseType != "varbinary" {
+			return fmt.Errorf("unsupported SQL type %q, expected VARBINARY(16) storing INET6_ATON values", col.DataType)
+		}
+		if length, ok := sqlTypeLength(*col); !ok || length != 16 {
+			return fmt.Errorf("VARBINARY requires length 16 for INET6_ATON storage")
+		}
+	case sqltype.TypeMoney:
+		if sqltype.MapToGraphQL(baseType) != sqltype.TypeDecimal {
+			return fmt.Errorf("unsupported SQL type %q, expected DECIMAL", col.DataType)
+		}
+		currency := findColumn(table, mapping.CurrencyColumn)
+		if currency == nil {
+			return fmt.Errorf("currency column %q not found", mapping.CurrencyColumn)
+		}
+		if !isTextType(strings.ToLower(currency.DataType)) {
+			return fmt.Errorf("currency column %q must be a text column", currency.Name)
+		}
+		col.CurrencyColumn = currency.Name
+	case sqltype.TypeDuration:
+		if !isIntegerType(baseType) {
+			return fmt.Errorf("unsupported SQL type %q, expected an integer column", col.DataType)
+		}
+	case sqltype.TypeTimestamp:
+		if baseType == "datetime" || baseType == "timestamp" {
+			if mapping.Unit != "" {
+				return fmt.Errorf("unit applies to integer columns only")
+			}
+			break
+		}
+		if !isIntegerType(baseType) {
+			return fmt.Errorf("unsupported SQL type %q, expected DATETIME, TIMESTAMP or an integer column", col.DataType)
+		}
+	}
+	unit := mappingUnit(typ, *col, mapping.Unit)
+	if unit != "" && unit != "s" && unit != "ms" {
+		return fmt.Errorf("unknown unit %q, expected \"s\" or \"ms\"", unit)
+	}
+	col.OverrideType = typ
+	col.HasOverrideType = true
+	col.OverrideUnit = unit
+	return nil
+}
+
+// mappingUnit returns the effective storage unit for Duration and integer
+// Timestamp columns, and "" for every other mapping.
+func mappingUnit(typ sqltype.GraphQLType, col Column, unit string) string {
+	switch {
+	case typ == sqltype.TypeDuration:
+		if unit == "" {
+			return "s"
+		}
+		return unit
+	case typ == sqltype.TypeTimestamp && isIntegerType(strings.ToLower(col.DataType)):
+		if unit == "" {
+			return "ms"
+		}
+		return unit
+	default:
+		return ""
+	}
+}
+
+func findColumn(table Table, name string) *Column {
+	for i := range table.Columns {
+		if strings.EqualFold(table.Columns[i].Name, name) {
+			return &table.Columns[i]
+		}
+	}
+	return nil
+}
+
+func isTextType(baseType string) bool {
+	switch baseType {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		return true
+	default:
+		return false
+	}
+}
+
+func isIntegerType(baseType string) bool {
+	switch sqltype.MapToGraphQL(baseType) {
+	case sqltype.TypeInt, sqltype.TypeBigInt:
+		return baseType != "bit"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package introspection
+
+import (
+	"testing"
+
+	"tidb-graphql/internal/sqltype"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyScalarTypeOverrides(t *testing.T) {
+	schema := &Schema{
+		Tables: []Table{
+			{
+				Name: "Orders",
+				Columns: []Column{
+					{Name: "contact_email", DataType: "varchar", ColumnType: "varchar(255)"},
+					{Name: "client_ip", DataType: "varbinary", ColumnType: "varbinary(16)"},
+					{Name: "total", DataType: "decimal", ColumnType: "decimal(12,2)"},
+					{Name: "currency", DataType: "char", ColumnType: "char(3)"},
+					{Name: "timeout", DataType: "int", ColumnType: "int"},
+					{Name: "placed_at", DataType: "bigint", ColumnType: "bigint"},
+					{Name: "shipped_at", DataType: "datetime", ColumnType: "datetime"},
+					{Name: "payload", DataType: "text", ColumnType: "text"},
+				},
+			},
+		},
+	}
+
+	err := ApplyScalarTypeOverrides(schema, []ScalarMapping{
+		{Scalar: "Email", Table: "orders", Columns: []string{"*_email"}},
+		{Scalar: "IPAddress", Table: "ord*", Columns: []string{"client_ip"}},
+		{Scalar: "Money", Table: "orders", Columns: []string{"total"}, CurrencyColumn: "CURRENCY"},
+		{Scalar: "Duration", Table: "orders", Columns: []string{"timeout"}},
+		{Scalar: "Timestamp", Table: "orders", Columns: []string{"placed_at", "shipped_at"}},
+		{Scalar: "JSON", Table: "orders", Columns: []string{"payload"}},
+	})
+	require.NoError(t, err)
+
+	cols := schema.Tables[0].Columns
+	assert.Equal(t, sqltype.TypeEmail, cols[0].OverrideType)
+	assert.Equal(t, sqltype.TypeIPAddress, cols[1].OverrideType)
+	assert.Equal(t, sqltype.TypeMoney, cols[2].OverrideType)
+	assert.Equal(t, "currency", cols[2].CurrencyColumn)
+	assert.False(t, cols[3].HasOverrideType)
+	assert.Equal(t, sqltype.TypeDuration, cols[4].OverrideType)
+	assert.Equal(t, "s", cols[4].OverrideUnit)
+	assert.Equal(t, sqltype.TypeTimestamp, cols[5].OverrideType)
+	assert.Equal(t, "ms", cols[5].OverrideUnit)
+	assert.Equal(t, sqltype.TypeTimestamp, cols[6].OverrideType)
+	assert.Equal(t, "", cols[6].OverrideUnit)
+	assert.Equal(t, sqltype.TypeJSON, cols[7].OverrideType)
+}
+
+func TestApplyScalarTypeOverrides_RejectsIncompatibleColumns(t *testing.T) {
+	newSchema := func() *Schema {
+		return &Schema{
+			Tables: []Table{
+				{
+					Name: "sessions",
+					Columns: []Column{
+						{Name: "ip", DataType: "binary", ColumnType: "binary(16)"},
+						{Name: "amount", DataType: "decimal", ColumnType: "decimal(10,2)"},
+						{Name: "email", DataType: "int", ColumnType: "int"},
+						{Name: "created_at", DataType: "datetime", ColumnType: "datetime"},
+						{Name: "token", DataType: "char", ColumnType: "char(36)", OverrideType: sqltype.TypeUUID, HasOverrideType: true},
+					},
+				},
+			},
+		}
+	}
+
+	cases := map[string]ScalarMapping{
+		"binary ip":          {Scalar: "IPAddress", Table: "sessions", Columns: []string{"ip"}},
+		"missing currency":   {Scalar: "Money", Table: "sessions", Columns: []string{"amount"}, CurrencyColumn: "currency"},
+		"numeric email":      {Scalar: "Email", Table: "sessions", Columns: []string{"email"}},
+		"datetime with unit": {Scalar: "Timestamp", Table: "sessions", Columns: []string{"created_at"}, Unit: "s"},
+		"already mapped":     {Scalar: "JSON", Table: "sessions", Columns: []string{"token"}},
+		"unknown scalar":     {Scalar: "Phone", Table: "sessions", Columns: []string{"email"}},
+	}
+	for name, mapping := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ApplyScalarTypeOverrides(newSchema(), []ScalarMapping{mapping})
+			assert.Error(t, err)
+		})
+	}
+}
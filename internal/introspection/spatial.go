@@ -0,0 +1,115 @@
+package introspection
+
+import (
+	"context"
+	"log/slog"
+
+	"tidb-graphql/internal/geojson"
+	"tidb-graphql/internal/sqltype"
+)
+
+// SpatialFunctions records which spatial SQL functions the server accepted
+// when probed. GeoJSON filter operators are only exposed for supported
+// functions so unsupported ones fail at schema build rather than query time.
+type SpatialFunctions struct {
+	// Distance reports support for ST_Distance and ST_GeomFromWKB.
+	Distance bool
+	// BoundingBox reports support for MBRIntersects and ST_GeomFromWKB.
+	BoundingBox bool
+}
+
+// IsGeometryColumn reports whether col is a spatial column exposed as GeoJSON.
+func IsGeometryColumn(col Column) bool {
+	return EffectiveGraphQLType(col) == sqltype.TypeGeometry
+}
+
+// HasGeometryColumns reports whether any table in schema has a spatial column.
+func HasGeometryColumns(schema *Schema) bool {
+	if schema == nil {
+		return false
+	}
+	for _, table := range schema.Tables {
+		for _, col := range table.Columns {
+			if IsGeometryColumn(col) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DetectSpatialFunctions probes the server's spatial functions with constant
+// geometries. Rejected probes disable the matching filter operators.
+func DetectSpatialFunctions(ctx context.Context, db Queryer) SpatialFunctions {
+	point := geojson.Geometry{Type: geojson.TypePoint}.WKB()
+	box := geojson.Box(-1, -1, 1, 1).WKB()
+	return SpatialFunctions{
+		Distance:    probeSpatialFunction(ctx, db, "SELECT ST_Distance(ST_GeomFromWKB(?, 0), ST_GeomFromWKB(?, 0))", point, point),
+		BoundingBox: probeSpatialFunction(ctx, db, "SELECT MBRIntersects(ST_GeomFromWKB(?, 0), ST_GeomFromWKB(?, 0))", point, box),
+	}
+}
+
+func probeSpatialFunction(ctx context.Context, db Queryer, query string, args ...any) bool {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.Default().Info("spatial function not supported; omitting filter operator", slog.String("query", query), slog.String("error", err.Error()))
+		return false
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	for rows.Next() {
+	}
+	return rows.Err() == nil
+}
+
+// applySpatialReferenceIDs records the SRID restriction of spatial columns
+// from INFORMATION_SCHEMA.COLUMNS.SRS_ID. Servers without that column keep
+// SRID 0.
+func applySpatialReferenceIDs(ctx context.Context, db Queryer, databaseName, tableName string, columns []Column) []Column {
+	hasGeometry := false
+	for _, col := range columns {
+		if IsGeometryColumn(col) {
+			hasGeometry = true
+			break
+		}
+	}
+	if !hasGeometry {
+		return columns
+	}
+
+	query := `
+		SELECT COLUMN_NAME, SRS_ID
+		FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = ?
+			AND TABLE_NAME = ?
+			AND SRS_ID IS NOT NULL
+	`
+	rows, err := db.QueryContext(ctx, query, databaseName, tableName)
+	if err != nil {
+		slog.Default().Debug("spatial reference IDs unavailable", slog.String("table", tableName), slog.String("error", err.Error()))
+		return columns
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	srids := make(map[string]uint32)
+	for rows.Next() {
+		var name string
+		var srid uint32
+		if err := rows.Scan(&name, &srid); err != nil {
+			return columns
+		}
+		srids[name] = srid
+	}
+	if rows.Err() != nil {
+		return columns
+	}
+	for i := range columns {
+		if srid, ok := srids[columns[i].Name]; ok {
+			columns[i].SRID = srid
+		}
+	}
+	return columns
+}
@@ -0,0 +1,51 @@
+package introspection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSpatialFunctions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT ST_Distance").
+		WillReturnRows(sqlmock.NewRows([]string{"d"}).AddRow(0.0))
+	mock.ExpectQuery("SELECT MBRIntersects").
+		WillReturnError(errors.New("FUNCTION MBRIntersects does not exist"))
+
+	got := DetectSpatialFunctions(context.Background(), db)
+	assert.Equal(t, SpatialFunctions{Distance: true, BoundingBox: false}, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplySpatialReferenceIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []Column{
+		{Name: "id", DataType: "int"},
+		{Name: "location", DataType: "point"},
+	}
+	mock.ExpectQuery("SELECT COLUMN_NAME, SRS_ID").
+		WithArgs("app", "stores").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "SRS_ID"}).AddRow("location", 4326))
+
+	columns = applySpatialReferenceIDs(context.Background(), db, "app", "stores", columns)
+	assert.Equal(t, uint32(4326), columns[1].SRID)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Servers without SRS_ID keep SRID 0; tables without geometry are not queried.
+	mock.ExpectQuery("SELECT COLUMN_NAME, SRS_ID").WillReturnError(errors.New("Unknown column 'SRS_ID'"))
+	fallback := applySpatialReferenceIDs(context.Background(), db, "app", "stores", []Column{{Name: "location", DataType: "geometry"}})
+	assert.Equal(t, uint32(0), fallback[0].SRID)
+	applySpatialReferenceIDs(context.Background(), db, "app", "users", []Column{{Name: "id", DataType: "int"}})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
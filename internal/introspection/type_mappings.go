@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"tidb-graphql/internal/sqltype"
 )
@@ -95,6 +96,33 @@ func ApplyUUIDTypeOverrides(schema *Schema, patterns map[string][]string) error
 	return nil
 }
 
+// ApplyDateTimeZone records loc as the zone of the wall-clock values stored in
+// DATETIME columns, which carry no zone of their own. TIMESTAMP columns are
+// unaffected; they are read as instants through the UTC session.
+func ApplyDateTimeZone(schema *Schema, loc *time.Location) {
+	if schema == nil || loc == nil || loc == time.UTC {
+		return
+	}
+	for ti := range schema.Tables {
+		table := &schema.Tables[ti]
+		for ci := range table.Columns {
+			col := &table.Columns[ci]
+			if strings.EqualFold(col.DataType, "datetime") {
+				col.TimeZone = loc
+			}
+		}
+	}
+}
+
+// WallClockZone returns the zone of col's stored wall-clock values: the
+// configured zone for DATETIME columns and UTC for everything else.
+func WallClockZone(col Column) *time.Location {
+	if col.TimeZone != nil {
+		return col.TimeZone
+	}
+	return time.UTC
+}
+
 func mergePatterns(patterns map[string][]string, table string) []string {
 	if patterns == nil {
 		return nil
@@ -2,6 +2,7 @@ package introspection
 
 import (
 	"testing"
+	"time"
 
 	"tidb-graphql/internal/sqltype"
 
@@ -219,3 +220,33 @@ func TestMergePatterns_DeduplicatesNonAdjacent(t *testing.T) {
 	merged := mergePatterns(patterns, "orders")
 	assert.Equal(t, []string{"*_uuid", "id", "customer_uuid"}, merged)
 }
+
+func TestApplyDateTimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	schema := &Schema{
+		Tables: []Table{
+			{
+				Name: "orders",
+				Columns: []Column{
+					{Name: "placed_at", DataType: "datetime", ColumnType: "datetime"},
+					{Name: "updated_at", DataType: "timestamp", ColumnType: "timestamp"},
+					{Name: "ship_date", DataType: "date", ColumnType: "date"},
+				},
+			},
+		},
+	}
+
+	ApplyDateTimeZone(schema, berlin)
+
+	cols := schema.Tables[0].Columns
+	assert.Equal(t, berlin, cols[0].TimeZone)
+	assert.Nil(t, cols[1].TimeZone)
+	assert.Nil(t, cols[2].TimeZone)
+	assert.Equal(t, berlin, WallClockZone(cols[0]))
+	assert.Equal(t, time.UTC, WallClockZone(cols[1]))
+
+	utcSchema := &Schema{Tables: []Table{{Name: "t", Columns: []Column{{Name: "c", DataType: "datetime"}}}}}
+	ApplyDateTimeZone(utcSchema, time.UTC)
+	assert.Nil(t, utcSchema.Tables[0].Columns[0].TimeZone)
+}
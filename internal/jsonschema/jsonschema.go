@@ -0,0 +1,342 @@
+// Package jsonschema parses and validates the subset of JSON Schema used to
+// expose JSON columns as typed GraphQL objects.
+//
+// Supported keywords: type (one type, optionally paired with "null"),
+// properties, required, additionalProperties (boolean), items, enum,
+// minimum, maximum, minLength, maxLength, pattern, title and description.
+// Composition and reference keywords ($ref, allOf, anyOf, oneOf, not) are
+// rejected so that every schema maps onto a single GraphQL type.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Type names supported by Schema.Type.
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+var (
+	propertyNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+	unsupportedKeywords = []string{"$ref", "allOf", "anyOf", "oneOf", "not", "if", "then", "else", "patternProperties", "dependentSchemas"}
+)
+
+// Schema is a parsed JSON Schema node.
+type Schema struct {
+	Type        string
+	Nullable    bool
+	Title       string
+	Description string
+	// Properties and Required apply to objects. PropertyNames lists the
+	// property names in sorted order for deterministic type generation.
+	Properties           map[string]*Schema
+	PropertyNames        []string
+	Required             map[string]bool
+	AdditionalProperties bool
+	// Items applies to arrays.
+	Items     *Schema
+	Enum      []interface{}
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int
+	MaxLength *int
+	Pattern   *regexp.Regexp
+}
+
+// Load reads and parses a schema file.
+func Load(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON schema %s: %w", path, err)
+	}
+	schema, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema %s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// Parse parses a schema document. The root must describe an object with at
+// least one property.
+func Parse(data []byte) (*Schema, error) {
+	schema, err := parseNode(data, "$")
+	if err != nil {
+		return nil, err
+	}
+	if schema.Type != TypeObject || len(schema.Properties) == 0 {
+		return nil, fmt.Errorf("$: root schema must be an object with properties")
+	}
+	return schema, nil
+}
+
+func parseNode(data []byte, path string) (*Schema, error) {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return nil, fmt.Errorf("%s: schema must be an object", path)
+	}
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := keywords[keyword]; ok {
+			return nil, fmt.Errorf("%s: keyword %q is not supported", path, keyword)
+		}
+	}
+
+	var raw struct {
+		Type                 json.RawMessage            `json:"type"`
+		Title                string                     `json:"title"`
+		Description          string                     `json:"description"`
+		Properties           map[string]json.RawMessage `json:"properties"`
+		Required             []string                   `json:"required"`
+		AdditionalProperties *bool                      `json:"additionalProperties"`
+		Items                json.RawMessage            `json:"items"`
+		Enum                 []interface{}              `json:"enum"`
+		Minimum              *float64                   `json:"minimum"`
+		Maximum              *float64                   `json:"maximum"`
+		MinLength            *int                       `json:"minLength"`
+		MaxLength            *int                       `json:"maxLength"`
+		Pattern              string                     `json:"pattern"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	schema := &Schema{
+		Title:                raw.Title,
+		Description:          raw.Description,
+		AdditionalProperties: raw.AdditionalProperties == nil || *raw.AdditionalProperties,
+		Enum:                 raw.Enum,
+		Minimum:              raw.Minimum,
+		Maximum:              raw.Maximum,
+		MinLength:            raw.MinLength,
+		MaxLength:            raw.MaxLength,
+	}
+	var err error
+	if schema.Type, schema.Nullable, err = parseType(raw.Type); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if raw.Pattern != "" {
+		if schema.Pattern, err = regexp.Compile(raw.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+	}
+
+	switch schema.Type {
+	case TypeObject:
+		schema.Properties = make(map[string]*Schema, len(raw.Properties))
+		for name, data := range raw.Properties {
+			if !propertyNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+				return nil, fmt.Errorf("%s: property name %q is not a valid GraphQL field name", path, name)
+			}
+			child, err := parseNode(data, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			schema.Properties[name] = child
+			schema.PropertyNames = append(schema.PropertyNames, name)
+		}
+		sort.Strings(schema.PropertyNames)
+		schema.Required = make(map[string]bool, len(raw.Required))
+		for _, name := range raw.Required {
+			if _, ok := schema.Properties[name]; !ok {
+				return nil, fmt.Errorf("%s: required property %q is not declared", path, name)
+			}
+			schema.Required[name] = true
+		}
+	case TypeArray:
+		if len(raw.Items) == 0 {
+			return nil, fmt.Errorf("%s: array schema requires items", path)
+		}
+		if schema.Items, err = parseNode(raw.Items, path+"[]"); err != nil {
+			return nil, err
+		}
+	}
+	if len(schema.Enum) > 0 && (schema.Type == TypeObject || schema.Type == TypeArray) {
+		return nil, fmt.Errorf("%s: enum is only supported for scalar types", path)
+	}
+	for _, value := range schema.Enum {
+		if err := schema.validateType(value, path); err != nil {
+			return nil, fmt.Errorf("%s: enum value %v does not match type %s", path, value, schema.Type)
+		}
+	}
+	return schema, nil
+}
+
+func parseType(data json.RawMessage) (string, bool, error) {
+	if len(data) == 0 {
+		return "", false, fmt.Errorf("type is required")
+	}
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		return single, false, checkType(single)
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return "", false, fmt.Errorf("type must be a string or a list of strings")
+	}
+	typ, nullable := "", false
+	for _, item := range list {
+		if item == "null" {
+			nullable = true
+			continue
+		}
+		if typ != "" {
+			return "", false, fmt.Errorf("type may name one type besides null")
+		}
+		typ = item
+	}
+	return typ, nullable, checkType(typ)
+}
+
+func checkType(typ string) error {
+	switch typ {
+	case TypeObject, TypeArray, TypeString, TypeInteger, TypeNumber, TypeBoolean:
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %q", typ)
+	}
+}
+
+// Validate checks a decoded JSON value against the schema. Errors name the
+// offending location, e.g. "$.address.zip: expected string".
+func (s *Schema) Validate(value interface{}) error {
+	return s.validate(value, "$")
+}
+
+func (s *Schema) validate(value interface{}, path string) error {
+	if value == nil {
+		if s.Nullable {
+			return nil
+		}
+		return fmt.Errorf("%s: value must not be null", path)
+	}
+	if err := s.validateType(value, path); err != nil {
+		return err
+	}
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		return fmt.Errorf("%s: value is not one of the allowed values", path)
+	}
+
+	switch s.Type {
+	case TypeObject:
+		obj := value.(map[string]interface{})
+		for _, name := range s.PropertyNames {
+			if _, ok := obj[name]; !ok && s.Required[name] {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child, ok := s.Properties[key]
+			if !ok {
+				if !s.AdditionalProperties {
+					return fmt.Errorf("%s: unknown property %q", path, key)
+				}
+				continue
+			}
+			if err := child.validate(obj[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+	case TypeArray:
+		for i, item := range value.([]interface{}) {
+			if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case TypeString:
+		str := value.(string)
+		length := utf8.RuneCountInString(str)
+		if s.MinLength != nil && length < *s.MinLength {
+			return fmt.Errorf("%s: must be at least %d characters", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fmt.Errorf("%s: must be at most %d characters", path, *s.MaxLength)
+		}
+		if s.Pattern != nil && !s.Pattern.MatchString(str) {
+			return fmt.Errorf("%s: does not match pattern %s", path, s.Pattern.String())
+		}
+	case TypeInteger, TypeNumber:
+		n, _ := toFloat(value)
+		if s.Minimum != nil && n < *s.Minimum {
+			return fmt.Errorf("%s: must be >= %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			return fmt.Errorf("%s: must be <= %v", path, *s.Maximum)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateType(value interface{}, path string) error {
+	ok := false
+	switch s.Type {
+	case TypeObject:
+		_, ok = value.(map[string]interface{})
+	case TypeArray:
+		_, ok = value.([]interface{})
+	case TypeString:
+		_, ok = value.(string)
+	case TypeBoolean:
+		_, ok = value.(bool)
+	case TypeNumber:
+		_, ok = toFloat(value)
+	case TypeInteger:
+		n, isNumber := toFloat(value)
+		ok = isNumber && n == math.Trunc(n)
+	}
+	if !ok {
+		return fmt.Errorf("%s: expected %s", path, s.Type)
+	}
+	return nil
+}
+
+func (s *Schema) inEnum(value interface{}) bool {
+	for _, allowed := range s.Enum {
+		if a, ok := toFloat(allowed); ok {
+			if v, ok := toFloat(value); ok && a == v {
+				return true
+			}
+			continue
+		}
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,105 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const addressSchema = `{
+  "type": "object",
+  "description": "Postal address",
+  "required": ["city", "zip"],
+  "additionalProperties": false,
+  "properties": {
+    "city": {"type": "string", "minLength": 1},
+    "zip": {"type": "string", "pattern": "^[0-9]{5}$"},
+    "floor": {"type": ["integer", "null"], "minimum": 0},
+    "kind": {"type": "string", "enum": ["home", "work"]},
+    "lines": {"type": "array", "items": {"type": "string"}},
+    "geo": {
+      "type": "object",
+      "required": ["lat", "lng"],
+      "properties": {"lat": {"type": "number"}, "lng": {"type": "number"}}
+    }
+  }
+}`
+
+func TestParse(t *testing.T) {
+	schema, err := Parse([]byte(addressSchema))
+	require.NoError(t, err)
+	assert.Equal(t, TypeObject, schema.Type)
+	assert.Equal(t, []string{"city", "floor", "geo", "kind", "lines", "zip"}, schema.PropertyNames)
+	assert.True(t, schema.Required["zip"])
+	assert.False(t, schema.AdditionalProperties)
+	assert.True(t, schema.Properties["floor"].Nullable)
+	assert.Equal(t, TypeString, schema.Properties["lines"].Items.Type)
+	assert.Equal(t, TypeNumber, schema.Properties["geo"].Properties["lat"].Type)
+}
+
+func TestParseRejectsUnsupportedSchemas(t *testing.T) {
+	cases := map[string]string{
+		"root not object":   `{"type": "string"}`,
+		"no properties":     `{"type": "object"}`,
+		"ref":               `{"type": "object", "properties": {"a": {"$ref": "#/defs/a"}}}`,
+		"one of":            `{"type": "object", "properties": {"a": {"oneOf": []}}}`,
+		"multiple types":    `{"type": "object", "properties": {"a": {"type": ["string", "integer"]}}}`,
+		"missing type":      `{"type": "object", "properties": {"a": {}}}`,
+		"invalid name":      `{"type": "object", "properties": {"first-name": {"type": "string"}}}`,
+		"undeclared req":    `{"type": "object", "required": ["b"], "properties": {"a": {"type": "string"}}}`,
+		"array no items":    `{"type": "object", "properties": {"a": {"type": "array"}}}`,
+		"enum type":         `{"type": "object", "properties": {"a": {"type": "integer", "enum": ["x"]}}}`,
+		"bad pattern":       `{"type": "object", "properties": {"a": {"type": "string", "pattern": "("}}}`,
+		"not a json object": `[]`,
+	}
+	for name, doc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(doc))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Parse([]byte(addressSchema))
+	require.NoError(t, err)
+
+	valid := map[string]interface{}{
+		"city":  "Berlin",
+		"zip":   "10115",
+		"floor": nil,
+		"kind":  "home",
+		"lines": []interface{}{"Unter den Linden 1"},
+		"geo":   map[string]interface{}{"lat": 52.5, "lng": 13},
+	}
+	require.NoError(t, schema.Validate(valid))
+
+	cases := map[string]struct {
+		mutate func(map[string]interface{})
+		want   string
+	}{
+		"missing required": {func(m map[string]interface{}) { delete(m, "zip") }, `$: missing required property "zip"`},
+		"wrong type":       {func(m map[string]interface{}) { m["city"] = 5 }, "$.city: expected string"},
+		"pattern":          {func(m map[string]interface{}) { m["zip"] = "1011" }, "$.zip: does not match pattern"},
+		"minimum":          {func(m map[string]interface{}) { m["floor"] = -1 }, "$.floor: must be >= 0"},
+		"integer":          {func(m map[string]interface{}) { m["floor"] = 1.5 }, "$.floor: expected integer"},
+		"enum":             {func(m map[string]interface{}) { m["kind"] = "other" }, "$.kind: value is not one of the allowed values"},
+		"unknown property": {func(m map[string]interface{}) { m["country"] = "DE" }, `$: unknown property "country"`},
+		"nested":           {func(m map[string]interface{}) { m["geo"] = map[string]interface{}{"lat": 1} }, `$.geo: missing required property "lng"`},
+		"array item":       {func(m map[string]interface{}) { m["lines"] = []interface{}{"a", 2} }, "$.lines[1]: expected string"},
+		"null":             {func(m map[string]interface{}) { m["city"] = nil }, "$.city: value must not be null"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc := make(map[string]interface{}, len(valid))
+			for k, v := range valid {
+				doc[k] = v
+			}
+			tc.mutate(doc)
+			err := schema.Validate(doc)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"tidb-graphql/internal/apikey"
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/observability"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// APIKeyHeader carries a static API key.
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyIssuerLabel is the issuer reported for API key logins, both in
+// AuthContext and in security metrics.
+const apiKeyIssuerLabel = "api_key"
+
+// APIKeyAuthMiddleware authenticates requests that carry an X-API-Key header
+// against store. A valid key places its ID and scopes in AuthContext and is
+// charged against the key's own rate limit. Requests without the header pass
+// through unchanged; sending both an API key and a bearer token is rejected.
+// Optional securityMetrics parameter enables security monitoring; pass nil to disable.
+func APIKeyAuthMiddleware(store *apikey.Store, logger *logging.Logger, securityMetrics ...*observability.SecurityMetrics) func(http.Handler) http.Handler {
+	var metrics *observability.SecurityMetrics
+	if len(securityMetrics) > 0 {
+		metrics = securityMetrics[0]
+	}
+	limiters := &apiKeyLimiters{buckets: map[string]*apiKeyBucket{}}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw := strings.TrimSpace(r.Header.Get(APIKeyHeader))
+			if raw == "" || store == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			endpoint := r.URL.Path
+			if metrics != nil {
+				metrics.RecordAuthAttempt(r.Context(), endpoint)
+			}
+			if bearerToken(r.Header.Get("Authorization")) != "" {
+				if metrics != nil {
+					metrics.RecordAuthFailure(r.Context(), endpoint, "ambiguous_credentials")
+				}
+				writeGraphQLError(w, http.StatusBadRequest, "send either an API key or a bearer token, not both", "BAD_REQUEST")
+				return
+			}
+
+			key, ok := store.Lookup(raw)
+			if !ok {
+				if metrics != nil {
+					metrics.RecordAuthFailure(r.Context(), endpoint, "invalid_api_key")
+					metrics.RecordUnauthorizedAttempt(r.Context(), endpoint, "invalid_api_key")
+				}
+				if logger != nil {
+					logging.FromContext(r.Context()).Warn("authentication failed: invalid API key",
+						slog.String("endpoint", endpoint),
+						slog.String("remote_addr", r.RemoteAddr),
+					)
+				}
+				writeGraphQLError(w, http.StatusUnauthorized, "invalid API key", "UNAUTHENTICATED")
+				return
+			}
+
+			if !limiters.allow(store, key) {
+				if metrics != nil {
+					metrics.RecordAPIKeyRequest(r.Context(), key.ID, "rate_limited")
+				}
+				w.Header().Set("Retry-After", "1")
+				writeGraphQLError(w, http.StatusTooManyRequests, "rate limit exceeded for API key", "RATE_LIMITED")
+				return
+			}
+
+			if metrics != nil {
+				metrics.RecordAuthSuccess(r.Context(), endpoint, apiKeyIssuerLabel)
+				metrics.RecordAPIKeyRequest(r.Context(), key.ID, "allowed")
+			}
+			// Tag every later log line of the request with the key ID for auditing.
+			reqLogger := logging.FromContext(r.Context()).WithFields(slog.String("api_key_id", key.ID))
+			ctx := logging.WithLogger(r.Context(), reqLogger)
+			if logger != nil {
+				reqLogger.Debug("API key authenticated", slog.String("endpoint", endpoint))
+			}
+			if span := trace.SpanFromContext(ctx); span.IsRecording() {
+				span.SetAttributes(
+					attribute.String("auth.subject", apiKeySubject(key)),
+					attribute.String("auth.issuer", apiKeyIssuerLabel),
+					attribute.String("auth.method", apiKeyIssuerLabel),
+					attribute.String("auth.api_key_id", key.ID),
+					attribute.Bool("auth.authenticated", true),
+				)
+			}
+
+			existing, _ := AuthFromContext(ctx)
+			ctx = WithAuthContext(ctx, AuthContext{
+				Subject:    apiKeySubject(key),
+				Issuer:     apiKeyIssuerLabel,
+				APIKey:     key,
+				ClientCert: existing.ClientCert,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func apiKeySubject(key *apikey.Key) string {
+	return "api_key:" + key.ID
+}
+
+// apiKeyLimiters keeps one token bucket per key ID. A bucket is replaced when
+// the key's limits change on reload, and dropped once its key is removed or
+// disabled.
+type apiKeyLimiters struct {
+	mu         sync.Mutex
+	buckets    map[string]*apiKeyBucket
+	generation uint64
+}
+
+type apiKeyBucket struct {
+	rps    float64
+	burst  int
+	bucket *tokenBucket
+}
+
+func (l *apiKeyLimiters) allow(store *apikey.Store, key *apikey.Key) bool {
+	l.mu.Lock()
+	if generation := store.Generation(); generation != l.generation {
+		l.prune(store.ActiveIDs())
+		l.generation = generation
+	}
+	if !key.RateLimited() {
+		l.mu.Unlock()
+		return true
+	}
+	b, ok := l.buckets[key.ID]
+	if !ok || b.rps != key.RateLimitRPS || b.burst != key.RateLimitBurst {
+		b = &apiKeyBucket{rps: key.RateLimitRPS, burst: key.RateLimitBurst, bucket: newTokenBucket(key.RateLimitRPS, key.RateLimitBurst)}
+		l.buckets[key.ID] = b
+	}
+	l.mu.Unlock()
+	return b.bucket.Allow()
+}
+
+// prune drops the buckets of keys that are no longer active. The caller holds l.mu.
+func (l *apiKeyLimiters) prune(active map[string]struct{}) {
+	for id := range l.buckets {
+		if _, ok := active[id]; !ok {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// APIKeyScopeConfig tells APIKeyScopeMiddleware which namespace each root
+// field belongs to.
+type APIKeyScopeConfig struct {
+	// NamespaceFields maps namespaced root field names to their namespace.
+	// It is empty when the schema uses flat root fields.
+	NamespaceFields map[string]string
+	// DefaultNamespace is the namespace of every root field of a flat schema.
+	DefaultNamespace string
+}
+
+// APIKeyScopeMiddleware enforces the operation types and namespaces of the
+// API key that authenticated the request. It must run after request analysis.
+// Optional securityMetrics parameter enables security monitoring; pass nil to disable.
+func APIKeyScopeMiddleware(cfg APIKeyScopeConfig, securityMetrics ...*observability.SecurityMetrics) func(http.Handler) http.Handler {
+	var metrics *observability.SecurityMetrics
+	if len(securityMetrics) > 0 {
+		metrics = securityMetrics[0]
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth, _ := AuthFromContext(r.Context())
+			analysis := gqlrequest.AnalysisFromContext(r.Context())
+			if auth.APIKey == nil || analysis == nil || analysis.Operation == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if message := apiKeyScopeViolation(auth.APIKey, analysis, cfg); message != "" {
+				if metrics != nil {
+					metrics.RecordAPIKeyRequest(r.Context(), auth.APIKey.ID, "forbidden")
+					metrics.RecordUnauthorizedAttempt(r.Context(), r.URL.Path, "api_key_scope")
+				}
+				writeGraphQLError(w, http.StatusForbidden, message, "FORBIDDEN")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func apiKeyScopeViolation(key *apikey.Key, analysis *gqlrequest.Analysis, cfg APIKeyScopeConfig) string {
+	if !key.AllowsOperation(analysis.OperationType) {
+		return fmt.Sprintf("API key %q is not allowed to run %s operations", key.ID, analysis.OperationType)
+	}
+	if len(key.Namespaces) == 0 {
+		return ""
+	}
+	for _, name := range rootFieldNames(analysis.Operation.SelectionSet, analysis.Fragments, map[string]bool{}) {
+		var namespace string
+		if len(cfg.NamespaceFields) > 0 {
+			namespace = cfg.NamespaceFields[name]
+		} else if !strings.HasPrefix(name, "__") {
+			namespace = cfg.DefaultNamespace
+		}
+		// Introspection and transaction control fields belong to no namespace.
+		if namespace == "" {
+			continue
+		}
+		if !key.AllowsNamespace(namespace) {
+			return fmt.Sprintf("API key %q is not allowed to access namespace %q", key.ID, namespace)
+		}
+	}
+	return ""
+}
+
+// rootFieldNames lists the root fields of a selection set, following
+// fragment spreads and inline fragments.
+func rootFieldNames(selectionSet *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, visited map[string]bool) []string {
+	if selectionSet == nil {
+		return nil
+	}
+	var names []string
+	for _, selection := range selectionSet.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if sel.Name != nil {
+				names = append(names, sel.Name.Value)
+			}
+		case *ast.InlineFragment:
+			names = append(names, rootFieldNames(sel.SelectionSet, fragments, visited)...)
+		case *ast.FragmentSpread:
+			if sel.Name == nil || visited[sel.Name.Value] {
+				continue
+			}
+			visited[sel.Name.Value] = true
+			if fragment, ok := fragments[sel.Name.Value]; ok {
+				names = append(names, rootFieldNames(fragment.SelectionSet, fragments, visited)...)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"tidb-graphql/internal/apikey"
+)
+
+func newTestAPIKeyStore(t *testing.T) *apikey.Store {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	content := `
+keys:
+  - id: reporting
+    hash: ` + apikey.Hash("read-key") + `
+    role: app_viewer
+    operations: [query]
+    namespaces: [sales]
+  - id: ingest
+    hash: ` + apikey.Hash("write-key") + `
+    role: app_writer
+    rate_limit_rps: 0.001
+    rate_limit_burst: 1
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	store, err := apikey.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return store
+}
+
+func TestAPIKeyAuthMiddleware(t *testing.T) {
+	var (
+		auth          AuthContext
+		authenticated bool
+		role          DBRoleContext
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, authenticated = AuthFromContext(r.Context())
+		role, _ = DBRoleFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := APIKeyAuthMiddleware(newTestAPIKeyStore(t), nil)(
+		DBRoleMiddleware("db_role", []string{"app_viewer", "app_writer"})(next))
+
+	serve := func(header map[string]string) *httptest.ResponseRecorder {
+		auth, authenticated, role = AuthContext{}, false, DBRoleContext{}
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(map[string]string{APIKeyHeader: "read-key"})
+	if rec.Code != http.StatusNoContent || !authenticated {
+		t.Fatalf("status = %d, authenticated = %v: %s", rec.Code, authenticated, rec.Body.String())
+	}
+	if auth.APIKey == nil || auth.APIKey.ID != "reporting" || auth.Subject != "api_key:reporting" || auth.Issuer != "api_key" {
+		t.Fatalf("unexpected auth context: %+v", auth)
+	}
+	if role.Role != "app_viewer" || !role.Validated {
+		t.Fatalf("role = %+v, want app_viewer", role)
+	}
+
+	if rec := serve(map[string]string{APIKeyHeader: "nope"}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("invalid key status = %d, want 401", rec.Code)
+	}
+	if rec := serve(map[string]string{APIKeyHeader: "read-key", "Authorization": "Bearer token"}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("key with bearer token status = %d, want 400", rec.Code)
+	}
+
+	if rec := serve(map[string]string{APIKeyHeader: "write-key"}); rec.Code != http.StatusNoContent {
+		t.Fatalf("first rate limited request status = %d, want 204", rec.Code)
+	}
+	rec = serve(map[string]string{APIKeyHeader: "write-key"})
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("second rate limited request status = %d, want 429", rec.Code)
+	}
+	if rec := serve(map[string]string{APIKeyHeader: "read-key"}); rec.Code != http.StatusNoContent {
+		t.Fatalf("other keys must not share the bucket, status = %d", rec.Code)
+	}
+}
+
+func TestAPIKeyScopeMiddleware(t *testing.T) {
+	store := newTestAPIKeyStore(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name         string
+		scope        APIKeyScopeConfig
+		key          string
+		query        string
+		expectStatus int
+	}{
+		{
+			name:         "query in allowed namespace",
+			scope:        APIKeyScopeConfig{NamespaceFields: map[string]string{"sales": "sales", "hr": "hr"}},
+			key:          "read-key",
+			query:        `{ sales { orders { id } } __typename }`,
+			expectStatus: http.StatusNoContent,
+		},
+		{
+			name:         "namespace reached through a fragment",
+			scope:        APIKeyScopeConfig{NamespaceFields: map[string]string{"sales": "sales", "hr": "hr"}},
+			key:          "read-key",
+			query:        `query { ...Root } fragment Root on Query { hr { staff { id } } }`,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "mutation with read-only key",
+			scope:        APIKeyScopeConfig{DefaultNamespace: "sales"},
+			key:          "read-key",
+			query:        `mutation { createOrder(input: {}) { id } }`,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "flat schema outside allowed namespace",
+			scope:        APIKeyScopeConfig{DefaultNamespace: "hr"},
+			key:          "read-key",
+			query:        `{ staff { id } }`,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "unscoped key",
+			scope:        APIKeyScopeConfig{DefaultNamespace: "hr"},
+			key:          "write-key",
+			query:        `mutation { createStaff(input: {}) { id } }`,
+			expectStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := APIKeyAuthMiddleware(store, nil)(
+				GraphQLRequestAnalysisMiddleware(nil)(APIKeyScopeMiddleware(tt.scope)(next)))
+			body := `{"query":` + strconv.Quote(tt.query) + `}`
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(APIKeyHeader, tt.key)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.expectStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.expectStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAPIKeyLimitersDropRemovedKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeys := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("keys:\n"+content), 0600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	ingest := "  - id: ingest\n    hash: " + apikey.Hash("write-key") + "\n    rate_limit_rps: 1\n    rate_limit_burst: 1\n"
+	reporting := "  - id: reporting\n    hash: " + apikey.Hash("read-key") + "\n"
+	writeKeys(ingest + reporting)
+	store, err := apikey.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	limiters := &apiKeyLimiters{buckets: map[string]*apiKeyBucket{}}
+	key, _ := store.Lookup("write-key")
+	limiters.allow(store, key)
+	if _, ok := limiters.buckets["ingest"]; !ok {
+		t.Fatal("expected a bucket for the rate limited key")
+	}
+
+	writeKeys(reporting)
+	if changed, err := store.Reload(); err != nil || !changed {
+		t.Fatalf("Reload() = %v, %v", changed, err)
+	}
+	key, _ = store.Lookup("read-key")
+	limiters.allow(store, key)
+	if _, ok := limiters.buckets["ingest"]; ok {
+		t.Fatal("bucket of a removed key must be dropped after reload")
+	}
+}
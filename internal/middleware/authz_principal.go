@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"tidb-graphql/internal/authz"
+)
+
+// AuthzPrincipalMiddleware exposes the authenticated caller to field-level
+// authorization rules. It must run after auth and DB role extraction.
+// Requests without an auth context get no principal and fail every rule.
+func AuthzPrincipalMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth, ok := AuthFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			principal := authz.Principal{
+				Subject: auth.Subject,
+				Scopes:  authz.ScopesFromClaims(auth.Claims),
+				Claims:  auth.Claims,
+			}
+			if role, ok := DBRoleFromContext(r.Context()); ok && role.Validated {
+				principal.Role = role.Role
+			}
+			next.ServeHTTP(w, r.WithContext(authz.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tidb-graphql/internal/authz"
+)
+
+func TestAuthzPrincipalMiddleware(t *testing.T) {
+	var (
+		principal authz.Principal
+		ok        bool
+	)
+	handler := AuthzPrincipalMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok = authz.PrincipalFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	ctx := WithAuthContext(req.Context(), AuthContext{
+		Subject: "alice",
+		Claims:  map[string]interface{}{"scope": "openid hr:read", "groups": []interface{}{"finance"}},
+	})
+	ctx = WithDBRole(ctx, "app_analyst", true)
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+	if !ok || principal.Subject != "alice" || principal.Role != "app_analyst" || len(principal.Scopes) != 2 || principal.Scopes[1] != "hr:read" {
+		t.Fatalf("principal = %+v, ok = %v", principal, ok)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/graphql", nil))
+	if ok {
+		t.Fatalf("anonymous request must not get a principal, got %+v", principal)
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"crypto/x509"
+	"log/slog"
+	"net/http"
+
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/observability"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// clientCertIssuerLabel is the issuer reported to security metrics for
+// certificate logins, keeping the label bounded regardless of the CA subject.
+const clientCertIssuerLabel = "client_cert"
+
+// ClientCertIdentity describes the verified client certificate of a request.
+type ClientCertIdentity struct {
+	Subject        string
+	Issuer         string
+	CommonName     string
+	SerialNumber   string
+	DNSNames       []string
+	URIs           []string
+	EmailAddresses []string
+	IPAddresses    []string
+}
+
+// Identities returns every name the certificate can be matched by: the full
+// subject DN, the common name and each DNS, URI, email and IP SAN.
+func (c *ClientCertIdentity) Identities() []string {
+	if c == nil {
+		return nil
+	}
+	ids := []string{c.Subject}
+	if c.CommonName != "" {
+		ids = append(ids, c.CommonName)
+	}
+	ids = append(ids, c.DNSNames...)
+	ids = append(ids, c.URIs...)
+	ids = append(ids, c.EmailAddresses...)
+	ids = append(ids, c.IPAddresses...)
+	return ids
+}
+
+// ClientCertIdentityFromRequest returns the leaf of the first verified chain.
+// Certificates that were presented but not verified against the client CA
+// bundle are ignored.
+func ClientCertIdentityFromRequest(r *http.Request) (*ClientCertIdentity, bool) {
+	if r == nil || r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	return newClientCertIdentity(r.TLS.VerifiedChains[0][0]), true
+}
+
+func newClientCertIdentity(cert *x509.Certificate) *ClientCertIdentity {
+	identity := &ClientCertIdentity{
+		Subject:        cert.Subject.String(),
+		Issuer:         cert.Issuer.String(),
+		CommonName:     cert.Subject.CommonName,
+		SerialNumber:   cert.SerialNumber.String(),
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		identity.IPAddresses = append(identity.IPAddresses, ip.String())
+	}
+	return identity
+}
+
+// ClientCertAuthMiddleware authenticates requests that arrive with a verified
+// client certificate. It places the certificate identity in AuthContext with
+// the subject and issuer DNs as Subject and Issuer. Requests without a
+// verified certificate pass through unchanged; the TLS handshake already
+// rejects them when client certificates are required.
+// Optional securityMetrics parameter enables security monitoring; pass nil to disable.
+func ClientCertAuthMiddleware(logger *logging.Logger, securityMetrics ...*observability.SecurityMetrics) func(http.Handler) http.Handler {
+	var metrics *observability.SecurityMetrics
+	if len(securityMetrics) > 0 {
+		metrics = securityMetrics[0]
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := ClientCertIdentityFromRequest(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			endpoint := r.URL.Path
+			if metrics != nil {
+				metrics.RecordAuthAttempt(r.Context(), endpoint)
+				metrics.RecordAuthSuccess(r.Context(), endpoint, clientCertIssuerLabel)
+			}
+			if logger != nil {
+				logging.FromContext(r.Context()).Debug("client certificate authenticated",
+					slog.String("subject", identity.Subject),
+					slog.String("issuer", identity.Issuer),
+					slog.String("endpoint", endpoint),
+				)
+			}
+			if span := trace.SpanFromContext(r.Context()); span.IsRecording() {
+				span.SetAttributes(
+					attribute.String("auth.subject", identity.Subject),
+					attribute.String("auth.issuer", identity.Issuer),
+					attribute.String("auth.method", clientCertIssuerLabel),
+					attribute.Bool("auth.authenticated", true),
+				)
+			}
+
+			ctx := WithAuthContext(r.Context(), AuthContext{
+				Subject:    identity.Subject,
+				Issuer:     identity.Issuer,
+				ClientCert: identity,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
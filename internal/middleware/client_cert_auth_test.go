@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newClientCertRequest(t *testing.T, verified bool) *http.Request {
+	t.Helper()
+	spiffe, err := url.Parse("spiffe://corp.test/ns/billing/sa/worker")
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "billing-worker", Organization: []string{"Corp"}},
+		Issuer:       pkix.Name{CommonName: "Corp Internal CA"},
+		DNSNames:     []string{"billing.internal"},
+		URIs:         []*url.URL{spiffe},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return req
+}
+
+func TestClientCertAuthMiddleware_SetsAuthContext(t *testing.T) {
+	var (
+		auth          AuthContext
+		authenticated bool
+	)
+	handler := ClientCertAuthMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, authenticated = AuthFromContext(r.Context())
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newClientCertRequest(t, false))
+	if authenticated {
+		t.Fatal("unverified certificate must not authenticate the request")
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), newClientCertRequest(t, true))
+	if !authenticated || auth.ClientCert == nil {
+		t.Fatal("expected verified certificate to authenticate the request")
+	}
+	if auth.Subject != "CN=billing-worker,O=Corp" || auth.Issuer != "CN=Corp Internal CA" {
+		t.Fatalf("unexpected subject/issuer: %q / %q", auth.Subject, auth.Issuer)
+	}
+	if auth.ClientCert.CommonName != "billing-worker" || auth.ClientCert.SerialNumber != "42" {
+		t.Fatalf("unexpected identity: %+v", auth.ClientCert)
+	}
+	if len(auth.ClientCert.URIs) != 1 || auth.ClientCert.URIs[0] != "spiffe://corp.test/ns/billing/sa/worker" {
+		t.Fatalf("unexpected URI SANs: %v", auth.ClientCert.URIs)
+	}
+}
+
+func TestDBRoleMiddleware_ClientCertMapping(t *testing.T) {
+	var role DBRoleContext
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, _ = DBRoleFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name         string
+		certRoles    []ClientCertRole
+		expectStatus int
+		expectRole   string
+	}{
+		{
+			name:         "mapped by URI SAN",
+			certRoles:    []ClientCertRole{{Identity: "spiffe://corp.test/ns/billing/sa/worker", Role: "app_billing"}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_billing",
+		},
+		{
+			name:         "mapped by common name",
+			certRoles:    []ClientCertRole{{Identity: "other", Role: "app_viewer"}, {Identity: "billing-worker", Role: "app_viewer"}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_viewer",
+		},
+		{
+			name:         "unmapped certificate",
+			certRoles:    []ClientCertRole{{Identity: "other", Role: "app_viewer"}},
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			name:         "mapped role not available",
+			certRoles:    []ClientCertRole{{Identity: "billing.internal", Role: "superuser"}},
+			expectStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role = DBRoleContext{}
+			handler := ClientCertAuthMiddleware(nil)(DBRoleMiddleware("db_role", []string{"app_viewer", "app_billing"}, tt.certRoles...)(next))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, newClientCertRequest(t, true))
+			if rec.Code != tt.expectStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.expectStatus, rec.Body.String())
+			}
+			if tt.expectRole != "" && (role.Role != tt.expectRole || !role.Validated) {
+				t.Fatalf("role = %+v, want %q", role, tt.expectRole)
+			}
+			if tt.expectStatus == http.StatusForbidden && !strings.Contains(rec.Body.String(), "FORBIDDEN") {
+				t.Fatalf("unexpected body: %s", rec.Body.String())
+			}
+		})
+	}
+}
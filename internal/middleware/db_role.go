@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/observability"
 )
 
 type dbRoleContextKey struct{}
@@ -33,14 +37,61 @@ func DBRoleFromContext(ctx context.Context) (DBRoleContext, bool) {
 	return role, ok
 }
 
+// ClientCertRole maps a client certificate identity (subject DN, common name or
+// SAN) to a database role.
+type ClientCertRole struct {
+	Identity string
+	Role     string
+}
+
+// DBRoleConfig configures DBRoleMiddlewareWithConfig.
+type DBRoleConfig struct {
+	// ClaimName is the JWT claim holding the role name (default db_role).
+	ClaimName      string
+	AvailableRoles []string
+	// ClientCertRoles map client certificate identities to roles.
+	ClientCertRoles []ClientCertRole
+	// Rules, when set, derive the role of JWT callers from their claims
+	// instead of reading ClaimName.
+	Rules []RoleRule
+}
+
 // DBRoleMiddleware extracts db_role claims from JWTs and enforces allowlist validation.
-func DBRoleMiddleware(claimName string, availableRoles []string) func(http.Handler) http.Handler {
+// The claim name is claimName unless the token's issuer configures its own.
+// Callers authenticated by an API key get the key's role. Callers authenticated
+// by a client certificate instead of a JWT get the role of the first certRoles
+// entry matching one of the certificate's identities.
+func DBRoleMiddleware(claimName string, availableRoles []string, certRoles ...ClientCertRole) func(http.Handler) http.Handler {
+	middleware, _ := DBRoleMiddlewareWithConfig(DBRoleConfig{
+		ClaimName:       claimName,
+		AvailableRoles:  availableRoles,
+		ClientCertRoles: certRoles,
+	})
+	return middleware
+}
+
+// DBRoleMiddlewareWithConfig is DBRoleMiddleware with claim-to-role rules. When
+// cfg.Rules is set, JWT callers get the role of the highest-priority matching
+// rule; the rule name is added to the request logger and recorded in metrics.
+// Optional securityMetrics parameter enables security monitoring; pass nil to disable.
+func DBRoleMiddlewareWithConfig(cfg DBRoleConfig, securityMetrics ...*observability.SecurityMetrics) (func(http.Handler) http.Handler, error) {
+	var metrics *observability.SecurityMetrics
+	if len(securityMetrics) > 0 {
+		metrics = securityMetrics[0]
+	}
+
+	claimName := cfg.ClaimName
 	if claimName == "" {
 		claimName = "db_role"
 	}
 
-	allowed := make(map[string]struct{}, len(availableRoles))
-	for _, role := range availableRoles {
+	rules, err := compileRoleRules(cfg.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]struct{}, len(cfg.AvailableRoles))
+	for _, role := range cfg.AvailableRoles {
 		allowed[role] = struct{}{}
 	}
 
@@ -52,15 +103,63 @@ func DBRoleMiddleware(claimName string, availableRoles []string) func(http.Handl
 				return
 			}
 
-			raw, ok := authCtx.Claims[claimName]
-			if !ok {
-				writeGraphQLError(w, http.StatusForbidden, "missing db_role claim", "FORBIDDEN")
-				return
+			roleClaim := claimName
+			if authCtx.RoleClaim != "" {
+				roleClaim = authCtx.RoleClaim
 			}
 
-			role, ok := raw.(string)
-			if !ok {
-				writeGraphQLError(w, http.StatusBadRequest, "invalid db_role claim type", "BAD_REQUEST")
+			ctx := r.Context()
+			var role string
+			if len(rules) > 0 && authCtx.Claims != nil {
+				rule, ok := matchRoleRule(rules, authCtx)
+				if !ok {
+					logging.FromContext(ctx).Debug("no database role rule matched",
+						slog.String("issuer", authCtx.Issuer),
+						slog.Int("rules", len(rules)),
+					)
+					if metrics != nil {
+						metrics.RecordDBRoleRuleMatch(ctx, "", "")
+					}
+					writeGraphQLError(w, http.StatusForbidden, "no database role rule matched", "FORBIDDEN")
+					return
+				}
+				role = rule.Role
+				if metrics != nil {
+					metrics.RecordDBRoleRuleMatch(ctx, rule.Name, role)
+				}
+				reqLogger := logging.FromContext(ctx).WithFields(slog.String("db_role_rule", rule.Name))
+				matchAttrs := []any{
+					slog.Int("rule_index", rule.index),
+					slog.String("role", role),
+				}
+				if rule.Claim != "" {
+					matchAttrs = append(matchAttrs, slog.String("claim", rule.Claim))
+				}
+				if rule.Pattern != "" {
+					matchAttrs = append(matchAttrs, slog.String("pattern", rule.Pattern))
+				}
+				reqLogger.Info("database role rule matched", matchAttrs...)
+				ctx = logging.WithLogger(ctx, reqLogger)
+			} else if raw, ok := authCtx.Claims[roleClaim]; ok {
+				role, ok = raw.(string)
+				if !ok {
+					writeGraphQLError(w, http.StatusBadRequest, "invalid db_role claim type", "BAD_REQUEST")
+					return
+				}
+			} else if authCtx.APIKey != nil {
+				role = authCtx.APIKey.Role
+				if role == "" {
+					writeGraphQLError(w, http.StatusForbidden, "no database role configured for API key", "FORBIDDEN")
+					return
+				}
+			} else if authCtx.ClientCert != nil {
+				role, ok = clientCertRole(authCtx.ClientCert, cfg.ClientCertRoles)
+				if !ok {
+					writeGraphQLError(w, http.StatusForbidden, "no database role mapped for client certificate", "FORBIDDEN")
+					return
+				}
+			} else {
+				writeGraphQLError(w, http.StatusForbidden, "missing db_role claim", "FORBIDDEN")
 				return
 			}
 
@@ -69,10 +168,22 @@ func DBRoleMiddleware(claimName string, availableRoles []string) func(http.Handl
 				return
 			}
 
-			ctx := WithDBRole(r.Context(), role, true)
+			ctx = WithDBRole(ctx, role, true)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
+	}, nil
+}
+
+func clientCertRole(cert *ClientCertIdentity, certRoles []ClientCertRole) (string, bool) {
+	identities := cert.Identities()
+	for _, mapping := range certRoles {
+		for _, identity := range identities {
+			if identity == mapping.Identity {
+				return mapping.Role, true
+			}
+		}
 	}
+	return "", false
 }
 
 func writeGraphQLError(w http.ResponseWriter, status int, message string, code string) {
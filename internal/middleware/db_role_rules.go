@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// RoleRule maps JWT claims to a database role. A rule matches when the claim
+// value, or any element of a list claim, equals one of Values or matches
+// Pattern. A rule without a Claim is a default and matches every token.
+type RoleRule struct {
+	// Name identifies the rule in logs and metrics.
+	Name string
+	// Issuer limits the rule to tokens from one issuer. Empty matches any issuer.
+	Issuer  string
+	Claim   string
+	Values  []string
+	Pattern string
+	Role    string
+	// Priority orders evaluation; higher runs first and ties keep config order.
+	Priority int
+}
+
+type compiledRoleRule struct {
+	RoleRule
+	// index is the rule's position in the configured list.
+	index   int
+	values  map[string]struct{}
+	pattern *regexp.Regexp
+}
+
+// compileRoleRules validates the rules and returns them in evaluation order.
+func compileRoleRules(rules []RoleRule) ([]compiledRoleRule, error) {
+	compiled := make([]compiledRoleRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule_%d", i)
+		}
+		if rule.Role == "" {
+			return nil, fmt.Errorf("db role rule %q has no role", rule.Name)
+		}
+		if rule.Claim != "" && len(rule.Values) == 0 && rule.Pattern == "" {
+			return nil, fmt.Errorf("db role rule %q needs values or a pattern for claim %q", rule.Name, rule.Claim)
+		}
+		entry := compiledRoleRule{RoleRule: rule, index: i}
+		if len(rule.Values) > 0 {
+			entry.values = make(map[string]struct{}, len(rule.Values))
+			for _, value := range rule.Values {
+				entry.values[value] = struct{}{}
+			}
+		}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("db role rule %q has an invalid pattern: %w", rule.Name, err)
+			}
+			entry.pattern = pattern
+		}
+		compiled = append(compiled, entry)
+	}
+	sort.SliceStable(compiled, func(i, j int) bool {
+		return compiled[i].Priority > compiled[j].Priority
+	})
+	return compiled, nil
+}
+
+// matchRoleRule returns the first rule, in priority order, that matches auth.
+func matchRoleRule(rules []compiledRoleRule, auth AuthContext) (*compiledRoleRule, bool) {
+	for i := range rules {
+		if rules[i].matches(auth) {
+			return &rules[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r *compiledRoleRule) matches(auth AuthContext) bool {
+	if r.Issuer != "" && r.Issuer != auth.Issuer {
+		return false
+	}
+	if r.Claim == "" {
+		return true
+	}
+	for _, value := range claimStrings(auth.Claims[r.Claim]) {
+		if _, ok := r.values[value]; ok {
+			return true
+		}
+		if r.pattern != nil && r.pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// claimStrings flattens a string or list-of-strings claim. Other types never match.
+func claimStrings(raw interface{}) []string {
+	switch value := raw.(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tidb-graphql/internal/logging"
+)
+
+func TestDBRoleMiddlewareWithConfig_Rules(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, ok := DBRoleFromContext(r.Context())
+		if ok {
+			w.Header().Set("X-Role", role.Role)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rules := []RoleRule{
+		{Name: "default", Role: "app_viewer"},
+		{Name: "analysts", Claim: "groups", Values: []string{"data-analysts"}, Role: "app_analyst", Priority: 10},
+		{Name: "admins", Claim: "groups", Values: []string{"tidb-admins"}, Role: "app_admin", Priority: 100},
+		{Name: "services", Claim: "sub", Pattern: `^svc-[a-z]+$`, Role: "app_service", Priority: 50},
+		{Name: "partners", Issuer: "https://partners.test", Role: "app_partner", Priority: 20},
+	}
+
+	tests := []struct {
+		name         string
+		auth         AuthContext
+		rules        []RoleRule
+		expectStatus int
+		expectRole   string
+	}{
+		{
+			name:         "group membership",
+			auth:         AuthContext{Claims: map[string]interface{}{"groups": []interface{}{"staff", "data-analysts"}}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_analyst",
+		},
+		{
+			name:         "highest priority wins",
+			auth:         AuthContext{Claims: map[string]interface{}{"groups": []interface{}{"data-analysts", "tidb-admins"}}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_admin",
+		},
+		{
+			name:         "pattern on subject",
+			auth:         AuthContext{Claims: map[string]interface{}{"sub": "svc-billing", "groups": []interface{}{"data-analysts"}}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_service",
+		},
+		{
+			name:         "issuer scoped rule",
+			auth:         AuthContext{Issuer: "https://partners.test", Claims: map[string]interface{}{"sub": "p-1"}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_partner",
+		},
+		{
+			name:         "default rule",
+			auth:         AuthContext{Claims: map[string]interface{}{"sub": "alice", "db_role": "app_admin"}},
+			expectStatus: http.StatusOK,
+			expectRole:   "app_viewer",
+		},
+		{
+			name:         "no rule matches",
+			auth:         AuthContext{Claims: map[string]interface{}{"groups": "staff"}},
+			rules:        rules[1:],
+			expectStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleSet := tt.rules
+			if ruleSet == nil {
+				ruleSet = rules
+			}
+			mw, err := DBRoleMiddlewareWithConfig(DBRoleConfig{
+				AvailableRoles: []string{"app_viewer", "app_analyst", "app_admin", "app_service", "app_partner"},
+				Rules:          ruleSet,
+			})
+			if err != nil {
+				t.Fatalf("DBRoleMiddlewareWithConfig() error = %v", err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+			req = req.WithContext(WithAuthContext(req.Context(), tt.auth))
+			rec := httptest.NewRecorder()
+			mw(handler).ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.expectStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("X-Role"); got != tt.expectRole {
+				t.Fatalf("role = %q, want %q", got, tt.expectRole)
+			}
+			if tt.expectStatus == http.StatusForbidden && !strings.Contains(rec.Body.String(), "no database role rule matched") {
+				t.Fatalf("unexpected body: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDBRoleMiddlewareWithConfig_InvalidRules(t *testing.T) {
+	for _, rules := range [][]RoleRule{
+		{{Name: "no-role", Claim: "groups", Values: []string{"a"}}},
+		{{Name: "no-matcher", Claim: "groups", Role: "app_viewer"}},
+		{{Name: "bad-pattern", Claim: "sub", Pattern: "(", Role: "app_viewer"}},
+	} {
+		if _, err := DBRoleMiddlewareWithConfig(DBRoleConfig{Rules: rules}); err == nil {
+			t.Fatalf("expected error for %+v", rules[0])
+		}
+	}
+}
+
+func TestDBRoleMiddlewareWithConfig_LogsMatchedRule(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &logging.Logger{
+		Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})),
+	}
+	mw, err := DBRoleMiddlewareWithConfig(DBRoleConfig{
+		AvailableRoles: []string{"app_viewer", "app_service"},
+		Rules: []RoleRule{
+			{Name: "default", Role: "app_viewer"},
+			{Name: "services", Claim: "sub", Pattern: `^svc-[a-z]+$`, Role: "app_service", Priority: 50},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DBRoleMiddlewareWithConfig() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	ctx := logging.WithLogger(req.Context(), logger)
+	ctx = WithAuthContext(ctx, AuthContext{Claims: map[string]interface{}{"sub": "svc-billing"}})
+	rec := httptest.NewRecorder()
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req.WithContext(ctx))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("expected one info log line, got %q: %v", buf.String(), err)
+	}
+	want := map[string]interface{}{
+		"level":        "INFO",
+		"msg":          "database role rule matched",
+		"db_role_rule": "services",
+		"rule_index":   float64(1),
+		"pattern":      `^svc-[a-z]+$`,
+		"role":         "app_service",
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Fatalf("log %s = %v, want %v (%s)", key, entry[key], value, buf.String())
+		}
+	}
+}
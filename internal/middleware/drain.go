@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+
+	"tidb-graphql/internal/drain"
+	"tidb-graphql/internal/gqlrequest"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// drainRetryAfterSeconds tells clients how soon to retry a refused mutation,
+// by which time the load balancer should route them to another instance.
+const drainRetryAfterSeconds = "1"
+
+// drainExemptRootFields may still run while draining so clients can finish
+// interactive transactions they already opened.
+var drainExemptRootFields = map[string]bool{
+	"commitTransaction":   true,
+	"rollbackTransaction": true,
+	"__typename":          true,
+}
+
+// DrainMiddleware counts mutations in flight and refuses new ones once the
+// controller is draining. Requests joined to an interactive transaction and
+// commitTransaction/rollbackTransaction are still served so open
+// transactions can finish. It must run after request analysis and before the
+// transaction middlewares so the count covers commit and rollback.
+func DrainMiddleware(controller *drain.Controller) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			analysis := gqlrequest.AnalysisFromContext(r.Context())
+			if controller == nil || analysis == nil || analysis.OperationType != "mutation" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Count before checking the flag so a drain that observes zero
+			// in-flight mutations cannot miss one that is about to start.
+			release := controller.Begin()
+			defer release()
+
+			if controller.Draining() && r.Header.Get(TransactionTokenHeader) == "" && !onlyDrainExemptFields(analysis.Operation) {
+				w.Header().Set("Retry-After", drainRetryAfterSeconds)
+				writeGraphQLError(w, http.StatusServiceUnavailable, "server is draining and not accepting new mutations", "SERVER_DRAINING")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func onlyDrainExemptFields(op *ast.OperationDefinition) bool {
+	if op == nil || op.SelectionSet == nil || len(op.SelectionSet.Selections) == 0 {
+		return false
+	}
+	for _, selection := range op.SelectionSet.Selections {
+		field, ok := selection.(*ast.Field)
+		if !ok || field.Name == nil || !drainExemptRootFields[field.Name.Value] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tidb-graphql/internal/drain"
+)
+
+func TestDrainMiddleware(t *testing.T) {
+	controller := drain.New(nil)
+	var observedInFlight int64
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		observedInFlight = controller.InFlight()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := GraphQLRequestAnalysisMiddleware(nil)(DrainMiddleware(controller)(next))
+
+	serve := func(body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	mutation := `{"query":"mutation { createUser(input: {}) { id } }"}`
+	if rec := serve(mutation, nil); rec.Code != http.StatusNoContent || observedInFlight != 1 {
+		t.Fatalf("expected mutation to run while counted, status=%d inFlight=%d", rec.Code, observedInFlight)
+	}
+	if controller.InFlight() != 0 {
+		t.Fatalf("in flight = %d after request, want 0", controller.InFlight())
+	}
+
+	controller.Start()
+
+	rec := serve(mutation, nil)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected new mutation to be refused, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" || !strings.Contains(rec.Body.String(), "SERVER_DRAINING") {
+		t.Fatalf("unexpected drain response: headers=%v body=%s", rec.Header(), rec.Body.String())
+	}
+	if controller.InFlight() != 0 {
+		t.Fatalf("refused mutation left in flight = %d", controller.InFlight())
+	}
+
+	tests := []struct {
+		name   string
+		body   string
+		header map[string]string
+	}{
+		{name: "query", body: `{"query":"{ users { id } }"}`},
+		{name: "commit", body: `{"query":"mutation { commitTransaction(token: \"t\") }"}`},
+		{name: "rollback", body: `{"query":"mutation { rollbackTransaction(token: \"t\") }"}`},
+		{name: "joined transaction", body: mutation, header: map[string]string{TransactionTokenHeader: "t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := serve(tt.body, tt.header); rec.Code != http.StatusNoContent {
+				t.Fatalf("expected request to pass while draining, got %d: %s", rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	mixed := `{"query":"mutation { commitTransaction(token: \"t\") createUser(input: {}) { id } }"}`
+	if rec := serve(mixed, nil); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected mixed mutation to be refused, got %d", rec.Code)
+	}
+}
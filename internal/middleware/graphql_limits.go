@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/planner"
+	"tidb-graphql/internal/resolver"
+)
+
+// LimitOverride replaces the server-wide plan limits for matching requests.
+// Every matcher that is set must hold: one of Roles, one of Values for Claim,
+// one of Operations, and one of OperationHashes. Zero limits inherit the
+// server-wide value.
+type LimitOverride struct {
+	Name            string
+	Roles           []string
+	Claim           string
+	Values          []string
+	Operations      []string
+	OperationHashes []string
+	MaxDepth        int
+	MaxComplexity   int
+	MaxRows         int
+}
+
+func (o LimitOverride) matches(role, operation, operationHash string, claims map[string]interface{}) bool {
+	if len(o.Roles) > 0 && (role == "" || !slices.Contains(o.Roles, role)) {
+		return false
+	}
+	if o.Claim != "" && !slices.ContainsFunc(claimStrings(claims[o.Claim]), func(value string) bool {
+		return slices.Contains(o.Values, value)
+	}) {
+		return false
+	}
+	if len(o.Operations) > 0 && !slices.Contains(o.Operations, operation) {
+		return false
+	}
+	if len(o.OperationHashes) > 0 && (operationHash == "" || !slices.Contains(o.OperationHashes, operationHash)) {
+		return false
+	}
+	return true
+}
+
+func (o LimitOverride) apply(base *planner.PlanLimits) planner.PlanLimits {
+	var limits planner.PlanLimits
+	if base != nil {
+		limits = *base
+	}
+	if o.MaxDepth > 0 {
+		limits.MaxDepth = o.MaxDepth
+	}
+	if o.MaxComplexity > 0 {
+		limits.MaxComplexity = o.MaxComplexity
+	}
+	if o.MaxRows > 0 {
+		limits.MaxRows = o.MaxRows
+	}
+	limits.Override = o.Name
+	return limits
+}
+
+// GraphQLLimitsMiddleware applies the first override matching the request's
+// database role, token claims, operation name and operation hash on top of base. It must run
+// after auth, DB role extraction and request analysis.
+func GraphQLLimitsMiddleware(base *planner.PlanLimits, overrides []LimitOverride) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(overrides) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			var role, operation, operationHash string
+			if dbRole, ok := DBRoleFromContext(ctx); ok && dbRole.Validated {
+				role = dbRole.Role
+			}
+			if analysis := gqlrequest.AnalysisFromContext(ctx); analysis != nil {
+				operation = analysis.OperationName
+				operationHash = analysis.OperationHash
+			}
+			auth, _ := AuthFromContext(ctx)
+			for _, override := range overrides {
+				if !override.matches(role, operation, operationHash, auth.Claims) {
+					continue
+				}
+				ctx = resolver.WithPlanLimits(ctx, override.apply(base))
+				reqLogger := logging.FromContext(ctx).WithFields(slog.String("limits_override", override.Name))
+				ctx = logging.WithLogger(ctx, reqLogger)
+				r = r.WithContext(ctx)
+				break
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/planner"
+	"tidb-graphql/internal/resolver"
+)
+
+func TestGraphQLLimitsMiddleware(t *testing.T) {
+	var (
+		limits planner.PlanLimits
+		ok     bool
+	)
+	base := &planner.PlanLimits{MaxDepth: 5, MaxComplexity: 200, MaxRows: 1000}
+	exportHash := gqlrequest.AnalyzeEnvelope(gqlrequest.Envelope{Query: "query Export { users { id } }"}).OperationHash
+	handler := GraphQLRequestAnalysisMiddleware(nil)(GraphQLLimitsMiddleware(base, []LimitOverride{
+		{Name: "reporting", Roles: []string{"app_reporting"}, MaxDepth: 12, MaxRows: 100000},
+		{Name: "analysts", Claim: "groups", Values: []string{"analysts"}, MaxComplexity: 1000},
+		{Name: "search", Operations: []string{"SearchProducts"}, MaxDepth: 2},
+		{Name: "pinned-export", OperationHashes: []string{exportHash}, MaxRows: 50000},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits, ok = resolver.PlanLimitsFromContext(r.Context())
+	})))
+
+	serve := func(operation string, auth *AuthContext, role string) {
+		limits, ok = planner.PlanLimits{}, false
+		body := `{"query":"query ` + operation + ` { users { id } }"}`
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := req.Context()
+		if auth != nil {
+			ctx = WithAuthContext(ctx, *auth)
+		}
+		if role != "" {
+			ctx = WithDBRole(ctx, role, true)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+	}
+
+	serve("Report", nil, "app_reporting")
+	if want := (planner.PlanLimits{MaxDepth: 12, MaxComplexity: 200, MaxRows: 100000, Override: "reporting"}); !ok || limits != want {
+		t.Fatalf("role override: limits = %+v, ok = %v", limits, ok)
+	}
+
+	serve("Report", &AuthContext{Subject: "alice", Claims: map[string]interface{}{"groups": []interface{}{"staff", "analysts"}}}, "")
+	if !ok || limits.Override != "analysts" || limits.MaxComplexity != 1000 || limits.MaxDepth != 5 {
+		t.Fatalf("claim override: limits = %+v, ok = %v", limits, ok)
+	}
+
+	serve("SearchProducts", nil, "app_reporting")
+	if !ok || limits.Override != "reporting" {
+		t.Fatalf("first matching override must win, got %+v", limits)
+	}
+
+	serve("SearchProducts", nil, "")
+	if !ok || limits.Override != "search" || limits.MaxDepth != 2 {
+		t.Fatalf("operation override: limits = %+v, ok = %v", limits, ok)
+	}
+
+	serve("Export", nil, "")
+	if !ok || limits.Override != "pinned-export" || limits.MaxRows != 50000 {
+		t.Fatalf("operation hash override: limits = %+v, ok = %v", limits, ok)
+	}
+
+	// The same operation name with another document does not match the hash.
+	body := `{"query":"query Export { users { id name } }"}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	limits, ok = planner.PlanLimits{}, false
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if ok {
+		t.Fatalf("a different document must not match the operation hash, got %+v", limits)
+	}
+
+	serve("Other", nil, "app_viewer")
+	if ok {
+		t.Fatalf("unmatched request must keep server-wide limits, got %+v", limits)
+	}
+}
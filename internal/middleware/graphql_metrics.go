@@ -31,8 +31,12 @@ func GraphQLMetricsMiddleware(metrics *observability.GraphQLMetrics) func(http.H
 			start := time.Now()
 
 			operationType := "unknown"
-			if analysis := gqlrequest.AnalysisFromContext(r.Context()); analysis != nil && analysis.OperationType != "" {
-				operationType = analysis.OperationType
+			dryRun := false
+			if analysis := gqlrequest.AnalysisFromContext(r.Context()); analysis != nil {
+				if analysis.OperationType != "" {
+					operationType = analysis.OperationType
+				}
+				dryRun = analysis.DryRun
 			}
 
 			// Wrap response writer to capture response
@@ -51,7 +55,7 @@ func GraphQLMetricsMiddleware(metrics *observability.GraphQLMetrics) func(http.H
 			hasErrors := wrapped.statusCode >= 400 || responseHasGraphQLErrors(wrapped.body.Bytes())
 
 			// Record the request metrics
-			metrics.RecordRequest(ctx, duration, hasErrors, operationType)
+			metrics.RecordRequest(ctx, duration, hasErrors, operationType, dryRun)
 		})
 	}
 }
@@ -28,6 +28,12 @@ func MutationTransactionMiddleware(executor dbexec.QueryExecutor) func(http.Hand
 				return
 			}
 
+			// Requests joined to an interactive transaction already carry one.
+			if resolver.MutationContextFromContext(r.Context()) != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			tx, err := executor.BeginTx(r.Context())
 			if err != nil {
 				reqLogger.Error("failed to start mutation transaction",
@@ -38,6 +44,10 @@ func MutationTransactionMiddleware(executor dbexec.QueryExecutor) func(http.Hand
 			}
 
 			mc := resolver.NewMutationContext(tx)
+			if analysis.DryRun {
+				// Dry runs execute normally and are always rolled back at Finalize.
+				mc.MarkError()
+			}
 			ctx := resolver.WithMutationContext(r.Context(), mc)
 
 			defer func() {
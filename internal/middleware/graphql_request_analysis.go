@@ -29,6 +29,7 @@ func GraphQLRequestAnalysisMiddleware(manager *schemarefresh.Manager) func(http.
 				meta.OperationName = analysis.OperationName
 				meta.OperationType = analysis.OperationType
 				meta.OperationHash = analysis.OperationHash
+				meta.DryRun = analysis.DryRun
 			}
 			ctx = gqlrequest.WithExecMeta(ctx, meta)
 
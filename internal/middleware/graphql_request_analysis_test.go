@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -95,6 +96,49 @@ func TestRequestAnalysisBeforeMutationTx_DoesNotStartTransactionForQuery(t *test
 	}
 }
 
+func TestRequestAnalysisBeforeMutationTx_DryRunRollsBack(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		header string
+	}{
+		{name: "directive", query: `mutation M @dryRun { createUser(input: {}) { id } }`},
+		{name: "header", query: `mutation M { createUser(input: {}) { id } }`, header: "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &recordingTx{}
+			executor := &fakeQueryExecutor{tx: tx}
+			var sawDryRunMeta bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				meta, _ := gqlrequest.ExecMetaFromContext(r.Context())
+				sawDryRunMeta = meta.DryRun
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			handler := GraphQLRequestAnalysisMiddleware(nil)(
+				MutationTransactionMiddleware(executor)(next),
+			)
+
+			body, _ := json.Marshal(map[string]string{"query": tt.query})
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set(gqlrequest.DryRunHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if !sawDryRunMeta {
+				t.Fatalf("expected dry run in exec meta")
+			}
+			if tx.committed || !tx.rolledBack {
+				t.Fatalf("expected dry run to roll back, committed=%v rolledBack=%v", tx.committed, tx.rolledBack)
+			}
+		})
+	}
+}
+
 type fakeQueryExecutor struct {
 	tx         dbexec.TxExecutor
 	beginCount int
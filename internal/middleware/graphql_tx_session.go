@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/resolver"
+	"tidb-graphql/internal/txsession"
+)
+
+// TransactionTokenHeader carries the token of the interactive transaction a request joins.
+const TransactionTokenHeader = "X-Transaction-Token"
+
+// TransactionSessionMiddleware runs requests that carry TransactionTokenHeader inside
+// the matching interactive transaction and exposes the registry to the
+// beginTransaction/commitTransaction/rollbackTransaction resolvers.
+// It must run before MutationTransactionMiddleware, which then leaves the request alone.
+func TransactionSessionMiddleware(registry *txsession.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := resolver.WithTransactionController(r.Context(), registry)
+
+			token := r.Header.Get(TransactionTokenHeader)
+			if token == "" {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			reqLogger := logging.FromContext(ctx)
+			lease, err := registry.Acquire(ctx, token)
+			if err != nil {
+				switch {
+				case errors.Is(err, txsession.ErrBusy):
+					writeGraphQLError(w, http.StatusConflict, err.Error(), "TRANSACTION_BUSY")
+				case errors.Is(err, txsession.ErrNotFound):
+					writeGraphQLError(w, http.StatusNotFound, err.Error(), "TRANSACTION_NOT_FOUND")
+				default:
+					writeGraphQLError(w, http.StatusInternalServerError, "failed to join transaction", "INTERNAL_SERVER_ERROR")
+				}
+				return
+			}
+
+			analysis := gqlrequest.AnalysisFromContext(ctx)
+			mutation := analysis != nil && analysis.OperationType == "mutation"
+			tx, err := lease.RequestTx(ctx, mutation)
+			if err != nil {
+				reqLogger.Error("failed to join interactive transaction",
+					slog.String("error", err.Error()),
+				)
+				lease.Release(true)
+				writeGraphQLError(w, http.StatusInternalServerError, "failed to join transaction", "INTERNAL_SERVER_ERROR")
+				return
+			}
+
+			mc := resolver.NewMutationContext(tx)
+			if analysis != nil && analysis.DryRun {
+				mc.MarkError()
+			}
+			ctx = txsession.WithActiveToken(ctx, token)
+			ctx = resolver.WithMutationContext(ctx, mc)
+
+			defer func() {
+				rec := recover()
+				if rec != nil {
+					mc.MarkError()
+				}
+				finalizeErr := mc.Finalize()
+				if finalizeErr != nil {
+					reqLogger.Error("failed to finalize request in interactive transaction; rolling back",
+						slog.String("error", finalizeErr.Error()),
+					)
+				}
+				lease.Release(finalizeErr != nil)
+				if rec != nil {
+					panic(rec)
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// TransactionSubject identifies the caller that owns an interactive transaction:
+// the authenticated issuer and subject plus the validated database role, so a
+// token cannot be replayed under a different identity or role.
+func TransactionSubject(ctx context.Context) string {
+	subject := ""
+	if auth, ok := AuthFromContext(ctx); ok {
+		subject = auth.Issuer + "|" + auth.Subject
+	}
+	if role, ok := DBRoleFromContext(ctx); ok && role.Validated {
+		subject += "|" + role.Role
+	}
+	return subject
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/resolver"
+	"tidb-graphql/internal/txsession"
+)
+
+type recordingTx struct {
+	fakeTx
+	execs      []string
+	committed  bool
+	rolledBack bool
+}
+
+func (t *recordingTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	t.execs = append(t.execs, query)
+	return nil, nil
+}
+
+func (t *recordingTx) Commit() error {
+	t.committed = true
+	return nil
+}
+
+func (t *recordingTx) Rollback() error {
+	t.rolledBack = true
+	return nil
+}
+
+func newTestTxRegistry(t *testing.T, executor dbexec.QueryExecutor) *txsession.Registry {
+	t.Helper()
+	registry, err := txsession.New(txsession.Config{
+		Executor:      executor,
+		IdleTimeout:   time.Minute,
+		MaxLifetime:   time.Minute,
+		MaxPerSubject: 1,
+		MaxOpen:       1,
+		OwnerFromCtx:  TransactionSubject,
+	})
+	if err != nil {
+		t.Fatalf("txsession.New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = registry.Close(context.Background()) })
+	return registry
+}
+
+func TestTransactionSessionMiddleware_JoinsSessionForMutation(t *testing.T) {
+	tx := &recordingTx{}
+	executor := &fakeQueryExecutor{tx: tx}
+	registry := newTestTxRegistry(t, executor)
+	ctx := WithAuthContext(context.Background(), AuthContext{Subject: "alice", Issuer: "https://issuer.test"})
+
+	token, _, err := registry.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	var (
+		sawMutationContext bool
+		activeToken        string
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawMutationContext = resolver.MutationContextFromContext(r.Context()) != nil
+		activeToken = txsession.ActiveToken(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := GraphQLRequestAnalysisMiddleware(nil)(
+		TransactionSessionMiddleware(registry)(MutationTransactionMiddleware(executor)(next)),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"mutation M { createUser(input: {}) { id } }","operationName":"M"}`))
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TransactionTokenHeader, token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if executor.beginCount != 1 {
+		t.Fatalf("BeginTx() count = %d, want 1 (no per-request transaction)", executor.beginCount)
+	}
+	if !sawMutationContext || activeToken != token {
+		t.Fatalf("expected request to run inside the session, mutation context=%v token=%q", sawMutationContext, activeToken)
+	}
+	want := []string{"SAVEPOINT tidb_graphql_request", "RELEASE SAVEPOINT tidb_graphql_request"}
+	if strings.Join(tx.execs, ";") != strings.Join(want, ";") {
+		t.Fatalf("execs = %v, want %v", tx.execs, want)
+	}
+	if tx.committed {
+		t.Fatalf("session transaction must stay open after the request")
+	}
+	if registry.Open() != 1 {
+		t.Fatalf("open sessions = %d, want 1", registry.Open())
+	}
+}
+
+func TestTransactionSessionMiddleware_RejectsUnknownAndForeignTokens(t *testing.T) {
+	executor := &fakeQueryExecutor{tx: &recordingTx{}}
+	registry := newTestTxRegistry(t, executor)
+	owner := WithAuthContext(context.Background(), AuthContext{Subject: "alice"})
+	token, _, err := registry.Begin(owner)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler must not run")
+	})
+	handler := TransactionSessionMiddleware(registry)(next)
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		token string
+	}{
+		{name: "unknown token", ctx: owner, token: "missing"},
+		{name: "token owned by another subject", ctx: WithAuthContext(context.Background(), AuthContext{Subject: "bob"}), token: token},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ users { id } }"}`))
+			req = req.WithContext(tt.ctx)
+			req.Header.Set(TransactionTokenHeader, tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+			}
+			if !strings.Contains(rec.Body.String(), "TRANSACTION_NOT_FOUND") {
+				t.Fatalf("expected TRANSACTION_NOT_FOUND, got %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTransactionSessionMiddleware_ExposesControllerWithoutHeader(t *testing.T) {
+	registry := newTestTxRegistry(t, &fakeQueryExecutor{tx: &recordingTx{}})
+
+	var controller resolver.TransactionController
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		controller = resolver.TransactionControllerFromContext(r.Context())
+		if resolver.MutationContextFromContext(r.Context()) != nil {
+			t.Error("requests without the header must not join a session")
+		}
+	})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ users { id } }"}`))
+	TransactionSessionMiddleware(registry)(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	if controller == nil {
+		t.Fatal("expected transaction controller in request context")
+	}
+}
@@ -0,0 +1,387 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/observability"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultIntrospectionCacheMaxEntries = 10000
+	maxIntrospectionResponseBytes       = 1 << 20
+)
+
+// IntrospectionConfig controls validation of opaque bearer tokens against an
+// OAuth2 token introspection endpoint (RFC 7662).
+type IntrospectionConfig struct {
+	Enabled bool
+	// URL is the introspection endpoint. It must use https.
+	URL string
+	// ClientID and ClientSecret authenticate this server to the endpoint with
+	// HTTP Basic auth.
+	ClientID     string
+	ClientSecret string
+	CAFile       string
+	// Audience, when set, must appear in the response's "aud".
+	Audience  string
+	ClockSkew time.Duration
+	// CacheTTL bounds how long an active response is reused. Entries never
+	// outlive the token's "exp". Zero disables caching.
+	CacheTTL        time.Duration
+	CacheMaxEntries int
+	// SkipJWTs leaves bearer tokens that parse as JWTs, and requests without a
+	// bearer token, to the OIDC middleware that runs next.
+	SkipJWTs bool
+}
+
+// errTokenInactive reports an introspection response with "active": false.
+var errTokenInactive = errors.New("token is not active")
+
+type introspectionEntry struct {
+	claims  map[string]interface{}
+	expires time.Time
+}
+
+// introspectionCache keeps active introspection responses keyed by the
+// SHA-256 of the token so raw tokens are not held in memory.
+type introspectionCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]introspectionEntry
+	now        func() time.Time
+}
+
+func newIntrospectionCache(ttl time.Duration, maxEntries int) *introspectionCache {
+	return &introspectionCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]introspectionEntry),
+		now:        time.Now,
+	}
+}
+
+func introspectionCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *introspectionCache) get(key string) (map[string]interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.claims, true
+}
+
+// put caches claims until the configured TTL or the token's "exp", whichever
+// comes first.
+func (c *introspectionCache) put(key string, claims map[string]interface{}) {
+	now := c.now()
+	expires := now.Add(c.ttl)
+	if exp, ok := numericDate(claims["exp"]); ok && exp.Before(expires) {
+		expires = exp
+	}
+	if !now.Before(expires) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = introspectionEntry{claims: claims, expires: expires}
+}
+
+type introspector struct {
+	cfg    IntrospectionConfig
+	client *http.Client
+	cache  *introspectionCache
+}
+
+func newIntrospector(cfg IntrospectionConfig) (*introspector, error) {
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, errors.New("token introspection enabled but introspection URL not configured")
+	}
+	if err := requireHTTPS(cfg.URL, "introspection url"); err != nil {
+		return nil, err
+	}
+	client, err := newOIDCHTTPClient(OIDCAuthConfig{CAFile: cfg.CAFile})
+	if err != nil {
+		return nil, err
+	}
+	i := &introspector{cfg: cfg, client: client}
+	if cfg.CacheTTL > 0 {
+		i.cache = newIntrospectionCache(cfg.CacheTTL, cfg.CacheMaxEntries)
+	}
+	return i, nil
+}
+
+// introspect returns the claims of an active token, using the cache when
+// possible. cached reports whether the endpoint was skipped.
+func (i *introspector) introspect(ctx context.Context, token string) (claims map[string]interface{}, cached bool, err error) {
+	key := ""
+	if i.cache != nil {
+		key = introspectionCacheKey(token)
+		if claims, ok := i.cache.get(key); ok {
+			return claims, true, nil
+		}
+	}
+
+	claims, err = i.fetch(ctx, token)
+	if err != nil {
+		return nil, false, err
+	}
+	if i.cache != nil {
+		i.cache.put(key, claims)
+	}
+	return claims, false, nil
+}
+
+func (i *introspector) fetch(ctx context.Context, token string) (map[string]interface{}, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, i.cfg.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if i.cfg.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(i.cfg.ClientID), url.QueryEscape(i.cfg.ClientSecret))
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspection request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspection endpoint returned status %d", resp.StatusCode)
+	}
+
+	var claims map[string]interface{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxIntrospectionResponseBytes)).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("failed to decode introspection response: %w", err)
+	}
+	if active, _ := claims["active"].(bool); !active {
+		return nil, errTokenInactive
+	}
+	return claims, nil
+}
+
+// introspectionSubject picks the caller identity from an introspection
+// response: "sub", then "username", then "client_id".
+func introspectionSubject(claims map[string]interface{}) string {
+	for _, name := range []string{"sub", "username", "client_id"} {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// isJWT reports whether token parses as a JWT, without verifying it.
+func isJWT(token string) bool {
+	_, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	return err == nil
+}
+
+// IntrospectionAuthMiddleware validates opaque bearer tokens against an OAuth2
+// introspection endpoint. Active responses are cached per token.
+// Optional securityMetrics parameter enables security monitoring; pass nil to disable.
+func IntrospectionAuthMiddleware(cfg IntrospectionConfig, logger *logging.Logger, securityMetrics ...*observability.SecurityMetrics) (func(http.Handler) http.Handler, error) {
+	if !cfg.Enabled {
+		return func(next http.Handler) http.Handler { return next }, nil
+	}
+
+	var metrics *observability.SecurityMetrics
+	if len(securityMetrics) > 0 {
+		metrics = securityMetrics[0]
+	}
+
+	if cfg.ClockSkew == 0 {
+		cfg.ClockSkew = 2 * time.Minute
+	}
+	if cfg.CacheMaxEntries <= 0 {
+		cfg.CacheMaxEntries = defaultIntrospectionCacheMaxEntries
+	}
+
+	introspector, err := newIntrospector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			endpoint := r.URL.Path
+			tokenString := bearerToken(r.Header.Get("Authorization"))
+			existing, _ := AuthFromContext(r.Context())
+			if tokenString == "" && (existing.ClientCert != nil || existing.APIKey != nil) {
+				// A verified client certificate or API key already authenticated the caller.
+				next.ServeHTTP(w, r)
+				return
+			}
+			if cfg.SkipJWTs && (tokenString == "" || isJWT(tokenString)) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if metrics != nil {
+				metrics.RecordAuthAttempt(r.Context(), endpoint)
+			}
+
+			if tokenString == "" {
+				if metrics != nil {
+					metrics.RecordAuthFailure(r.Context(), endpoint, "missing_token")
+					metrics.RecordUnauthorizedAttempt(r.Context(), endpoint, "missing_token")
+				}
+				if logger != nil {
+					reqLogger := logging.FromContext(r.Context())
+					reqLogger.Warn("authentication failed: missing bearer token",
+						slog.String("endpoint", endpoint),
+						slog.String("remote_addr", r.RemoteAddr),
+					)
+				}
+				writeUnauthorized(w, "missing bearer token")
+				return
+			}
+
+			claims, cached, err := introspector.introspect(r.Context(), tokenString)
+			if errors.Is(err, errTokenInactive) {
+				if metrics != nil {
+					metrics.RecordIntrospection(r.Context(), "inactive")
+					metrics.RecordAuthFailure(r.Context(), endpoint, "token_inactive")
+					metrics.RecordTokenValidationError(r.Context(), "inactive")
+					metrics.RecordUnauthorizedAttempt(r.Context(), endpoint, "invalid_token")
+				}
+				if logger != nil {
+					reqLogger := logging.FromContext(r.Context())
+					reqLogger.Warn("introspected token is not active",
+						slog.String("endpoint", endpoint),
+						slog.String("remote_addr", r.RemoteAddr),
+					)
+				}
+				writeUnauthorized(w, "invalid token")
+				return
+			}
+			if err != nil {
+				if metrics != nil {
+					metrics.RecordIntrospection(r.Context(), "error")
+					metrics.RecordAuthFailure(r.Context(), endpoint, "introspection_failed")
+				}
+				if logger != nil {
+					reqLogger := logging.FromContext(r.Context())
+					reqLogger.Error("token introspection failed",
+						slog.String("error", err.Error()),
+						slog.String("endpoint", endpoint),
+					)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = fmt.Fprint(w, `{"error":"token introspection unavailable"}`)
+				return
+			}
+			if metrics != nil {
+				outcome := "active"
+				if cached {
+					outcome = "cache_hit"
+				}
+				metrics.RecordIntrospection(r.Context(), outcome)
+			}
+
+			aud := extractAudience(claims)
+			if err := validateTimeClaims(claims, cfg.ClockSkew); err != nil || (cfg.Audience != "" && !slices.Contains(aud, cfg.Audience)) {
+				reason := "audience_mismatch"
+				if err != nil {
+					reason = "time_validation_failed"
+				}
+				if metrics != nil {
+					metrics.RecordAuthFailure(r.Context(), endpoint, reason)
+					metrics.RecordTokenValidationError(r.Context(), reason)
+					metrics.RecordUnauthorizedAttempt(r.Context(), endpoint, "invalid_token")
+				}
+				if logger != nil {
+					reqLogger := logging.FromContext(r.Context())
+					reqLogger.Warn("introspected token rejected",
+						slog.String("reason", reason),
+						slog.String("endpoint", endpoint),
+					)
+				}
+				writeUnauthorized(w, "invalid token")
+				return
+			}
+
+			subject := introspectionSubject(claims)
+			issuer, _ := claims["iss"].(string)
+			if issuer == "" {
+				issuer = cfg.URL
+			}
+
+			if metrics != nil {
+				metrics.RecordAuthSuccess(r.Context(), endpoint, issuer)
+			}
+			if logger != nil {
+				reqLogger := logging.FromContext(r.Context())
+				reqLogger.Debug("authentication successful",
+					slog.String("subject", subject),
+					slog.String("issuer", issuer),
+					slog.Bool("introspection_cached", cached),
+					slog.String("endpoint", endpoint),
+				)
+			}
+			if span := trace.SpanFromContext(r.Context()); span.IsRecording() {
+				span.SetAttributes(
+					attribute.String("auth.subject", subject),
+					attribute.String("auth.issuer", issuer),
+					attribute.Bool("auth.authenticated", true),
+					attribute.Bool("auth.introspected", true),
+				)
+			}
+
+			ctx := WithAuthContext(r.Context(), AuthContext{
+				Subject:      subject,
+				Issuer:       issuer,
+				Audience:     aud,
+				Claims:       claims,
+				ClientCert:   existing.ClientCert,
+				Introspected: true,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}, nil
+}
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestIntrospectionServer stands in for an authorization server. It knows
+// the tokens in responses and reports every other token as inactive.
+func newTestIntrospectionServer(t *testing.T, responses map[string]map[string]interface{}) (*httptest.Server, string, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if id, secret, ok := r.BasicAuth(); !ok || id != "tidb-graphql" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		response, ok := responses[r.PostForm.Get("token")]
+		if !ok {
+			response = map[string]interface{}{"active": false}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return server, caFile, &calls
+}
+
+func TestIntrospectionAuthMiddleware(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	server, caFile, calls := newTestIntrospectionServer(t, map[string]map[string]interface{}{
+		"opaque-partner": {"active": true, "sub": "partner-7", "iss": "https://partners.test", "aud": "tidb-graphql", "exp": exp, "db_role": "app_partner"},
+		"opaque-client":  {"active": true, "client_id": "batch-job", "aud": []string{"tidb-graphql"}, "exp": exp, "db_role": "app_partner"},
+		"opaque-other":   {"active": true, "sub": "partner-8", "aud": "other-api", "exp": exp},
+		"opaque-expired": {"active": true, "sub": "partner-9", "aud": "tidb-graphql", "exp": time.Now().Add(-time.Hour).Unix()},
+	})
+
+	mw, err := IntrospectionAuthMiddleware(IntrospectionConfig{
+		Enabled:      true,
+		URL:          server.URL,
+		ClientID:     "tidb-graphql",
+		ClientSecret: "s3cret",
+		CAFile:       caFile,
+		Audience:     "tidb-graphql",
+		CacheTTL:     time.Minute,
+	}, nil)
+	if err != nil {
+		t.Fatalf("IntrospectionAuthMiddleware() error = %v", err)
+	}
+
+	var (
+		role DBRoleContext
+		auth AuthContext
+	)
+	handler := mw(DBRoleMiddleware("db_role", []string{"app_partner"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth, _ = AuthFromContext(r.Context())
+			role, _ = DBRoleFromContext(r.Context())
+			w.WriteHeader(http.StatusNoContent)
+		})))
+
+	serve := func(token string) *httptest.ResponseRecorder {
+		auth, role = AuthContext{}, DBRoleContext{}
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("opaque-partner")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if auth.Subject != "partner-7" || auth.Issuer != "https://partners.test" || !auth.Introspected || role.Role != "app_partner" {
+		t.Fatalf("unexpected auth: %+v role=%+v", auth, role)
+	}
+	if rec := serve("opaque-partner"); rec.Code != http.StatusNoContent {
+		t.Fatalf("cached status = %d", rec.Code)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("introspection calls = %d, want 1 (second request cached)", got)
+	}
+
+	rec = serve("opaque-client")
+	if rec.Code != http.StatusNoContent || auth.Subject != "batch-job" || auth.Issuer != server.URL {
+		t.Fatalf("client credentials token: status = %d, auth = %+v", rec.Code, auth)
+	}
+
+	for _, token := range []string{"", "unknown", "opaque-other", "opaque-expired"} {
+		if rec := serve(token); rec.Code != http.StatusUnauthorized {
+			t.Fatalf("token %q status = %d, want 401", token, rec.Code)
+		}
+	}
+	before := calls.Load()
+	serve("unknown")
+	if calls.Load() != before+1 {
+		t.Fatal("inactive tokens must not be cached")
+	}
+
+	server.Close()
+	if rec := serve("opaque-partner"); rec.Code != http.StatusNoContent {
+		t.Fatalf("cached token with endpoint down status = %d", rec.Code)
+	}
+	if rec := serve("opaque-new"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("endpoint down status = %d, want 503", rec.Code)
+	}
+}
+
+func TestIntrospectionAuthMiddleware_LeavesJWTsToOIDC(t *testing.T) {
+	key, jwksPath := writeTestJWKS(t, "k1")
+	server, caFile, calls := newTestIntrospectionServer(t, map[string]map[string]interface{}{
+		"opaque-partner": {"active": true, "sub": "partner-7"},
+	})
+
+	introspection, err := IntrospectionAuthMiddleware(IntrospectionConfig{
+		Enabled:      true,
+		URL:          server.URL,
+		ClientID:     "tidb-graphql",
+		ClientSecret: "s3cret",
+		CAFile:       caFile,
+		CacheTTL:     time.Minute,
+		SkipJWTs:     true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("IntrospectionAuthMiddleware() error = %v", err)
+	}
+	oidcAuth, err := OIDCAuthMiddleware(OIDCAuthConfig{
+		Enabled:   true,
+		IssuerURL: "https://workforce.test",
+		Audience:  "tidb-graphql",
+		JWKSFile:  jwksPath,
+	}, nil)
+	if err != nil {
+		t.Fatalf("OIDCAuthMiddleware() error = %v", err)
+	}
+
+	var subject string
+	handler := introspection(oidcAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, _ := AuthFromContext(r.Context())
+		subject = auth.Subject
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	serve := func(token string) int {
+		subject = ""
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	jwtToken := signTestToken(t, key, "k1", jwt.MapClaims{"iss": "https://workforce.test", "aud": "tidb-graphql", "sub": "alice"})
+	if code := serve(jwtToken); code != http.StatusNoContent || subject != "alice" {
+		t.Fatalf("jwt status = %d, subject = %q", code, subject)
+	}
+	if code := serve("opaque-partner"); code != http.StatusNoContent || subject != "partner-7" {
+		t.Fatalf("opaque status = %d, subject = %q", code, subject)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("introspection calls = %d, want 1", got)
+	}
+}
+
+func TestIntrospectionCache_HonorsExp(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cache := newIntrospectionCache(time.Hour, 2)
+	cache.now = func() time.Time { return now }
+
+	cache.put("short", map[string]interface{}{"exp": float64(now.Add(30 * time.Second).Unix())})
+	cache.put("long", map[string]interface{}{})
+	cache.put("expired", map[string]interface{}{"exp": float64(now.Add(-time.Second).Unix())})
+	if _, ok := cache.get("expired"); ok {
+		t.Fatal("expired token must not be cached")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.get("short"); ok {
+		t.Fatal("entry must expire with the token")
+	}
+	if _, ok := cache.get("long"); !ok {
+		t.Fatal("entry without exp must live for the TTL")
+	}
+
+	cache.put("a", map[string]interface{}{})
+	cache.put("b", map[string]interface{}{})
+	if len(cache.entries) > 2 {
+		t.Fatalf("cache grew to %d entries, max 2", len(cache.entries))
+	}
+}
+
+func TestIntrospectionAuthMiddleware_ConfigErrors(t *testing.T) {
+	for _, cfg := range []IntrospectionConfig{
+		{Enabled: true},
+		{Enabled: true, URL: "http://auth.test/introspect"},
+		{Enabled: true, URL: "https://auth.test/introspect", CAFile: filepath.Join(t.TempDir(), "none.pem")},
+	} {
+		if _, err := IntrospectionAuthMiddleware(cfg, nil); err == nil {
+			t.Fatalf("expected error for %+v", cfg)
+		}
+	}
+}
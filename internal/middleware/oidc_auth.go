@@ -9,19 +9,17 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"tidb-graphql/internal/apikey"
 	"tidb-graphql/internal/logging"
 	"tidb-graphql/internal/observability"
 
-	"github.com/coreos/go-oidc/v3/oidc"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/oauth2"
 )
 
 // OIDCAuthConfig controls OIDC/JWKS validation behavior.
@@ -30,17 +28,31 @@ type OIDCAuthConfig struct {
 	IssuerURL string
 	Audience  string
 	CAFile    string
+	// JWKSURL or JWKSFile verify IssuerURL's tokens without discovery.
+	JWKSURL   string
+	JWKSFile  string
 	ClockSkew time.Duration
+	// Issuers lists further trusted issuers. Tokens are routed to an issuer by
+	// their "iss" claim.
+	Issuers []OIDCIssuer
 }
 
 type authContextKey struct{}
 
-// AuthContext carries validated JWT claims.
+// AuthContext carries validated JWT claims and, for mTLS callers, the
+// verified client certificate. For API key callers APIKey is the matched key.
+// For opaque tokens Claims holds the introspection response.
 type AuthContext struct {
 	Subject  string
 	Issuer   string
 	Audience []string
 	Claims   map[string]interface{}
+	// RoleClaim overrides the DB role claim name for the issuer of the token.
+	RoleClaim  string
+	ClientCert *ClientCertIdentity
+	APIKey     *apikey.Key
+	// Introspected marks bearer tokens already validated by token introspection.
+	Introspected bool
 }
 
 // AuthFromContext returns the auth context from a request context.
@@ -71,48 +83,36 @@ func OIDCAuthMiddleware(cfg OIDCAuthConfig, logger *logging.Logger, securityMetr
 		metrics = securityMetrics[0]
 	}
 
-	if cfg.IssuerURL == "" || cfg.Audience == "" {
-		return nil, errors.New("oidc auth enabled but issuer/audience not configured")
-	}
-
 	if cfg.ClockSkew == 0 {
 		cfg.ClockSkew = 2 * time.Minute
 	}
 
-	issuerURL, err := url.Parse(cfg.IssuerURL)
-	if err != nil {
-		return nil, fmt.Errorf("invalid oidc issuer url: %w", err)
-	}
-	if issuerURL.Scheme != "https" {
-		return nil, errors.New("oidc issuer url must use https")
-	}
-
-	httpClient, err := newOIDCHTTPClient(cfg)
+	issuers, err := newIssuerSet(cfg)
 	if err != nil {
 		return nil, err
 	}
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
-
-	provider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize oidc provider: %w", err)
-	}
-
-	verifier := provider.Verifier(&oidc.Config{
-		ClientID:        cfg.Audience,
-		SkipIssuerCheck: false,
-	})
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			endpoint := r.URL.Path
+			tokenString := bearerToken(r.Header.Get("Authorization"))
+			existing, _ := AuthFromContext(r.Context())
+			if tokenString == "" && (existing.ClientCert != nil || existing.APIKey != nil) {
+				// A verified client certificate or API key already authenticated the caller.
+				next.ServeHTTP(w, r)
+				return
+			}
+			if existing.Introspected {
+				// The token was opaque and validated by the introspection endpoint.
+				next.ServeHTTP(w, r)
+				return
+			}
 
 			// Record authentication attempt
 			if metrics != nil {
 				metrics.RecordAuthAttempt(r.Context(), endpoint)
 			}
 
-			tokenString := bearerToken(r.Header.Get("Authorization"))
 			if tokenString == "" {
 				// Record auth failure
 				if metrics != nil {
@@ -130,7 +130,25 @@ func OIDCAuthMiddleware(cfg OIDCAuthConfig, logger *logging.Logger, securityMetr
 				return
 			}
 
-			idToken, err := verifier.Verify(r.Context(), tokenString)
+			issuer, ok := issuers.match(tokenString)
+			if !ok {
+				if metrics != nil {
+					metrics.RecordAuthFailure(r.Context(), endpoint, "unknown_issuer")
+					metrics.RecordTokenValidationError(r.Context(), "unknown_issuer")
+					metrics.RecordUnauthorizedAttempt(r.Context(), endpoint, "invalid_token")
+				}
+				if logger != nil {
+					reqLogger := logging.FromContext(r.Context())
+					reqLogger.Warn("oidc token from untrusted issuer",
+						slog.String("endpoint", endpoint),
+						slog.String("remote_addr", r.RemoteAddr),
+					)
+				}
+				writeUnauthorized(w, "invalid token")
+				return
+			}
+
+			idToken, err := issuer.verifier.Verify(r.Context(), tokenString)
 			if err != nil {
 				// Record auth failure
 				if metrics != nil {
@@ -185,12 +203,15 @@ func OIDCAuthMiddleware(cfg OIDCAuthConfig, logger *logging.Logger, securityMetr
 				return
 			}
 
+			// Mappings apply only after the token's own time claims are checked.
+			issuer.mapClaims(claims)
+
 			subject, _ := claims["sub"].(string)
 			aud := extractAudience(claims)
 
 			// Record successful authentication
 			if metrics != nil {
-				metrics.RecordAuthSuccess(r.Context(), endpoint, cfg.IssuerURL)
+				metrics.RecordAuthSuccess(r.Context(), endpoint, issuer.IssuerURL)
 			}
 
 			// Add authentication context to logger
@@ -198,7 +219,7 @@ func OIDCAuthMiddleware(cfg OIDCAuthConfig, logger *logging.Logger, securityMetr
 				reqLogger := logging.FromContext(r.Context())
 				reqLogger.Debug("authentication successful",
 					slog.String("subject", subject),
-					slog.String("issuer", cfg.IssuerURL),
+					slog.String("issuer", issuer.IssuerURL),
 					slog.String("endpoint", endpoint),
 				)
 			}
@@ -207,7 +228,7 @@ func OIDCAuthMiddleware(cfg OIDCAuthConfig, logger *logging.Logger, securityMetr
 			if span := trace.SpanFromContext(r.Context()); span.IsRecording() {
 				span.SetAttributes(
 					attribute.String("auth.subject", subject),
-					attribute.String("auth.issuer", cfg.IssuerURL),
+					attribute.String("auth.issuer", issuer.IssuerURL),
 					attribute.Bool("auth.authenticated", true),
 				)
 				if len(aud) > 0 {
@@ -216,10 +237,12 @@ func OIDCAuthMiddleware(cfg OIDCAuthConfig, logger *logging.Logger, securityMetr
 			}
 
 			ctx := WithAuthContext(r.Context(), AuthContext{
-				Subject:  subject,
-				Issuer:   cfg.IssuerURL,
-				Audience: aud,
-				Claims:   claims,
+				Subject:    subject,
+				Issuer:     issuer.IssuerURL,
+				Audience:   aud,
+				Claims:     claims,
+				RoleClaim:  issuer.RoleClaim,
+				ClientCert: existing.ClientCert,
 			})
 
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// OIDCIssuer is one trusted token issuer.
+type OIDCIssuer struct {
+	IssuerURL string
+	Audience  string
+	CAFile    string
+	// JWKSURL or JWKSFile skip OIDC discovery and verify tokens against the
+	// given key set. With JWKSFile no network access is needed at all.
+	JWKSURL  string
+	JWKSFile string
+	// RoleClaim names the claim holding the database role in this issuer's
+	// tokens. Empty uses the DB role middleware's claim name.
+	RoleClaim string
+	// ClaimMappings copies token claims to the names used downstream, keyed by
+	// target claim with the source claim as value, e.g. {"tenant_role": "roles"}.
+	// They are applied after the token's time claims are validated.
+	ClaimMappings map[string]string
+}
+
+// jwksSigningAlgs are accepted for issuers verified without discovery, which
+// would otherwise advertise their algorithms.
+var jwksSigningAlgs = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+	oidc.PS256, oidc.PS384, oidc.PS512,
+	oidc.EdDSA,
+}
+
+type issuerVerifier struct {
+	OIDCIssuer
+	verifier *oidc.IDTokenVerifier
+}
+
+// issuerSet selects the verifier for a token by its unverified "iss" claim.
+// The selected verifier then checks the signature, issuer and audience.
+type issuerSet map[string]*issuerVerifier
+
+// oidcIssuers returns the trusted issuers of cfg: the top-level issuer first,
+// when set, followed by cfg.Issuers.
+func oidcIssuers(cfg OIDCAuthConfig) []OIDCIssuer {
+	issuers := make([]OIDCIssuer, 0, len(cfg.Issuers)+1)
+	if cfg.IssuerURL != "" || cfg.Audience != "" {
+		issuers = append(issuers, OIDCIssuer{
+			IssuerURL: cfg.IssuerURL,
+			Audience:  cfg.Audience,
+			CAFile:    cfg.CAFile,
+			JWKSURL:   cfg.JWKSURL,
+			JWKSFile:  cfg.JWKSFile,
+		})
+	}
+	return append(issuers, cfg.Issuers...)
+}
+
+func newIssuerSet(cfg OIDCAuthConfig) (issuerSet, error) {
+	issuers := oidcIssuers(cfg)
+	if len(issuers) == 0 {
+		return nil, errors.New("oidc auth enabled but issuer/audience not configured")
+	}
+	set := make(issuerSet, len(issuers))
+	for _, issuer := range issuers {
+		if issuer.IssuerURL == "" || issuer.Audience == "" {
+			return nil, errors.New("oidc auth enabled but issuer/audience not configured")
+		}
+		if _, dup := set[issuer.IssuerURL]; dup {
+			return nil, fmt.Errorf("oidc issuer %q is configured more than once", issuer.IssuerURL)
+		}
+		verifier, err := newIssuerVerifier(issuer)
+		if err != nil {
+			return nil, err
+		}
+		set[issuer.IssuerURL] = verifier
+	}
+	return set, nil
+}
+
+func newIssuerVerifier(issuer OIDCIssuer) (*issuerVerifier, error) {
+	if issuer.JWKSURL != "" && issuer.JWKSFile != "" {
+		return nil, fmt.Errorf("oidc issuer %q: set either a JWKS URL or a JWKS file, not both", issuer.IssuerURL)
+	}
+
+	if issuer.JWKSFile != "" {
+		keySet, err := loadJWKSFile(issuer.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("oidc issuer %q: %w", issuer.IssuerURL, err)
+		}
+		return &issuerVerifier{
+			OIDCIssuer: issuer,
+			verifier:   oidc.NewVerifier(issuer.IssuerURL, keySet, jwksVerifierConfig(issuer)),
+		}, nil
+	}
+
+	if err := requireHTTPS(issuer.IssuerURL, "oidc issuer url"); err != nil {
+		return nil, err
+	}
+	httpClient, err := newOIDCHTTPClient(OIDCAuthConfig{CAFile: issuer.CAFile})
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+
+	if issuer.JWKSURL != "" {
+		if err := requireHTTPS(issuer.JWKSURL, "oidc jwks url"); err != nil {
+			return nil, err
+		}
+		keySet := oidc.NewRemoteKeySet(ctx, issuer.JWKSURL)
+		return &issuerVerifier{
+			OIDCIssuer: issuer,
+			verifier:   oidc.NewVerifier(issuer.IssuerURL, keySet, jwksVerifierConfig(issuer)),
+		}, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, issuer.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize oidc provider: %w", err)
+	}
+	return &issuerVerifier{
+		OIDCIssuer: issuer,
+		verifier: provider.Verifier(&oidc.Config{
+			ClientID:        issuer.Audience,
+			SkipIssuerCheck: false,
+		}),
+	}, nil
+}
+
+func jwksVerifierConfig(issuer OIDCIssuer) *oidc.Config {
+	return &oidc.Config{
+		ClientID:             issuer.Audience,
+		SupportedSigningAlgs: jwksSigningAlgs,
+	}
+}
+
+func requireHTTPS(raw, name string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if parsed.Scheme != "https" {
+		return fmt.Errorf("%s must use https", name)
+	}
+	return nil
+}
+
+// loadJWKSFile reads a JSON Web Key Set and keeps its public signing keys.
+func loadJWKSFile(path string) (*oidc.StaticKeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file %q: %w", path, err)
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %q: %w", path, err)
+	}
+	keySet := &oidc.StaticKeySet{}
+	for _, key := range jwks.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		public := key.Public()
+		if !public.Valid() {
+			continue
+		}
+		keySet.PublicKeys = append(keySet.PublicKeys, public.Key)
+	}
+	if len(keySet.PublicKeys) == 0 {
+		return nil, fmt.Errorf("no public signing keys found in JWKS file %q", path)
+	}
+	return keySet, nil
+}
+
+// match returns the issuer named by the token's unverified "iss" claim.
+func (s issuerSet) match(token string) (*issuerVerifier, bool) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil, false
+	}
+	iss, err := claims.GetIssuer()
+	if err != nil || iss == "" {
+		return nil, false
+	}
+	issuer, ok := s[iss]
+	return issuer, ok
+}
+
+// mapClaims applies the issuer's claim mappings in place. Missing source
+// claims leave the target untouched.
+func (i *issuerVerifier) mapClaims(claims map[string]interface{}) {
+	for target, source := range i.ClaimMappings {
+		target, source = strings.TrimSpace(target), strings.TrimSpace(source)
+		if value, ok := claims[source]; ok && target != "" {
+			claims[target] = value
+		}
+	}
+}
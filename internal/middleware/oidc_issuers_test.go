@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeTestJWKS(t *testing.T, kid string) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &key.PublicKey,
+		KeyID:     kid,
+		Algorithm: "RS256",
+		Use:       "sig",
+	}}})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return key, path
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	now := time.Now()
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(time.Hour).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func TestOIDCAuthMiddleware_MultipleIssuers(t *testing.T) {
+	workforceKey, workforceJWKS := writeTestJWKS(t, "workforce")
+	customerKey, customerJWKS := writeTestJWKS(t, "customer")
+
+	authMiddleware, err := OIDCAuthMiddleware(OIDCAuthConfig{
+		Enabled:   true,
+		IssuerURL: "https://workforce.test",
+		Audience:  "tidb-graphql",
+		JWKSFile:  workforceJWKS,
+		Issuers: []OIDCIssuer{{
+			IssuerURL:     "https://customers.test",
+			Audience:      "graphql-api",
+			JWKSFile:      customerJWKS,
+			RoleClaim:     "tenant_role",
+			ClaimMappings: map[string]string{"tenant_role": "customer_role"},
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("OIDCAuthMiddleware() error = %v", err)
+	}
+
+	var (
+		auth AuthContext
+		role DBRoleContext
+	)
+	handler := authMiddleware(DBRoleMiddleware("db_role", []string{"app_staff", "app_customer"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth, _ = AuthFromContext(r.Context())
+			role, _ = DBRoleFromContext(r.Context())
+			w.WriteHeader(http.StatusNoContent)
+		})))
+
+	serve := func(token string) *httptest.ResponseRecorder {
+		auth, role = AuthContext{}, DBRoleContext{}
+		req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(signTestToken(t, workforceKey, "workforce", jwt.MapClaims{
+		"iss": "https://workforce.test", "aud": "tidb-graphql", "sub": "alice", "db_role": "app_staff",
+	}))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("workforce token status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if auth.Issuer != "https://workforce.test" || auth.Subject != "alice" || role.Role != "app_staff" {
+		t.Fatalf("unexpected workforce auth: %+v role=%+v", auth, role)
+	}
+
+	rec = serve(signTestToken(t, customerKey, "customer", jwt.MapClaims{
+		"iss": "https://customers.test", "aud": "graphql-api", "sub": "cust-42", "customer_role": "app_customer", "tenant_role": "app_staff",
+	}))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("customer token status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if auth.Issuer != "https://customers.test" || auth.Subject != "cust-42" || role.Role != "app_customer" {
+		t.Fatalf("unexpected customer auth: %+v role=%+v", auth, role)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name: "untrusted issuer",
+			token: signTestToken(t, workforceKey, "workforce", jwt.MapClaims{
+				"iss": "https://other.test", "aud": "tidb-graphql", "sub": "alice",
+			}),
+		},
+		{
+			name: "signed by another issuer's key",
+			token: signTestToken(t, workforceKey, "workforce", jwt.MapClaims{
+				"iss": "https://customers.test", "aud": "graphql-api", "sub": "alice",
+			}),
+		},
+		{
+			name: "audience of another issuer",
+			token: signTestToken(t, customerKey, "customer", jwt.MapClaims{
+				"iss": "https://customers.test", "aud": "tidb-graphql", "sub": "alice",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := serve(tt.token); rec.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, want 401", rec.Code)
+			}
+		})
+	}
+}
+
+func TestOIDCAuthMiddleware_IssuerConfigErrors(t *testing.T) {
+	_, jwksPath := writeTestJWKS(t, "k1")
+
+	tests := []struct {
+		name string
+		cfg  OIDCAuthConfig
+	}{
+		{name: "no issuers", cfg: OIDCAuthConfig{Enabled: true}},
+		{name: "missing audience", cfg: OIDCAuthConfig{Enabled: true, Issuers: []OIDCIssuer{{IssuerURL: "https://a.test", JWKSFile: jwksPath}}}},
+		{name: "duplicate issuer", cfg: OIDCAuthConfig{Enabled: true, IssuerURL: "https://a.test", Audience: "x", JWKSFile: jwksPath,
+			Issuers: []OIDCIssuer{{IssuerURL: "https://a.test", Audience: "y", JWKSFile: jwksPath}}}},
+		{name: "both key sources", cfg: OIDCAuthConfig{Enabled: true, IssuerURL: "https://a.test", Audience: "x", JWKSFile: jwksPath, JWKSURL: "https://a.test/keys"}},
+		{name: "plain http jwks url", cfg: OIDCAuthConfig{Enabled: true, IssuerURL: "https://a.test", Audience: "x", JWKSURL: "http://a.test/keys"}},
+		{name: "missing jwks file", cfg: OIDCAuthConfig{Enabled: true, IssuerURL: "https://a.test", Audience: "x", JWKSFile: filepath.Join(t.TempDir(), "none.json")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := OIDCAuthMiddleware(tt.cfg, nil); err == nil {
+				t.Fatal("expected configuration error")
+			}
+		})
+	}
+}
@@ -16,8 +16,46 @@ type Config struct {
 	// TypeOverrides maps SQL table name -> explicit GraphQL type name.
 	// Matching is case-insensitive at lookup time.
 	TypeOverrides map[string]string `mapstructure:"type_overrides"`
+
+	// FieldCase selects how SQL names become GraphQL field names:
+	// "camel" (default, "user_name" -> "userName"), "snake" ("userName" -> "user_name")
+	// or "preserve" (SQL names unchanged). Type names are always PascalCase.
+	FieldCase string `mapstructure:"field_case"`
+
+	// StripTablePrefixes lists table name prefixes (e.g. "tbl_", "app_") removed
+	// before names are derived. The first case-insensitive match is stripped.
+	StripTablePrefixes []string `mapstructure:"strip_table_prefixes"`
+
+	// InflectionFile names a YAML or JSON file with additional "plurals" and
+	// "singulars" dictionaries. Entries in PluralOverrides and SingularOverrides win.
+	InflectionFile string `mapstructure:"inflection_file"`
+
+	// RelationshipOverrides names or hides relationship fields per foreign key,
+	// keyed by referencing table name and then FK constraint name.
+	// Example: {"orders": {"orders_ibfk_2": {Forward: "shippingAddress", Reverse: "shippedOrders"}}}
+	RelationshipOverrides map[string]map[string]RelationshipOverride `mapstructure:"relationship_overrides"`
 }
 
+// RelationshipOverride customizes both directions of one foreign key. Names are
+// used verbatim as GraphQL field names; empty names keep the derived default.
+type RelationshipOverride struct {
+	// Forward names the many-to-one field on the referencing table.
+	Forward string `mapstructure:"forward"`
+	// Reverse names the one-to-many field on the referenced table.
+	Reverse string `mapstructure:"reverse"`
+	// HideForward omits the many-to-one field.
+	HideForward bool `mapstructure:"hide_forward"`
+	// HideReverse omits the one-to-many field.
+	HideReverse bool `mapstructure:"hide_reverse"`
+}
+
+// Field name cases accepted by Config.FieldCase.
+const (
+	FieldCaseCamel    = "camel"
+	FieldCaseSnake    = "snake"
+	FieldCasePreserve = "preserve"
+)
+
 // DefaultConfig returns sensible defaults
 func DefaultConfig() Config {
 	return Config{
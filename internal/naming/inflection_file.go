@@ -0,0 +1,46 @@
+package naming
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+type inflectionFile struct {
+	Plurals   map[string]string `mapstructure:"plurals"`
+	Singulars map[string]string `mapstructure:"singulars"`
+}
+
+// WithInflectionFile returns a copy of c with the dictionaries from
+// c.InflectionFile merged into the overrides. Overrides already present in c
+// take precedence. A config without an inflection file is returned unchanged.
+func (c Config) WithInflectionFile() (Config, error) {
+	if c.InflectionFile == "" {
+		return c, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(c.InflectionFile)
+	if err := v.ReadInConfig(); err != nil {
+		return c, fmt.Errorf("failed to read inflection file: %w", err)
+	}
+	var file inflectionFile
+	if err := v.Unmarshal(&file); err != nil {
+		return c, fmt.Errorf("failed to parse inflection file %s: %w", c.InflectionFile, err)
+	}
+
+	merged := c
+	merged.PluralOverrides = mergeOverrides(file.Plurals, c.PluralOverrides)
+	merged.SingularOverrides = mergeOverrides(file.Singulars, c.SingularOverrides)
+	return merged, nil
+}
+
+func mergeOverrides(base, override map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = value
+	}
+	return merged
+}
@@ -3,6 +3,7 @@ package naming
 import (
 	"log/slog"
 	"strings"
+	"unicode"
 )
 
 // Namer provides all name transformation functions for converting SQL names
@@ -59,10 +60,106 @@ func (n *Namer) ToGraphQLTypeName(tableName string) string {
 	return n.validateTypeAndSuffix(name)
 }
 
-// ToGraphQLFieldName converts a column/table name to GraphQL field (camelCase)
-// Example: "user_name" -> "userName"
+// ToGraphQLFieldName converts a column/table name to a GraphQL field name in
+// the configured field case.
+// Example: "user_name" -> "userName" (camel), "userName" -> "user_name" (snake)
 func (n *Namer) ToGraphQLFieldName(columnName string) string {
-	return toCamelCase(columnName)
+	switch n.config.FieldCase {
+	case FieldCaseSnake:
+		return toSnakeCase(columnName)
+	case FieldCasePreserve:
+		return columnName
+	default:
+		return toCamelCase(columnName)
+	}
+}
+
+// StripTablePrefix removes the first configured table prefix matching
+// tableName case-insensitively. Names that would become empty are kept.
+// Example: with prefixes ["tbl_"], "tbl_users" -> "users"
+func (n *Namer) StripTablePrefix(tableName string) string {
+	for _, prefix := range n.config.StripTablePrefixes {
+		if prefix != "" && len(tableName) > len(prefix) && strings.EqualFold(tableName[:len(prefix)], prefix) {
+			return tableName[len(prefix):]
+		}
+	}
+	return tableName
+}
+
+// RelationshipOverride returns the configured override for a foreign key constraint
+// on tableName, or the zero value when none is set. Names match case-insensitively.
+func (n *Namer) RelationshipOverride(tableName, constraintName string) RelationshipOverride {
+	for table, constraints := range n.config.RelationshipOverrides {
+		if !strings.EqualFold(table, tableName) {
+			continue
+		}
+		for constraint, override := range constraints {
+			if strings.EqualFold(constraint, constraintName) {
+				return override
+			}
+		}
+	}
+	return RelationshipOverride{}
+}
+
+// JoinFieldName appends name to a field name prefix in the configured field case.
+// Example: ("author", "posts") -> "authorPosts" (camel), "author_posts" (snake)
+func (n *Namer) JoinFieldName(prefix, name string) string {
+	if name == "" {
+		return prefix
+	}
+	if n.config.FieldCase == FieldCaseSnake || n.config.FieldCase == FieldCasePreserve {
+		return prefix + "_" + name
+	}
+	return prefix + upperFirst(name)
+}
+
+// TypeNamePart converts a GraphQL field name into a PascalCase part of a
+// generated type or operation name.
+// Example: "authorPosts" -> "AuthorPosts" (camel), "author_posts" -> "AuthorPosts" (snake)
+func (n *Namer) TypeNamePart(fieldName string) string {
+	if n.config.FieldCase == FieldCaseSnake || n.config.FieldCase == FieldCasePreserve {
+		return toPascalCase(toSnakeCase(fieldName))
+	}
+	return upperFirst(fieldName)
+}
+
+// FieldNamePart converts a PascalCase type name into a GraphQL field name in
+// the configured field case, for use with JoinFieldName.
+// Example: "OrderItem" -> "orderItem" (camel), "order_item" (snake)
+func (n *Namer) FieldNamePart(typeName string) string {
+	if n.config.FieldCase == FieldCaseSnake || n.config.FieldCase == FieldCasePreserve {
+		return toSnakeCase(typeName)
+	}
+	return lowerFirst(typeName)
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// fieldSuffix appends a PascalCase disambiguation suffix such as "Ref" to a
+// field name in the configured field case.
+// Example: ("author", "Ref") -> "authorRef" (camel), "author_ref" (snake)
+func (n *Namer) fieldSuffix(name, suffix string) string {
+	if n.config.FieldCase == FieldCaseSnake || n.config.FieldCase == FieldCasePreserve {
+		return name + "_" + toSnakeCase(suffix)
+	}
+	return name + suffix
 }
 
 // ManyToOneFieldName generates the GraphQL field name for a many-to-one relationship
@@ -86,19 +183,14 @@ func (n *Namer) ManyToOneFieldName(fkColumn string) string {
 // Example: isOnlyFK=true: "comments" -> "comments"
 // Example: isOnlyFK=false, fkColumn="author_id": "posts" -> "authorPosts"
 func (n *Namer) OneToManyFieldName(sourceTable, fkColumn string, isOnlyFK bool) string {
-	tablePlural := n.Pluralize(n.ToGraphQLFieldName(sourceTable))
+	tablePlural := n.Pluralize(n.ToGraphQLFieldName(n.StripTablePrefix(sourceTable)))
 
 	if isOnlyFK {
 		return tablePlural
 	}
 
-	// Multiple FKs: prefix with FK column name (minus _id)
-	prefix := n.ManyToOneFieldName(fkColumn)
-	// Capitalize first letter of table for camelCase: authorPosts
-	if len(tablePlural) > 0 {
-		return prefix + strings.ToUpper(tablePlural[:1]) + tablePlural[1:]
-	}
-	return prefix
+	// Multiple FKs: prefix with FK column name (minus _id), e.g. authorPosts
+	return n.JoinFieldName(n.ManyToOneFieldName(fkColumn), tablePlural)
 }
 
 // JunctionFieldName generates the field name for junction relationships.
@@ -107,7 +199,7 @@ func (n *Namer) OneToManyFieldName(sourceTable, fkColumn string, isOnlyFK bool)
 // is a simple combination of the two table names; otherwise it uses the junction name.
 func (n *Namer) JunctionFieldName(junctionTable, leftTable, rightTable, targetTable string, isAttribute bool) string {
 	if isAttribute || !n.isSimpleJunctionName(junctionTable, leftTable, rightTable) {
-		fieldName := n.ToGraphQLFieldName(junctionTable)
+		fieldName := n.ToGraphQLFieldName(n.StripTablePrefix(junctionTable))
 		return n.Pluralize(fieldName)
 	}
 	return n.ManyToManyFieldName(targetTable)
@@ -117,11 +209,11 @@ func (n *Namer) JunctionFieldName(junctionTable, leftTable, rightTable, targetTa
 // an attribute junction (edge) back to a base table.
 // Example: "employees" -> "employee".
 func (n *Namer) JunctionEdgeRefFieldName(targetTable string) string {
-	return n.Singularize(n.ToGraphQLFieldName(targetTable))
+	return n.Singularize(n.ToGraphQLFieldName(n.StripTablePrefix(targetTable)))
 }
 
 func (n *Namer) isSimpleJunctionName(junctionTable, leftTable, rightTable string) bool {
-	junctionTokens := splitTokens(junctionTable)
+	junctionTokens := splitTokens(n.StripTablePrefix(junctionTable))
 	if len(junctionTokens) == 0 {
 		return false
 	}
@@ -139,7 +231,7 @@ func (n *Namer) isSimpleJunctionName(junctionTable, leftTable, rightTable string
 }
 
 func (n *Namer) addNameTokens(set map[string]struct{}, name string) {
-	for _, token := range splitTokens(name) {
+	for _, token := range splitTokens(n.StripTablePrefix(name)) {
 		set[token] = struct{}{}
 		set[n.Singularize(token)] = struct{}{}
 		set[n.Pluralize(token)] = struct{}{}
@@ -170,8 +262,8 @@ func (n *Namer) EdgeTypeName(leftTable, rightTable string) string {
 	}
 
 	// Convert each to singular PascalCase
-	leftType := n.Singularize(n.ToGraphQLTypeName(leftTable))
-	rightType := n.Singularize(n.ToGraphQLTypeName(rightTable))
+	leftType := n.Singularize(n.ToGraphQLTypeName(n.StripTablePrefix(leftTable)))
+	rightType := n.Singularize(n.ToGraphQLTypeName(n.StripTablePrefix(rightTable)))
 
 	// Concatenate
 	name := leftType + rightType
@@ -180,13 +272,15 @@ func (n *Namer) EdgeTypeName(leftTable, rightTable string) string {
 }
 
 // EdgeFieldName generates the field name for edge list access on parent types.
-// Returns pluralized edge type name in camelCase.
+// Returns pluralized edge type name in the configured field case.
 // Example: ("departments", "employees") -> "departmentEmployees"
 func (n *Namer) EdgeFieldName(leftTable, rightTable string) string {
 	edgeType := n.EdgeTypeName(leftTable, rightTable)
 
-	// Convert PascalCase to camelCase
-	if len(edgeType) > 0 {
+	// Convert PascalCase to the field case
+	if n.config.FieldCase == FieldCaseSnake || n.config.FieldCase == FieldCasePreserve {
+		edgeType = toSnakeCase(edgeType)
+	} else if len(edgeType) > 0 {
 		edgeType = strings.ToLower(edgeType[:1]) + edgeType[1:]
 	}
 
@@ -198,7 +292,7 @@ func (n *Namer) EdgeFieldName(leftTable, rightTable string) string {
 // Returns pluralized target table name in camelCase.
 // Example: "employees" -> "employees", "role" -> "roles"
 func (n *Namer) ManyToManyFieldName(targetTable string) string {
-	fieldName := n.ToGraphQLFieldName(targetTable)
+	fieldName := n.ToGraphQLFieldName(n.StripTablePrefix(targetTable))
 	return n.Pluralize(fieldName)
 }
 
@@ -210,7 +304,7 @@ func (n *Namer) RegisterEdgeField(typeName, leftTable, rightTable string) string
 
 	// Check for collision with existing fields
 	if n.resolver.FieldExists(typeName, fieldName) {
-		fieldName = fieldName + "Edge"
+		fieldName = n.fieldSuffix(fieldName, "Edge")
 	}
 
 	source := "edge:" + leftTable + "+" + rightTable
@@ -226,8 +320,8 @@ func (n *Namer) RegisterManyToManyField(typeName, targetTable, junctionTable str
 	// Check for collision with existing fields
 	if n.resolver.FieldExists(typeName, fieldName) {
 		// Use Via{JunctionType} suffix for disambiguation
-		junctionTypeName := n.ToGraphQLTypeName(junctionTable)
-		fieldName = fieldName + "Via" + junctionTypeName
+		junctionTypeName := n.ToGraphQLTypeName(n.StripTablePrefix(junctionTable))
+		fieldName = n.fieldSuffix(fieldName, "Via"+junctionTypeName)
 	}
 
 	source := "m2m:" + junctionTable + "->" + targetTable
@@ -237,7 +331,7 @@ func (n *Namer) RegisterManyToManyField(typeName, targetTable, junctionTable str
 // RegisterType registers a table name and returns the resolved GraphQL type name.
 // If a collision occurs, returns a suffixed name and logs a warning.
 func (n *Namer) RegisterType(tableName string) string {
-	graphqlName := n.ToGraphQLTypeName(tableName)
+	graphqlName := n.ToGraphQLTypeName(n.StripTablePrefix(tableName))
 	return n.resolver.RegisterType(graphqlName, tableName)
 }
 
@@ -262,9 +356,9 @@ func (n *Namer) RegisterRelationshipField(typeName, fieldName, source string, is
 	if n.resolver.FieldExists(typeName, fieldName) {
 		// Apply suffix based on relationship type
 		if isManyToOne {
-			fieldName = fieldName + "Ref"
+			fieldName = n.fieldSuffix(fieldName, "Ref")
 		} else {
-			fieldName = fieldName + "Rel"
+			fieldName = n.fieldSuffix(fieldName, "Rel")
 		}
 	}
 	fieldName = n.validateFieldAndSuffix(fieldName)
@@ -273,15 +367,16 @@ func (n *Namer) RegisterRelationshipField(typeName, fieldName, source string, is
 
 // RegisterQueryField registers a query field and returns the resolved name.
 func (n *Namer) RegisterQueryField(tableName string) string {
-	if isReservedPattern(strings.ToLower(tableName)) {
-		fieldName := n.ToGraphQLFieldName(tableName) + "_"
+	baseName := n.StripTablePrefix(tableName)
+	if isReservedPattern(strings.ToLower(baseName)) {
+		fieldName := n.ToGraphQLFieldName(baseName) + "_"
 		n.logger.Warn("GraphQL name conflicts with reserved pattern, auto-suffixed",
-			slog.String("original", n.ToGraphQLFieldName(tableName)),
+			slog.String("original", n.ToGraphQLFieldName(baseName)),
 			slog.String("renamed", fieldName),
 		)
 		return n.resolver.RegisterQuery(fieldName, tableName)
 	}
-	fieldName := n.validateFieldAndSuffix(n.ToGraphQLFieldName(tableName))
+	fieldName := n.validateFieldAndSuffix(n.ToGraphQLFieldName(baseName))
 	return n.resolver.RegisterQuery(fieldName, tableName)
 }
 
@@ -330,3 +425,27 @@ func toCamelCase(s string) string {
 	}
 	return strings.Join(parts, "")
 }
+
+// toSnakeCase converts camelCase or PascalCase to lower snake_case, keeping
+// acronyms together: "userName" -> "user_name", "HTTPServer" -> "http_server"
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		isUpper := r >= 'A' && r <= 'Z'
+		if isUpper && i > 0 && runes[i-1] != '_' {
+			prev := runes[i-1]
+			prevLowerOrDigit := (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9')
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			prevUpper := prev >= 'A' && prev <= 'Z'
+			if prevLowerOrDigit || (prevUpper && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		if isUpper {
+			r = r - 'A' + 'a'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -3,9 +3,12 @@ package naming
 import (
 	"bytes"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestToGraphQLTypeName(t *testing.T) {
@@ -507,3 +510,74 @@ func TestRegisterTypeName(t *testing.T) {
 		assert.Equal(t, "Mutation_", result)
 	})
 }
+
+func TestFieldCaseStrategies(t *testing.T) {
+	snake := DefaultConfig()
+	snake.FieldCase = FieldCaseSnake
+	preserve := DefaultConfig()
+	preserve.FieldCase = FieldCasePreserve
+
+	tests := []struct {
+		name      string
+		namer     *Namer
+		field     string
+		oneToMany string
+		joined    string
+		edge      string
+		typePart  string
+		operation string
+	}{
+		{"camel", Default(), "userName", "authorPosts", "byEmailTenantId", "departmentEmployees", "AuthorPosts", "createOrderItem"},
+		{"snake", New(snake, nil), "user_name", "author_posts", "by_email_tenant_id", "department_employees", "AuthorPosts", "create_order_item"},
+		{"preserve", New(preserve, nil), "user_name", "author_posts", "by_email_tenant_id", "department_employees", "AuthorPosts", "create_order_item"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.field, tt.namer.ToGraphQLFieldName("user_name"))
+			assert.Equal(t, tt.oneToMany, tt.namer.OneToManyFieldName("posts", "author_id", false))
+			assert.Equal(t, tt.joined, tt.namer.JoinFieldName(tt.namer.JoinFieldName("by", tt.namer.ToGraphQLFieldName("email")), tt.namer.ToGraphQLFieldName("tenant_id")))
+			assert.Equal(t, tt.edge, tt.namer.EdgeFieldName("departments", "employees"))
+			assert.Equal(t, tt.typePart, tt.namer.TypeNamePart(tt.oneToMany))
+			assert.Equal(t, tt.operation, tt.namer.JoinFieldName("create", tt.namer.FieldNamePart("OrderItem")))
+			assert.Equal(t, "UserProfiles", tt.namer.ToGraphQLTypeName("user_profiles"))
+		})
+	}
+
+	assert.Equal(t, "http_server_id", toSnakeCase("HTTPServerId"))
+	assert.Equal(t, "created_at", New(snake, nil).ToGraphQLFieldName("createdAt"))
+	assert.Equal(t, "createdAt", New(preserve, nil).ToGraphQLFieldName("createdAt"))
+}
+
+func TestStripTablePrefix(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.StripTablePrefixes = []string{"tbl_", "app_"}
+	namer := New(cfg, nil)
+
+	assert.Equal(t, "users", namer.StripTablePrefix("TBL_users"))
+	assert.Equal(t, "orders", namer.StripTablePrefix("app_orders"))
+	assert.Equal(t, "tbl_", namer.StripTablePrefix("tbl_"))
+	assert.Equal(t, "Users", namer.RegisterType("tbl_users"))
+	assert.Equal(t, "orders", namer.RegisterQueryField("app_orders"))
+	assert.Equal(t, "users", namer.OneToManyFieldName("tbl_users", "team_id", true))
+	assert.Equal(t, "DepartmentEmployee", namer.EdgeTypeName("tbl_departments", "tbl_employees"))
+}
+
+func TestWithInflectionFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inflections.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("plurals:\n  cactus: cacti\n  person: folks\nsingulars:\n  cacti: cactus\n"), 0o600))
+
+	cfg := DefaultConfig()
+	cfg.PluralOverrides["person"] = "people"
+	cfg.InflectionFile = path
+	loaded, err := cfg.WithInflectionFile()
+	require.NoError(t, err)
+
+	namer := New(loaded, nil)
+	assert.Equal(t, "cacti", namer.Pluralize("cactus"))
+	assert.Equal(t, "cactus", namer.Singularize("cacti"))
+	assert.Equal(t, "people", namer.Pluralize("person"), "config overrides win over the file")
+
+	cfg.InflectionFile = filepath.Join(t.TempDir(), "missing.yaml")
+	_, err = cfg.WithInflectionFile()
+	assert.ErrorContains(t, err, "failed to read inflection file")
+}
@@ -0,0 +1,64 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// DrainSource reports the drain state observed by the drain gauges.
+type DrainSource interface {
+	Draining() bool
+	InFlight() int64
+	OpenTransactions() int
+}
+
+// RegisterDrainMetrics exposes drain state as observable gauges. Call
+// Unregister on the returned registration during shutdown.
+func RegisterDrainMetrics(source DrainSource) (metric.Registration, error) {
+	meter := otel.Meter("tidb-graphql")
+
+	drainingGauge, err := meter.Int64ObservableGauge(
+		"server.draining",
+		metric.WithDescription("1 while the server is draining, 0 otherwise"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create draining gauge: %w", err)
+	}
+
+	inFlightGauge, err := meter.Int64ObservableGauge(
+		"server.drain.inflight_mutations",
+		metric.WithDescription("Number of mutations whose transaction has not finished yet"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-flight mutations gauge: %w", err)
+	}
+
+	openTxGauge, err := meter.Int64ObservableGauge(
+		"server.drain.open_transactions",
+		metric.WithDescription("Number of open interactive transactions"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create open transactions gauge: %w", err)
+	}
+
+	registration, err := meter.RegisterCallback(
+		func(ctx context.Context, observer metric.Observer) error {
+			var draining int64
+			if source.Draining() {
+				draining = 1
+			}
+			observer.ObserveInt64(drainingGauge, draining)
+			observer.ObserveInt64(inFlightGauge, source.InFlight())
+			observer.ObserveInt64(openTxGauge, int64(source.OpenTransactions()))
+			return nil
+		},
+		drainingGauge, inFlightGauge, openTxGauge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register drain gauge callback: %w", err)
+	}
+	return registration, nil
+}
@@ -45,6 +45,9 @@ func GraphQLSpanAttributes(analysis *gqlrequest.Analysis, meta gqlrequest.ExecMe
 	if meta.Fingerprint != "" {
 		attrs = append(attrs, attribute.String("schema.fingerprint", meta.Fingerprint))
 	}
+	if meta.DryRun {
+		attrs = append(attrs, attribute.Bool("graphql.mutation.dry_run", true))
+	}
 
 	return attrs
 }
@@ -74,6 +77,9 @@ func GraphQLLogFields(ctx context.Context, analysis *gqlrequest.Analysis, meta g
 	if meta.Fingerprint != "" {
 		fields = append(fields, slog.String("schema_fingerprint", meta.Fingerprint))
 	}
+	if meta.DryRun {
+		fields = append(fields, slog.Bool("dry_run", true))
+	}
 
 	spanCtx := trace.SpanContextFromContext(ctx)
 	if spanCtx.IsValid() {
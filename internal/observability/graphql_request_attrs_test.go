@@ -2,6 +2,7 @@ package observability
 
 import (
 	"context"
+	"log/slog"
 	"testing"
 
 	"tidb-graphql/internal/gqlrequest"
@@ -57,3 +58,27 @@ func TestGraphQLLogFieldsIncludesTraceID(t *testing.T) {
 		t.Fatalf("expected log fields")
 	}
 }
+
+func TestGraphQLRequestAttrsTagDryRun(t *testing.T) {
+	meta := gqlrequest.ExecMeta{OperationType: "mutation", DryRun: true}
+
+	foundAttr := false
+	for _, attr := range GraphQLSpanAttributes(nil, meta) {
+		if string(attr.Key) == "graphql.mutation.dry_run" && attr.Value.AsBool() {
+			foundAttr = true
+		}
+	}
+	if !foundAttr {
+		t.Fatalf("expected graphql.mutation.dry_run span attribute")
+	}
+
+	foundField := false
+	for _, field := range GraphQLLogFields(context.Background(), nil, meta) {
+		if attr, ok := field.(slog.Attr); ok && attr.Key == "dry_run" && attr.Value.Bool() {
+			foundField = true
+		}
+	}
+	if !foundField {
+		t.Fatalf("expected dry_run log field")
+	}
+}
@@ -124,11 +124,13 @@ func InitGraphQLMetrics() (*GraphQLMetrics, error) {
 	}, nil
 }
 
-// RecordRequest records a GraphQL request with its duration and outcome
-func (m *GraphQLMetrics) RecordRequest(ctx context.Context, duration time.Duration, hasErrors bool, operationType string) {
+// RecordRequest records a GraphQL request with its duration and outcome.
+// Dry-run mutations are tagged so they can be separated from committed writes.
+func (m *GraphQLMetrics) RecordRequest(ctx context.Context, duration time.Duration, hasErrors bool, operationType string, dryRun bool) {
 	attrs := []attribute.KeyValue{
 		attribute.String("operation_type", operationType),
 		attribute.Bool("has_errors", hasErrors),
+		attribute.Bool("dry_run", dryRun),
 	}
 
 	// Record duration in milliseconds
@@ -141,6 +143,7 @@ func (m *GraphQLMetrics) RecordRequest(ctx context.Context, duration time.Durati
 	if hasErrors {
 		m.errorCounter.Add(ctx, 1, metric.WithAttributes(
 			attribute.String("operation_type", operationType),
+			attribute.Bool("dry_run", dryRun),
 		))
 	}
 }
@@ -17,6 +17,9 @@ type SecurityMetrics struct {
 	adminEndpointAccess   metric.Int64Counter
 	unauthorizedAttempts  metric.Int64Counter
 	tokenValidationErrors metric.Int64Counter
+	apiKeyRequests        metric.Int64Counter
+	dbRoleRuleMatches     metric.Int64Counter
+	introspections        metric.Int64Counter
 }
 
 // InitSecurityMetrics initializes security-specific metrics
@@ -71,6 +74,30 @@ func InitSecurityMetrics() (*SecurityMetrics, error) {
 		return nil, fmt.Errorf("failed to create token validation errors counter: %w", err)
 	}
 
+	apiKeyRequests, err := meter.Int64Counter(
+		"security.api_key.requests.total",
+		metric.WithDescription("Total number of requests authenticated with an API key"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key requests counter: %w", err)
+	}
+
+	dbRoleRuleMatches, err := meter.Int64Counter(
+		"security.db_role.rule_matches.total",
+		metric.WithDescription("Total number of database roles chosen by claim-to-role rules"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create db role rule matches counter: %w", err)
+	}
+
+	introspections, err := meter.Int64Counter(
+		"security.introspection.requests.total",
+		metric.WithDescription("Total number of opaque tokens checked by token introspection, including cache hits"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token introspection counter: %w", err)
+	}
+
 	return &SecurityMetrics{
 		authAttempts:          authAttempts,
 		authFailures:          authFailures,
@@ -78,6 +105,9 @@ func InitSecurityMetrics() (*SecurityMetrics, error) {
 		adminEndpointAccess:   adminEndpointAccess,
 		unauthorizedAttempts:  unauthorizedAttempts,
 		tokenValidationErrors: tokenValidationErrors,
+		apiKeyRequests:        apiKeyRequests,
+		dbRoleRuleMatches:     dbRoleRuleMatches,
+		introspections:        introspections,
 	}, nil
 }
 
@@ -128,3 +158,34 @@ func (m *SecurityMetrics) RecordTokenValidationError(ctx context.Context, errorT
 		attribute.String("error_type", errorType),
 	))
 }
+
+// RecordAPIKeyRequest records a request made with an API key and whether it was
+// allowed, rate limited or outside the key's scopes
+func (m *SecurityMetrics) RecordAPIKeyRequest(ctx context.Context, keyID, outcome string) {
+	m.apiKeyRequests.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("key_id", keyID),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// RecordDBRoleRuleMatch records the claim-to-role rule that chose a request's
+// database role. An empty rule records that no rule matched
+func (m *SecurityMetrics) RecordDBRoleRuleMatch(ctx context.Context, rule, role string) {
+	matched := rule != ""
+	if !matched {
+		rule = "none"
+	}
+	m.dbRoleRuleMatches.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("rule", rule),
+		attribute.String("role", role),
+		attribute.Bool("matched", matched),
+	))
+}
+
+// RecordIntrospection records one token introspection lookup and its outcome:
+// active, inactive, cache_hit or error
+func (m *SecurityMetrics) RecordIntrospection(ctx context.Context, outcome string) {
+	m.introspections.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("outcome", outcome),
+	))
+}
@@ -0,0 +1,77 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// TLSMetrics holds certificate reload and expiry metrics.
+type TLSMetrics struct {
+	reloadCounter metric.Int64Counter
+	errorCounter  metric.Int64Counter
+}
+
+// InitTLSMetrics initializes certificate metrics. expiries reports the
+// earliest certificate expiry per source and is read on every collection.
+func InitTLSMetrics(expiries func() map[string]time.Time) (*TLSMetrics, error) {
+	meter := otel.Meter("tidb-graphql")
+
+	reloadCounter, err := meter.Int64Counter(
+		"tls.certificate.reloads.total",
+		metric.WithDescription("Total number of certificate reload attempts after a file change"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate reload counter: %w", err)
+	}
+
+	errorCounter, err := meter.Int64Counter(
+		"tls.certificate.reload_errors.total",
+		metric.WithDescription("Total number of failed certificate reloads"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate reload error counter: %w", err)
+	}
+
+	expiryGauge, err := meter.Int64ObservableGauge(
+		"tls.certificate.expiry_unix",
+		metric.WithDescription("Unix timestamp at which the active certificate expires"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate expiry gauge: %w", err)
+	}
+
+	_, err = meter.RegisterCallback(
+		func(ctx context.Context, observer metric.Observer) error {
+			for source, notAfter := range expiries() {
+				observer.ObserveInt64(expiryGauge, notAfter.Unix(), metric.WithAttributes(attribute.String("source", source)))
+			}
+			return nil
+		},
+		expiryGauge,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register certificate expiry gauge callback: %w", err)
+	}
+
+	return &TLSMetrics{
+		reloadCounter: reloadCounter,
+		errorCounter:  errorCounter,
+	}, nil
+}
+
+// RecordReload records a certificate reload attempt for source.
+func (m *TLSMetrics) RecordReload(ctx context.Context, source string, success bool) {
+	m.reloadCounter.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("source", source),
+		attribute.Bool("success", success),
+	))
+	if !success {
+		m.errorCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("source", source)))
+	}
+}
@@ -99,15 +99,19 @@ func PlanAggregate(
 }
 
 // PlanRelationshipAggregate builds SQL for aggregating related rows via a foreign key.
+// remoteColumns and fkValues are positional and may span a composite key.
 func PlanRelationshipAggregate(
 	relatedTable introspection.Table,
 	selection AggregateSelection,
-	remoteColumn string,
-	fkValue interface{},
+	remoteColumns []string,
+	fkValues []interface{},
 	filters *AggregateFilters,
 ) (SQLQuery, error) {
 	// Build WHERE combining relationship filter and user filter
-	fkCondition := sq.Eq{sqlutil.QuoteIdentifier(remoteColumn): fkValue}
+	fkCondition, err := oneToManyKeyCondition(remoteColumns, fkValues)
+	if err != nil {
+		return SQLQuery{}, err
+	}
 	var finalCondition sq.Sqlizer = fkCondition
 
 	if filters != nil && filters.Where != nil && filters.Where.Condition != nil {
@@ -137,35 +141,42 @@ func PlanRelationshipAggregate(
 }
 
 // PlanRelationshipAggregateBatch builds SQL for batched relationship aggregates with GROUP BY.
+// Each parent tuple supplies one value per remote column; the grouping columns are
+// returned as __group_key (single column) or __group_key_0..n (composite keys).
 func PlanRelationshipAggregateBatch(
 	relatedTable introspection.Table,
 	selection AggregateSelection,
-	remoteColumn string,
-	values []interface{},
+	remoteColumns []string,
+	values []ParentTuple,
 	whereClause *WhereClause,
 ) (SQLQuery, error) {
 	if len(values) == 0 {
 		return SQLQuery{}, nil
 	}
 
-	selectClauses := SQLClauses(BuildAggregateColumns(selection))
-
-	// Add grouping column to select
-	groupCol := sqlutil.QuoteIdentifier(remoteColumn)
-	selectClauses = append([]string{groupCol + " AS __group_key"}, selectClauses...)
+	groupCols := quotedColumnNames(remoteColumns)
+	groupAliases := AggregateGroupKeyAliases(len(remoteColumns))
+	selectClauses := make([]string, 0, len(groupCols))
+	for i, col := range groupCols {
+		selectClauses = append(selectClauses, fmt.Sprintf("%s AS %s", col, groupAliases[i]))
+	}
+	selectClauses = append(selectClauses, SQLClauses(BuildAggregateColumns(selection))...)
 
-	// Build WHERE combining IN clause and user filter
-	inCondition := sq.Eq{groupCol: values}
-	var finalCondition sq.Sqlizer = inCondition
+	// Build WHERE combining tuple IN clause and user filter
+	inSQL, inArgs, err := buildTupleInCondition(groupCols, values)
+	if err != nil {
+		return SQLQuery{}, err
+	}
+	var finalCondition sq.Sqlizer = sq.Expr(inSQL, inArgs...)
 
 	if whereClause != nil && whereClause.Condition != nil {
-		finalCondition = sq.And{inCondition, whereClause.Condition}
+		finalCondition = sq.And{finalCondition, whereClause.Condition}
 	}
 
 	builder := sq.Select(selectClauses...).
 		From(relatedTable.SQLFrom()).
 		Where(finalCondition).
-		GroupBy(groupCol)
+		GroupBy(groupCols...)
 
 	query, args, err := builder.PlaceholderFormat(sq.Question).ToSql()
 	if err != nil {
@@ -175,6 +186,18 @@ func PlanRelationshipAggregateBatch(
 	return SQLQuery{SQL: query, Args: args}, nil
 }
 
+// AggregateGroupKeyAliases returns the group key aliases emitted by PlanRelationshipAggregateBatch.
+func AggregateGroupKeyAliases(columnCount int) []string {
+	if columnCount <= 1 {
+		return []string{"__group_key"}
+	}
+	aliases := make([]string, columnCount)
+	for i := range aliases {
+		aliases[i] = fmt.Sprintf("__group_key_%d", i)
+	}
+	return aliases
+}
+
 // BuildAggregateColumns returns the ordered list of aggregate columns for a selection.
 // This is the SINGLE SOURCE OF TRUTH for the order of aggregate operations.
 // Both SQL generation (via SQLClauses) and result scanning must use this order.
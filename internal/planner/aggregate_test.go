@@ -130,7 +130,7 @@ func TestPlanRelationshipAggregate(t *testing.T) {
 
 	t.Run("basic relationship aggregate", func(t *testing.T) {
 		selection := AggregateSelection{Count: true}
-		planned, err := PlanRelationshipAggregate(table, selection, "customer_id", 123, nil)
+		planned, err := PlanRelationshipAggregate(table, selection, []string{"customer_id"}, []interface{}{123}, nil)
 		require.NoError(t, err)
 		assert.Contains(t, planned.SQL, "COUNT(*)")
 		assert.Contains(t, planned.SQL, "WHERE")
@@ -148,7 +148,7 @@ func TestPlanRelationshipAggregate(t *testing.T) {
 		})
 		require.NoError(t, err)
 
-		planned, err := PlanRelationshipAggregate(table, selection, "customer_id", 123, &AggregateFilters{Where: whereClause})
+		planned, err := PlanRelationshipAggregate(table, selection, []string{"customer_id"}, []interface{}{123}, &AggregateFilters{Where: whereClause})
 		require.NoError(t, err)
 		assert.Contains(t, planned.SQL, "SUM(`total`)")
 		// Should have both the FK condition and the where condition
@@ -168,7 +168,7 @@ func TestPlanRelationshipAggregateBatch(t *testing.T) {
 
 	t.Run("batch aggregate with GROUP BY", func(t *testing.T) {
 		selection := AggregateSelection{Count: true}
-		planned, err := PlanRelationshipAggregateBatch(table, selection, "customer_id", []interface{}{1, 2, 3}, nil)
+		planned, err := PlanRelationshipAggregateBatch(table, selection, []string{"customer_id"}, []ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}, {Values: []interface{}{3}}}, nil)
 		require.NoError(t, err)
 		assert.Contains(t, planned.SQL, "GROUP BY")
 		assert.Contains(t, planned.SQL, "__group_key")
@@ -178,7 +178,7 @@ func TestPlanRelationshipAggregateBatch(t *testing.T) {
 
 	t.Run("empty values returns empty query", func(t *testing.T) {
 		selection := AggregateSelection{Count: true}
-		planned, err := PlanRelationshipAggregateBatch(table, selection, "customer_id", []interface{}{}, nil)
+		planned, err := PlanRelationshipAggregateBatch(table, selection, []string{"customer_id"}, nil, nil)
 		require.NoError(t, err)
 		assert.Empty(t, planned.SQL)
 	})
@@ -212,3 +212,27 @@ func TestBuildAggregateSelectClauses(t *testing.T) {
 		assert.Contains(t, clauses[0], "COUNT(*)")
 	})
 }
+
+func TestPlanRelationshipAggregateBatch_CompositeKey(t *testing.T) {
+	table := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "tenant_id", IsPrimaryKey: true},
+			{Name: "id", IsPrimaryKey: true},
+			{Name: "customer_id"},
+			{Name: "total"},
+		},
+	}
+	selection := AggregateSelection{Count: true, SumColumns: []string{"total"}}
+
+	planned, err := PlanRelationshipAggregateBatch(table, selection, []string{"tenant_id", "customer_id"}, []ParentTuple{{Values: []interface{}{7, 1}}, {Values: []interface{}{7, 2}}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT `tenant_id` AS __group_key_0, `customer_id` AS __group_key_1, COUNT(*) AS __count, SUM(`total`) AS `__sum_total` FROM `orders` WHERE (`tenant_id`, `customer_id`) IN ((?,?), (?,?)) GROUP BY `tenant_id`, `customer_id`", planned.SQL)
+	assert.Equal(t, []interface{}{7, 1, 7, 2}, planned.Args)
+	assert.Equal(t, []string{"__group_key_0", "__group_key_1"}, AggregateGroupKeyAliases(2))
+
+	count, err := PlanRelationshipAggregate(table, selection, []string{"tenant_id", "customer_id"}, []interface{}{7, 1}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, count.SQL, "WHERE `customer_id` = ? AND `tenant_id` = ?")
+	assert.Equal(t, []interface{}{1, 7}, count.Args)
+}
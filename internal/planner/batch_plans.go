@@ -121,7 +121,7 @@ func PlanEdgeListBatch(
 }
 
 // buildBatchWindowQuery emits the shared ROW_NUMBER() window pattern used by
-// PlanManyToManyBatch and PlanEdgeListBatch.
+// PlanOneToManyBatch, PlanManyToManyBatch and PlanEdgeListBatch.
 func buildBatchWindowQuery(
 	fromClause string,
 	columnList string,
@@ -186,11 +186,13 @@ func buildBatchWindowQuery(
 }
 
 // PlanOneToManyBatch builds a batched SQL query with per-parent limits.
+// remoteColumns are the FK columns on relatedTable; each parent tuple supplies
+// one value per column, so composite keys batch via tuple IN matching.
 func PlanOneToManyBatch(
 	relatedTable introspection.Table,
 	columns []introspection.Column,
-	remoteColumn string,
-	values []interface{},
+	remoteColumns []string,
+	values []ParentTuple,
 	limit, offset int,
 	orderBy *OrderBy,
 	where *WhereClause,
@@ -198,63 +200,19 @@ func PlanOneToManyBatch(
 	if len(values) == 0 {
 		return SQLQuery{}, nil
 	}
-	if err := validateLimitOffset(limit, offset); err != nil {
-		return SQLQuery{}, err
+	if len(remoteColumns) == 0 {
+		return SQLQuery{}, fmt.Errorf("one-to-many batch requires at least one remote FK column")
 	}
-
-	pkCols := introspection.PrimaryKeyColumns(relatedTable)
-	if len(pkCols) == 0 {
+	if len(introspection.PrimaryKeyColumns(relatedTable)) == 0 {
 		return SQLQuery{}, fmt.Errorf("%w: table %s", ErrNoPrimaryKey, relatedTable.Name)
 	}
 
-	cols := columnNames(relatedTable, columns)
-	columnList := strings.Join(cols, ", ")
-	placeholders := sq.Placeholders(len(values))
-
-	// Build ORDER BY clause from all primary key columns (for composite PKs)
-	var pkOrderClauses []string
-	for _, pk := range pkCols {
-		pkOrderClauses = append(pkOrderClauses, sqlutil.QuoteIdentifier(pk.Name))
-	}
-	orderClause := strings.Join(pkOrderClauses, ", ")
-	if orderBy != nil && len(orderBy.Columns) > 0 {
-		orderClause = strings.Join(orderByClauses(orderBy), ", ")
-	}
-
-	quotedRemoteColumn := sqlutil.QuoteIdentifier(remoteColumn)
-	quotedTable := relatedTable.SQLFrom()
-
-	whereSQL := ""
-	var whereArgs []interface{}
-	if where != nil && where.Condition != nil {
-		condSQL, condArgs, err := where.Condition.ToSql()
-		if err != nil {
-			return SQLQuery{}, err
-		}
-		whereSQL = " AND " + condSQL
-		whereArgs = condArgs
+	orderClause, err := batchOrderClause(relatedTable, orderBy)
+	if err != nil {
+		return SQLQuery{}, err
 	}
-	// Unfortunately as these are column lists, we can't use Squirrel to build
-	// the query so need to create it directly.
-	outerSelect := fmt.Sprintf("%s, %s", columnList, BatchParentAlias)
-	innerSelect := fmt.Sprintf("%s, %s AS %s", columnList, quotedRemoteColumn, BatchParentAlias)
-	query := fmt.Sprintf(
-		"SELECT %s FROM (SELECT %s, ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s) AS __rn FROM %s WHERE %s IN (%s)%s) AS __batch WHERE __rn > ? AND __rn <= ? ORDER BY %s, __rn",
-		outerSelect,
-		innerSelect,
-		quotedRemoteColumn,
-		orderClause,
-		quotedTable,
-		quotedRemoteColumn,
-		placeholders,
-		whereSQL,
-		BatchParentAlias,
-	)
-
-	args := append([]interface{}{}, values...)
-	args = append(args, whereArgs...)
-	args = append(args, offset, offset+limit)
-	return SQLQuery{SQL: query, Args: args}, nil
+	columnList := strings.Join(columnNames(relatedTable, columns), ", ")
+	return buildBatchWindowQuery(relatedTable.SQLFrom(), columnList, quotedColumnNames(remoteColumns), orderClause, values, limit, offset, where)
 }
 
 // quotedColumnNames returns the backtick-quoted column identifiers with no table prefix.
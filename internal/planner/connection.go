@@ -89,7 +89,7 @@ func parseConnectionArgs(
 	}
 
 	if options.limits != nil {
-		cost := EstimateCost(field, args, defaultLimit, options.fragments)
+		cost := EstimateTableCost(options.schema, table, field, args, defaultLimit, options.fragments)
 		if err := validateLimits(cost, *options.limits); err != nil {
 			return nil, err
 		}
@@ -245,10 +245,11 @@ func PlanConnection(
 }
 
 // PlanOneToManyConnection plans a connection for a one-to-many relationship.
+// remoteColumns and fkValues are positional and may span a composite key.
 func PlanOneToManyConnection(
 	table introspection.Table,
-	remoteColumn string,
-	fkValue interface{},
+	remoteColumns []string,
+	fkValues []interface{},
 	field *ast.Field,
 	args map[string]interface{},
 	opts ...PlanOption,
@@ -262,8 +263,11 @@ func PlanOneToManyConnection(
 		return nil, err
 	}
 
-	// FK filter: WHERE remoteColumn = fkValue
-	fkCondition := sq.Eq{sqlutil.QuoteIdentifier(remoteColumn): fkValue}
+	// FK filter: WHERE remoteColumns = fkValues
+	fkCondition, err := oneToManyKeyCondition(remoteColumns, fkValues)
+	if err != nil {
+		return nil, err
+	}
 
 	// Build root SQL with FK + seek
 	builder := sq.Select(columnNames(table, ca.selected)...).
@@ -286,11 +290,11 @@ func PlanOneToManyConnection(
 		return nil, err
 	}
 
-	countQuery, err := BuildOneToManyCountSQL(table, remoteColumn, fkValue, ca.whereClause)
+	countQuery, err := BuildOneToManyCountSQL(table, remoteColumns, fkValues, ca.whereClause)
 	if err != nil {
 		return nil, err
 	}
-	aggregateBase, err := BuildOneToManyAggregateBaseSQL(table, remoteColumn, fkValue, ca.whereClause)
+	aggregateBase, err := BuildOneToManyAggregateBaseSQL(table, remoteColumns, fkValues, ca.whereClause)
 	if err != nil {
 		return nil, err
 	}
@@ -457,11 +461,11 @@ func PlanEdgeListConnection(
 // BuildOneToManyCountSQL builds the count query for a one-to-many connection.
 func BuildOneToManyCountSQL(
 	table introspection.Table,
-	remoteColumn string,
-	fkValue interface{},
+	remoteColumns []string,
+	fkValues []interface{},
 	whereClause *WhereClause,
 ) (SQLQuery, error) {
-	base, err := BuildOneToManyAggregateBaseSQL(table, remoteColumn, fkValue, whereClause)
+	base, err := BuildOneToManyAggregateBaseSQL(table, remoteColumns, fkValues, whereClause)
 	if err != nil {
 		return SQLQuery{}, err
 	}
@@ -563,13 +567,17 @@ func BuildEdgeListCountSQL(
 // BuildOneToManyAggregateBaseSQL builds the base rowset query for one-to-many aggregates.
 func BuildOneToManyAggregateBaseSQL(
 	table introspection.Table,
-	remoteColumn string,
-	fkValue interface{},
+	remoteColumns []string,
+	fkValues []interface{},
 	whereClause *WhereClause,
 ) (SQLQuery, error) {
+	fkCondition, err := oneToManyKeyCondition(remoteColumns, fkValues)
+	if err != nil {
+		return SQLQuery{}, err
+	}
 	builder := sq.Select("*").
 		From(table.SQLFrom()).
-		Where(sq.Eq{sqlutil.QuoteIdentifier(remoteColumn): fkValue})
+		Where(fkCondition)
 
 	if whereClause != nil && whereClause.Condition != nil {
 		builder = builder.Where(whereClause.Condition)
@@ -582,6 +590,18 @@ func BuildOneToManyAggregateBaseSQL(
 	return SQLQuery{SQL: query, Args: args}, nil
 }
 
+// oneToManyKeyCondition matches the remote FK columns against a single parent key.
+func oneToManyKeyCondition(remoteColumns []string, fkValues []interface{}) (sq.Eq, error) {
+	if len(remoteColumns) == 0 || len(remoteColumns) != len(fkValues) {
+		return nil, fmt.Errorf("one-to-many key mapping width mismatch")
+	}
+	condition := make(sq.Eq, len(remoteColumns))
+	for i, col := range remoteColumns {
+		condition[sqlutil.QuoteIdentifier(col)] = fkValues[i]
+	}
+	return condition, nil
+}
+
 // BuildEdgeListAggregateBaseSQL builds the base rowset query for edge-list aggregates.
 func BuildEdgeListAggregateBaseSQL(
 	junctionTable introspection.Table,
@@ -688,9 +708,9 @@ func orderByClausesQualified(tableName string, orderBy *OrderBy) []string {
 // It uses offset=0 and limit=first+1 to allow per-parent hasNextPage detection.
 func PlanOneToManyConnectionBatch(
 	relatedTable introspection.Table,
-	remoteColumn string,
+	remoteColumns []string,
 	columns []introspection.Column,
-	parentValues []interface{},
+	parentValues []ParentTuple,
 	first int,
 	orderBy *OrderBy,
 	whereClause *WhereClause,
@@ -698,7 +718,7 @@ func PlanOneToManyConnectionBatch(
 	return PlanOneToManyBatch(
 		relatedTable,
 		columns,
-		remoteColumn,
+		remoteColumns,
 		parentValues,
 		first+1,
 		0,
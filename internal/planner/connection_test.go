@@ -598,7 +598,7 @@ func TestBuildOneToManyCountSQL_WithWhere(t *testing.T) {
 		Condition: sq.Eq{sqlutil.QuoteIdentifier("title"): "first"},
 	}
 
-	count, err := BuildOneToManyCountSQL(table, "user_id", 7, where)
+	count, err := BuildOneToManyCountSQL(table, []string{"user_id"}, []interface{}{7}, where)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -639,7 +639,7 @@ func TestPlanOneToManyConnectionBatch(t *testing.T) {
 		{Name: "user_id"},
 	}
 
-	query, err := PlanOneToManyConnectionBatch(table, "user_id", columns, []interface{}{1, 2}, 2, nil, nil)
+	query, err := PlanOneToManyConnectionBatch(table, []string{"user_id"}, columns, []ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 2, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -673,7 +673,7 @@ func TestPlanOneToManyConnectionBatch_FirstZero(t *testing.T) {
 		{Name: "user_id"},
 	}
 
-	query, err := PlanOneToManyConnectionBatch(table, "user_id", columns, []interface{}{1, 2}, 0, nil, nil)
+	query, err := PlanOneToManyConnectionBatch(table, []string{"user_id"}, columns, []ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 0, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
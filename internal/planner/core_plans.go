@@ -102,6 +102,30 @@ func PlanUniqueKeyLookup(table introspection.Table, columns []introspection.Colu
 	return SQLQuery{SQL: query, Args: args}, nil
 }
 
+// PlanRowMatching builds SQL selecting rows whose columns equal every value in match.
+// Mutations use it to confirm a child row belongs to a parent before modifying it.
+func PlanRowMatching(table introspection.Table, columns []introspection.Column, match map[string]interface{}) (SQLQuery, error) {
+	if len(match) == 0 {
+		return SQLQuery{}, fmt.Errorf("row match cannot be empty")
+	}
+
+	whereClause := sq.Eq{}
+	for col, val := range match {
+		whereClause[sqlutil.QuoteIdentifier(col)] = val
+	}
+
+	query, args, err := sq.Select(columnNames(table, columns)...).
+		From(table.SQLFrom()).
+		Where(whereClause).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return SQLQuery{}, err
+	}
+
+	return SQLQuery{SQL: query, Args: args}, nil
+}
+
 // PlanManyToOne builds SQL for a many-to-one lookup (FK -> parent table), including composite mappings.
 func PlanManyToOne(relatedTable introspection.Table, columns []introspection.Column, remoteColumns []string, fkValues []interface{}) (SQLQuery, error) {
 	if len(remoteColumns) == 0 || len(remoteColumns) != len(fkValues) {
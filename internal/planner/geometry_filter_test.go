@@ -0,0 +1,58 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tidb-graphql/internal/geojson"
+	"tidb-graphql/internal/introspection"
+)
+
+func TestBuildColumnFilter_Geometry(t *testing.T) {
+	col := introspection.Column{Name: "location", DataType: "point", SRID: 4326}
+	from := geojson.Geometry{Type: geojson.TypePoint, Point: geojson.Position{13.4, 52.5}}
+
+	sql, args := scalarFilterSQL(t, col, map[string]interface{}{
+		"distanceWithin": map[string]interface{}{"from": from, "distance": 1000.0},
+	})
+	assert.Contains(t, sql, "ST_Distance(`location`, ST_GeomFromWKB(?, ?)) <= ?")
+	assert.Equal(t, []interface{}{from.WKB(), uint32(4326), 1000.0}, args)
+
+	sql, args = scalarFilterSQL(t, col, map[string]interface{}{
+		"intersectsBox": map[string]interface{}{"minX": 13.0, "minY": 52.0, "maxX": 14.0, "maxY": 53.0},
+	})
+	assert.Contains(t, sql, "MBRIntersects(`location`, ST_GeomFromWKB(?, ?))")
+	assert.Equal(t, []interface{}{geojson.Box(13, 52, 14, 53).WKB(), uint32(4326)}, args)
+
+	sql, _ = scalarFilterSQL(t, col, map[string]interface{}{"isNull": true})
+	assert.Contains(t, sql, "`location` IS NULL")
+
+	invalid := []map[string]interface{}{
+		{"eq": from},
+		{"distanceWithin": map[string]interface{}{"from": from, "distance": -1.0}},
+		{"distanceWithin": map[string]interface{}{"from": "not geojson", "distance": 1.0}},
+		{"intersectsBox": map[string]interface{}{"minX": 14.0, "minY": 52.0, "maxX": 13.0, "maxY": 53.0}},
+	}
+	for _, filter := range invalid {
+		_, err := buildColumnFilter(col, "", filter)
+		assert.Error(t, err, filter)
+	}
+}
+
+func TestStorageValue_Geometry(t *testing.T) {
+	col := introspection.Column{Name: "location", DataType: "point", SRID: 4326}
+	g := geojson.Geometry{Type: geojson.TypePoint, Point: geojson.Position{1, 2}}
+
+	stored, err := StorageValue(col, g)
+	require.NoError(t, err)
+	assert.Equal(t, g.Stored(4326), stored)
+
+	stored, err = StorageValue(col, `{"type":"Point","coordinates":[1,2]}`)
+	require.NoError(t, err)
+	assert.Equal(t, g.Stored(4326), stored)
+
+	_, err = StorageValue(col, map[string]interface{}{"type": "Point"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/sqlutil"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// HierarchyDirection selects which rows PlanHierarchy returns for a
+// self-referencing table.
+type HierarchyDirection int
+
+const (
+	// HierarchyAncestors walks parent links upwards, nearest ancestor first.
+	HierarchyAncestors HierarchyDirection = iota
+	// HierarchyDescendants walks child links downwards, breadth first.
+	HierarchyDescendants
+	// HierarchyPath returns the ancestors plus the start row, root first.
+	HierarchyPath
+)
+
+const (
+	// DefaultHierarchyDepth is the traversal depth used when maxDepth is omitted.
+	DefaultHierarchyDepth = 10
+	// MaxHierarchyDepth is the largest maxDepth a hierarchy field accepts.
+	MaxHierarchyDepth = 100
+	// HierarchyDepthAlias is the extra result column carrying each row's
+	// distance from the start row.
+	HierarchyDepthAlias = "__hierarchy_depth"
+	// HierarchyTruncatedAlias is the extra result column of path queries that
+	// is true on the last row when maxDepth stopped the walk before the root.
+	HierarchyTruncatedAlias = "__hierarchy_truncated"
+
+	// hierarchyPathWidth sizes the visited-key column used for cycle detection.
+	// It holds MaxHierarchyDepth hex-encoded keys of about 80 bytes each.
+	hierarchyPathWidth = 16383
+)
+
+// PlanHierarchy plans a recursive CTE that walks a table's self-referencing
+// foreign key from the row whose key is startValue. Depth is tracked per row
+// and exposed as HierarchyDepthAlias; every step records the visited keys so
+// cyclic data terminates instead of recursing until maxDepth. Path queries
+// also expose HierarchyTruncatedAlias so callers can reject partial paths.
+//
+// args accepts maxDepth (1..MaxHierarchyDepth) and, for descendants, limit.
+func PlanHierarchy(
+	table introspection.Table,
+	ref introspection.SelfReference,
+	direction HierarchyDirection,
+	startValue interface{},
+	field *ast.Field,
+	args map[string]interface{},
+	opts ...PlanOption,
+) (*Plan, error) {
+	if ref.KeyColumn == "" || ref.ParentColumn == "" {
+		return nil, errors.New("hierarchy reference is incomplete")
+	}
+	options := applyOptions(opts)
+
+	maxDepth, err := parseHierarchyDepth(args)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := maxDepth
+	if direction == HierarchyPath {
+		limit = maxDepth + 1
+	}
+	if direction == HierarchyDescendants {
+		if err := validateNonNegativeIntArg(args, "limit"); err != nil {
+			return nil, err
+		}
+		limit = DefaultListLimit
+		if options.defaultLimit > 0 {
+			limit = options.defaultLimit
+		}
+		if value, ok := argInt(args, "limit"); ok {
+			limit = value
+		}
+	}
+
+	if options.limits != nil {
+		// Estimate with the effective depth and limit so omitted arguments
+		// are still charged for the rows the traversal can return.
+		costArgs := map[string]interface{}{"maxDepth": maxDepth, "limit": limit}
+		cost := EstimateTableCost(options.schema, table, field, costArgs, limit, options.fragments)
+		if err := validateLimits(cost, *options.limits); err != nil {
+			return nil, err
+		}
+	}
+
+	selectionField := field
+	if direction != HierarchyPath {
+		selectionField = hierarchyNodeField(field, options.fragments)
+	}
+	selected := SelectedColumns(table, selectionField, options.fragments)
+
+	query := buildHierarchyQuery(table, ref, direction, selected, startValue, maxDepth, limit)
+	return &Plan{Root: query, Table: table, Columns: selected}, nil
+}
+
+func parseHierarchyDepth(args map[string]interface{}) (int, error) {
+	raw, ok := args["maxDepth"]
+	if !ok || raw == nil {
+		return DefaultHierarchyDepth, nil
+	}
+	depth, ok := raw.(int)
+	if !ok {
+		return 0, errors.New("maxDepth must be an integer")
+	}
+	if depth < 1 || depth > MaxHierarchyDepth {
+		return 0, fmt.Errorf("maxDepth must be between 1 and %d", MaxHierarchyDepth)
+	}
+	return depth, nil
+}
+
+// hierarchyNodeField merges the node selections of a hierarchy edge field
+// into one field so SelectedColumns can read them.
+func hierarchyNodeField(field *ast.Field, fragments map[string]ast.Definition) *ast.Field {
+	selections := hierarchyDataSelections(field, fragments)
+	if len(selections) == 0 {
+		return nil
+	}
+	return &ast.Field{SelectionSet: &ast.SelectionSet{Selections: selections}}
+}
+
+func buildHierarchyQuery(
+	table introspection.Table,
+	ref introspection.SelfReference,
+	direction HierarchyDirection,
+	selected []introspection.Column,
+	startValue interface{},
+	maxDepth, limit int,
+) SQLQuery {
+	from := table.SQLFrom()
+	key := sqlutil.QuoteIdentifier(ref.KeyColumn)
+	parent := sqlutil.QuoteIdentifier(ref.ParentColumn)
+
+	// The anchor is the start row at depth 0. Each recursive step joins one
+	// level further and skips rows already on the visited path.
+	var cte string
+	if direction == HierarchyDescendants {
+		cte = fmt.Sprintf(
+			"__hierarchy (__key, __depth, __path) AS ("+
+				"SELECT %[2]s, 0, CAST(CONCAT(',', HEX(%[2]s), ',') AS CHAR(%[4]d)) FROM %[1]s WHERE %[2]s = ? "+
+				"UNION ALL "+
+				"SELECT __step.%[2]s, __hierarchy.__depth + 1, CONCAT(__hierarchy.__path, HEX(__step.%[2]s), ',') "+
+				"FROM %[1]s AS __step JOIN __hierarchy ON __step.%[3]s = __hierarchy.__key "+
+				"WHERE __hierarchy.__depth < ? AND LOCATE(CONCAT(',', HEX(__step.%[2]s), ','), __hierarchy.__path) = 0)",
+			from, key, parent, hierarchyPathWidth,
+		)
+	} else {
+		cte = fmt.Sprintf(
+			"__hierarchy (__key, __parent_key, __depth, __path) AS ("+
+				"SELECT %[2]s, %[3]s, 0, CAST(CONCAT(',', HEX(%[2]s), ',') AS CHAR(%[4]d)) FROM %[1]s WHERE %[2]s = ? "+
+				"UNION ALL "+
+				"SELECT __step.%[2]s, __step.%[3]s, __hierarchy.__depth + 1, CONCAT(__hierarchy.__path, HEX(__step.%[2]s), ',') "+
+				"FROM %[1]s AS __step JOIN __hierarchy ON __step.%[2]s = __hierarchy.__parent_key "+
+				"WHERE __hierarchy.__depth < ? AND LOCATE(CONCAT(',', HEX(__step.%[2]s), ','), __hierarchy.__path) = 0)",
+			from, key, parent, hierarchyPathWidth,
+		)
+	}
+
+	depthFilter := "__hierarchy.__depth > 0"
+	orderBy := fmt.Sprintf("__hierarchy.__depth, __node.%s", key)
+	switch direction {
+	case HierarchyAncestors:
+		orderBy = "__hierarchy.__depth"
+	case HierarchyPath:
+		depthFilter = "__hierarchy.__depth >= 0"
+		orderBy = "__hierarchy.__depth DESC"
+	}
+
+	selectList := append(qualifiedColumnNames("__node", columnNamesFromColumns(selected)), "__hierarchy.__depth AS "+HierarchyDepthAlias)
+	args := []interface{}{startValue, maxDepth}
+	if direction == HierarchyPath {
+		// The walk was cut short when the deepest row still has an existing
+		// parent that is not already on the path (a cycle ends the walk too).
+		selectList = append(selectList, fmt.Sprintf(
+			"(__hierarchy.__depth = ? AND LOCATE(CONCAT(',', HEX(__hierarchy.__parent_key), ','), __hierarchy.__path) = 0 "+
+				"AND EXISTS (SELECT 1 FROM %s AS __parent WHERE __parent.%s = __hierarchy.__parent_key)) AS %s",
+			from, key, HierarchyTruncatedAlias,
+		))
+		args = append(args, maxDepth)
+	}
+	query := fmt.Sprintf(
+		"WITH RECURSIVE %s SELECT %s FROM __hierarchy JOIN %s AS __node ON __node.%s = __hierarchy.__key WHERE %s ORDER BY %s LIMIT ?",
+		cte,
+		strings.Join(selectList, ", "),
+		from,
+		key,
+		depthFilter,
+		orderBy,
+	)
+	return SQLQuery{SQL: query, Args: append(args, limit)}
+}
@@ -0,0 +1,217 @@
+package planner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"tidb-graphql/internal/introspection"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hierarchyTestTable() (introspection.Table, introspection.SelfReference) {
+	table := introspection.Table{
+		Name: "categories",
+		Columns: []introspection.Column{
+			{Name: "id", IsPrimaryKey: true},
+			{Name: "name"},
+			{Name: "parent_id", IsNullable: true},
+		},
+		ForeignKeys: []introspection.ForeignKey{
+			{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ConstraintName: "fk_parent"},
+		},
+	}
+	ref, _ := introspection.HierarchyReference(table)
+	return table, ref
+}
+
+func hierarchyTestField(name string, args map[string]string, selections ...ast.Selection) *ast.Field {
+	field := &ast.Field{Name: &ast.Name{Value: name}}
+	for argName, value := range args {
+		field.Arguments = append(field.Arguments, &ast.Argument{
+			Name:  &ast.Name{Value: argName},
+			Value: &ast.IntValue{Value: value},
+		})
+	}
+	if len(selections) > 0 {
+		field.SelectionSet = &ast.SelectionSet{Selections: selections}
+	}
+	return field
+}
+
+func hierarchyEdgeSelections(nodeFields ...string) []ast.Selection {
+	nodeSelections := make([]ast.Selection, len(nodeFields))
+	for i, name := range nodeFields {
+		nodeSelections[i] = &ast.Field{Name: &ast.Name{Value: name}}
+	}
+	return []ast.Selection{
+		&ast.Field{Name: &ast.Name{Value: "depth"}},
+		&ast.Field{Name: &ast.Name{Value: "node"}, SelectionSet: &ast.SelectionSet{Selections: nodeSelections}},
+	}
+}
+
+func TestPlanHierarchy_Descendants(t *testing.T) {
+	table, ref := hierarchyTestTable()
+	field := hierarchyTestField("descendants", nil, hierarchyEdgeSelections("name")...)
+
+	plan, err := PlanHierarchy(table, ref, HierarchyDescendants, 5, field, map[string]interface{}{"maxDepth": 3}, WithDefaultListLimit(25))
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"WITH RECURSIVE __hierarchy (__key, __depth, __path) AS ("+
+			"SELECT `id`, 0, CAST(CONCAT(',', HEX(`id`), ',') AS CHAR(16383)) FROM `categories` WHERE `id` = ? "+
+			"UNION ALL "+
+			"SELECT __step.`id`, __hierarchy.__depth + 1, CONCAT(__hierarchy.__path, HEX(__step.`id`), ',') "+
+			"FROM `categories` AS __step JOIN __hierarchy ON __step.`parent_id` = __hierarchy.__key "+
+			"WHERE __hierarchy.__depth < ? AND LOCATE(CONCAT(',', HEX(__step.`id`), ','), __hierarchy.__path) = 0) "+
+			"SELECT __node.`id`, __node.`name`, __hierarchy.__depth AS __hierarchy_depth "+
+			"FROM __hierarchy JOIN `categories` AS __node ON __node.`id` = __hierarchy.__key "+
+			"WHERE __hierarchy.__depth > 0 ORDER BY __hierarchy.__depth, __node.`id` LIMIT ?",
+		plan.Root.SQL)
+	assert.Equal(t, []interface{}{5, 3, 25}, plan.Root.Args)
+	assert.Equal(t, []string{"id", "name"}, columnNamesOnly(plan.Columns))
+}
+
+func TestPlanHierarchy_AncestorsAndPath(t *testing.T) {
+	table, ref := hierarchyTestTable()
+
+	ancestors, err := PlanHierarchy(table, ref, HierarchyAncestors, 7, hierarchyTestField("ancestors", nil, hierarchyEdgeSelections("name")...), nil)
+	require.NoError(t, err)
+	assert.Contains(t, ancestors.Root.SQL, "__hierarchy (__key, __parent_key, __depth, __path)")
+	assert.Contains(t, ancestors.Root.SQL, "JOIN __hierarchy ON __step.`id` = __hierarchy.__parent_key")
+	assert.True(t, strings.HasSuffix(ancestors.Root.SQL, "WHERE __hierarchy.__depth > 0 ORDER BY __hierarchy.__depth LIMIT ?"), ancestors.Root.SQL)
+	assert.Equal(t, []interface{}{7, DefaultHierarchyDepth, DefaultHierarchyDepth}, ancestors.Root.Args)
+
+	pathField := hierarchyTestField("path", map[string]string{"maxDepth": "4"}, &ast.Field{Name: &ast.Name{Value: "name"}})
+	path, err := PlanHierarchy(table, ref, HierarchyPath, 7, pathField, map[string]interface{}{"maxDepth": 4})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(path.Root.SQL, "WHERE __hierarchy.__depth >= 0 ORDER BY __hierarchy.__depth DESC LIMIT ?"), path.Root.SQL)
+	assert.Contains(t, path.Root.SQL, "__hierarchy.__depth AS __hierarchy_depth, (__hierarchy.__depth = ? AND "+
+		"LOCATE(CONCAT(',', HEX(__hierarchy.__parent_key), ','), __hierarchy.__path) = 0 AND "+
+		"EXISTS (SELECT 1 FROM `categories` AS __parent WHERE __parent.`id` = __hierarchy.__parent_key)) AS __hierarchy_truncated ")
+	assert.NotContains(t, ancestors.Root.SQL, HierarchyTruncatedAlias)
+	assert.Equal(t, []interface{}{7, 4, 4, 5}, path.Root.Args)
+	assert.Equal(t, []string{"id", "name"}, columnNamesOnly(path.Columns))
+}
+
+func TestPlanHierarchy_InvalidArgs(t *testing.T) {
+	table, ref := hierarchyTestTable()
+	field := hierarchyTestField("descendants", nil, hierarchyEdgeSelections("name")...)
+
+	for _, args := range []map[string]interface{}{
+		{"maxDepth": 0},
+		{"maxDepth": MaxHierarchyDepth + 1},
+		{"maxDepth": "3"},
+		{"limit": -1},
+	} {
+		_, err := PlanHierarchy(table, ref, HierarchyDescendants, 1, field, args)
+		assert.Error(t, err, "args %v", args)
+	}
+
+	_, err := PlanHierarchy(table, introspection.SelfReference{}, HierarchyDescendants, 1, field, nil)
+	assert.Error(t, err)
+}
+
+func TestPlanHierarchy_LimitsChargeEffectiveDepthAndLimit(t *testing.T) {
+	table, ref := hierarchyTestTable()
+	field := hierarchyTestField("descendants", nil, hierarchyEdgeSelections("name")...)
+
+	// No limit argument: the default list limit still bounds the estimate.
+	_, err := PlanHierarchy(table, ref, HierarchyDescendants, 1, field, nil,
+		WithDefaultListLimit(50), WithLimits(PlanLimits{MaxRows: 60}))
+	var limitErr *LimitError
+	require.True(t, errors.As(err, &limitErr), "expected limit error, got %v", err)
+	assert.Equal(t, "rows", limitErr.Limit)
+	assert.Equal(t, 150, limitErr.Actual)
+
+	_, err = PlanHierarchy(table, ref, HierarchyAncestors, 1, field, map[string]interface{}{"maxDepth": 20},
+		WithLimits(PlanLimits{MaxRows: 60}))
+	require.NoError(t, err)
+}
+
+func TestEstimateCostHierarchyFields(t *testing.T) {
+	// descendants(maxDepth:3, limit:20) { depth node { name } }
+	descendants := hierarchyTestField("descendants", map[string]string{"maxDepth": "3", "limit": "20"}, hierarchyEdgeSelections("name")...)
+	cost := EstimateCost(descendants, nil, DefaultListLimit, nil)
+	require.Equal(t, 2, cost.Depth)
+	require.Equal(t, 60, cost.Rows)
+	require.Equal(t, 41, cost.Complexity)
+
+	// categories(limit:2) { ancestors(maxDepth:4) { node { name } } }
+	ancestors := hierarchyTestField("ancestors", map[string]string{"maxDepth": "4"},
+		&ast.Field{Name: &ast.Name{Value: "node"}, SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "name"}},
+		}}})
+	root := hierarchyTestField("categories", map[string]string{"limit": "2"}, ancestors)
+	cost = EstimateCost(root, nil, DefaultListLimit, nil)
+	require.Equal(t, 3, cost.Depth)
+	require.Equal(t, 18, cost.Rows)
+}
+
+func TestEstimateCostHierarchyFieldsWithoutArguments(t *testing.T) {
+	table, _ := hierarchyTestTable()
+	schema := &introspection.Schema{Tables: []introspection.Table{table}}
+
+	// categories(limit:2) { descendants { depth node { name } } }
+	descendants := hierarchyTestField("descendants", nil, hierarchyEdgeSelections("name")...)
+	root := hierarchyTestField("categories", map[string]string{"limit": "2"}, descendants)
+	cost := EstimateTableCost(schema, table, root, nil, 25, nil)
+	require.Equal(t, 3, cost.Depth)
+	// Each category walks up to the default list limit of descendants.
+	require.Equal(t, 2+2*(25+25+25), cost.Rows)
+
+	// categories(limit:2) { path { name } }
+	path := hierarchyTestField("path", nil, &ast.Field{Name: &ast.Name{Value: "name"}})
+	root = hierarchyTestField("categories", map[string]string{"limit": "2"}, path)
+	cost = EstimateTableCost(schema, table, root, nil, 25, nil)
+	// The default depth plus the start row.
+	require.Equal(t, 2+2*(2*(DefaultHierarchyDepth+1)), cost.Rows)
+
+	// Without the table, only a maxDepth argument marks a hierarchy field.
+	cost = EstimateCost(root, nil, 25, nil)
+	require.Equal(t, 2+2*2, cost.Rows)
+
+	// A column named path keeps the name, so it is not a hierarchy field.
+	table.Columns = append(table.Columns, introspection.Column{Name: "path"})
+	scalar := hierarchyTestField("categories", map[string]string{"limit": "2"}, &ast.Field{Name: &ast.Name{Value: "path"}})
+	cost = EstimateTableCost(schema, table, scalar, nil, 25, nil)
+	require.Equal(t, 4, cost.Rows)
+}
+
+func TestEstimateCostHierarchyNamesWithoutSelfReference(t *testing.T) {
+	// orders has no self-reference; path is a many-to-one relationship to
+	// routes, and descendants is a JSON column with subfield selections.
+	orders := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "id", IsPrimaryKey: true},
+			{Name: "route_id"},
+			{Name: "descendants", DataType: "json"},
+		},
+		Relationships: []introspection.Relationship{
+			{IsManyToOne: true, LocalColumns: []string{"route_id"}, RemoteTable: "routes", RemoteColumns: []string{"id"}, GraphQLFieldName: "path"},
+		},
+	}
+	routes := introspection.Table{
+		Name:    "routes",
+		Columns: []introspection.Column{{Name: "id", IsPrimaryKey: true}, {Name: "name"}},
+	}
+	schema := &introspection.Schema{Tables: []introspection.Table{orders, routes}}
+
+	// orders(limit:2) { path { node { name } } descendants { name } }
+	node := &ast.Field{Name: &ast.Name{Value: "node"}, SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+		&ast.Field{Name: &ast.Name{Value: "name"}},
+	}}}
+	root := hierarchyTestField("orders", map[string]string{"limit": "2"},
+		hierarchyTestField("path", nil, node),
+		hierarchyTestField("descendants", nil, &ast.Field{Name: &ast.Name{Value: "name"}}),
+	)
+	cost := EstimateTableCost(schema, orders, root, nil, 25, nil)
+	// Each order has one route with one node and one JSON value with one name.
+	require.Equal(t, 2+2*((1+1*(1+1))+(1+1)), cost.Rows)
+	// node is a field of the route, not an unwrapped edge.
+	require.Equal(t, 4, cost.Depth)
+}
@@ -3,6 +3,8 @@ package planner
 import (
 	"fmt"
 
+	"tidb-graphql/internal/introspection"
+
 	"github.com/graphql-go/graphql/language/ast"
 )
 
@@ -15,6 +17,49 @@ type PlanLimits struct {
 	MaxRows        int
 	MaxStatements  int
 	MaxRowsPerNode int
+	// Override names the per-role or per-operation override these limits come
+	// from. Empty means the server-wide limits.
+	Override string
+}
+
+// LimitError reports a query rejected by a plan limit.
+type LimitError struct {
+	// Limit is the exceeded limit: depth, complexity, rows, statements or rows_per_node.
+	Limit    string
+	Max      int
+	Actual   int
+	Override string
+	message  string
+}
+
+func (e *LimitError) Error() string {
+	return e.message
+}
+
+// Extensions implements gqlerrors.ExtendedError so clients can see which limit
+// and which override rejected the query.
+func (e *LimitError) Extensions() map[string]interface{} {
+	override := e.Override
+	if override == "" {
+		override = "global"
+	}
+	return map[string]interface{}{
+		"code":     "LIMIT_EXCEEDED",
+		"limit":    e.Limit,
+		"max":      e.Max,
+		"actual":   e.Actual,
+		"override": override,
+	}
+}
+
+func newLimitError(limits PlanLimits, limit string, max, actual int, message string) error {
+	return &LimitError{
+		Limit:    limit,
+		Max:      max,
+		Actual:   actual,
+		Override: limits.Override,
+		message:  message,
+	}
 }
 
 // PlanCost captures estimated cost for a query.
@@ -26,14 +71,27 @@ type PlanCost struct {
 }
 
 // EstimateCost estimates cost based on the field selection and arguments.
+// Without table context only fields passing maxDepth count as hierarchy walks;
+// use EstimateTableCost when the table is known.
 func EstimateCost(field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition) PlanCost {
+	return estimateCost(field, args, fallbackLimit, fragments, costScope{})
+}
+
+// EstimateTableCost is EstimateCost for a field returning rows of table. It
+// recognises hierarchy fields from the schema, following relationships
+// through schema, so omitted maxDepth and limit arguments are still charged.
+func EstimateTableCost(schema *introspection.Schema, table introspection.Table, field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition) PlanCost {
+	return estimateCost(field, args, fallbackLimit, fragments, costScope{schema: schema, table: &table})
+}
+
+func estimateCost(field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition, scope costScope) PlanCost {
 	if field == nil {
 		return PlanCost{}
 	}
 
-	depth := selectionDepth(field, 1, fragments)
-	rows := estimateRowsRecursive(field, args, fallbackLimit, fragments)
-	complexity := estimateComplexityRecursive(field, args, fallbackLimit, fragments)
+	depth := selectionDepth(field, args, 1, fragments, scope)
+	rows := estimateRowsRecursive(field, args, fallbackLimit, fragments, scope)
+	complexity := estimateComplexityRecursive(field, args, fallbackLimit, fragments, scope)
 
 	return PlanCost{
 		Depth:      depth,
@@ -45,19 +103,24 @@ func EstimateCost(field *ast.Field, args map[string]interface{}, fallbackLimit i
 
 func validateLimits(cost PlanCost, limits PlanLimits) error {
 	if limits.MaxDepth > 0 && cost.Depth > limits.MaxDepth {
-		return fmt.Errorf("query exceeds maximum depth of %d (depth: %d)", limits.MaxDepth, cost.Depth)
+		return newLimitError(limits, "depth", limits.MaxDepth, cost.Depth,
+			fmt.Sprintf("query exceeds maximum depth of %d (depth: %d)", limits.MaxDepth, cost.Depth))
 	}
 	if limits.MaxComplexity > 0 && cost.Complexity > limits.MaxComplexity {
-		return fmt.Errorf("query exceeds maximum complexity of %d (complexity: %d)", limits.MaxComplexity, cost.Complexity)
+		return newLimitError(limits, "complexity", limits.MaxComplexity, cost.Complexity,
+			fmt.Sprintf("query exceeds maximum complexity of %d (complexity: %d)", limits.MaxComplexity, cost.Complexity))
 	}
 	if limits.MaxRows > 0 && cost.Rows > limits.MaxRows {
-		return fmt.Errorf("query exceeds maximum rows of %d (estimated: %d)", limits.MaxRows, cost.Rows)
+		return newLimitError(limits, "rows", limits.MaxRows, cost.Rows,
+			fmt.Sprintf("query exceeds maximum rows of %d (estimated: %d)", limits.MaxRows, cost.Rows))
 	}
 	if limits.MaxStatements > 0 && cost.Statements > limits.MaxStatements {
-		return fmt.Errorf("query exceeds maximum statement count of %d (estimated: %d)", limits.MaxStatements, cost.Statements)
+		return newLimitError(limits, "statements", limits.MaxStatements, cost.Statements,
+			fmt.Sprintf("query exceeds maximum statement count of %d (estimated: %d)", limits.MaxStatements, cost.Statements))
 	}
 	if limits.MaxRowsPerNode > 0 && cost.Rows > limits.MaxRowsPerNode {
-		return fmt.Errorf("query exceeds maximum rows per node of %d (estimated: %d)", limits.MaxRowsPerNode, cost.Rows)
+		return newLimitError(limits, "rows_per_node", limits.MaxRowsPerNode, cost.Rows,
+			fmt.Sprintf("query exceeds maximum rows per node of %d (estimated: %d)", limits.MaxRowsPerNode, cost.Rows))
 	}
 	return nil
 }
@@ -238,7 +301,147 @@ func connectionDataSelections(field *ast.Field, fragments map[string]ast.Definit
 	return result
 }
 
-func selectionDepth(field *ast.Field, current int, fragments map[string]ast.Definition) int {
+// costScope is the table whose rows a field returns, so hierarchy fields can
+// be recognised from the schema rather than by name. The zero value knows no
+// table; only fields passing maxDepth then count as hierarchy walks.
+type costScope struct {
+	schema *introspection.Schema
+	table  *introspection.Table
+}
+
+// hierarchyField returns the direction name (ancestors, descendants or path)
+// when field is a hierarchy walk of the scope's table, or "" otherwise.
+// maxDepth is only accepted by hierarchy fields, so passing it is enough.
+func (s costScope) hierarchyField(field *ast.Field, args map[string]interface{}) string {
+	if field == nil || field.Name == nil {
+		return ""
+	}
+	name := field.Name.Value
+	switch name {
+	case "ancestors", "descendants", "path":
+	default:
+		return ""
+	}
+	if hasArgNamed(field, "maxDepth") {
+		return name
+	}
+	if _, ok := argInt(args, "maxDepth"); ok {
+		return name
+	}
+	if s.table == nil {
+		return ""
+	}
+	if _, ok := introspection.HierarchyReference(*s.table); !ok {
+		return ""
+	}
+	// Columns and relationships keep their name; the hierarchy field is skipped.
+	for _, col := range s.table.Columns {
+		if introspection.GraphQLFieldName(col) == name {
+			return ""
+		}
+	}
+	for _, rel := range s.table.Relationships {
+		if rel.GraphQLFieldName == name {
+			return ""
+		}
+	}
+	return name
+}
+
+// child returns the scope of a selected field: the same table for hierarchy
+// walks, the related table for relationships, and no table otherwise.
+func (s costScope) child(field *ast.Field) costScope {
+	if s.table == nil || field == nil || field.Name == nil {
+		return costScope{}
+	}
+	if s.hierarchyField(field, nil) != "" {
+		return s
+	}
+	for _, rel := range s.table.Relationships {
+		if rel.GraphQLFieldName != field.Name.Value {
+			continue
+		}
+		key, name := rel.RemoteTableKey, rel.RemoteTable
+		if rel.IsEdgeList {
+			key, name = rel.JunctionTableKey, rel.JunctionTable
+		}
+		if table := s.lookupTable(key.MapKey(), name, key.IsZero()); table != nil {
+			return costScope{schema: s.schema, table: table}
+		}
+		return costScope{}
+	}
+	return costScope{}
+}
+
+func (s costScope) lookupTable(mapKey, name string, bareName bool) *introspection.Table {
+	if s.schema == nil {
+		return nil
+	}
+	if !bareName {
+		for i := range s.schema.Tables {
+			if s.schema.Tables[i].MapKey() == mapKey {
+				return &s.schema.Tables[i]
+			}
+		}
+	}
+	for i := range s.schema.Tables {
+		if s.schema.Tables[i].Name == name {
+			return &s.schema.Tables[i]
+		}
+	}
+	return nil
+}
+
+// hierarchyDataSelections unwraps the node field of hierarchy edges, keeping
+// the remaining selections (depth, or the row fields of a path) as they are.
+func hierarchyDataSelections(field *ast.Field, fragments map[string]ast.Definition) []ast.Selection {
+	if field == nil || field.SelectionSet == nil {
+		return nil
+	}
+	if field.Name != nil && field.Name.Value == "path" {
+		// path returns rows, not edges; a field named node is a real field.
+		return field.SelectionSet.Selections
+	}
+
+	var result []ast.Selection
+
+	var visit func(selections []ast.Selection)
+	visit = func(selections []ast.Selection) {
+		for _, sel := range selections {
+			switch s := sel.(type) {
+			case *ast.Field:
+				if s.Name != nil && s.Name.Value == "node" && s.SelectionSet != nil {
+					result = append(result, s.SelectionSet.Selections...)
+					continue
+				}
+				result = append(result, s)
+			case *ast.InlineFragment:
+				if s.SelectionSet != nil {
+					visit(s.SelectionSet.Selections)
+				}
+			case *ast.FragmentSpread:
+				if fragments == nil || s.Name == nil {
+					continue
+				}
+				def, ok := fragments[s.Name.Value]
+				if !ok {
+					continue
+				}
+				frag, ok := def.(*ast.FragmentDefinition)
+				if !ok || frag.SelectionSet == nil {
+					continue
+				}
+				visit(frag.SelectionSet.Selections)
+			}
+		}
+	}
+
+	visit(field.SelectionSet.Selections)
+
+	return result
+}
+
+func selectionDepth(field *ast.Field, args map[string]interface{}, current int, fragments map[string]ast.Definition, scope costScope) int {
 	if field.SelectionSet == nil || len(field.SelectionSet.Selections) == 0 {
 		return current
 	}
@@ -251,6 +454,8 @@ func selectionDepth(field *ast.Field, current int, fragments map[string]ast.Defi
 		if len(selections) == 0 {
 			return current
 		}
+	} else if scope.hierarchyField(field, args) != "" {
+		selections = hierarchyDataSelections(field, fragments)
 	}
 
 	maxDepth := current
@@ -259,7 +464,7 @@ func selectionDepth(field *ast.Field, current int, fragments map[string]ast.Defi
 		if !ok {
 			continue
 		}
-		depth := selectionDepth(sub, current+1, fragments)
+		depth := selectionDepth(sub, nil, current+1, fragments, scope.child(sub))
 		if depth > maxDepth {
 			maxDepth = depth
 		}
@@ -267,12 +472,12 @@ func selectionDepth(field *ast.Field, current int, fragments map[string]ast.Defi
 	return maxDepth
 }
 
-func estimateRowsRecursive(field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition) int {
+func estimateRowsRecursive(field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition, scope costScope) int {
 	if field == nil {
 		return 0
 	}
 
-	limit := listLimitForField(field, args, fallbackLimit)
+	limit := listLimitForField(field, args, fallbackLimit, scope)
 	rows := limit
 
 	if field.SelectionSet == nil || len(field.SelectionSet.Selections) == 0 {
@@ -286,6 +491,8 @@ func estimateRowsRecursive(field *ast.Field, args map[string]interface{}, fallba
 		if len(selections) == 0 {
 			return rows
 		}
+	} else if scope.hierarchyField(field, args) != "" {
+		selections = hierarchyDataSelections(field, fragments)
 	}
 
 	for _, selection := range selections {
@@ -293,19 +500,19 @@ func estimateRowsRecursive(field *ast.Field, args map[string]interface{}, fallba
 		if !ok {
 			continue
 		}
-		childRows := estimateRowsRecursive(sub, nil, fallbackLimit, fragments)
+		childRows := estimateRowsRecursive(sub, nil, fallbackLimit, fragments, scope.child(sub))
 		rows += limit * childRows
 	}
 
 	return rows
 }
 
-func estimateComplexityRecursive(field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition) int {
+func estimateComplexityRecursive(field *ast.Field, args map[string]interface{}, fallbackLimit int, fragments map[string]ast.Definition, scope costScope) int {
 	if field == nil {
 		return 0
 	}
 
-	limit := listLimitForField(field, args, fallbackLimit)
+	limit := listLimitForField(field, args, fallbackLimit, scope)
 	complexity := 1
 
 	if field.SelectionSet == nil || len(field.SelectionSet.Selections) == 0 {
@@ -319,6 +526,8 @@ func estimateComplexityRecursive(field *ast.Field, args map[string]interface{},
 		if len(selections) == 0 {
 			return complexity * limit
 		}
+	} else if scope.hierarchyField(field, args) != "" {
+		selections = hierarchyDataSelections(field, fragments)
 	}
 
 	for _, selection := range selections {
@@ -326,7 +535,7 @@ func estimateComplexityRecursive(field *ast.Field, args map[string]interface{},
 		if !ok {
 			continue
 		}
-		complexity += limit * estimateComplexityRecursive(sub, nil, fallbackLimit, fragments)
+		complexity += limit * estimateComplexityRecursive(sub, nil, fallbackLimit, fragments, scope.child(sub))
 	}
 
 	return complexity
@@ -372,12 +581,12 @@ func argInt(args map[string]interface{}, key string) (int, bool) {
 	return intVal, true
 }
 
-func listLimitForField(field *ast.Field, args map[string]interface{}, fallback int) int {
+func listLimitForField(field *ast.Field, args map[string]interface{}, fallback int, scope costScope) int {
 	if !hasLimitArg(field) && !hasFirstArg(field) && !hasLastArg(field) {
 		if _, ok := argInt(args, "limit"); !ok {
 			if _, ok := argInt(args, "first"); !ok {
 				if _, ok := argInt(args, "last"); !ok {
-					return 1
+					return hierarchyDepthLimit(field, args, fallback, scope)
 				}
 			}
 		}
@@ -406,6 +615,28 @@ func listLimitForField(field *ast.Field, args map[string]interface{}, fallback i
 	return fallback
 }
 
+// hierarchyDepthLimit bounds the rows of a field without a page size. A
+// hierarchy walk returns at most one row per level (plus the start row for
+// path), descendants are capped by the default list limit like the resolver
+// does, and anything else is one row.
+func hierarchyDepthLimit(field *ast.Field, args map[string]interface{}, fallback int, scope costScope) int {
+	direction := scope.hierarchyField(field, args)
+	if direction == "" {
+		return 1
+	}
+	depth, ok := argInt(args, "maxDepth")
+	if !ok {
+		depth = maxDepthFromAST(field, DefaultHierarchyDepth)
+	}
+	switch direction {
+	case "descendants":
+		return fallback
+	case "path":
+		return depth + 1
+	}
+	return depth
+}
+
 func hasLimitArg(field *ast.Field) bool {
 	return hasArgNamed(field, "limit")
 }
@@ -477,6 +708,28 @@ func lastFromAST(field *ast.Field, fallback int) int {
 	return fallback
 }
 
+func maxDepthFromAST(field *ast.Field, fallback int) int {
+	if field == nil {
+		return fallback
+	}
+	for _, arg := range field.Arguments {
+		if arg == nil || arg.Name == nil || arg.Value == nil {
+			continue
+		}
+		if arg.Name.Value != "maxDepth" {
+			continue
+		}
+		if intVal, ok := arg.Value.(*ast.IntValue); ok {
+			if intVal.Value != "" {
+				if parsed, err := parseInt(intVal.Value); err == nil {
+					return parsed
+				}
+			}
+		}
+	}
+	return fallback
+}
+
 func parseInt(value string) (int, error) {
 	var result int
 	_, err := fmt.Sscanf(value, "%d", &result)
@@ -305,3 +305,22 @@ func TestEstimateCostScalarOnly(t *testing.T) {
 	require.Equal(t, 2, cost.Rows)
 	require.Equal(t, 2, cost.Complexity)
 }
+
+func TestValidateLimits_ReportsLimitAndOverride(t *testing.T) {
+	err := validateLimits(PlanCost{Depth: 3, Complexity: 500}, PlanLimits{MaxDepth: 5, MaxComplexity: 100, Override: "reporting"})
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "query exceeds maximum complexity of 100 (complexity: 500)", err.Error())
+	require.Equal(t, map[string]interface{}{
+		"code":     "LIMIT_EXCEEDED",
+		"limit":    "complexity",
+		"max":      100,
+		"actual":   500,
+		"override": "reporting",
+	}, limitErr.Extensions())
+
+	err = validateLimits(PlanCost{Depth: 6}, PlanLimits{MaxDepth: 5})
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "depth", limitErr.Extensions()["limit"])
+	require.Equal(t, "global", limitErr.Extensions()["override"])
+}
@@ -99,3 +99,26 @@ func validatePKValues(table introspection.Table, pkValues map[string]interface{}
 	}
 	return nil
 }
+
+// PlanDeleteMatching builds SQL for deleting rows whose columns equal every value in match.
+// It backs scoped nested deletes and many-to-many disconnects, so match must not be empty.
+func PlanDeleteMatching(table introspection.Table, match map[string]interface{}) (SQLQuery, error) {
+	if len(match) == 0 {
+		return SQLQuery{}, fmt.Errorf("delete match cannot be empty")
+	}
+
+	where := sq.Eq{}
+	for col, val := range match {
+		where[sqlutil.QuoteIdentifier(col)] = val
+	}
+
+	query, args, err := sq.Delete(table.SQLFrom()).
+		Where(where).
+		PlaceholderFormat(sq.Question).
+		ToSql()
+	if err != nil {
+		return SQLQuery{}, err
+	}
+
+	return SQLQuery{SQL: query, Args: args}, nil
+}
@@ -364,3 +364,27 @@ func TestPlanUpdate_NullValue(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, planned.SQL, "`bio` = ?")
 }
+
+func TestPlanDeleteMatching_ScopesByAllColumns(t *testing.T) {
+	table := introspection.Table{
+		Name: "product_tags",
+		Columns: []introspection.Column{
+			{Name: "product_id", IsPrimaryKey: true},
+			{Name: "tag_id", IsPrimaryKey: true},
+		},
+	}
+
+	planned, err := PlanDeleteMatching(table, map[string]interface{}{"product_id": 1, "tag_id": 2})
+	require.NoError(t, err)
+	assert.Contains(t, planned.SQL, "DELETE FROM `product_tags`")
+	assert.Contains(t, planned.SQL, "`product_id` = ?")
+	assert.Contains(t, planned.SQL, "`tag_id` = ?")
+	assert.Len(t, planned.Args, 2)
+}
+
+func TestPlanDeleteMatching_EmptyMatchReturnsError(t *testing.T) {
+	table := introspection.Table{Name: "product_tags"}
+
+	_, err := PlanDeleteMatching(table, nil)
+	require.Error(t, err)
+}
@@ -31,7 +31,7 @@ func OrderByOptions(table introspection.Table) map[string][]string {
 	}
 
 	for _, index := range table.Indexes {
-		if len(index.Columns) == 0 {
+		if len(index.Columns) == 0 || isSpatialIndex(index) {
 			continue
 		}
 		for i := 1; i <= len(index.Columns); i++ {
@@ -56,6 +56,9 @@ func OrderByIndexedFields(table introspection.Table) map[string]string {
 		columnNames[col.Name] = introspection.GraphQLFieldName(col)
 	}
 	for _, index := range table.Indexes {
+		if isSpatialIndex(index) {
+			continue
+		}
 		for _, colName := range index.Columns {
 			fieldName, ok := columnNames[colName]
 			if !ok || fieldName == "" {
@@ -67,6 +70,12 @@ func OrderByIndexedFields(table introspection.Table) map[string]string {
 	return fields
 }
 
+// isSpatialIndex reports whether index is an R-tree index over a geometry
+// column, which cannot order rows.
+func isSpatialIndex(index introspection.Index) bool {
+	return strings.EqualFold(index.Type, "SPATIAL")
+}
+
 // ParseOrderBy validates and parses the orderBy argument for a table.
 func ParseOrderBy(table introspection.Table, args map[string]interface{}) (*OrderBy, error) {
 	if args == nil {
@@ -102,13 +102,24 @@ func PlanQuery(dbSchema *introspection.Schema, field *ast.Field, args map[string
 		return nil, err
 	}
 
+	plan, err := planQueryField(dbSchema, field, args, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check limits once the table is known so hierarchy fields are
+	// recognised from the schema.
 	if options.limits != nil {
-		cost := EstimateCost(field, args, defaultLimit, options.fragments)
+		cost := EstimateTableCost(dbSchema, plan.Table, field, args, defaultLimit, options.fragments)
 		if err := validateLimits(cost, *options.limits); err != nil {
 			return nil, err
 		}
 	}
+	return plan, nil
+}
 
+// planQueryField plans a single root or relationship field.
+func planQueryField(dbSchema *introspection.Schema, field *ast.Field, args map[string]interface{}, options *planOptions) (*Plan, error) {
 	if options.relationship != nil {
 		ctx := options.relationship
 		remoteCols := ctx.RemoteColumns
@@ -154,6 +165,10 @@ func PlanQuery(dbSchema *introspection.Schema, field *ast.Field, args map[string
 				if !ok {
 					return nil, fmt.Errorf("missing primary key argument %s", pkArgName)
 				}
+				pkValue, err := StorageValue(*pk, pkValue)
+				if err != nil {
+					return nil, err
+				}
 				planned, err := PlanTableByPK(table, selected, pk, pkValue)
 				if err != nil {
 					return nil, err
@@ -169,7 +184,11 @@ func PlanQuery(dbSchema *introspection.Schema, field *ast.Field, args map[string
 				if !ok {
 					return nil, fmt.Errorf("missing primary key argument %s", argName)
 				}
-				values[col.Name] = argValue
+				stored, err := StorageValue(col, argValue)
+				if err != nil {
+					return nil, err
+				}
+				values[col.Name] = stored
 			}
 			planned, err := PlanTableByPKColumns(table, selected, pkCols, values)
 			if err != nil {
@@ -195,7 +214,11 @@ func PlanQuery(dbSchema *introspection.Schema, field *ast.Field, args map[string
 					if !ok {
 						return nil, fmt.Errorf("missing primary key argument %s", argName)
 					}
-					values[col.Name] = argValue
+					stored, err := StorageValue(col, argValue)
+					if err != nil {
+						return nil, err
+					}
+					values[col.Name] = stored
 				}
 
 				selected := SelectedColumns(table, field, options.fragments)
@@ -228,9 +251,9 @@ func PlanQuery(dbSchema *introspection.Schema, field *ast.Field, args map[string
 				// Extract argument values
 				values := make(map[string]interface{})
 				for _, colName := range idx.Columns {
-					col, ok := findColumn(table.Columns, colName)
+					col, found := findColumn(table.Columns, colName)
 					var argName string
-					if ok {
+					if found {
 						argName = introspection.GraphQLFieldName(col)
 					} else {
 						argName = introspection.ToGraphQLFieldName(colName)
@@ -239,6 +262,13 @@ func PlanQuery(dbSchema *introspection.Schema, field *ast.Field, args map[string
 					if !ok {
 						return nil, fmt.Errorf("missing unique key argument %s", argName)
 					}
+					if found {
+						stored, err := StorageValue(col, argValue)
+						if err != nil {
+							return nil, err
+						}
+						argValue = stored
+					}
 					values[colName] = argValue
 				}
 
@@ -323,6 +353,7 @@ func SelectedColumns(table introspection.Table, field *ast.Field, fragments map[
 	for _, rel := range table.Relationships {
 		relationshipByField[rel.GraphQLFieldName] = rel.EffectiveLocalColumns()
 	}
+	addCurrencyColumns(table, relationshipByField)
 
 	selected := make(map[string]struct{})
 
@@ -394,6 +425,18 @@ func SelectedColumns(table introspection.Table, field *ast.Field, fragments map[
 	return columns
 }
 
+// addCurrencyColumns makes Money fields pull in their currency column, using
+// the same implied-column map as relationship join keys.
+func addCurrencyColumns(table introspection.Table, impliedByField map[string][]string) {
+	for _, col := range table.Columns {
+		if col.CurrencyColumn == "" {
+			continue
+		}
+		fieldName := introspection.GraphQLFieldName(col)
+		impliedByField[fieldName] = append(impliedByField[fieldName], col.Name, col.CurrencyColumn)
+	}
+}
+
 // SelectedColumnsForConnection extracts the column selection from a connection
 // field's selection set. Connection fields wrap actual columns inside nodes { ... }
 // and/or edges { node { ... } }, so we traverse into those sub-selections.
@@ -417,6 +460,7 @@ func SelectedColumnsForConnection(table introspection.Table, field *ast.Field, f
 	for _, rel := range table.Relationships {
 		relationshipByField[rel.GraphQLFieldName] = rel.EffectiveLocalColumns()
 	}
+	addCurrencyColumns(table, relationshipByField)
 
 	selected := make(map[string]struct{})
 	visitedFragments := make(map[string]struct{})
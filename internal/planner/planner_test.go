@@ -193,7 +193,7 @@ func TestPlanOneToManyBatch(t *testing.T) {
 		},
 	}
 
-	planned, err := PlanOneToManyBatch(table, nil, "user_id", []interface{}{1, 2}, 10, 0, nil, nil)
+	planned, err := PlanOneToManyBatch(table, nil, []string{"user_id"}, []ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 10, 0, nil, nil)
 	require.NoError(t, err)
 	assertSQLMatches(t, planned.SQL,
 		"SELECT `id`, `user_id`, `title`, __batch_parent_id FROM (SELECT `id`, `user_id`, `title`, `user_id` AS __batch_parent_id, ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `id`) AS __rn FROM `posts` WHERE `user_id` IN (?,?)) AS __batch WHERE __rn > ? AND __rn <= ? ORDER BY __batch_parent_id, __rn",
@@ -216,7 +216,7 @@ func TestPlanOneToManyBatch_OrdersByBatchAliasWhenFKNotSelected(t *testing.T) {
 		{Name: "title"},
 	}
 
-	planned, err := PlanOneToManyBatch(table, selection, "user_id", []interface{}{1, 2}, 5, 0, nil, nil)
+	planned, err := PlanOneToManyBatch(table, selection, []string{"user_id"}, []ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 5, 0, nil, nil)
 	require.NoError(t, err)
 	assertSQLMatches(t, planned.SQL,
 		"SELECT `id`, `title`, __batch_parent_id FROM (SELECT `id`, `title`, `user_id` AS __batch_parent_id, ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `id`) AS __rn FROM `posts` WHERE `user_id` IN (?,?)) AS __batch WHERE __rn > ? AND __rn <= ? ORDER BY __batch_parent_id, __rn",
@@ -238,7 +238,7 @@ func TestPlanOneToManyBatch_WithWhere(t *testing.T) {
 		Condition: sq.Eq{sqlutil.QuoteIdentifier("title"): "first"},
 	}
 
-	planned, err := PlanOneToManyBatch(table, nil, "user_id", []interface{}{1, 2}, 5, 0, nil, where)
+	planned, err := PlanOneToManyBatch(table, nil, []string{"user_id"}, []ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 5, 0, nil, where)
 	require.NoError(t, err)
 	assertSQLMatches(t, planned.SQL,
 		"SELECT `id`, `user_id`, `title`, __batch_parent_id FROM (SELECT `id`, `user_id`, `title`, `user_id` AS __batch_parent_id, ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `id`) AS __rn FROM `posts` WHERE `user_id` IN (?,?) AND `title` = ?) AS __batch WHERE __rn > ? AND __rn <= ? ORDER BY __batch_parent_id, __rn",
@@ -365,3 +365,21 @@ func normalizeArgs(args []interface{}) []string {
 func normalizeSQL(sql string) string {
 	return strings.Join(strings.Fields(sql), " ")
 }
+
+func TestPlanOneToManyBatch_CompositeKey(t *testing.T) {
+	table := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "tenant_id", IsPrimaryKey: true},
+			{Name: "id", IsPrimaryKey: true},
+			{Name: "customer_id"},
+		},
+	}
+
+	planned, err := PlanOneToManyBatch(table, nil, []string{"tenant_id", "customer_id"}, []ParentTuple{{Values: []interface{}{7, 1}}, {Values: []interface{}{7, 2}}}, 5, 0, nil, nil)
+	require.NoError(t, err)
+	assertSQLMatches(t, planned.SQL,
+		"SELECT `tenant_id`, `id`, `customer_id`, __batch_parent_0, __batch_parent_1 FROM (SELECT `tenant_id`, `id`, `customer_id`, `tenant_id` AS __batch_parent_0, `customer_id` AS __batch_parent_1, ROW_NUMBER() OVER (PARTITION BY `tenant_id`, `customer_id` ORDER BY `tenant_id`, `id`) AS __rn FROM `orders` WHERE (`tenant_id`, `customer_id`) IN ((?,?), (?,?))) AS __batch WHERE __rn > ? AND __rn <= ? ORDER BY __batch_parent_0, __batch_parent_1, __rn",
+	)
+	assertArgsEqual(t, planned.Args, []interface{}{7, 1, 7, 2, 0, 5})
+}
@@ -210,3 +210,64 @@ func TestValidateWhereClauseIndexes_RelationPathFailure(t *testing.T) {
 		t.Fatalf("expected table name in error, got: %v", err)
 	}
 }
+
+func TestBuildWhereClauseWithSchema_CompositeOneToManySome(t *testing.T) {
+	customers := introspection.Table{
+		Name: "customers",
+		Columns: []introspection.Column{
+			{Name: "tenant_id", IsPrimaryKey: true, GraphQLFieldName: "tenantId"},
+			{Name: "id", IsPrimaryKey: true, GraphQLFieldName: "databaseId"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"tenant_id", "id"}},
+		},
+		Relationships: []introspection.Relationship{
+			{
+				IsOneToMany:      true,
+				LocalColumns:     []string{"tenant_id", "id"},
+				RemoteTable:      "orders",
+				RemoteColumns:    []string{"tenant_id", "customer_id"},
+				GraphQLFieldName: "orders",
+			},
+		},
+	}
+	orders := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "tenant_id", IsPrimaryKey: true, GraphQLFieldName: "tenantId"},
+			{Name: "id", IsPrimaryKey: true, GraphQLFieldName: "databaseId"},
+			{Name: "customer_id", GraphQLFieldName: "customerId"},
+			{Name: "status", GraphQLFieldName: "status"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"tenant_id", "id"}},
+			{Name: "fk_orders_customer", Columns: []string{"tenant_id", "customer_id"}},
+		},
+	}
+	schema := &introspection.Schema{Tables: []introspection.Table{customers, orders}}
+
+	where, err := BuildWhereClauseWithSchema(schema, customers, map[string]interface{}{
+		"orders": map[string]interface{}{
+			"some": map[string]interface{}{
+				"status": map[string]interface{}{"eq": "open"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateWhereClauseIndexes(schema, customers, where); err != nil {
+		t.Fatalf("unexpected index validation error: %v", err)
+	}
+
+	sql := whereToSQL(t, where)
+	for _, want := range []string{
+		"EXISTS",
+		"`__orders_1`.`tenant_id` = `customers`.`tenant_id`",
+		"`__orders_1`.`customer_id` = `customers`.`id`",
+	} {
+		if !strings.Contains(sql, want) {
+			t.Fatalf("expected %q in SQL, got: %s", want, sql)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/sqltype"
+)
+
+func scalarFilterSQL(t *testing.T, col introspection.Column, filter map[string]interface{}) (string, []interface{}) {
+	t.Helper()
+	conds, err := buildColumnFilter(col, "", filter)
+	require.NoError(t, err)
+	require.Len(t, conds, 1)
+	sql, args, err := sq.Select("1").From("t").Where(conds[0]).ToSql()
+	require.NoError(t, err)
+	return sql, args
+}
+
+func TestBuildColumnFilter_EmailDomain(t *testing.T) {
+	col := introspection.Column{Name: "email", DataType: "varchar", OverrideType: sqltype.TypeEmail, HasOverrideType: true}
+
+	sql, args := scalarFilterSQL(t, col, map[string]interface{}{"domain": " Example_Corp.com "})
+	assert.Contains(t, sql, "LOWER(`email`) LIKE ?")
+	assert.Equal(t, []interface{}{`%@example\_corp.com`}, args)
+
+	_, err := buildColumnFilter(col, "", map[string]interface{}{"lt": "a@example.com"})
+	assert.Error(t, err)
+}
+
+func TestBuildColumnFilter_IPAddress(t *testing.T) {
+	col := introspection.Column{Name: "client_ip", DataType: "varbinary", ColumnType: "varbinary(16)", OverrideType: sqltype.TypeIPAddress, HasOverrideType: true}
+
+	_, args := scalarFilterSQL(t, col, map[string]interface{}{"eq": "10.0.0.1"})
+	assert.Equal(t, []interface{}{[]byte{10, 0, 0, 1}}, args)
+
+	sql, args := scalarFilterSQL(t, col, map[string]interface{}{"inSubnet": "192.168.0.0/16"})
+	assert.Contains(t, sql, "(LENGTH(`client_ip`) = ? AND `client_ip` BETWEEN ? AND ?)")
+	assert.Equal(t, []interface{}{4, []byte{192, 168, 0, 0}, []byte{192, 168, 255, 255}}, args)
+
+	_, err := buildColumnFilter(col, "", map[string]interface{}{"eq": "not-an-ip"})
+	assert.Error(t, err)
+	_, err = buildColumnFilter(col, "", map[string]interface{}{"inSubnet": "10.0.0.0"})
+	assert.Error(t, err)
+}
+
+func TestBuildColumnFilter_DurationAndTimestamp(t *testing.T) {
+	duration := introspection.Column{Name: "timeout_ms", DataType: "int", OverrideType: sqltype.TypeDuration, HasOverrideType: true, OverrideUnit: "ms"}
+	sql, args := scalarFilterSQL(t, duration, map[string]interface{}{"gte": 90 * time.Second})
+	assert.Contains(t, sql, "`timeout_ms` >= ?")
+	assert.Equal(t, []interface{}{int64(90000)}, args)
+
+	timestamp := introspection.Column{Name: "created_at", DataType: "datetime", OverrideType: sqltype.TypeTimestamp, HasOverrideType: true}
+	_, args = scalarFilterSQL(t, timestamp, map[string]interface{}{"lt": int64(1_700_000_000_000)})
+	assert.Equal(t, []interface{}{time.UnixMilli(1_700_000_000_000).UTC()}, args)
+
+	epochSeconds := introspection.Column{Name: "seen_at", DataType: "bigint", OverrideType: sqltype.TypeTimestamp, HasOverrideType: true, OverrideUnit: "s"}
+	_, args = scalarFilterSQL(t, epochSeconds, map[string]interface{}{"in": []interface{}{int64(1_700_000_000_000)}})
+	assert.Equal(t, []interface{}{int64(1_700_000_000)}, args)
+}
+
+func TestBuildColumnFilter_DateTimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	col := introspection.Column{Name: "placed_at", DataType: "datetime", ColumnType: "datetime", TimeZone: berlin}
+	instant := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	sql, args := scalarFilterSQL(t, col, map[string]interface{}{"gte": instant})
+	assert.Contains(t, sql, "`placed_at` >= ?")
+	assert.Equal(t, []interface{}{time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)}, args)
+
+	_, args = scalarFilterSQL(t, col, map[string]interface{}{"in": []interface{}{instant}})
+	assert.Equal(t, []interface{}{time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)}, args)
+
+	utc := introspection.Column{Name: "placed_at", DataType: "datetime", ColumnType: "datetime"}
+	_, args = scalarFilterSQL(t, utc, map[string]interface{}{"eq": instant.In(berlin)})
+	assert.Equal(t, []interface{}{instant}, args)
+}
+
+func TestStorageValue_TextJSON(t *testing.T) {
+	col := introspection.Column{Name: "payload", DataType: "text", OverrideType: sqltype.TypeJSON, HasOverrideType: true}
+	value, err := StorageValue(col, `{"a":1}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, value)
+
+	_, err = StorageValue(col, `{"a":`)
+	assert.Error(t, err)
+}
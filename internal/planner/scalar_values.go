@@ -0,0 +1,212 @@
+package planner
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/jsonschema"
+	"tidb-graphql/internal/scalarutil"
+	"tidb-graphql/internal/sqltype"
+)
+
+// StorageValue converts a parsed GraphQL argument for a column mapped to a
+// semantic scalar into the value stored in SQL. Values for other columns are
+// returned unchanged.
+func StorageValue(col introspection.Column, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if col.EnumLookup != nil {
+		return lookupEnumValue(col, value)
+	}
+	switch introspection.EffectiveGraphQLType(col) {
+	case sqltype.TypeIPAddress:
+		raw, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("IP address value for %s must be a string", col.Name)
+		}
+		addr, err := scalarutil.ParseIP(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address value for %s", col.Name)
+		}
+		return scalarutil.IPToBytes(addr), nil
+	case sqltype.TypeDuration:
+		d, ok := value.(time.Duration)
+		if !ok {
+			return nil, fmt.Errorf("invalid duration value for %s", col.Name)
+		}
+		stored, err := scalarutil.DurationToUnit(d, col.OverrideUnit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration value for %s: %w", col.Name, err)
+		}
+		return stored, nil
+	case sqltype.TypeTimestamp:
+		millis, err := scalarutil.ToInt64(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp value for %s", col.Name)
+		}
+		stored, err := scalarutil.TimestampToStored(millis, col.OverrideUnit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp value for %s: %w", col.Name, err)
+		}
+		if t, ok := stored.(time.Time); ok {
+			return storedTime(col, t), nil
+		}
+		return stored, nil
+	case sqltype.TypeGeometry:
+		g, err := geometryValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON value for %s: %w", col.Name, err)
+		}
+		return g.Stored(col.SRID), nil
+	case sqltype.TypeDateTime:
+		if t, ok := value.(time.Time); ok {
+			return storedTime(col, t), nil
+		}
+		return value, nil
+	case sqltype.TypeJSON:
+		if col.JSONSchema != nil {
+			return jsonDocumentValue(col, value)
+		}
+		// Native JSON columns are validated by the database; text columns
+		// mapped to JSON are not.
+		if strings.EqualFold(col.DataType, "json") {
+			return value, nil
+		}
+		raw, ok := value.(string)
+		if !ok || !json.Valid([]byte(raw)) {
+			return nil, fmt.Errorf("invalid JSON value for %s", col.Name)
+		}
+		return raw, nil
+	default:
+		return value, nil
+	}
+}
+
+// lookupEnumValue converts a lookup enum value, the string form of the
+// stored code, back to an integer for integer columns.
+func lookupEnumValue(col introspection.Column, value interface{}) (interface{}, error) {
+	raw, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	switch introspection.EffectiveGraphQLType(col) {
+	case sqltype.TypeInt, sqltype.TypeBigInt:
+		if strings.Contains(strings.ToLower(col.ColumnType), "unsigned") {
+			n, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid lookup enum value for %s", col.Name)
+			}
+			return n, nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lookup enum value for %s", col.Name)
+		}
+		return n, nil
+	default:
+		return raw, nil
+	}
+}
+
+// storedTime converts an instant to the wall clock stored in col, labeled UTC
+// to match the connection location. DATETIME columns store the wall clock of
+// their configured zone; TIMESTAMP columns are written through the UTC session.
+func storedTime(col introspection.Column, t time.Time) time.Time {
+	w := t.In(introspection.WallClockZone(col))
+	return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), time.UTC)
+}
+
+// jsonDocumentValue validates a typed JSON input object against the column's
+// schema and serializes it for storage.
+func jsonDocumentValue(col introspection.Column, value interface{}) (interface{}, error) {
+	doc, err := decodeFreeformObjects(col.JSONSchema, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON value for %s: %w", col.Name, err)
+	}
+	if err := col.JSONSchema.Validate(doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON value for %s: %w", col.Name, err)
+	}
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON value for %s: %w", col.Name, err)
+	}
+	return string(encoded), nil
+}
+
+// decodeFreeformObjects decodes the JSON scalar strings that GraphQL inputs
+// carry for objects declared without properties, so the whole document can be
+// validated and stored as structured JSON.
+func decodeFreeformObjects(schema *jsonschema.Schema, value interface{}) (interface{}, error) {
+	switch schema.Type {
+	case jsonschema.TypeObject:
+		if len(schema.Properties) == 0 {
+			raw, ok := value.(string)
+			if !ok {
+				return value, nil
+			}
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+				return nil, fmt.Errorf("invalid JSON object")
+			}
+			return decoded, nil
+		}
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
+		}
+		out := make(map[string]interface{}, len(obj))
+		for key, item := range obj {
+			child, ok := schema.Properties[key]
+			if !ok || item == nil {
+				out[key] = item
+				continue
+			}
+			decoded, err := decodeFreeformObjects(child, item)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = decoded
+		}
+		return out, nil
+	case jsonschema.TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			return value, nil
+		}
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			if item == nil {
+				continue
+			}
+			decoded, err := decodeFreeformObjects(schema.Items, item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = decoded
+		}
+		return out, nil
+	default:
+		return value, nil
+	}
+}
+
+func storageValues(col introspection.Column, value interface{}) ([]interface{}, error) {
+	arr, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("filter value for %s must be an array", col.Name)
+	}
+	out := make([]interface{}, 0, len(arr))
+	for _, item := range arr {
+		stored, err := StorageValue(col, item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, stored)
+	}
+	return out, nil
+}
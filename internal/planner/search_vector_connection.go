@@ -85,7 +85,7 @@ func PlanVectorSearchConnection(
 	}
 
 	if options.limits != nil {
-		cost := EstimateCost(field, args, defaultFirst, options.fragments)
+		cost := EstimateTableCost(options.schema, table, field, args, defaultFirst, options.fragments)
 		if err := validateLimits(cost, *options.limits); err != nil {
 			return nil, err
 		}
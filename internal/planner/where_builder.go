@@ -3,10 +3,13 @@ package planner
 import (
 	"encoding/base64"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
+	"tidb-graphql/internal/geojson"
 	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/scalarutil"
 	"tidb-graphql/internal/setutil"
 	"tidb-graphql/internal/sqltype"
 	"tidb-graphql/internal/sqlutil"
@@ -606,6 +609,26 @@ func buildColumnFilter(col introspection.Column, alias string, filterMap map[str
 	if effectiveType == sqltype.TypeUUID {
 		return buildUUIDColumnFilter(col, quotedColumn, filterMap)
 	}
+	if effectiveType == sqltype.TypeGeometry {
+		return buildGeometryColumnFilter(col, quotedColumn, filterMap)
+	}
+	switch {
+	case col.EnumLookup != nil:
+		converted, err := storageFilterValues(col, filterMap)
+		if err != nil {
+			return nil, err
+		}
+		filterMap = converted
+	case effectiveType == sqltype.TypeEmail, effectiveType == sqltype.TypeURL, effectiveType == sqltype.TypeIPAddress,
+		effectiveType == sqltype.TypeDuration, effectiveType == sqltype.TypeTimestamp:
+		return buildScalarColumnFilter(col, effectiveType, quotedColumn, filterMap)
+	case effectiveType == sqltype.TypeDateTime:
+		converted, err := storageFilterValues(col, filterMap)
+		if err != nil {
+			return nil, err
+		}
+		filterMap = converted
+	}
 
 	for op, value := range filterMap {
 		switch op {
@@ -817,6 +840,86 @@ func buildBytesColumnFilter(quotedColumn string, filterMap map[string]interface{
 	return conditions, nil
 }
 
+// buildGeometryColumnFilter builds spatial conditions. Filter geometries are
+// passed as WKB in the column's SRID so the server compares like with like.
+func buildGeometryColumnFilter(col introspection.Column, quotedColumn string, filterMap map[string]interface{}) ([]sq.Sqlizer, error) {
+	conditions := []sq.Sqlizer{}
+
+	ops := make([]string, 0, len(filterMap))
+	for op := range filterMap {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	for _, op := range ops {
+		value := filterMap[op]
+		switch op {
+		case "distanceWithin":
+			args, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("distanceWithin requires from and distance")
+			}
+			from, err := geometryValue(args["from"])
+			if err != nil {
+				return nil, fmt.Errorf("invalid distanceWithin.from for %s: %w", col.Name, err)
+			}
+			distance, ok := args["distance"].(float64)
+			if !ok || distance < 0 || math.IsNaN(distance) || math.IsInf(distance, 0) {
+				return nil, fmt.Errorf("distanceWithin.distance must be a non-negative number")
+			}
+			conditions = append(conditions, sq.Expr(
+				fmt.Sprintf("ST_Distance(%s, ST_GeomFromWKB(?, ?)) <= ?", quotedColumn),
+				from.WKB(), col.SRID, distance,
+			))
+		case "intersectsBox":
+			box, err := boundingBoxValue(value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, sq.Expr(
+				fmt.Sprintf("MBRIntersects(%s, ST_GeomFromWKB(?, ?))", quotedColumn),
+				box.WKB(), col.SRID,
+			))
+		case "isNull":
+			cond, err := isNullCondition(quotedColumn, value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, cond)
+		default:
+			return nil, fmt.Errorf("operator %s is not supported for geometry columns", op)
+		}
+	}
+
+	return conditions, nil
+}
+
+func geometryValue(value interface{}) (geojson.Geometry, error) {
+	if g, ok := value.(geojson.Geometry); ok {
+		return g, nil
+	}
+	return geojson.Parse(value)
+}
+
+func boundingBoxValue(value interface{}) (geojson.Geometry, error) {
+	args, ok := value.(map[string]interface{})
+	if !ok {
+		return geojson.Geometry{}, fmt.Errorf("intersectsBox requires minX, minY, maxX and maxY")
+	}
+	bounds := make([]float64, 0, 4)
+	for _, key := range []string{"minX", "minY", "maxX", "maxY"} {
+		n, ok := args[key].(float64)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return geojson.Geometry{}, fmt.Errorf("intersectsBox.%s must be a finite number", key)
+		}
+		bounds = append(bounds, n)
+	}
+	if bounds[0] > bounds[2] || bounds[1] > bounds[3] {
+		return geojson.Geometry{}, fmt.Errorf("intersectsBox minimums must not exceed maximums")
+	}
+	return geojson.Box(bounds[0], bounds[1], bounds[2], bounds[3]), nil
+}
+
 func decodeBase64Bytes(value interface{}) ([]byte, error) {
 	switch v := value.(type) {
 	case []byte:
@@ -895,6 +998,117 @@ func buildUUIDColumnFilter(col introspection.Column, quotedColumn string, filter
 	return conditions, nil
 }
 
+// buildScalarColumnFilter builds conditions for columns mapped to semantic
+// scalars. Filter values are converted to their stored form first.
+func buildScalarColumnFilter(col introspection.Column, effectiveType sqltype.GraphQLType, quotedColumn string, filterMap map[string]interface{}) ([]sq.Sqlizer, error) {
+	conditions := []sq.Sqlizer{}
+	ordered := effectiveType == sqltype.TypeDuration || effectiveType == sqltype.TypeTimestamp
+	text := effectiveType == sqltype.TypeEmail || effectiveType == sqltype.TypeURL
+
+	for op, value := range filterMap {
+		switch {
+		case op == "isNull":
+			cond, err := isNullCondition(quotedColumn, value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, cond)
+		case op == "in" || op == "notIn":
+			values, err := storageValues(col, value)
+			if err != nil {
+				return nil, err
+			}
+			if op == "in" {
+				conditions = append(conditions, sq.Eq{quotedColumn: values})
+			} else {
+				conditions = append(conditions, sq.NotEq{quotedColumn: values})
+			}
+		case op == "eq" || op == "ne" || (ordered && (op == "lt" || op == "lte" || op == "gt" || op == "gte")):
+			stored, err := StorageValue(col, value)
+			if err != nil {
+				return nil, err
+			}
+			switch op {
+			case "eq":
+				conditions = append(conditions, sq.Eq{quotedColumn: stored})
+			case "ne":
+				conditions = append(conditions, sq.NotEq{quotedColumn: stored})
+			case "lt":
+				conditions = append(conditions, sq.Lt{quotedColumn: stored})
+			case "lte":
+				conditions = append(conditions, sq.LtOrEq{quotedColumn: stored})
+			case "gt":
+				conditions = append(conditions, sq.Gt{quotedColumn: stored})
+			case "gte":
+				conditions = append(conditions, sq.GtOrEq{quotedColumn: stored})
+			}
+		case text && op == "like":
+			conditions = append(conditions, sq.Like{quotedColumn: value})
+		case text && op == "notLike":
+			conditions = append(conditions, sq.NotLike{quotedColumn: value})
+		case effectiveType == sqltype.TypeEmail && op == "domain":
+			domain, ok := value.(string)
+			if !ok || strings.TrimSpace(domain) == "" {
+				return nil, fmt.Errorf("domain must be a non-empty string")
+			}
+			pattern := "%@" + escapeLikePattern(strings.ToLower(strings.TrimSpace(domain)))
+			conditions = append(conditions, sq.Expr(fmt.Sprintf("LOWER(%s) LIKE ?", quotedColumn), pattern))
+		case effectiveType == sqltype.TypeURL && op == "startsWith":
+			prefix, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("startsWith must be a string")
+			}
+			conditions = append(conditions, sq.Like{quotedColumn: escapeLikePattern(prefix) + "%"})
+		case effectiveType == sqltype.TypeIPAddress && op == "inSubnet":
+			cidr, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("inSubnet must be a CIDR string")
+			}
+			first, last, err := scalarutil.SubnetRange(cidr)
+			if err != nil {
+				return nil, err
+			}
+			// IPv4 and IPv6 values share the column; the length check keeps a
+			// byte-wise range from matching addresses of the other family.
+			conditions = append(conditions, sq.Expr(fmt.Sprintf("(LENGTH(%s) = ? AND %s BETWEEN ? AND ?)", quotedColumn, quotedColumn), len(first), first, last))
+		default:
+			return nil, fmt.Errorf("operator %s is not supported for %s columns", op, effectiveType)
+		}
+	}
+
+	return conditions, nil
+}
+
+// storageFilterValues converts the operands of comparison and list operators
+// to stored values, leaving other operators untouched.
+func storageFilterValues(col introspection.Column, filterMap map[string]interface{}) (map[string]interface{}, error) {
+	converted := make(map[string]interface{}, len(filterMap))
+	for op, value := range filterMap {
+		switch op {
+		case "eq", "ne", "lt", "lte", "gt", "gte":
+			stored, err := StorageValue(col, value)
+			if err != nil {
+				return nil, err
+			}
+			converted[op] = stored
+		case "in", "notIn":
+			stored, err := storageValues(col, value)
+			if err != nil {
+				return nil, err
+			}
+			converted[op] = stored
+		default:
+			converted[op] = value
+		}
+	}
+	return converted, nil
+}
+
+// escapeLikePattern escapes LIKE wildcards so value matches literally.
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func parseUUIDFilterValue(value interface{}, binaryStorage bool) (interface{}, error) {
 	var raw string
 	switch v := value.(type) {
@@ -14,14 +14,14 @@ import (
 
 const snapshotRowField = "__snapshot_read"
 
-func asOfDirective() *graphql.Directive {
+func asOfDirective(dateTime *graphql.Scalar) *graphql.Directive {
 	return graphql.NewDirective(graphql.DirectiveConfig{
 		Name:        asof.DirectiveName,
 		Description: "Reads the selected root field subtree from an exact TiDB historical snapshot.",
 		Locations:   []string{graphql.DirectiveLocationField},
 		Args: graphql.FieldConfigArgument{
 			asof.ArgTime: &graphql.ArgumentConfig{
-				Type: graphql.DateTime,
+				Type: dateTime,
 			},
 			asof.ArgOffsetSeconds: &graphql.ArgumentConfig{
 				Type: graphql.Int,
@@ -43,7 +43,7 @@ func (r *Resolver) withSnapshotContext(p graphql.ResolveParams) (graphql.Resolve
 	}
 
 	field := firstFieldAST(p.Info.FieldASTs)
-	spec, err := asof.ResolveFieldDirective(field, p.Info.VariableValues, snapshotValidationTime(ctx))
+	spec, err := asof.ResolveFieldDirective(field, p.Info.VariableValues, snapshotValidationTime(ctx), r.requestTimeZone(ctx))
 	if err != nil {
 		return p, err
 	}
@@ -0,0 +1,208 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"tidb-graphql/internal/authz"
+	"tidb-graphql/internal/introspection"
+
+	"github.com/graphql-go/graphql"
+)
+
+// applyFieldAuthorization wraps every field guarded by the resolver's policy.
+// Query-side fields the caller may not access resolve to null with an
+// authz.Error, so they are made nullable; mutations whose result union has a
+// PermissionError member return it instead. Rules on a table's create, update or delete mutation also guard
+// its unique-key variants and nested writes to the table from other mutations.
+// Rules naming a field the schema lacks are an error unless the schema is
+// built for a single role.
+func (r *Resolver) applyFieldAuthorization(schema *graphql.Schema, groups []rootFieldGroup) error {
+	r.nestedWriteRules = nil
+	policy := r.authorization
+	if policy == nil {
+		return nil
+	}
+	roots := rootObjectNames(schema)
+	if !r.partialAuthorization {
+		if err := checkAuthorizationTargets(schema, policy, roots); err != nil {
+			return err
+		}
+	}
+
+	aliases := make(map[string]string)
+	nestedWriteRules := make(map[string][]authz.Rule)
+	for _, group := range groups {
+		rootName := "Mutation"
+		if group.wrapper {
+			rootName = group.nsPascal + "_Mutation"
+		}
+		for _, table := range group.tables {
+			for _, kind := range []string{mutationKindCreate, mutationKindUpdate, mutationKindDelete} {
+				canonical := r.mutationFieldName(kind, table)
+				if rules := policy.RulesFor(rootName, canonical, true); len(rules) > 0 {
+					nestedWriteRules[nestedWriteRuleKey(table, kind)] = rules
+				}
+				if kind == mutationKindCreate {
+					continue
+				}
+				for _, idx := range table.Indexes {
+					if idx.Unique && idx.Name != "PRIMARY" {
+						aliases[rootName+"."+r.uniqueKeyMutationFieldName(kind, table, idx)] = canonical
+					}
+				}
+			}
+		}
+	}
+	r.nestedWriteRules = nestedWriteRules
+
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, field := range obj.Fields() {
+			rules := policy.RulesFor(name, fieldName, roots[name])
+			if canonical, ok := aliases[name+"."+fieldName]; ok {
+				rules = append(slices.Clip(rules), policy.RulesFor(name, canonical, true)...)
+			}
+			if len(rules) == 0 {
+				continue
+			}
+			next := field.Resolve
+			if next == nil {
+				next = graphql.DefaultResolveFn
+			}
+			typedFailure := hasPermissionErrorMember(field.Type)
+			if nonNull, ok := field.Type.(*graphql.NonNull); ok && !typedFailure {
+				// A denied field resolves to null, which must not null its parent.
+				field.Type = nonNull.OfType.(graphql.Output)
+			}
+			field.Resolve = authorizedFieldResolver(name+"."+fieldName, rules, typedFailure, next)
+		}
+	}
+	return nil
+}
+
+// checkAuthorizationTargets reports rules whose field is not in schema, so a
+// misspelled type or field fails the build instead of guarding nothing.
+func checkAuthorizationTargets(schema *graphql.Schema, policy *authz.Policy, roots map[string]bool) error {
+	var unknown []string
+	for _, rule := range policy.Rules() {
+		typeName, fieldName, qualified := strings.Cut(rule.Field, ".")
+		found := false
+		if qualified {
+			obj, ok := schema.Type(typeName).(*graphql.Object)
+			found = ok && obj.Fields()[fieldName] != nil
+		} else {
+			for root := range roots {
+				if obj, ok := schema.Type(root).(*graphql.Object); ok && obj.Fields()[rule.Field] != nil {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			unknown = append(unknown, fmt.Sprintf("%q (%s)", rule.Name, rule.Field))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("authorization rules name fields not in the schema: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// columnGuarded reports whether a field rule guards col on table's type. Such
+// columns are left out of where, orderBy and aggregate inputs, which are not
+// checked per caller and would otherwise reveal the values.
+func (r *Resolver) columnGuarded(table introspection.Table, col introspection.Column) bool {
+	return len(r.authorization.RulesFor(introspection.GraphQLTypeName(table), introspection.GraphQLFieldName(col), false)) > 0
+}
+
+// unguardedColumns returns cols without the columns guarded by field rules.
+func (r *Resolver) unguardedColumns(table introspection.Table, cols []introspection.Column) []introspection.Column {
+	if r.authorization == nil {
+		return cols
+	}
+	return slices.DeleteFunc(slices.Clone(cols), func(col introspection.Column) bool {
+		return r.columnGuarded(table, col)
+	})
+}
+
+// unguardedOrderByFields drops guarded columns from the GraphQL field name to
+// column name map returned by planner.OrderByIndexedFields.
+func (r *Resolver) unguardedOrderByFields(table introspection.Table, fields map[string]string) map[string]string {
+	if r.authorization == nil {
+		return fields
+	}
+	for _, col := range table.Columns {
+		if r.columnGuarded(table, col) && fields[introspection.GraphQLFieldName(col)] == col.Name {
+			delete(fields, introspection.GraphQLFieldName(col))
+		}
+	}
+	return fields
+}
+
+func nestedWriteRuleKey(table introspection.Table, kind string) string {
+	return table.MapKey() + "|" + kind
+}
+
+// authorizeNestedWrite checks a nested write of kind to table against the rules
+// of the table's own root mutation. Failures map to PermissionError.
+func (r *Resolver) authorizeNestedWrite(ctx context.Context, table introspection.Table, kind string) error {
+	rules := r.nestedWriteRules[nestedWriteRuleKey(table, kind)]
+	if err := authz.Check(ctx, r.mutationFieldName(kind, table), rules); err != nil {
+		return newMutationError(err.Error(), "access_denied", 0)
+	}
+	return nil
+}
+
+func authorizedFieldResolver(field string, rules []authz.Rule, typedFailure bool, next graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		err := authz.Check(p.Context, field, rules)
+		if err == nil {
+			return next(p)
+		}
+		if !typedFailure {
+			return nil, err
+		}
+		if mc := MutationContextFromContext(p.Context); mc != nil {
+			mc.MarkError()
+		}
+		return mutationErrorPayload("PermissionError", err.Error(), nil), nil
+	}
+}
+
+// rootObjectNames returns Query, Mutation and the namespace root objects
+// reachable from them, where bare root-field rules apply.
+func rootObjectNames(schema *graphql.Schema) map[string]bool {
+	roots := make(map[string]bool)
+	for _, root := range []*graphql.Object{schema.QueryType(), schema.MutationType()} {
+		if root == nil {
+			continue
+		}
+		roots[root.Name()] = true
+		for _, field := range root.Fields() {
+			obj, ok := graphql.GetNullable(field.Type).(*graphql.Object)
+			if ok && (strings.HasSuffix(obj.Name(), "_Query") || strings.HasSuffix(obj.Name(), "_Mutation")) {
+				roots[obj.Name()] = true
+			}
+		}
+	}
+	return roots
+}
+
+func hasPermissionErrorMember(t graphql.Output) bool {
+	union, ok := graphql.GetNullable(t).(*graphql.Union)
+	if !ok {
+		return false
+	}
+	for _, member := range union.Types() {
+		if member.Name() == "PermissionError" {
+			return true
+		}
+	}
+	return false
+}
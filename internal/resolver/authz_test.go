@@ -0,0 +1,298 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/authz"
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/nodeid"
+	"tidb-graphql/internal/planner"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldAuthorization(t *testing.T) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "salary", DataType: "int", IsNullable: true},
+			{Name: "grade", DataType: "int"},
+			{Name: "team", DataType: "int"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "idx_salary", Columns: []string{"salary"}},
+			{Name: "idx_team", Columns: []string{"team"}},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users}}
+	// Schema naming is not applied here, so the row type is "Users".
+	policy, err := authz.NewPolicy([]authz.Rule{
+		{Field: "Users.salary", Scopes: []string{"hr:read"}},
+		{Field: "Users.grade", Scopes: []string{"hr:read"}},
+		{Name: "admins-delete", Field: "deleteUser", Roles: []string{"app_admin"}},
+	})
+	require.NoError(t, err)
+
+	hr := authz.Principal{Subject: "alice", Scopes: []string{"openid", "hr:read"}}
+	staff := authz.Principal{Subject: "bob", Role: "app_viewer", Scopes: []string{"openid"}}
+
+	for _, tc := range []struct {
+		name      string
+		principal *authz.Principal
+		allowed   bool
+	}{
+		{name: "scope granted", principal: &hr, allowed: true},
+		{name: "scope missing", principal: &staff},
+		{name: "anonymous", principal: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			defer db.Close()
+			r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{Authorization: policy})
+			schema, err := r.BuildGraphQLSchema()
+			require.NoError(t, err)
+
+			ctx := NewBatchingContext(context.Background())
+			if tc.principal != nil {
+				ctx = authz.WithPrincipal(ctx, *tc.principal)
+			}
+			mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "salary"}).AddRow(1, 5000))
+			result := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: `{ users { nodes { databaseId salary } } }`,
+				Context:       ctx,
+			})
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			nodes := result.Data.(map[string]interface{})["users"].(map[string]interface{})["nodes"].([]interface{})
+			require.Len(t, nodes, 1)
+			node := nodes[0].(map[string]interface{})
+			assert.Equal(t, 1, node["databaseId"])
+			if tc.allowed {
+				require.Empty(t, result.Errors)
+				assert.Equal(t, 5000, node["salary"])
+				return
+			}
+			assert.Nil(t, node["salary"])
+			require.Len(t, result.Errors, 1)
+			assert.Equal(t, "not authorized to access Users.salary", result.Errors[0].Message)
+			assert.Equal(t, "FORBIDDEN", result.Errors[0].Extensions["code"])
+			assert.Equal(t, "Users.salary", result.Errors[0].Extensions["rule"])
+		})
+	}
+
+	t.Run("denied NOT NULL column keeps the row", func(t *testing.T) {
+		db, mock := newMockDB(t)
+		defer db.Close()
+		r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{Authorization: policy})
+		schema, err := r.BuildGraphQLSchema()
+		require.NoError(t, err)
+
+		userType := unwrapObjectType(t, schema.Type("Users"))
+		assert.Equal(t, "Int", userType.Fields()["grade"].Type.String())
+		assert.Equal(t, "Int!", userType.Fields()["team"].Type.String())
+
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id", "grade"}).AddRow(1, 3))
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ users { nodes { databaseId grade } } }`,
+			Context:       authz.WithPrincipal(NewBatchingContext(context.Background()), staff),
+		})
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, result.Errors, 1)
+		nodes := result.Data.(map[string]interface{})["users"].(map[string]interface{})["nodes"].([]interface{})
+		require.Len(t, nodes, 1)
+		node := nodes[0].(map[string]interface{})
+		assert.Equal(t, 1, node["databaseId"])
+		assert.Nil(t, node["grade"])
+	})
+
+	t.Run("guarded columns are left out of filters, ordering and aggregates", func(t *testing.T) {
+		r := NewResolverWithConfig(nil, dbSchema, nil, 0, ResolverConfig{Authorization: policy})
+		schema, err := r.BuildGraphQLSchema()
+		require.NoError(t, err)
+
+		where, ok := schema.Type("UsersWhere").(*graphql.InputObject)
+		require.True(t, ok)
+		assert.NotContains(t, where.Fields(), "salary")
+		assert.NotContains(t, where.Fields(), "grade")
+		assert.Contains(t, where.Fields(), "team")
+
+		orderBy, ok := schema.Type("UsersOrderByClauseInput").(*graphql.InputObject)
+		require.True(t, ok)
+		assert.NotContains(t, orderBy.Fields(), "salary")
+		assert.Contains(t, orderBy.Fields(), "team")
+
+		for _, name := range []string{"UsersSumFields", "UsersMinFields", "UsersCountDistinctFields"} {
+			fields := unwrapObjectType(t, schema.Type(name)).Fields()
+			assert.NotContains(t, fields, "salary", name)
+			assert.NotContains(t, fields, "grade", name)
+			assert.Contains(t, fields, "team", name)
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ users(where: {salary: {gt: 100000}}) { nodes { databaseId } } }`,
+			Context:       NewBatchingContext(context.Background()),
+		})
+		require.NotEmpty(t, result.Errors)
+	})
+
+	t.Run("mutation returns PermissionError", func(t *testing.T) {
+		r := NewResolverWithConfig(nil, dbSchema, nil, 0, ResolverConfig{Authorization: policy})
+		schema, err := r.BuildGraphQLSchema()
+		require.NoError(t, err)
+
+		mc := NewMutationContext(nil)
+		ctx := WithMutationContext(authz.WithPrincipal(context.Background(), staff), mc)
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `mutation { deleteUser(id: "VXNlcjox") { __typename ... on PermissionError { message } } }`,
+			Context:       ctx,
+		})
+		require.Empty(t, result.Errors)
+		payload := result.Data.(map[string]interface{})["deleteUser"].(map[string]interface{})
+		assert.Equal(t, "PermissionError", payload["__typename"])
+		assert.Equal(t, "not authorized to access Mutation.deleteUser", payload["message"])
+		assert.True(t, mc.hasError, "a denied mutation rolls back the request transaction")
+	})
+}
+
+func TestFieldAuthorization_UnknownTargets(t *testing.T) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users}}
+	policy, err := authz.NewPolicy([]authz.Rule{
+		{Field: "Users.salery", Scopes: []string{"hr:read"}},
+		{Name: "admins-delete", Field: "deleteUsr", Roles: []string{"app_admin"}},
+		{Field: "deleteUser", Roles: []string{"app_admin"}},
+	})
+	require.NoError(t, err)
+
+	_, err = NewResolverWithConfig(nil, dbSchema, nil, 0, ResolverConfig{Authorization: policy}).BuildGraphQLSchema()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"Users.salery" (Users.salery)`)
+	assert.Contains(t, err.Error(), `"admins-delete" (deleteUsr)`)
+	assert.NotContains(t, err.Error(), "(deleteUser)")
+
+	// Role schemas see only part of the tables, so missing fields are expected there.
+	_, err = NewResolverWithConfig(nil, dbSchema, nil, 0, ResolverConfig{Authorization: policy, PartialAuthorization: true}).BuildGraphQLSchema()
+	require.NoError(t, err)
+}
+
+func TestFieldAuthorization_UniqueKeyMutationsShareRootRules(t *testing.T) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "email", DataType: "varchar"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "uniq_email", Unique: true, Columns: []string{"email"}},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users}}
+	policy, err := authz.NewPolicy([]authz.Rule{
+		{Name: "admins-delete", Field: "deleteUser", Roles: []string{"app_admin"}},
+		{Name: "admins-update", Field: "Mutation.updateUser", Roles: []string{"app_admin"}},
+	})
+	require.NoError(t, err)
+
+	r := NewResolverWithConfig(nil, dbSchema, nil, 0, ResolverConfig{Authorization: policy})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		field string
+		query string
+	}{
+		{field: "deleteUserByEmail", query: `mutation { deleteUserByEmail(email: "a@example.com") { __typename ... on PermissionError { message } } }`},
+		{field: "updateUserByEmail", query: `mutation { updateUserByEmail(email: "a@example.com", set: {email: "b@example.com"}) { __typename ... on PermissionError { message } } }`},
+	} {
+		t.Run(tc.field, func(t *testing.T) {
+			ctx := WithMutationContext(authz.WithPrincipal(context.Background(), authz.Principal{Role: "app_viewer"}), NewMutationContext(nil))
+			result := graphql.Do(graphql.Params{Schema: schema, RequestString: tc.query, Context: ctx})
+			require.Empty(t, result.Errors)
+			payload := result.Data.(map[string]interface{})[tc.field].(map[string]interface{})
+			assert.Equal(t, "PermissionError", payload["__typename"])
+			assert.Equal(t, "not authorized to access Mutation."+tc.field, payload["message"])
+		})
+	}
+}
+
+func TestFieldAuthorization_NestedWritesUseChildRootRules(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	policy, err := authz.NewPolicy([]authz.Rule{
+		{Name: "admins-delete-sessions", Field: "deleteSession", Roles: []string{"app_admin"}},
+	})
+	require.NoError(t, err)
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{Authorization: policy})
+	_, err = r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	updatable := columnNameSet(r.mutationUpdatableColumns(users))
+	pkCols := introspection.PrimaryKeyColumns(users)
+	field := &ast.Field{
+		Name: &ast.Name{Value: "updateUser"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "username"}},
+		}},
+	}
+	selected := planner.SelectedColumns(users, field, nil)
+	selected = planner.EnsureColumns(users, selected, []string{"id"})
+	selectPlan, err := planner.PlanTableByPK(users, selected, &pkCols[0], int64(1))
+	require.NoError(t, err)
+
+	// The child delete is refused before any statement touches sessions.
+	mock.ExpectBegin()
+	expectQuery(t, mock, selectPlan.SQL, selectPlan.Args, sqlmock.NewRows([]string{"id", "username"}).AddRow(int64(1), "alice"))
+	mock.ExpectRollback()
+
+	tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+	require.NoError(t, err)
+	mc := NewMutationContext(tx)
+	ctx := WithMutationContext(authz.WithPrincipal(context.Background(), authz.Principal{Role: "app_viewer"}), mc)
+
+	resolverFn := r.makeUpdateResolver(users, updatable, pkCols, r.updateSuccessType(users, r.buildGraphQLType(users)))
+	result, err := resolverFn(graphql.ResolveParams{
+		Args: map[string]interface{}{
+			"id": nodeid.Encode(introspection.GraphQLTypeName(users), 1),
+			"set": map[string]interface{}{
+				"sessionsDelete": []interface{}{nodeid.Encode(introspection.GraphQLTypeName(sessions), 99)},
+			},
+		},
+		Context: ctx,
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	})
+	require.NoError(t, err)
+
+	payload, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "PermissionError", payload["__typename"])
+	assert.Equal(t, "not authorized to access deleteSession", payload["message"])
+
+	require.NoError(t, mc.Finalize())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
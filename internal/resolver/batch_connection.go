@@ -69,9 +69,7 @@ func runBatchConnectionChunks(
 	planned, err := chunkFn()
 	if err != nil {
 		if errors.Is(err, planner.ErrNoPrimaryKey) {
-			if metrics != nil {
-				metrics.RecordBatchSkipped(ctx, bp.relation, "no_primary_key")
-			}
+			recordBatchSkipped(ctx, metrics, bp.relation, "no_primary_key")
 			span.SetAttributes(attribute.String("graphql.batch.skip_reason", "no_primary_key"))
 			outcome = "skipped"
 			return nil, errBatchSkip
@@ -12,39 +12,33 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func oneToManyMappingColumns(rel introspection.Relationship) (localColumn string, remoteColumn string, err error) {
-	localColumns := rel.EffectiveLocalColumns()
-	remoteColumns := rel.EffectiveRemoteColumns()
-	if len(localColumns) != 1 || len(remoteColumns) != 1 {
-		return "", "", fmt.Errorf("invalid one-to-many mapping for %s", rel.GraphQLFieldName)
+func oneToManyMappingColumns(rel introspection.Relationship) (localColumns []string, remoteColumns []string, err error) {
+	localColumns = rel.EffectiveLocalColumns()
+	remoteColumns = rel.EffectiveRemoteColumns()
+	if len(localColumns) == 0 || len(localColumns) != len(remoteColumns) {
+		return nil, nil, fmt.Errorf("invalid one-to-many mapping for %s", rel.GraphQLFieldName)
 	}
-	return localColumns[0], remoteColumns[0], nil
+	return localColumns, remoteColumns, nil
 }
 
-func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table introspection.Table, rel introspection.Relationship, pkValue interface{}) (map[string]interface{}, bool, error) {
+func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table introspection.Table, rel introspection.Relationship, pkValues []interface{}) (map[string]interface{}, bool, error) {
 	metrics := graphQLMetricsFromContext(p.Context)
 
 	state, ok := getBatchState(p.Context)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationOneToMany, "no_batch_state")
-		}
+		recordBatchSkipped(p.Context, metrics, relationOneToMany, "no_batch_state")
 		return nil, false, nil
 	}
 
 	parentKey, ok := parentKeyFromSource(p.Source)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationOneToMany, "missing_parent_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationOneToMany, "missing_parent_key")
 		return nil, false, nil
 	}
 
 	parentRows := state.getParentRows(parentKey)
 	if len(parentRows) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationOneToMany, "missing_parent_rows")
-		}
+		recordBatchSkipped(p.Context, metrics, relationOneToMany, "missing_parent_rows")
 		return nil, false, nil
 	}
 
@@ -55,9 +49,7 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 
 	pkCols := introspection.PrimaryKeyColumns(relatedTable)
 	if len(pkCols) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationOneToMany, "no_primary_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationOneToMany, "no_primary_key")
 		return nil, false, nil
 	}
 
@@ -65,10 +57,14 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 	if field == nil {
 		return nil, true, fmt.Errorf("missing field AST")
 	}
-	localColumn, remoteColumn, err := oneToManyMappingColumns(rel)
+	localColumns, remoteColumns, err := oneToManyMappingColumns(rel)
 	if err != nil {
 		return nil, true, err
 	}
+	if len(pkValues) != len(localColumns) {
+		return nil, true, fmt.Errorf("invalid one-to-many local key mapping")
+	}
+	currentParentTupleKey := tupleKeyFromValues(pkValues)
 
 	first, err := planner.ParseFirstWithDefault(p.Args, r.defaultLimit)
 	if err != nil {
@@ -115,7 +111,7 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 		"%s|%s|%s|%s|%s|%s|%s",
 		table.Name,
 		rel.RemoteTable,
-		remoteColumn,
+		strings.Join(remoteColumns, ","),
 		orderByKey,
 		columnsKey(selection),
 		stableArgsKey(p.Args),
@@ -124,10 +120,8 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 
 	if cached := state.getConnectionRows(relKey); cached != nil {
 		state.IncrementCacheHit()
-		if metrics != nil {
-			metrics.RecordBatchCacheHit(p.Context, relationOneToMany)
-		}
-		if result, ok := cached[fmt.Sprint(pkValue)]; ok {
+		recordBatchCacheHit(p.Context, metrics, relationOneToMany)
+		if result, ok := cached[currentParentTupleKey]; ok {
 			if nodes, ok := result["nodes"].([]map[string]interface{}); ok {
 				seedBatchRows(p, nodes)
 			}
@@ -136,27 +130,29 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 		return r.buildConnectionResult(p.Context, nil, nil, false, false), true, nil
 	}
 	state.IncrementCacheMiss()
-	if metrics != nil {
-		metrics.RecordBatchCacheMiss(p.Context, relationOneToMany)
-	}
+	recordBatchCacheMiss(p.Context, metrics, relationOneToMany)
 
-	parentField := graphQLFieldNameForColumn(table, localColumn)
-	parentValues := uniqueParentValues(parentRows, parentField)
-	if len(parentValues) == 0 {
+	parentFields := make([]string, len(localColumns))
+	for i, colName := range localColumns {
+		parentFields[i] = graphQLFieldNameForColumn(table, colName)
+	}
+	parentTuples := uniqueParentTuples(parentRows, parentFields)
+	if len(parentTuples) == 0 {
 		state.setConnectionRows(relKey, map[string]map[string]interface{}{})
 		return r.buildConnectionResult(p.Context, nil, nil, false, false), true, nil
 	}
 	// Keep original typed parent values so count queries can bind FK args correctly.
-	parentValueByKey := make(map[string]interface{}, len(parentValues))
-	for _, value := range parentValues {
-		parentValueByKey[fmt.Sprint(value)] = value
+	parentValueByKey := make(map[string]planner.ParentTuple, len(parentTuples))
+	for _, tuple := range parentTuples {
+		parentValueByKey[tupleKeyFromValues(tuple.Values)] = tuple
 	}
 
-	chunks := chunkValues(parentValues, batchMaxInClause)
+	chunks := chunkParentTuples(parentTuples, batchMaxInClause)
 	if metrics != nil {
-		metrics.RecordBatchQueriesSaved(p.Context, listBatchQueriesSaved(len(parentValues), len(chunks)), relationOneToMany)
+		metrics.RecordBatchQueriesSaved(p.Context, listBatchQueriesSaved(len(parentTuples), len(chunks)), relationOneToMany)
 	}
 
+	parentAliases := planner.BatchParentAliases(len(remoteColumns))
 	bp := batchConnectionPlan{
 		table:         relatedTable,
 		selection:     selection,
@@ -164,7 +160,7 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 		orderByKey:    orderByKey,
 		cursorCols:    cursorCols,
 		first:         first,
-		parentAliases: []string{planner.BatchParentAlias},
+		parentAliases: parentAliases,
 		relation:      relationOneToMany,
 	}
 	groupedConnections := make(map[string]map[string]interface{})
@@ -172,18 +168,18 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 		partial, err := runBatchConnectionChunks(
 			p.Context, r, bp, len(chunk), metrics,
 			func() (planner.SQLQuery, error) {
-				return planner.PlanOneToManyConnectionBatch(relatedTable, remoteColumn, selection, chunk, first, orderBy, whereClause)
+				return planner.PlanOneToManyConnectionBatch(relatedTable, remoteColumns, selection, chunk, first, orderBy, whereClause)
 			},
 			func(results []map[string]interface{}) map[string][]map[string]interface{} {
-				return groupByAlias(results, planner.BatchParentAlias)
+				return groupByAliases(results, parentAliases)
 			},
 			func(parentID string) (planner.SQLQuery, planner.SQLQuery, error) {
-				parentValue := parentValueByKey[parentID]
-				count, err := planner.BuildOneToManyCountSQL(relatedTable, remoteColumn, parentValue, whereClause)
+				tuple := parentValueByKey[parentID]
+				count, err := planner.BuildOneToManyCountSQL(relatedTable, remoteColumns, tuple.Values, whereClause)
 				if err != nil {
 					return planner.SQLQuery{}, planner.SQLQuery{}, err
 				}
-				agg, err := planner.BuildOneToManyAggregateBaseSQL(relatedTable, remoteColumn, parentValue, whereClause)
+				agg, err := planner.BuildOneToManyAggregateBaseSQL(relatedTable, remoteColumns, tuple.Values, whereClause)
 				return count, agg, err
 			},
 		)
@@ -204,7 +200,7 @@ func (r *Resolver) tryBatchOneToManyConnection(p graphql.ResolveParams, table in
 	}
 	state.setConnectionRows(relKey, groupedConnections)
 
-	if result, ok := groupedConnections[fmt.Sprint(pkValue)]; ok {
+	if result, ok := groupedConnections[currentParentTupleKey]; ok {
 		if nodes, ok := result["nodes"].([]map[string]interface{}); ok {
 			seedBatchRows(p, nodes)
 		}
@@ -218,25 +214,19 @@ func (r *Resolver) tryBatchManyToManyConnection(p graphql.ResolveParams, table i
 
 	state, ok := getBatchState(p.Context)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToMany, "no_batch_state")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToMany, "no_batch_state")
 		return nil, false, nil
 	}
 
 	parentKey, ok := parentKeyFromSource(p.Source)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToMany, "missing_parent_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToMany, "missing_parent_key")
 		return nil, false, nil
 	}
 
 	parentRows := state.getParentRows(parentKey)
 	if len(parentRows) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToMany, "missing_parent_rows")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToMany, "missing_parent_rows")
 		return nil, false, nil
 	}
 
@@ -257,9 +247,7 @@ func (r *Resolver) tryBatchManyToManyConnection(p graphql.ResolveParams, table i
 
 	pkCols := introspection.PrimaryKeyColumns(relatedTable)
 	if len(pkCols) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToMany, "no_primary_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToMany, "no_primary_key")
 		return nil, false, nil
 	}
 
@@ -337,9 +325,7 @@ func (r *Resolver) tryBatchManyToManyConnection(p graphql.ResolveParams, table i
 
 	if cached := state.getConnectionRows(relKey); cached != nil {
 		state.IncrementCacheHit()
-		if metrics != nil {
-			metrics.RecordBatchCacheHit(p.Context, relationManyToMany)
-		}
+		recordBatchCacheHit(p.Context, metrics, relationManyToMany)
 		if result, ok := cached[currentParentTupleKey]; ok {
 			if nodes, ok := result["nodes"].([]map[string]interface{}); ok {
 				seedBatchRows(p, nodes)
@@ -349,9 +335,7 @@ func (r *Resolver) tryBatchManyToManyConnection(p graphql.ResolveParams, table i
 		return r.buildConnectionResult(p.Context, nil, nil, false, false), true, nil
 	}
 	state.IncrementCacheMiss()
-	if metrics != nil {
-		metrics.RecordBatchCacheMiss(p.Context, relationManyToMany)
-	}
+	recordBatchCacheMiss(p.Context, metrics, relationManyToMany)
 
 	parentFields := make([]string, len(localColumns))
 	for i, colName := range localColumns {
@@ -434,25 +418,19 @@ func (r *Resolver) tryBatchEdgeListConnection(p graphql.ResolveParams, table int
 
 	state, ok := getBatchState(p.Context)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationEdgeList, "no_batch_state")
-		}
+		recordBatchSkipped(p.Context, metrics, relationEdgeList, "no_batch_state")
 		return nil, false, nil
 	}
 
 	parentKey, ok := parentKeyFromSource(p.Source)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationEdgeList, "missing_parent_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationEdgeList, "missing_parent_key")
 		return nil, false, nil
 	}
 
 	parentRows := state.getParentRows(parentKey)
 	if len(parentRows) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationEdgeList, "missing_parent_rows")
-		}
+		recordBatchSkipped(p.Context, metrics, relationEdgeList, "missing_parent_rows")
 		return nil, false, nil
 	}
 
@@ -463,9 +441,7 @@ func (r *Resolver) tryBatchEdgeListConnection(p graphql.ResolveParams, table int
 
 	pkCols := introspection.PrimaryKeyColumns(junctionTable)
 	if len(pkCols) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationEdgeList, "no_primary_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationEdgeList, "no_primary_key")
 		return nil, false, nil
 	}
 
@@ -537,9 +513,7 @@ func (r *Resolver) tryBatchEdgeListConnection(p graphql.ResolveParams, table int
 
 	if cached := state.getConnectionRows(relKey); cached != nil {
 		state.IncrementCacheHit()
-		if metrics != nil {
-			metrics.RecordBatchCacheHit(p.Context, relationEdgeList)
-		}
+		recordBatchCacheHit(p.Context, metrics, relationEdgeList)
 		if result, ok := cached[currentParentTupleKey]; ok {
 			if nodes, ok := result["nodes"].([]map[string]interface{}); ok {
 				seedBatchRows(p, nodes)
@@ -549,9 +523,7 @@ func (r *Resolver) tryBatchEdgeListConnection(p graphql.ResolveParams, table int
 		return r.buildConnectionResult(p.Context, nil, nil, false, false), true, nil
 	}
 	state.IncrementCacheMiss()
-	if metrics != nil {
-		metrics.RecordBatchCacheMiss(p.Context, relationEdgeList)
-	}
+	recordBatchCacheMiss(p.Context, metrics, relationEdgeList)
 
 	parentFields := make([]string, len(localColumns))
 	for i, colName := range localColumns {
@@ -645,9 +617,7 @@ func (r *Resolver) tryBatchManyToOne(p graphql.ResolveParams, table introspectio
 
 	state, ok := getBatchState(p.Context)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToOne, "no_batch_state")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToOne, "no_batch_state")
 		span.SetAttributes(attribute.String("graphql.batch.skip_reason", "no_batch_state"))
 		outcome = "skipped"
 		return nil, false, nil
@@ -655,9 +625,7 @@ func (r *Resolver) tryBatchManyToOne(p graphql.ResolveParams, table introspectio
 
 	parentKey, ok := parentKeyFromSource(p.Source)
 	if !ok {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToOne, "missing_parent_key")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToOne, "missing_parent_key")
 		span.SetAttributes(attribute.String("graphql.batch.skip_reason", "missing_parent_key"))
 		outcome = "skipped"
 		return nil, false, nil
@@ -665,9 +633,7 @@ func (r *Resolver) tryBatchManyToOne(p graphql.ResolveParams, table introspectio
 
 	parentRows := state.getParentRows(parentKey)
 	if len(parentRows) == 0 {
-		if metrics != nil {
-			metrics.RecordBatchSkipped(p.Context, relationManyToOne, "missing_parent_rows")
-		}
+		recordBatchSkipped(p.Context, metrics, relationManyToOne, "missing_parent_rows")
 		span.SetAttributes(attribute.String("graphql.batch.skip_reason", "missing_parent_rows"))
 		outcome = "skipped"
 		return nil, false, nil
@@ -692,15 +658,11 @@ func (r *Resolver) tryBatchManyToOne(p graphql.ResolveParams, table introspectio
 	relKey := fmt.Sprintf("%s|%s|%s|%s|%s", relatedTable.Name, strings.Join(remoteColumns, ","), parentKey, columnsKey(selection), snapshotKeyPart(p.Context))
 	if cached := state.getChildRows(relKey); cached != nil {
 		state.IncrementCacheHit()
-		if metrics != nil {
-			metrics.RecordBatchCacheHit(p.Context, relationManyToOne)
-		}
+		recordBatchCacheHit(p.Context, metrics, relationManyToOne)
 		return firstGroupedRecordByTuple(cached, fkValues), true, nil
 	}
 	state.IncrementCacheMiss()
-	if metrics != nil {
-		metrics.RecordBatchCacheMiss(p.Context, relationManyToOne)
-	}
+	recordBatchCacheMiss(p.Context, metrics, relationManyToOne)
 
 	parentFields := make([]string, len(localColumns))
 	for i, colName := range localColumns {
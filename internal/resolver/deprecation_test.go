@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"testing"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/naming"
+	"tidb-graphql/internal/schemafilter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecatedTablesAndColumns(t *testing.T) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "name", DataType: "varchar", Comment: "Legal name", DeprecationReason: "Use fullName."},
+			{Name: "full_name", DataType: "varchar"},
+		},
+		Indexes: []introspection.Index{{Name: "PRIMARY", Unique: true, Columns: []string{"id"}}},
+		Relationships: []introspection.Relationship{
+			{
+				IsOneToMany:      true,
+				LocalColumns:     []string{"id"},
+				RemoteTable:      "legacy_notes",
+				RemoteColumns:    []string{"user_id"},
+				GraphQLFieldName: "legacyNotes",
+			},
+		},
+	}
+	notes := introspection.Table{
+		Name:              "legacy_notes",
+		DeprecationReason: "Notes move to the CRM.",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "user_id", DataType: "int"},
+			{Name: "body", DataType: "text"},
+		},
+		Indexes: []introspection.Index{{Name: "PRIMARY", Unique: true, Columns: []string{"id"}}},
+	}
+	renamePrimaryKeyID(&users)
+	renamePrimaryKeyID(&notes)
+
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, notes}}
+	r := NewResolver(nil, dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	userFields := r.buildGraphQLType(users).Fields()
+	assert.Equal(t, "Use fullName.", userFields["name"].DeprecationReason)
+	assert.Equal(t, "Legal name", userFields["name"].Description)
+	assert.Empty(t, userFields["fullName"].DeprecationReason)
+	require.Contains(t, userFields, "legacyNotes")
+	assert.Equal(t, "Notes move to the CRM.", userFields["legacyNotes"].DeprecationReason)
+
+	queryFields := schema.QueryType().Fields()
+	assert.Empty(t, queryFields["users"].DeprecationReason)
+	assert.Empty(t, queryFields["user"].DeprecationReason)
+	require.Contains(t, queryFields, "legacyNotes")
+	assert.Equal(t, "Notes move to the CRM.", queryFields["legacyNotes"].DeprecationReason)
+	assert.Equal(t, "Notes move to the CRM.", queryFields["legacyNote"].DeprecationReason)
+
+	mutationFields := schema.MutationType().Fields()
+	assert.Empty(t, mutationFields["createUser"].DeprecationReason)
+	require.Contains(t, mutationFields, "deleteLegacyNote")
+	assert.Equal(t, "Notes move to the CRM.", mutationFields["deleteLegacyNote"].DeprecationReason)
+}
@@ -0,0 +1,18 @@
+package resolver
+
+import (
+	"tidb-graphql/internal/gqlrequest"
+
+	"github.com/graphql-go/graphql"
+)
+
+// dryRunDirective declares @dryRun. The HTTP layer detects it during request
+// analysis and rolls back the mutation transaction once the operation has run,
+// so resolvers need no dry-run specific code paths.
+func dryRunDirective() *graphql.Directive {
+	return graphql.NewDirective(graphql.DirectiveConfig{
+		Name:        gqlrequest.DryRunDirectiveName,
+		Description: "Runs the mutation operation normally, returning its success payloads and typed errors, then rolls back every write.",
+		Locations:   []string{graphql.DirectiveLocationMutation},
+	})
+}
@@ -0,0 +1,37 @@
+package resolver
+
+import (
+	"testing"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/naming"
+	"tidb-graphql/internal/schemafilter"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGraphQLSchema_DryRunDirectiveOnMutations(t *testing.T) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "name", DataType: "varchar"},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	r := NewResolver(nil, &introspection.Schema{Tables: []introspection.Table{users}}, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	directive := schema.Directive("dryRun")
+	require.NotNil(t, directive)
+	assert.Equal(t, []string{graphql.DirectiveLocationMutation}, directive.Locations)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query @dryRun { users { nodes { name } } }`,
+	})
+	require.NotEmpty(t, result.Errors, "@dryRun must be rejected on queries")
+}
@@ -0,0 +1,71 @@
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/explain"
+	"tidb-graphql/internal/observability"
+
+	"github.com/graphql-go/graphql"
+)
+
+// instrumentExplainResolvers wraps every query-side field resolver so SQL issued
+// beneath it is attributed to the field's response path when an
+// explain.Recorder is in the request context. Mutation fields are left alone;
+// the explain endpoint only runs queries.
+func instrumentExplainResolvers(schema *graphql.Schema) {
+	mutationType := schema.MutationType()
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") || (mutationType != nil && obj == mutationType) {
+			continue
+		}
+		for _, field := range obj.Fields() {
+			if field.Resolve != nil {
+				field.Resolve = explainFieldResolver(field.Resolve)
+			}
+		}
+	}
+}
+
+func explainFieldResolver(next graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if explain.RecorderFromContext(p.Context) != nil && p.Info.Path != nil {
+			p.Context = explain.WithPath(p.Context, explain.PathString(p.Info.Path.AsArray()))
+		}
+		return next(p)
+	}
+}
+
+// explainingExecutor records each statement before delegating to the real executor.
+type explainingExecutor struct {
+	next queryContextExecutor
+}
+
+func (e explainingExecutor) QueryContext(ctx context.Context, query string, args ...any) (dbexec.Rows, error) {
+	explain.RecordQuery(ctx, query, args)
+	return e.next.QueryContext(ctx, query, args...)
+}
+
+func recordBatchSkipped(ctx context.Context, metrics *observability.GraphQLMetrics, relation, reason string) {
+	if metrics != nil {
+		metrics.RecordBatchSkipped(ctx, relation, reason)
+	}
+	explain.RecordBatching(ctx, explain.BatchSkippedPrefix+reason)
+}
+
+func recordBatchCacheHit(ctx context.Context, metrics *observability.GraphQLMetrics, relation string) {
+	if metrics != nil {
+		metrics.RecordBatchCacheHit(ctx, relation)
+	}
+	explain.RecordBatching(ctx, explain.BatchCacheHit)
+}
+
+func recordBatchCacheMiss(ctx context.Context, metrics *observability.GraphQLMetrics, relation string) {
+	if metrics != nil {
+		metrics.RecordBatchCacheMiss(ctx, relation)
+	}
+	explain.RecordBatching(ctx, explain.BatchBatched)
+}
@@ -0,0 +1,154 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/explain"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/naming"
+	"tidb-graphql/internal/planner"
+	"tidb-graphql/internal/schemafilter"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func responsePath(keys ...interface{}) *graphql.ResponsePath {
+	var path *graphql.ResponsePath
+	for _, key := range keys {
+		path = path.WithKey(key)
+	}
+	return path
+}
+
+func TestExplainRecordsSQLAndBatchingPerField(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	users := introspection.Table{
+		Name:    "users",
+		Columns: []introspection.Column{{Name: "id", IsPrimaryKey: true}, {Name: "username"}},
+	}
+	posts := introspection.Table{
+		Name:    "posts",
+		Columns: []introspection.Column{{Name: "id", IsPrimaryKey: true}, {Name: "user_id"}, {Name: "title"}},
+	}
+	renamePrimaryKeyID(&users)
+	renamePrimaryKeyID(&posts)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, posts}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	recorder := explain.NewRecorder(0)
+	ctx := explain.WithRecorder(NewBatchingContext(context.Background()), recorder)
+
+	listField := &ast.Field{
+		Name: &ast.Name{Value: "posts"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{
+				Name: &ast.Name{Value: "nodes"},
+				SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+					&ast.Field{Name: &ast.Name{Value: "id"}},
+					&ast.Field{Name: &ast.Name{Value: "userId"}},
+				}},
+			},
+		}},
+	}
+	listArgs := map[string]interface{}{"first": 2}
+	listPlan, err := planner.PlanConnection(dbSchema, posts, listField, listArgs)
+	require.NoError(t, err)
+	expectQuery(t, mock, listPlan.Root.SQL, listPlan.Root.Args, sqlmock.NewRows([]string{"id", "user_id"}).
+		AddRow(10, 1).
+		AddRow(11, 2))
+
+	listResult, err := explainFieldResolver(r.makeConnectionResolver(posts))(graphql.ResolveParams{
+		Args:    listArgs,
+		Context: ctx,
+		Info:    graphql.ResolveInfo{FieldASTs: []*ast.Field{listField}, Path: responsePath("posts")},
+	})
+	require.NoError(t, err)
+	parentRows := listResult.(map[string]interface{})["nodes"].([]map[string]interface{})
+	require.Len(t, parentRows, 2)
+
+	batchPlan, err := planner.PlanManyToOneBatch(users, nil, []string{"id"}, []planner.ParentTuple{
+		{Values: []interface{}{1}},
+		{Values: []interface{}{2}},
+	})
+	require.NoError(t, err)
+	expectQuery(t, mock, batchPlan.SQL, batchPlan.Args, sqlmock.NewRows([]string{"id", "username", "__batch_parent_id"}).
+		AddRow(1, "alice", 1).
+		AddRow(2, "bob", 2))
+
+	rel := introspection.Relationship{
+		IsManyToOne:      true,
+		LocalColumns:     []string{"user_id"},
+		RemoteTable:      "users",
+		RemoteColumns:    []string{"id"},
+		GraphQLFieldName: "user",
+	}
+	childResolver := explainFieldResolver(r.makeManyToOneResolver(posts, rel))
+	for i, parent := range parentRows {
+		_, err := childResolver(graphql.ResolveParams{
+			Source:  parent,
+			Context: ctx,
+			Info: graphql.ResolveInfo{
+				FieldASTs: []*ast.Field{{Name: &ast.Name{Value: "user"}}},
+				Path:      responsePath("posts", "nodes", i, "user"),
+			},
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	fields := recorder.Fields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "posts", fields[0].Path)
+	require.Len(t, fields[0].Queries, 1)
+	assert.Equal(t, listPlan.Root.SQL, fields[0].Queries[0].SQL)
+	assert.Equal(t, listPlan.Root.Args, fields[0].Queries[0].Args)
+
+	assert.Equal(t, "posts.nodes.user", fields[1].Path)
+	require.Len(t, fields[1].Queries, 1)
+	assert.Equal(t, batchPlan.SQL, fields[1].Queries[0].SQL)
+	assert.Equal(t, map[string]int{explain.BatchBatched: 1, explain.BatchCacheHit: 1}, fields[1].Batching)
+}
+
+func TestExplainInstrumentationAttributesRootFields(t *testing.T) {
+	users := introspection.Table{
+		Name:    "users",
+		Columns: []introspection.Column{{Name: "id", DataType: "int", IsPrimaryKey: true}},
+	}
+	renamePrimaryKeyID(&users)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users}}
+
+	for _, enabled := range []bool{false, true} {
+		db, mock := newMockDB(t)
+		r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{Explain: enabled})
+		schema, err := r.BuildGraphQLSchema()
+		require.NoError(t, err)
+
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		recorder := explain.NewRecorder(0)
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ users { nodes { databaseId } } }`,
+			Context:       explain.WithRecorder(NewBatchingContext(context.Background()), recorder),
+		})
+		require.Empty(t, result.Errors)
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+
+		fields := recorder.Fields()
+		require.Len(t, fields, 1)
+		require.Len(t, fields[0].Queries, 1)
+		if enabled {
+			assert.Equal(t, "users", fields[0].Path)
+		} else {
+			assert.Empty(t, fields[0].Path, "resolvers are only instrumented when explain is enabled")
+		}
+	}
+}
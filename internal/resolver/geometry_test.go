@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/geojson"
+	"tidb-graphql/internal/introspection"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryColumnsResolveAndFilter(t *testing.T) {
+	stores := introspection.Table{
+		Name: "stores",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "location", DataType: "point", ColumnType: "point", IsNullable: true, SRID: 4326},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "idx_stores_location", Type: "SPATIAL", Columns: []string{"location"}},
+		},
+	}
+	renamePrimaryKeyID(&stores)
+	dbSchema := &introspection.Schema{
+		Tables:  []introspection.Table{stores},
+		Spatial: introspection.SpatialFunctions{Distance: true},
+	}
+
+	db, mock := newMockDB(t)
+	defer db.Close()
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	filter, ok := schema.Type("GeoJSONFilter").(*graphql.InputObject)
+	require.True(t, ok)
+	assert.Contains(t, filter.Fields(), "distanceWithin")
+	assert.NotContains(t, filter.Fields(), "intersectsBox")
+	orderBy, ok := schema.Type("StoresOrderByClauseInput").(*graphql.InputObject)
+	require.True(t, ok)
+	assert.NotContains(t, orderBy.Fields(), "location")
+
+	point := geojson.Geometry{Type: geojson.TypePoint, Point: geojson.Position{13.4, 52.5}}
+	rows := sqlmock.NewRows([]string{"id", "location"}).AddRow(1, point.Stored(4326))
+	mock.ExpectQuery("ST_Distance\\(`location`, ST_GeomFromWKB\\(\\?, \\?\\)\\) <= \\?").
+		WithArgs(point.WKB(), uint32(4326), 500.0).
+		WillReturnRows(rows)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ stores(where: { location: { distanceWithin: { from: { type: "Point", coordinates: [13.4, 52.5] }, distance: 500 } } }) { nodes { location } } }`,
+		Context:       NewBatchingContext(context.Background()),
+	})
+	require.Empty(t, result.Errors)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	nodes := result.Data.(map[string]interface{})["stores"].(map[string]interface{})["nodes"].([]interface{})
+	require.Len(t, nodes, 1)
+	assert.Equal(t, map[string]interface{}{
+		"type":        "Point",
+		"coordinates": []interface{}{13.4, 52.5},
+	}, nodes[0].(map[string]interface{})["location"])
+}
@@ -0,0 +1,181 @@
+package resolver
+
+import (
+	"fmt"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/planner"
+
+	"github.com/graphql-go/graphql"
+)
+
+// addHierarchyFields adds ancestors, descendants and path fields to tables with
+// a self-referencing foreign key. Names already used by a column or
+// relationship keep their existing field.
+func (r *Resolver) addHierarchyFields(fields graphql.Fields, table introspection.Table) {
+	ref, ok := introspection.HierarchyReference(table)
+	if !ok {
+		return
+	}
+
+	tableType := r.buildGraphQLType(table)
+	edgeType := r.buildHierarchyEdgeType(table, tableType)
+	maxDepthArg := func() *graphql.ArgumentConfig {
+		return &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: fmt.Sprintf("Levels to walk, between 1 and %d. Defaults to %d.", planner.MaxHierarchyDepth, planner.DefaultHierarchyDepth),
+		}
+	}
+
+	hierarchyFields := map[string]*graphql.Field{
+		"ancestors": {
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))),
+			Description: "Parent rows reached through " + ref.ParentColumn + ", nearest first.",
+			Args: graphql.FieldConfigArgument{
+				"maxDepth": maxDepthArg(),
+			},
+			Resolve: r.makeHierarchyResolver(table, ref, planner.HierarchyAncestors),
+		},
+		"descendants": {
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))),
+			Description: "Child rows reached through " + ref.ParentColumn + ", ordered by depth.",
+			Args: graphql.FieldConfigArgument{
+				"maxDepth": maxDepthArg(),
+				"limit": &graphql.ArgumentConfig{
+					Type: r.nonNegativeIntScalar(),
+				},
+			},
+			Resolve: r.makeHierarchyResolver(table, ref, planner.HierarchyDescendants),
+		},
+		"path": {
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tableType))),
+			Description: "Rows from the root down to and including this row. Fails when the root is more than maxDepth levels up.",
+			Args: graphql.FieldConfigArgument{
+				"maxDepth": maxDepthArg(),
+			},
+			Resolve: r.makeHierarchyResolver(table, ref, planner.HierarchyPath),
+		},
+	}
+	for name, field := range hierarchyFields {
+		if _, exists := fields[name]; exists {
+			continue
+		}
+		fields[name] = field
+	}
+}
+
+func (r *Resolver) buildHierarchyEdgeType(table introspection.Table, tableType *graphql.Object) *graphql.Object {
+	typeName := introspection.GraphQLTypeName(table) + "HierarchyEdge"
+
+	r.mu.RLock()
+	if cached, ok := r.hierarchyEdgeCache[typeName]; ok {
+		r.mu.RUnlock()
+		return cached
+	}
+	r.mu.RUnlock()
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: typeName,
+		Fields: graphql.Fields{
+			"depth": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Levels between this row and the row the field was selected on.",
+			},
+			"node": &graphql.Field{
+				Type: graphql.NewNonNull(tableType),
+			},
+		},
+	})
+
+	r.mu.Lock()
+	if cached, ok := r.hierarchyEdgeCache[typeName]; ok {
+		r.mu.Unlock()
+		return cached
+	}
+	r.hierarchyEdgeCache[typeName] = edgeType
+	r.mu.Unlock()
+
+	return edgeType
+}
+
+// makeHierarchyResolver resolves one hierarchy field with a single recursive
+// query per parent row.
+func (r *Resolver) makeHierarchyResolver(table introspection.Table, ref introspection.SelfReference, direction planner.HierarchyDirection) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		var err error
+		p, err = r.withSnapshotContext(p)
+		if err != nil {
+			return nil, err
+		}
+
+		source, ok := p.Source.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid source type")
+		}
+		keyValues, ok := sourceValuesForColumns(table, source, []string{ref.KeyColumn})
+		if !ok {
+			return []interface{}{}, nil
+		}
+
+		field := firstFieldAST(p.Info.FieldASTs)
+		if field == nil {
+			return nil, fmt.Errorf("missing field AST")
+		}
+
+		var opts []planner.PlanOption
+		opts = append(opts, planner.WithSchema(r.dbSchema))
+		opts = append(opts, planner.WithFragments(p.Info.Fragments))
+		opts = append(opts, planner.WithDefaultListLimit(r.defaultLimit))
+		if limits := r.limitsFor(p.Context); limits != nil {
+			opts = append(opts, planner.WithLimits(*limits))
+		}
+
+		plan, err := planner.PlanHierarchy(table, ref, direction, keyValues[0], field, p.Args, opts...)
+		if err != nil {
+			return nil, planError("failed to plan hierarchy", err)
+		}
+
+		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, plan.Root.SQL, plan.Root.Args...)
+		if err != nil {
+			return nil, normalizeQueryError(err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		extras := []string{planner.HierarchyDepthAlias}
+		if direction == planner.HierarchyPath {
+			extras = append(extras, planner.HierarchyTruncatedAlias)
+		}
+		results, err := scanRowsWithExtras(rows, plan.Columns, extras)
+		if err != nil {
+			return nil, err
+		}
+
+		depths := make([]interface{}, len(results))
+		truncated := false
+		for i, row := range results {
+			depths[i] = row[planner.HierarchyDepthAlias]
+			delete(row, planner.HierarchyDepthAlias)
+			if flag, _ := coerceBooleanColumnValue(row[planner.HierarchyTruncatedAlias]).(bool); flag {
+				truncated = true
+			}
+			delete(row, planner.HierarchyTruncatedAlias)
+		}
+		if truncated {
+			return nil, fmt.Errorf("path is deeper than maxDepth; raise maxDepth (up to %d) to reach the root", planner.MaxHierarchyDepth)
+		}
+		annotateRowsWithSnapshot(p.Context, results)
+		seedBatchRows(p, results)
+
+		if direction == planner.HierarchyPath {
+			return results, nil
+		}
+		edges := make([]map[string]interface{}, len(results))
+		for i, row := range results {
+			edges[i] = map[string]interface{}{
+				"depth": depths[i],
+				"node":  row,
+			}
+		}
+		return edges, nil
+	}
+}
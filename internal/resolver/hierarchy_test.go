@@ -0,0 +1,208 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/naming"
+	"tidb-graphql/internal/planner"
+	"tidb-graphql/internal/schemafilter"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hierarchyCategoriesTable() introspection.Table {
+	categories := introspection.Table{
+		Name: "categories",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "name", DataType: "varchar"},
+			{Name: "parent_id", DataType: "int", IsNullable: true},
+		},
+		ForeignKeys: []introspection.ForeignKey{
+			{ColumnName: "parent_id", ReferencedTable: "categories", ReferencedColumn: "id", ConstraintName: "fk_parent"},
+		},
+	}
+	renamePrimaryKeyID(&categories)
+	return categories
+}
+
+func TestHierarchyFields_Schema(t *testing.T) {
+	categories := hierarchyCategoriesTable()
+	folders := introspection.Table{
+		Name: "folders",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "path", DataType: "varchar"},
+			{Name: "parent_id", DataType: "int", IsNullable: true},
+		},
+		ForeignKeys: []introspection.ForeignKey{
+			{ColumnName: "parent_id", ReferencedTable: "folders", ReferencedColumn: "id", ConstraintName: "fk_folder_parent"},
+		},
+	}
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+		},
+	}
+	renamePrimaryKeyID(&folders)
+	renamePrimaryKeyID(&users)
+
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{categories, folders, users}}
+	r := NewResolver(nil, dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	categoryType := unwrapObjectType(t, schema.Type(introspection.GraphQLTypeName(categories)))
+	fields := categoryType.Fields()
+	for _, name := range []string{"ancestors", "descendants", "path"} {
+		require.Contains(t, fields, name)
+	}
+	assert.Equal(t, "["+introspection.GraphQLTypeName(categories)+"HierarchyEdge!]!", fields["ancestors"].Type.String())
+	assert.Equal(t, "["+introspection.GraphQLTypeName(categories)+"!]!", fields["path"].Type.String())
+
+	var descendantArgs []string
+	for _, arg := range fields["descendants"].Args {
+		descendantArgs = append(descendantArgs, arg.Name())
+	}
+	assert.ElementsMatch(t, []string{"maxDepth", "limit"}, descendantArgs)
+
+	edgeType := unwrapObjectType(t, schema.Type(introspection.GraphQLTypeName(categories)+"HierarchyEdge"))
+	assert.Contains(t, edgeType.Fields(), "depth")
+	assert.Contains(t, edgeType.Fields(), "node")
+
+	// An existing column keeps its name; the other hierarchy fields are still added.
+	folderFields := unwrapObjectType(t, schema.Type(introspection.GraphQLTypeName(folders))).Fields()
+	assert.Equal(t, "String!", folderFields["path"].Type.String())
+	assert.Contains(t, folderFields, "ancestors")
+
+	userFields := unwrapObjectType(t, schema.Type(introspection.GraphQLTypeName(users))).Fields()
+	assert.NotContains(t, userFields, "ancestors")
+	assert.NotContains(t, userFields, "descendants")
+}
+
+func TestHierarchyResolver_DescendantEdges(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	categories := hierarchyCategoriesTable()
+	ref, ok := introspection.HierarchyReference(categories)
+	require.True(t, ok)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{categories}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	field := &ast.Field{
+		Name: &ast.Name{Value: "descendants"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "depth"}},
+			&ast.Field{
+				Name: &ast.Name{Value: "node"},
+				SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+					&ast.Field{Name: &ast.Name{Value: "name"}},
+				}},
+			},
+		}},
+	}
+	args := map[string]interface{}{"maxDepth": 2}
+	plan, err := planner.PlanHierarchy(categories, ref, planner.HierarchyDescendants, 1, field, args, planner.WithDefaultListLimit(r.defaultLimit))
+	require.NoError(t, err)
+
+	rows := sqlmock.NewRows([]string{"id", "name", planner.HierarchyDepthAlias}).
+		AddRow(2, "Computers", 1).
+		AddRow(3, "Laptops", 2)
+	expectQuery(t, mock, plan.Root.SQL, plan.Root.Args, rows)
+
+	resolverFn := r.makeHierarchyResolver(categories, ref, planner.HierarchyDescendants)
+	result, err := resolverFn(graphql.ResolveParams{
+		Source:  map[string]interface{}{"databaseId": 1},
+		Args:    args,
+		Context: context.Background(),
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	})
+	require.NoError(t, err)
+
+	edges, ok := result.([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, edges, 2)
+	assert.EqualValues(t, 1, edges[0]["depth"])
+	assert.EqualValues(t, 2, edges[1]["depth"])
+	node := edges[1]["node"].(map[string]interface{})
+	assert.Equal(t, "Laptops", node["name"])
+	assert.NotContains(t, node, planner.HierarchyDepthAlias)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHierarchyResolver_NullKeyReturnsEmpty(t *testing.T) {
+	categories := hierarchyCategoriesTable()
+	ref, _ := introspection.HierarchyReference(categories)
+	r := NewResolver(nil, &introspection.Schema{Tables: []introspection.Table{categories}}, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	result, err := r.makeHierarchyResolver(categories, ref, planner.HierarchyPath)(graphql.ResolveParams{
+		Source:  map[string]interface{}{},
+		Context: context.Background(),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestHierarchyResolver_PathFailsWhenTruncated(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	categories := hierarchyCategoriesTable()
+	ref, ok := introspection.HierarchyReference(categories)
+	require.True(t, ok)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{categories}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	field := &ast.Field{
+		Name: &ast.Name{Value: "path"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "name"}},
+		}},
+	}
+	args := map[string]interface{}{"maxDepth": 1}
+	plan, err := planner.PlanHierarchy(categories, ref, planner.HierarchyPath, 3, field, args)
+	require.NoError(t, err)
+	resolverFn := r.makeHierarchyResolver(categories, ref, planner.HierarchyPath)
+	params := graphql.ResolveParams{
+		Source:  map[string]interface{}{"databaseId": 3},
+		Args:    args,
+		Context: context.Background(),
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	}
+	columns := []string{"id", "name", planner.HierarchyDepthAlias, planner.HierarchyTruncatedAlias}
+
+	// The parent of the deepest row exists beyond maxDepth.
+	expectQuery(t, mock, plan.Root.SQL, plan.Root.Args, sqlmock.NewRows(columns).
+		AddRow(2, "Computers", 1, 1).
+		AddRow(3, "Laptops", 0, 0))
+	_, err = resolverFn(params)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maxDepth")
+
+	// The walk reached the root.
+	expectQuery(t, mock, plan.Root.SQL, plan.Root.Args, sqlmock.NewRows(columns).
+		AddRow(2, "Computers", 1, 0).
+		AddRow(3, "Laptops", 0, 0))
+	result, err := resolverFn(params)
+	require.NoError(t, err)
+	path, ok := result.([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, path, 2)
+	assert.NotContains(t, path[0], planner.HierarchyTruncatedAlias)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
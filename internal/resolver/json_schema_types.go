@@ -0,0 +1,159 @@
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/jsonschema"
+
+	"github.com/graphql-go/graphql"
+)
+
+// jsonSchemaObjectType returns the object type generated from the JSON Schema
+// attached to col, named like enum types (e.g. users.settings -> UserSettings).
+// Nested objects append the property name (UserSettingsNotifications).
+func (r *Resolver) jsonSchemaObjectType(table introspection.Table, col *introspection.Column) *graphql.Object {
+	return r.jsonSchemaObject(r.enumTypeName(table, *col), col.JSONSchema)
+}
+
+// jsonSchemaInputType returns the input counterpart of jsonSchemaObjectType.
+func (r *Resolver) jsonSchemaInputType(table introspection.Table, col *introspection.Column) *graphql.InputObject {
+	return r.jsonSchemaInput(r.enumTypeName(table, *col), col.JSONSchema)
+}
+
+func (r *Resolver) jsonSchemaObject(name string, schema *jsonschema.Schema) *graphql.Object {
+	r.mu.RLock()
+	cached := r.jsonObjectCache[name]
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	fields := graphql.Fields{}
+	for _, prop := range schema.PropertyNames {
+		child := schema.Properties[prop]
+		fields[prop] = &graphql.Field{
+			Type:        r.jsonSchemaOutputField(name+r.singularNamer.ToGraphQLTypeName(prop), child, schema.Required[prop]),
+			Description: child.Description,
+		}
+	}
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name,
+		Description: schema.Description,
+		Fields:      fields,
+	})
+
+	r.mu.Lock()
+	if existing := r.jsonObjectCache[name]; existing != nil {
+		obj = existing
+	} else {
+		r.jsonObjectCache[name] = obj
+	}
+	r.mu.Unlock()
+	return obj
+}
+
+func (r *Resolver) jsonSchemaInput(name string, schema *jsonschema.Schema) *graphql.InputObject {
+	inputName := name + "Input"
+	r.mu.RLock()
+	cached := r.jsonInputCache[inputName]
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	for _, prop := range schema.PropertyNames {
+		child := schema.Properties[prop]
+		fields[prop] = &graphql.InputObjectFieldConfig{
+			Type:        r.jsonSchemaInputField(name+r.singularNamer.ToGraphQLTypeName(prop), child, schema.Required[prop]),
+			Description: child.Description,
+		}
+	}
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        inputName,
+		Description: schema.Description,
+		Fields:      fields,
+	})
+
+	r.mu.Lock()
+	if existing := r.jsonInputCache[inputName]; existing != nil {
+		input = existing
+	} else {
+		r.jsonInputCache[inputName] = input
+	}
+	r.mu.Unlock()
+	return input
+}
+
+func (r *Resolver) jsonSchemaOutputField(name string, schema *jsonschema.Schema, required bool) graphql.Output {
+	var out graphql.Output
+	switch schema.Type {
+	case jsonschema.TypeObject:
+		if len(schema.Properties) == 0 {
+			out = r.jsonScalar()
+		} else {
+			out = r.jsonSchemaObject(name, schema)
+		}
+	case jsonschema.TypeArray:
+		out = graphql.NewList(r.jsonSchemaOutputField(name, schema.Items, true))
+	default:
+		out = jsonSchemaScalar(schema.Type)
+	}
+	if required && !schema.Nullable {
+		return graphql.NewNonNull(out)
+	}
+	return out
+}
+
+func (r *Resolver) jsonSchemaInputField(name string, schema *jsonschema.Schema, required bool) graphql.Input {
+	var in graphql.Input
+	switch schema.Type {
+	case jsonschema.TypeObject:
+		if len(schema.Properties) == 0 {
+			in = r.jsonScalar()
+		} else {
+			in = r.jsonSchemaInput(name, schema)
+		}
+	case jsonschema.TypeArray:
+		in = graphql.NewList(r.jsonSchemaInputField(name, schema.Items, true))
+	default:
+		in = jsonSchemaScalar(schema.Type)
+	}
+	if required && !schema.Nullable {
+		return graphql.NewNonNull(in)
+	}
+	return in
+}
+
+func jsonSchemaScalar(typ string) *graphql.Scalar {
+	switch typ {
+	case jsonschema.TypeInteger:
+		return graphql.Int
+	case jsonschema.TypeNumber:
+		return graphql.Float
+	case jsonschema.TypeBoolean:
+		return graphql.Boolean
+	default:
+		return graphql.String
+	}
+}
+
+// decodeJSONDocument decodes a stored JSON column value for a typed object field.
+func decodeJSONDocument(raw interface{}) (interface{}, error) {
+	var data []byte
+	switch v := raw.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return raw, nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("stored value is not valid JSON")
+	}
+	return doc, nil
+}
@@ -0,0 +1,109 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/jsonschema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jsonSchemaUsersTable(t *testing.T) introspection.Table {
+	t.Helper()
+	settings, err := jsonschema.Parse([]byte(`{
+	  "type": "object",
+	  "required": ["theme"],
+	  "properties": {
+	    "theme": {"type": "string", "enum": ["light", "dark"]},
+	    "fontSize": {"type": "integer", "minimum": 8},
+	    "notifications": {
+	      "type": "object",
+	      "properties": {"email": {"type": "boolean"}, "channels": {"type": "array", "items": {"type": "string"}}}
+	    },
+	    "extra": {"type": "object"}
+	  }
+	}`))
+	require.NoError(t, err)
+
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "settings", DataType: "json", IsNullable: true, JSONSchema: settings},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	return users
+}
+
+func TestJSONSchemaColumnTypes(t *testing.T) {
+	users := jsonSchemaUsersTable(t)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users}}
+
+	db, mock := newMockDB(t)
+	defer db.Close()
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	settingsType, ok := schema.Type("UserSettings").(*graphql.Object)
+	require.True(t, ok)
+	fields := settingsType.Fields()
+	assert.Equal(t, "String!", fields["theme"].Type.String())
+	assert.Equal(t, "Int", fields["fontSize"].Type.String())
+	assert.Equal(t, "UserSettingsNotifications", fields["notifications"].Type.String())
+	assert.Equal(t, "JSON", fields["extra"].Type.String())
+	assert.Equal(t, "[String!]", settingsType.Fields()["notifications"].Type.(*graphql.Object).Fields()["channels"].Type.String())
+
+	inputType, ok := schema.Type("UserSettingsInput").(*graphql.InputObject)
+	require.True(t, ok)
+	assert.Equal(t, "String!", inputType.Fields()["theme"].Type.String())
+	assert.Equal(t, "UserSettingsNotificationsInput", inputType.Fields()["notifications"].Type.String())
+
+	rows := sqlmock.NewRows([]string{"id", "settings"}).
+		AddRow(1, []byte(`{"theme":"dark","fontSize":14,"notifications":{"email":true,"channels":["sms"]},"extra":{"beta":true}}`))
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users { nodes { settings { theme fontSize notifications { email channels } extra } } } }`,
+		Context:       NewBatchingContext(context.Background()),
+	})
+	require.Empty(t, result.Errors)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	nodes := result.Data.(map[string]interface{})["users"].(map[string]interface{})["nodes"].([]interface{})
+	require.Len(t, nodes, 1)
+	assert.Equal(t, map[string]interface{}{
+		"theme":         "dark",
+		"fontSize":      14,
+		"notifications": map[string]interface{}{"email": true, "channels": []interface{}{"sms"}},
+		"extra":         `{"beta":true}`,
+	}, nodes[0].(map[string]interface{})["settings"])
+}
+
+func TestJSONSchemaMutationInputValidation(t *testing.T) {
+	col := jsonSchemaUsersTable(t).Columns[1]
+
+	stored, err := normalizeMutationInputValue(col, map[string]interface{}{
+		"theme":         "light",
+		"notifications": map[string]interface{}{"channels": []interface{}{"email"}},
+		"extra":         `{"beta":true}`,
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"theme":"light","notifications":{"channels":["email"]},"extra":{"beta":true}}`, stored.(string))
+
+	_, err = normalizeMutationInputValue(col, map[string]interface{}{"theme": "blue"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$.theme: value is not one of the allowed values")
+
+	_, err = normalizeMutationInputValue(col, map[string]interface{}{"theme": "dark", "fontSize": 4})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$.fontSize: must be >= 8")
+}
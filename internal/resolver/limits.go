@@ -0,0 +1,47 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tidb-graphql/internal/planner"
+)
+
+type planLimitsKey struct{}
+
+// WithPlanLimits overrides the server-wide plan limits for one request. The
+// HTTP layer attaches them when a per-role or per-operation override matches.
+func WithPlanLimits(ctx context.Context, limits planner.PlanLimits) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, planLimitsKey{}, limits)
+}
+
+// PlanLimitsFromContext returns the request-scoped plan limits, if any.
+func PlanLimitsFromContext(ctx context.Context) (planner.PlanLimits, bool) {
+	if ctx == nil {
+		return planner.PlanLimits{}, false
+	}
+	limits, ok := ctx.Value(planLimitsKey{}).(planner.PlanLimits)
+	return limits, ok
+}
+
+// limitsFor returns the plan limits that apply to the request in ctx.
+func (r *Resolver) limitsFor(ctx context.Context) *planner.PlanLimits {
+	if limits, ok := PlanLimitsFromContext(ctx); ok {
+		return &limits
+	}
+	return r.limits
+}
+
+// planError wraps planning failures, except limit rejections, which are
+// returned as-is so their extensions reach the client.
+func planError(msg string, err error) error {
+	var limitErr *planner.LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
@@ -0,0 +1,44 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/planner"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanLimitsFromContext(t *testing.T) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users}}
+
+	db, mock := newMockDB(t)
+	defer db.Close()
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, &planner.PlanLimits{MaxRows: 1000}, 0, ResolverConfig{})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	ctx := WithPlanLimits(NewBatchingContext(context.Background()), planner.PlanLimits{MaxRows: 10, Override: "public"})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users(first: 50) { nodes { databaseId } } }`,
+		Context:       ctx,
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "query exceeds maximum rows of 10 (estimated: 100)", result.Errors[0].Message)
+	assert.Equal(t, "LIMIT_EXCEEDED", result.Errors[0].Extensions["code"])
+	assert.Equal(t, "rows", result.Errors[0].Extensions["limit"])
+	assert.Equal(t, "public", result.Errors[0].Extensions["override"])
+}
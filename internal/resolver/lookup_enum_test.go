@@ -0,0 +1,77 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupEnumColumnsResolveAndFilter(t *testing.T) {
+	lookup := &introspection.EnumLookup{Table: "order_statuses", Column: "code"}
+	orders := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{
+				Name:       "status_code",
+				DataType:   "int",
+				ColumnType: "int",
+				EnumValues: []string{"1", "2", "3"},
+				EnumValueMeta: map[string]introspection.EnumValueMeta{
+					"1": {Name: "OPEN", Description: "Open order"},
+					"2": {Name: "Shipped to customer"},
+					"3": {DeprecationReason: "No longer assigned."},
+				},
+				EnumLookup: lookup,
+			},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "idx_status", Columns: []string{"status_code"}},
+		},
+	}
+	renamePrimaryKeyID(&orders)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{orders}}
+
+	db, mock := newMockDB(t)
+	defer db.Close()
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	enumType, ok := schema.Type("OrderStatusCode").(*graphql.Enum)
+	require.True(t, ok)
+	values := make(map[string]*graphql.EnumValueDefinition)
+	for _, value := range enumType.Values() {
+		values[value.Name] = value
+	}
+	require.Contains(t, values, "OPEN")
+	assert.Equal(t, "Open order", values["OPEN"].Description)
+	assert.Contains(t, values, "SHIPPED_TO_CUSTOMER")
+	require.Contains(t, values, "VALUE_3")
+	assert.Equal(t, "No longer assigned.", values["VALUE_3"].DeprecationReason)
+
+	rows := sqlmock.NewRows([]string{"id", "status_code"}).AddRow(1, int64(1))
+	mock.ExpectQuery("`status_code` = \\?").
+		WithArgs(int64(1)).
+		WillReturnRows(rows)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ orders(where: { statusCode: { eq: OPEN } }) { nodes { statusCode } } }`,
+		Context:       NewBatchingContext(context.Background()),
+	})
+	require.Empty(t, result.Errors)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	nodes := result.Data.(map[string]interface{})["orders"].(map[string]interface{})["nodes"].([]interface{})
+	require.Len(t, nodes, 1)
+	assert.Equal(t, "OPEN", nodes[0].(map[string]interface{})["statusCode"])
+}
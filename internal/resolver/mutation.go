@@ -41,7 +41,6 @@ func (r *Resolver) addTableMutations(fields graphql.Fields, table introspection.
 	pkCols := introspection.PrimaryKeyColumns(table)
 	hasPK := len(pkCols) > 0
 
-	typeName := r.singularTypeName(table)
 	tableType := r.buildGraphQLType(table)
 
 	insertableCols := r.mutationInsertableColumns(table)
@@ -51,7 +50,7 @@ func (r *Resolver) addTableMutations(fields graphql.Fields, table introspection.
 		createInput := r.createInputType(table, insertableCols)
 		createSuccess := r.createSuccessType(table, tableType)
 		createResult := r.createResultUnion(table, createSuccess)
-		fields["create"+typeName] = &graphql.Field{
+		fields[r.mutationFieldName(mutationKindCreate, table)] = &graphql.Field{
 			Type: graphql.NewNonNull(createResult),
 			Args: graphql.FieldConfigArgument{
 				"input": &graphql.ArgumentConfig{
@@ -64,7 +63,7 @@ func (r *Resolver) addTableMutations(fields graphql.Fields, table introspection.
 
 	updatableCols := r.mutationUpdatableColumns(table)
 	updatableMap := columnNameSet(updatableCols)
-	if hasPK && len(updatableCols) > 0 {
+	if hasPK && (len(updatableCols) > 0 || !r.buildUpdateMutationPlan(table).empty()) {
 		updateInput := r.updateSetInputType(table, updatableCols)
 		updateSuccess := r.updateSuccessType(table, tableType)
 		updateResult := r.updateResultUnion(table, updateSuccess)
@@ -72,7 +71,7 @@ func (r *Resolver) addTableMutations(fields graphql.Fields, table introspection.
 		args["set"] = &graphql.ArgumentConfig{
 			Type: updateInput,
 		}
-		fields["update"+typeName] = &graphql.Field{
+		fields[r.mutationFieldName(mutationKindUpdate, table)] = &graphql.Field{
 			Type:    graphql.NewNonNull(updateResult),
 			Args:    args,
 			Resolve: r.makeUpdateResolver(table, updatableMap, pkCols, updateSuccess),
@@ -83,16 +82,106 @@ func (r *Resolver) addTableMutations(fields graphql.Fields, table introspection.
 		deleteSuccess := r.deleteSuccessType(table, pkCols)
 		deleteResult := r.deleteResultUnion(table, deleteSuccess)
 		args := r.primaryKeyArgs()
-		fields["delete"+typeName] = &graphql.Field{
+		fields[r.mutationFieldName(mutationKindDelete, table)] = &graphql.Field{
 			Type:    graphql.NewNonNull(deleteResult),
 			Args:    args,
 			Resolve: r.makeDeleteResolver(table, pkCols, deleteSuccess),
 		}
 	}
 
+	if hasPK {
+		r.addUniqueKeyMutations(fields, table, updatableMap, pkCols)
+	}
+
 	return fields
 }
 
+// Root mutation kinds; each is also the prefix of the mutation's field name.
+const (
+	mutationKindCreate = "create"
+	mutationKindUpdate = "update"
+	mutationKindDelete = "delete"
+)
+
+// mutationFieldName returns the root mutation for one kind of write to table,
+// e.g. "createUser" ("create_user" with snake field case).
+func (r *Resolver) mutationFieldName(kind string, table introspection.Table) string {
+	return r.singularNamer.JoinFieldName(kind, r.singularNamer.FieldNamePart(r.singularTypeName(table)))
+}
+
+// uniqueKeyMutationFieldName returns the root mutation of one kind addressed by
+// a non-primary unique index, e.g. "updateUserByEmail" ("update_user_by_email").
+func (r *Resolver) uniqueKeyMutationFieldName(kind string, table introspection.Table, idx introspection.Index) string {
+	return r.singularNamer.JoinFieldName(r.mutationFieldName(kind, table), r.connectFieldKey(table, idx))
+}
+
+// relationMutationFieldName returns the input field for one nested operation on
+// a relationship, e.g. "postsCreate" ("posts_create" with snake field case).
+func (r *Resolver) relationMutationFieldName(rel introspection.Relationship, op string) string {
+	return r.singularNamer.JoinFieldName(rel.GraphQLFieldName, op)
+}
+
+// addUniqueKeyMutations adds update/delete mutations addressed by a non-primary unique index,
+// e.g. updateUserByEmail(email:, set:) and deleteUserByEmail(email:). They reuse the result
+// unions of the node-ID variants so clients handle both shapes identically.
+func (r *Resolver) addUniqueKeyMutations(fields graphql.Fields, table introspection.Table, updatable map[string]bool, pkCols []introspection.Column) {
+	tableType := r.buildGraphQLType(table)
+	for _, idx := range table.Indexes {
+		if !idx.Unique || idx.Name == "PRIMARY" {
+			continue
+		}
+		args := r.uniqueKeyMutationArgs(table, idx)
+		if args == nil {
+			continue
+		}
+		if len(updatable) > 0 || !r.buildUpdateMutationPlan(table).empty() {
+			updateName := r.uniqueKeyMutationFieldName(mutationKindUpdate, table, idx)
+			if _, exists := fields[updateName]; !exists {
+				updateSuccess := r.updateSuccessType(table, tableType)
+				updateArgs := r.uniqueKeyMutationArgs(table, idx)
+				updateArgs["set"] = &graphql.ArgumentConfig{
+					Type: r.updateSetInputType(table, r.mutationUpdatableColumns(table)),
+				}
+				fields[updateName] = &graphql.Field{
+					Type:    graphql.NewNonNull(r.updateResultUnion(table, updateSuccess)),
+					Args:    updateArgs,
+					Resolve: r.makeUpdateResolverWithLocator(table, updatable, pkCols, updateSuccess, r.uniqueKeyRowLocator(table, pkCols, idx)),
+				}
+			}
+		}
+
+		deleteName := r.uniqueKeyMutationFieldName(mutationKindDelete, table, idx)
+		if _, exists := fields[deleteName]; !exists {
+			deleteSuccess := r.deleteSuccessType(table, pkCols)
+			fields[deleteName] = &graphql.Field{
+				Type:    graphql.NewNonNull(r.deleteResultUnion(table, deleteSuccess)),
+				Args:    args,
+				Resolve: r.makeDeleteResolverWithLocator(table, pkCols, deleteSuccess, r.uniqueKeyRowLocator(table, pkCols, idx)),
+			}
+		}
+	}
+}
+
+// uniqueKeyMutationArgs returns one non-null argument per unique index column.
+// Returns nil if any index column is not exposed on the table.
+func (r *Resolver) uniqueKeyMutationArgs(table introspection.Table, idx introspection.Index) graphql.FieldConfigArgument {
+	colMap := columnMap(table)
+	args := graphql.FieldConfigArgument{}
+	for _, colName := range idx.Columns {
+		col, ok := colMap[colName]
+		if !ok {
+			return nil
+		}
+		args[introspection.GraphQLFieldName(*col)] = &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(r.mapColumnTypeToGraphQLInput(table, col)),
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return args
+}
+
 func (r *Resolver) mutationInsertableColumns(table introspection.Table) []introspection.Column {
 	cols := make([]introspection.Column, 0, len(table.Columns))
 	for _, col := range table.Columns {
@@ -229,6 +318,9 @@ func (r *Resolver) updateSetInputType(table introspection.Table, columns []intro
 		}
 	}
 
+	// Relationship operations (reconnect, nested create/update/delete, junction connect/disconnect).
+	r.addUpdateRelationFields(fields, table, r.buildUpdateMutationPlan(table))
+
 	objType := graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:   typeName,
 		Fields: fields,
@@ -305,7 +397,7 @@ func (r *Resolver) buildCreateMutationPlan(table introspection.Table) createMuta
 			if r.connectInputForRel(rel) == nil {
 				continue
 			}
-			plan.connectFields[rel.GraphQLFieldName+"Connect"] = rel
+			plan.connectFields[r.relationMutationFieldName(rel, "connect")] = rel
 		case rel.IsOneToMany || rel.IsEdgeList:
 			if rel.IsCrossDatabase {
 				continue // cross-database nested create not supported
@@ -313,7 +405,7 @@ func (r *Resolver) buildCreateMutationPlan(table introspection.Table) createMuta
 			if r.nestedCreateInputForRel(table, rel) == nil {
 				continue
 			}
-			plan.nestedFields[rel.GraphQLFieldName+"Create"] = rel
+			plan.nestedFields[r.relationMutationFieldName(rel, "create")] = rel
 		case rel.IsManyToMany:
 			if rel.IsCrossDatabase {
 				continue // cross-database M2M connect not supported
@@ -321,7 +413,7 @@ func (r *Resolver) buildCreateMutationPlan(table introspection.Table) createMuta
 			if !r.m2mConnectSupported(rel) {
 				continue
 			}
-			plan.m2mFields[rel.GraphQLFieldName+"Connect"] = rel
+			plan.m2mFields[r.relationMutationFieldName(rel, "connect")] = rel
 		}
 	}
 	return plan
@@ -330,7 +422,7 @@ func (r *Resolver) buildCreateMutationPlan(table introspection.Table) createMuta
 // connectByUniqueInputTypeName returns the GraphQL type name for a connect-by-unique-index input.
 // e.g. "ConnectUserByEmailInput" for the unique email index on users.
 func (r *Resolver) connectByUniqueInputTypeName(remoteTable introspection.Table, idx introspection.Index) string {
-	return "Connect" + r.singularTypeName(remoteTable) + upperFirst(r.connectFieldKey(remoteTable, idx)) + "Input"
+	return "Connect" + r.singularTypeName(remoteTable) + r.singularNamer.TypeNamePart(r.connectFieldKey(remoteTable, idx)) + "Input"
 }
 
 // connectByUniqueFieldName returns the GraphQL field name used inside ConnectXxxInput
@@ -341,14 +433,13 @@ func (r *Resolver) connectByUniqueFieldName(remoteTable introspection.Table, idx
 
 func (r *Resolver) connectFieldKey(remoteTable introspection.Table, idx introspection.Index) string {
 	colMap := columnMap(remoteTable)
-	var sb strings.Builder
-	sb.WriteString("by")
+	key := "by"
 	for _, colName := range idx.Columns {
 		if col, ok := colMap[colName]; ok {
-			sb.WriteString(upperFirst(introspection.GraphQLFieldName(*col)))
+			key = r.singularNamer.JoinFieldName(key, introspection.GraphQLFieldName(*col))
 		}
 	}
-	return sb.String()
+	return key
 }
 
 // connectByUniqueInput builds the input object for a single unique-index connect strategy.
@@ -475,7 +566,7 @@ func (r *Resolver) nestedCreateInputForRel(parentTable introspection.Table, rel
 		return nil
 	}
 
-	typeName := "Create" + r.singularTypeName(parentTable) + upperFirst(rel.GraphQLFieldName) + "NestedInput"
+	typeName := "Create" + r.singularTypeName(parentTable) + r.singularNamer.TypeNamePart(rel.GraphQLFieldName) + "NestedInput"
 	cacheKey := nestedCreateCacheKey(parentTable, rel)
 	r.mu.RLock()
 	cached, ok := r.nestedCreateCache[cacheKey]
@@ -527,7 +618,7 @@ func (r *Resolver) nestedCreateInputForRel(parentTable introspection.Table, rel
 		if connectInput == nil {
 			continue
 		}
-		fieldName := childRel.GraphQLFieldName + "Connect"
+		fieldName := r.relationMutationFieldName(childRel, "connect")
 		if _, exists := fields[fieldName]; exists {
 			continue
 		}
@@ -888,15 +979,6 @@ func lowerFirst(s string) string {
 	return string(runes)
 }
 
-func upperFirst(s string) string {
-	if s == "" {
-		return s
-	}
-	runes := []rune(s)
-	runes[0] = unicode.ToUpper(runes[0])
-	return string(runes)
-}
-
 func (r *Resolver) createSuccessType(table introspection.Table, tableType *graphql.Object) *graphql.Object {
 	typeName := "Create" + r.singularTypeName(table) + "Success"
 	r.mu.RLock()
@@ -1039,6 +1121,7 @@ func (r *Resolver) updateResultUnion(table introspection.Table, successType *gra
 			r.sharedConflictErrorType(),
 			r.sharedConstraintErrorType(),
 			r.sharedPermissionErrorType(),
+			r.sharedNotFoundErrorType(),
 			r.sharedInternalErrorType(),
 		},
 		ResolveType: r.mutationResolveType(successType),
@@ -1204,6 +1287,8 @@ func mutationErrToPayloadAndTelemetry(err error) (map[string]interface{}, mutati
 		return mutationErrorPayload("ConstraintError", me.message, nil), mutationTypedFailureTelemetry("ConstraintError", mutationResultCodeNotNullViolation)
 	case "access_denied":
 		return mutationErrorPayload("PermissionError", me.message, nil), mutationTypedFailureTelemetry("PermissionError", mutationResultCodeAccessDenied)
+	case "not_found":
+		return mutationErrorPayload("NotFoundError", me.message, nil), mutationTypedFailureTelemetry("NotFoundError", mutationResultCodeNotFound)
 	default:
 		return mutationErrorPayload("InternalError", "internal server error", nil), mutationTypedFailureTelemetry("InternalError", mutationResultCodeInternal)
 	}
@@ -1291,7 +1376,7 @@ func (r *Resolver) makeCreateResolver(table introspection.Table, insertable map[
 			if !rel.IsManyToOne {
 				continue
 			}
-			fieldName := rel.GraphQLFieldName + "Connect"
+			fieldName := r.relationMutationFieldName(rel, "connect")
 			_, hasConnect := plan.connectFields[fieldName]
 			if !hasConnect {
 				continue
@@ -1393,7 +1478,72 @@ func (r *Resolver) makeCreateResolver(table introspection.Table, insertable map[
 	})
 }
 
+// mutationRowLocator resolves the primary key values of the row targeted by an update or
+// delete mutation. A nil map with a nil error means no row matched the arguments.
+type mutationRowLocator func(p graphql.ResolveParams, tx dbexec.TxExecutor) (map[string]interface{}, error)
+
+// nodeIDRowLocator locates the target row from the global Node `id` argument.
+func nodeIDRowLocator(table introspection.Table, pkCols []introspection.Column) mutationRowLocator {
+	return func(p graphql.ResolveParams, _ dbexec.TxExecutor) (map[string]interface{}, error) {
+		return pkValuesFromArgs(table, pkCols, p.Args)
+	}
+}
+
+// uniqueKeyRowLocator locates the target row from unique index arguments by reading its
+// primary key inside the mutation transaction.
+func (r *Resolver) uniqueKeyRowLocator(table introspection.Table, pkCols []introspection.Column, idx introspection.Index) mutationRowLocator {
+	return func(p graphql.ResolveParams, tx dbexec.TxExecutor) (map[string]interface{}, error) {
+		colMap := columnMap(table)
+		lookupValues := make(map[string]interface{}, len(idx.Columns))
+		for _, colName := range idx.Columns {
+			col, ok := colMap[colName]
+			if !ok {
+				return nil, fmt.Errorf("unique index %s references unknown column %s", idx.Name, colName)
+			}
+			gqlName := introspection.GraphQLFieldName(*col)
+			v, present := p.Args[gqlName]
+			if !present || v == nil {
+				return nil, newMutationError("missing unique key argument: "+gqlName, "invalid_input", 0)
+			}
+			normalized, err := normalizeMutationInputValue(*col, v)
+			if err != nil {
+				return nil, err
+			}
+			lookupValues[colName] = normalized
+		}
+
+		query, err := planner.PlanUniqueKeyLookup(table, pkCols, idx, lookupValues)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := tx.QueryContext(p.Context, query.SQL, query.Args...)
+		if err != nil {
+			return nil, normalizeMutationError(err)
+		}
+		defer func() { _ = rows.Close() }()
+
+		results, err := scanRows(rows, pkCols)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == 0 {
+			return nil, nil
+		}
+		pkValues := make(map[string]interface{}, len(pkCols))
+		for _, col := range pkCols {
+			pkValues[col.Name] = results[0][introspection.GraphQLFieldName(col)]
+		}
+		return pkValues, nil
+	}
+}
+
 func (r *Resolver) makeUpdateResolver(table introspection.Table, updatable map[string]bool, pkCols []introspection.Column, successType *graphql.Object) graphql.FieldResolveFn {
+	return r.makeUpdateResolverWithLocator(table, updatable, pkCols, successType, nodeIDRowLocator(table, pkCols))
+}
+
+func (r *Resolver) makeUpdateResolverWithLocator(table introspection.Table, updatable map[string]bool, pkCols []introspection.Column, successType *graphql.Object, locate mutationRowLocator) graphql.FieldResolveFn {
+	plan := r.buildUpdateMutationPlan(table)
+
 	return withMutationContextUnion(func(p graphql.ResolveParams, mc *MutationContext) (result interface{}, err error) {
 		resultTelemetry := mutationSuccessTelemetry("Update" + r.singularTypeName(table) + "Success")
 		if successType != nil && successType.Name() != "" {
@@ -1420,10 +1570,15 @@ func (r *Resolver) makeUpdateResolver(table introspection.Table, updatable map[s
 
 		entityFieldName := r.mutationEntityFieldName(table)
 
-		pkValues, err := pkValuesFromArgs(table, pkCols, p.Args)
+		pkValues, err := locate(p, mc.Tx())
 		if err != nil {
 			return nil, err
 		}
+		if pkValues == nil {
+			return map[string]interface{}{
+				entityFieldName: nil,
+			}, nil
+		}
 
 		setArg, hasSet := p.Args["set"]
 		if !hasSet || setArg == nil {
@@ -1450,45 +1605,102 @@ func (r *Resolver) makeUpdateResolver(table introspection.Table, updatable map[s
 			}, nil
 		}
 
-		setValues, err := mapSetColumns(table, setMap, updatable)
+		// Phase 1: Split the set input into scalar columns and relationship operations.
+		partitioned, err := partitionUpdateInput(setMap, plan)
 		if err != nil {
 			return nil, err
 		}
-		if len(setMap) > 0 && len(setValues) == 0 {
-			return nil, newMutationError("no updatable columns in set", "invalid_input", 0)
+		hasRelationOps := partitioned.hasRelationOps()
+
+		// Phase 2: Resolve many-to-one reconnects into FK column values.
+		for _, fieldName := range sortedRelationFields(plan.connectFields) {
+			rel := plan.connectFields[fieldName]
+			connectSub, ok := partitioned.connects[fieldName]
+			if !ok {
+				continue
+			}
+			if err := validateScalarVsConnectXOR(partitioned.scalars, table, rel.LocalColumns, fieldName); err != nil {
+				return nil, err
+			}
+			remoteTable, err := r.findRelationshipRemoteTable(rel)
+			if err != nil {
+				return nil, err
+			}
+			fkValues, err := r.resolveConnectField(p.Context, mc.Tx(), remoteTable, rel.LocalColumns, rel.RemoteColumns, connectSub)
+			if err != nil {
+				return nil, err
+			}
+			for localCol, val := range fkValues {
+				partitioned.scalars[graphQLFieldNameForColumn(table, localCol)] = val
+			}
 		}
 
-		planned, err := planner.PlanUpdate(table, setValues, pkValues)
+		setValues, err := mapSetColumns(table, partitioned.scalars, updatable)
 		if err != nil {
 			return nil, err
 		}
+		if len(setValues) == 0 && !hasRelationOps {
+			return nil, newMutationError("no updatable columns in set", "invalid_input", 0)
+		}
 
-		execResult, err := mc.Tx().ExecContext(p.Context, planned.SQL, planned.Args...)
-		if err != nil {
-			return nil, normalizeMutationError(err)
+		// Phase 3: Update the parent row's columns.
+		if len(setValues) > 0 {
+			planned, err := planner.PlanUpdate(table, setValues, pkValues)
+			if err != nil {
+				return nil, err
+			}
+
+			execResult, err := mc.Tx().ExecContext(p.Context, planned.SQL, planned.Args...)
+			if err != nil {
+				return nil, normalizeMutationError(err)
+			}
+
+			rowsAffected, err := execResult.RowsAffected()
+			if err != nil {
+				return nil, err
+			}
+			if rowsAffected == 0 && !hasRelationOps {
+				return map[string]interface{}{
+					entityFieldName: nil,
+				}, nil
+			}
 		}
 
-		rowsAffected, err := execResult.RowsAffected()
+		if !hasRelationOps {
+			row, err := r.selectRowByPK(p, table, pkCols, pkValues, mc.Tx())
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				entityFieldName: row,
+			}, nil
+		}
+
+		// Phase 4: Reload the parent and apply to-many relationship operations against it.
+		parentRow, err := r.selectRowByPKWithRequiredColumns(p, table, pkCols, pkValues, updateRelationParentColumns(plan, partitioned), mc.Tx())
 		if err != nil {
 			return nil, err
 		}
-		if rowsAffected == 0 {
+		if parentRow == nil {
 			return map[string]interface{}{
 				entityFieldName: nil,
 			}, nil
 		}
-
-		row, err := r.selectRowByPK(p, table, pkCols, pkValues, mc.Tx())
-		if err != nil {
+		if err := r.executeUpdateRelationOps(p.Context, mc.Tx(), table, plan, partitioned, parentRow); err != nil {
 			return nil, err
 		}
+
 		return map[string]interface{}{
-			entityFieldName: row,
+			entityFieldName: parentRow,
 		}, nil
 	})
 }
 
 func (r *Resolver) makeDeleteResolver(table introspection.Table, pkCols []introspection.Column, successType *graphql.Object) graphql.FieldResolveFn {
+	return r.makeDeleteResolverWithLocator(table, pkCols, successType, nodeIDRowLocator(table, pkCols))
+}
+
+func (r *Resolver) makeDeleteResolverWithLocator(table introspection.Table, pkCols []introspection.Column, successType *graphql.Object, locate mutationRowLocator) graphql.FieldResolveFn {
 	return withMutationContextUnion(func(p graphql.ResolveParams, mc *MutationContext) (result interface{}, err error) {
 		resultTelemetry := mutationSuccessTelemetry("Delete" + r.singularTypeName(table) + "Success")
 		if successType != nil && successType.Name() != "" {
@@ -1513,10 +1725,14 @@ func (r *Resolver) makeDeleteResolver(table introspection.Table, pkCols []intros
 			span.End()
 		}()
 
-		pkValues, err := pkValuesFromArgs(table, pkCols, p.Args)
+		pkValues, err := locate(p, mc.Tx())
 		if err != nil {
 			return nil, err
 		}
+		if pkValues == nil {
+			resultTelemetry = mutationTypedFailureTelemetry("NotFoundError", mutationResultCodeNotFound)
+			return mutationErrorPayload("NotFoundError", "row not found", nil), nil
+		}
 
 		planned, err := planner.PlanDelete(table, pkValues)
 		if err != nil {
@@ -1798,6 +2014,9 @@ func (r *Resolver) executeNestedCreate(
 	if err != nil {
 		return err
 	}
+	if err := r.authorizeNestedWrite(ctx, remoteTable, mutationKindCreate); err != nil {
+		return err
+	}
 	if len(rel.LocalColumns) == 0 || len(rel.LocalColumns) != len(rel.RemoteColumns) {
 		return fmt.Errorf("invalid nested relationship mapping for %s", rel.GraphQLFieldName)
 	}
@@ -1828,7 +2047,7 @@ func (r *Resolver) executeNestedCreate(
 		if r.connectInputForRel(childRel) == nil {
 			continue
 		}
-		childConnectFieldNames[childRel.GraphQLFieldName+"Connect"] = childRel
+		childConnectFieldNames[r.relationMutationFieldName(childRel, "connect")] = childRel
 	}
 
 	// Build the FK values to inject from parent row columns.
@@ -2060,7 +2279,11 @@ func normalizeMutationInputValue(col introspection.Column, value interface{}) (i
 	case sqltype.TypeVector:
 		return normalizeVectorInputValue(col, value)
 	default:
-		return value, nil
+		stored, err := planner.StorageValue(col, value)
+		if err != nil {
+			return nil, newMutationError(err.Error(), "invalid_input", 0)
+		}
+		return stored, nil
 	}
 }
 
@@ -0,0 +1,598 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/planner"
+	"tidb-graphql/internal/schemafilter"
+)
+
+// updateMutationPlan records which relationship operations are exposed on UpdateXxxSetInput,
+// keyed by the GraphQL field name inside the set input.
+type updateMutationPlan struct {
+	// connectFields re-point many-to-one relationships ("<rel>Connect").
+	connectFields map[string]introspection.Relationship
+	// nestedCreateFields insert one-to-many/edge-list children ("<rel>Create").
+	nestedCreateFields map[string]introspection.Relationship
+	// nestedUpdateFields update owned one-to-many/edge-list children ("<rel>Update").
+	nestedUpdateFields map[string]introspection.Relationship
+	// nestedDeleteFields delete owned one-to-many/edge-list children ("<rel>Delete").
+	nestedDeleteFields map[string]introspection.Relationship
+	// m2mConnectFields insert pure junction rows ("<rel>Connect").
+	m2mConnectFields map[string]introspection.Relationship
+	// m2mDisconnectFields delete pure junction rows ("<rel>Disconnect").
+	m2mDisconnectFields map[string]introspection.Relationship
+}
+
+// sortedRelationFields returns the field names of one operation kind in a fixed
+// order, so statements, lock order and the reported error do not vary between runs.
+func sortedRelationFields(fields map[string]introspection.Relationship) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p updateMutationPlan) empty() bool {
+	return len(p.connectFields) == 0 &&
+		len(p.nestedCreateFields) == 0 &&
+		len(p.nestedUpdateFields) == 0 &&
+		len(p.nestedDeleteFields) == 0 &&
+		len(p.m2mConnectFields) == 0 &&
+		len(p.m2mDisconnectFields) == 0
+}
+
+func (r *Resolver) buildUpdateMutationPlan(table introspection.Table) updateMutationPlan {
+	plan := updateMutationPlan{
+		connectFields:       make(map[string]introspection.Relationship),
+		nestedCreateFields:  make(map[string]introspection.Relationship),
+		nestedUpdateFields:  make(map[string]introspection.Relationship),
+		nestedDeleteFields:  make(map[string]introspection.Relationship),
+		m2mConnectFields:    make(map[string]introspection.Relationship),
+		m2mDisconnectFields: make(map[string]introspection.Relationship),
+	}
+	updatable := columnNameSet(r.mutationUpdatableColumns(table))
+	for _, rel := range table.Relationships {
+		switch {
+		case rel.IsManyToOne:
+			if !allInSet(rel.LocalColumns, updatable) {
+				continue // FK columns that are part of the PK or generated cannot be re-pointed
+			}
+			if r.connectInputForRel(rel) == nil {
+				continue
+			}
+			plan.connectFields[r.relationMutationFieldName(rel, "connect")] = rel
+		case rel.IsOneToMany || rel.IsEdgeList:
+			if rel.IsCrossDatabase {
+				continue // cross-database nested mutations not supported
+			}
+			if r.nestedCreateInputForRel(table, rel) != nil {
+				plan.nestedCreateFields[r.relationMutationFieldName(rel, "create")] = rel
+			}
+			remoteTable, err := r.findRelationshipRemoteTable(rel)
+			if err != nil || len(introspection.PrimaryKeyColumns(remoteTable)) == 0 {
+				continue
+			}
+			if !schemafilter.MutationTableAllowed(remoteTable.Name, r.mutationFiltersFor(remoteTable)) {
+				continue
+			}
+			if r.nestedUpdateInputForRel(table, rel) != nil {
+				plan.nestedUpdateFields[r.relationMutationFieldName(rel, "update")] = rel
+			}
+			plan.nestedDeleteFields[r.relationMutationFieldName(rel, "delete")] = rel
+		case rel.IsManyToMany:
+			if !r.m2mConnectSupported(rel) {
+				continue
+			}
+			plan.m2mConnectFields[r.relationMutationFieldName(rel, "connect")] = rel
+			plan.m2mDisconnectFields[r.relationMutationFieldName(rel, "disconnect")] = rel
+		}
+	}
+	return plan
+}
+
+// addUpdateRelationFields adds the relationship operation fields from plan to an
+// UpdateXxxSetInput field map. Column fields win on name collisions.
+func (r *Resolver) addUpdateRelationFields(fields graphql.InputObjectConfigFieldMap, table introspection.Table, plan updateMutationPlan) {
+	addField := func(name string, fieldType graphql.Input, description string) {
+		if fieldType == nil {
+			return
+		}
+		if _, exists := fields[name]; exists {
+			return
+		}
+		fields[name] = &graphql.InputObjectFieldConfig{
+			Type:        fieldType,
+			Description: description,
+		}
+	}
+
+	for _, fieldName := range sortedRelationFields(plan.connectFields) {
+		rel := plan.connectFields[fieldName]
+		if connectInput := r.connectInputForRel(rel); connectInput != nil {
+			addField(fieldName, connectInput, "Re-point "+rel.GraphQLFieldName+" to an existing row by id or unique field.")
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedCreateFields) {
+		rel := plan.nestedCreateFields[fieldName]
+		if nestedInput := r.nestedCreateInputForRel(table, rel); nestedInput != nil {
+			addField(fieldName, graphql.NewList(graphql.NewNonNull(nestedInput)), "Inline-create "+rel.GraphQLFieldName+" within this mutation.")
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedUpdateFields) {
+		rel := plan.nestedUpdateFields[fieldName]
+		if nestedInput := r.nestedUpdateInputForRel(table, rel); nestedInput != nil {
+			addField(fieldName, graphql.NewList(graphql.NewNonNull(nestedInput)), "Update "+rel.GraphQLFieldName+" owned by this row.")
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedDeleteFields) {
+		rel := plan.nestedDeleteFields[fieldName]
+		addField(fieldName, graphql.NewList(graphql.NewNonNull(graphql.ID)), "Delete "+rel.GraphQLFieldName+" owned by this row, by node ID.")
+	}
+	for _, fieldName := range sortedRelationFields(plan.m2mConnectFields) {
+		rel := plan.m2mConnectFields[fieldName]
+		if connectInput := r.connectInputForRel(rel); connectInput != nil {
+			addField(fieldName, graphql.NewList(graphql.NewNonNull(connectInput)), "Connect existing "+rel.GraphQLFieldName+" within this mutation.")
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.m2mDisconnectFields) {
+		rel := plan.m2mDisconnectFields[fieldName]
+		if connectInput := r.connectInputForRel(rel); connectInput != nil {
+			addField(fieldName, graphql.NewList(graphql.NewNonNull(connectInput)), "Disconnect "+rel.GraphQLFieldName+" within this mutation.")
+		}
+	}
+}
+
+// nestedUpdateInputForRel builds "UpdateXxxYyyNestedInput" { id: ID!, set: UpdateXxxYyyNestedSetInput! }
+// for a one-to-many or edge-list relationship. The nested set omits the FK column(s) pointing
+// back to the parent so children cannot be moved to another parent through this path.
+// Returns nil if the child has no updatable columns left.
+func (r *Resolver) nestedUpdateInputForRel(parentTable introspection.Table, rel introspection.Relationship) *graphql.InputObject {
+	remoteTable, err := r.findRelationshipRemoteTable(rel)
+	if err != nil {
+		return nil
+	}
+
+	baseName := "Update" + r.singularTypeName(parentTable) + r.singularNamer.TypeNamePart(rel.GraphQLFieldName) + "Nested"
+	cacheKey := "update|" + nestedCreateCacheKey(parentTable, rel)
+	r.mu.RLock()
+	cached, ok := r.updateInputCache[cacheKey]
+	r.mu.RUnlock()
+	if ok {
+		return cached
+	}
+
+	setFields := graphql.InputObjectConfigFieldMap{}
+	for _, col := range r.nestedUpdatableColumns(remoteTable, rel) {
+		setFields[introspection.GraphQLFieldName(col)] = &graphql.InputObjectFieldConfig{
+			Type:        r.mapColumnTypeToGraphQLInput(remoteTable, &col),
+			Description: col.Comment,
+		}
+	}
+	if len(setFields) == 0 {
+		return nil
+	}
+
+	setInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   baseName + "SetInput",
+		Fields: setFields,
+	})
+	obj := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: baseName + "Input",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"id": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Opaque node ID of the child row",
+			},
+			"set": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(setInput),
+			},
+		},
+	})
+
+	r.mu.Lock()
+	if c, ok := r.updateInputCache[cacheKey]; ok {
+		r.mu.Unlock()
+		return c
+	}
+	r.updateInputCache[cacheKey] = obj
+	r.mu.Unlock()
+	return obj
+}
+
+// nestedUpdatableColumns returns the child's updatable columns minus the FK back to the parent.
+func (r *Resolver) nestedUpdatableColumns(remoteTable introspection.Table, rel introspection.Relationship) []introspection.Column {
+	parentFKSet := make(map[string]bool, len(rel.RemoteColumns))
+	for _, col := range rel.RemoteColumns {
+		parentFKSet[col] = true
+	}
+	updatable := r.mutationUpdatableColumns(remoteTable)
+	cols := make([]introspection.Column, 0, len(updatable))
+	for _, col := range updatable {
+		if !parentFKSet[col.Name] {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+type partitionedUpdateInput struct {
+	partitionedMutationInput
+	// nestedUpdates maps "<relFieldName>Update" -> list of { id, set } objects.
+	nestedUpdates map[string][]map[string]interface{}
+	// nestedDeletes maps "<relFieldName>Delete" -> list of child node IDs.
+	nestedDeletes map[string][]interface{}
+	// m2mDisconnects maps "<relFieldName>Disconnect" -> list of connect objects.
+	m2mDisconnects map[string][]map[string]interface{}
+}
+
+func (in partitionedUpdateInput) hasRelationOps() bool {
+	return len(in.connects) > 0 ||
+		len(in.nesteds) > 0 ||
+		len(in.m2mConnects) > 0 ||
+		len(in.nestedUpdates) > 0 ||
+		len(in.nestedDeletes) > 0 ||
+		len(in.m2mDisconnects) > 0
+}
+
+// partitionUpdateInput splits an update set input into scalar columns and relationship operations.
+func partitionUpdateInput(raw map[string]interface{}, plan updateMutationPlan) (partitionedUpdateInput, error) {
+	base, err := partitionMutationInput(raw, plan.connectFields, plan.nestedCreateFields, plan.m2mConnectFields)
+	if err != nil {
+		return partitionedUpdateInput{}, err
+	}
+	result := partitionedUpdateInput{
+		partitionedMutationInput: base,
+		nestedUpdates:            make(map[string][]map[string]interface{}),
+		nestedDeletes:            make(map[string][]interface{}),
+		m2mDisconnects:           make(map[string][]map[string]interface{}),
+	}
+	for k, v := range base.scalars {
+		if _, ok := plan.nestedUpdateFields[k]; ok {
+			rows, err := objectListInput(v, "nested field "+k+" must be a list of objects")
+			if err != nil {
+				return partitionedUpdateInput{}, err
+			}
+			result.nestedUpdates[k] = rows
+			delete(result.scalars, k)
+			continue
+		}
+		if _, ok := plan.nestedDeleteFields[k]; ok {
+			items, ok := v.([]interface{})
+			if !ok {
+				return partitionedUpdateInput{}, newMutationError("nested field "+k+" must be a list of ids", "invalid_input", 0)
+			}
+			result.nestedDeletes[k] = items
+			delete(result.scalars, k)
+			continue
+		}
+		if _, ok := plan.m2mDisconnectFields[k]; ok {
+			rows, err := objectListInput(v, "many-to-many field "+k+" must be a list of connect objects")
+			if err != nil {
+				return partitionedUpdateInput{}, err
+			}
+			result.m2mDisconnects[k] = rows
+			delete(result.scalars, k)
+		}
+	}
+	return result, nil
+}
+
+func objectListInput(value interface{}, message string) ([]map[string]interface{}, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, newMutationError(message, "invalid_input", 0)
+	}
+	rows := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, newMutationError(message, "invalid_input", 0)
+		}
+		rows = append(rows, m)
+	}
+	return rows, nil
+}
+
+// updateRelationParentColumns returns the parent columns the requested relationship
+// operations read from the parent row.
+func updateRelationParentColumns(plan updateMutationPlan, in partitionedUpdateInput) []string {
+	cols := make([]string, 0)
+	for _, fieldName := range sortedRelationFields(plan.nestedCreateFields) {
+		rel := plan.nestedCreateFields[fieldName]
+		if len(in.nesteds[fieldName]) > 0 {
+			cols = append(cols, rel.LocalColumns...)
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedUpdateFields) {
+		rel := plan.nestedUpdateFields[fieldName]
+		if len(in.nestedUpdates[fieldName]) > 0 {
+			cols = append(cols, rel.LocalColumns...)
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedDeleteFields) {
+		rel := plan.nestedDeleteFields[fieldName]
+		if len(in.nestedDeletes[fieldName]) > 0 {
+			cols = append(cols, rel.LocalColumns...)
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.m2mConnectFields) {
+		rel := plan.m2mConnectFields[fieldName]
+		if len(in.m2mConnects[fieldName]) > 0 {
+			cols = append(cols, rel.LocalColumns...)
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.m2mDisconnectFields) {
+		rel := plan.m2mDisconnectFields[fieldName]
+		if len(in.m2mDisconnects[fieldName]) > 0 {
+			cols = append(cols, rel.LocalColumns...)
+		}
+	}
+	return cols
+}
+
+// executeUpdateRelationOps runs the to-many relationship operations of an update after the
+// parent row has been updated and reloaded. Removals run before additions so a single
+// mutation can replace a set of children or junction rows.
+func (r *Resolver) executeUpdateRelationOps(
+	ctx context.Context,
+	tx dbexec.TxExecutor,
+	table introspection.Table,
+	plan updateMutationPlan,
+	in partitionedUpdateInput,
+	parentRow map[string]interface{},
+) error {
+	for _, fieldName := range sortedRelationFields(plan.m2mDisconnectFields) {
+		rel := plan.m2mDisconnectFields[fieldName]
+		if rows := in.m2mDisconnects[fieldName]; len(rows) > 0 {
+			if err := r.executeM2MDisconnect(ctx, tx, table, rel, parentRow, rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedDeleteFields) {
+		rel := plan.nestedDeleteFields[fieldName]
+		if ids := in.nestedDeletes[fieldName]; len(ids) > 0 {
+			if err := r.executeNestedDelete(ctx, tx, table, rel, parentRow, ids); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedUpdateFields) {
+		rel := plan.nestedUpdateFields[fieldName]
+		if rows := in.nestedUpdates[fieldName]; len(rows) > 0 {
+			if err := r.executeNestedUpdate(ctx, tx, table, rel, parentRow, rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.m2mConnectFields) {
+		rel := plan.m2mConnectFields[fieldName]
+		if rows := in.m2mConnects[fieldName]; len(rows) > 0 {
+			if err := r.executeM2MConnect(ctx, tx, table, rel, parentRow, rows); err != nil {
+				return err
+			}
+		}
+	}
+	for _, fieldName := range sortedRelationFields(plan.nestedCreateFields) {
+		rel := plan.nestedCreateFields[fieldName]
+		if rows := in.nesteds[fieldName]; len(rows) > 0 {
+			if err := r.executeNestedCreate(ctx, tx, table, rel, parentRow, rows); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ownedChildMatch decodes a child node ID and scopes it to the parent via the relationship FK.
+// Returned map keys are DB column names on the child table.
+func (r *Resolver) ownedChildMatch(
+	parentTable introspection.Table,
+	remoteTable introspection.Table,
+	rel introspection.Relationship,
+	parentRow map[string]interface{},
+	rawID interface{},
+) (map[string]interface{}, []introspection.Column, error) {
+	if len(rel.LocalColumns) == 0 || len(rel.LocalColumns) != len(rel.RemoteColumns) {
+		return nil, nil, fmt.Errorf("invalid nested relationship mapping for %s", rel.GraphQLFieldName)
+	}
+	pkCols := introspection.PrimaryKeyColumns(remoteTable)
+	pkValues, err := decodeNodeIDToPKValues(remoteTable, pkCols, rawID)
+	if err != nil {
+		return nil, nil, err
+	}
+	match := make(map[string]interface{}, len(pkValues)+len(rel.RemoteColumns))
+	for col, val := range pkValues {
+		match[col] = val
+	}
+	for i, parentCol := range rel.LocalColumns {
+		parentValue, ok := parentRow[graphQLFieldNameForColumn(parentTable, parentCol)]
+		if !ok {
+			return nil, nil, fmt.Errorf("missing parent value for column %s while building nested mutation", parentCol)
+		}
+		match[rel.RemoteColumns[i]] = parentValue
+	}
+	return match, pkCols, nil
+}
+
+// executeNestedDelete deletes child rows by node ID, scoped to the parent so rows owned by
+// another parent are never touched.
+func (r *Resolver) executeNestedDelete(
+	ctx context.Context,
+	tx dbexec.TxExecutor,
+	parentTable introspection.Table,
+	rel introspection.Relationship,
+	parentRow map[string]interface{},
+	ids []interface{},
+) error {
+	remoteTable, err := r.findRelationshipRemoteTable(rel)
+	if err != nil {
+		return err
+	}
+	if err := r.authorizeNestedWrite(ctx, remoteTable, mutationKindDelete); err != nil {
+		return err
+	}
+	for _, rawID := range ids {
+		match, _, err := r.ownedChildMatch(parentTable, remoteTable, rel, parentRow, rawID)
+		if err != nil {
+			return err
+		}
+		query, err := planner.PlanDeleteMatching(remoteTable, match)
+		if err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, query.SQL, query.Args...)
+		if err != nil {
+			return normalizeMutationError(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return newMutationError(rel.GraphQLFieldName+" item not found for this "+r.singularTypeName(parentTable), "not_found", 0)
+		}
+	}
+	return nil
+}
+
+// executeNestedUpdate updates child rows by node ID after confirming each one belongs to the parent.
+func (r *Resolver) executeNestedUpdate(
+	ctx context.Context,
+	tx dbexec.TxExecutor,
+	parentTable introspection.Table,
+	rel introspection.Relationship,
+	parentRow map[string]interface{},
+	items []map[string]interface{},
+) error {
+	remoteTable, err := r.findRelationshipRemoteTable(rel)
+	if err != nil {
+		return err
+	}
+	if err := r.authorizeNestedWrite(ctx, remoteTable, mutationKindUpdate); err != nil {
+		return err
+	}
+	allowed := columnNameSet(r.nestedUpdatableColumns(remoteTable, rel))
+
+	for _, item := range items {
+		for key := range item {
+			if key != "id" && key != "set" {
+				return newMutationError("unknown nested update field: "+key, "invalid_input", 0)
+			}
+		}
+		match, pkCols, err := r.ownedChildMatch(parentTable, remoteTable, rel, parentRow, item["id"])
+		if err != nil {
+			return err
+		}
+		setMap, ok := item["set"].(map[string]interface{})
+		if !ok {
+			return newMutationError("nested update set must be an object", "invalid_input", 0)
+		}
+		setValues, err := mapSetColumns(remoteTable, setMap, allowed)
+		if err != nil {
+			return err
+		}
+
+		// Check ownership before the empty-set shortcut so a foreign or missing
+		// ID fails the same way whether or not it changes anything.
+		lookup, err := planner.PlanRowMatching(remoteTable, pkCols, match)
+		if err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, lookup.SQL, lookup.Args...)
+		if err != nil {
+			return normalizeMutationError(err)
+		}
+		found, err := scanRows(rows, pkCols)
+		_ = rows.Close()
+		if err != nil {
+			return err
+		}
+		if len(found) == 0 {
+			return newMutationError(rel.GraphQLFieldName+" item not found for this "+r.singularTypeName(parentTable), "not_found", 0)
+		}
+		if len(setValues) == 0 {
+			continue
+		}
+
+		pkValues := make(map[string]interface{}, len(pkCols))
+		for _, col := range pkCols {
+			pkValues[col.Name] = match[col.Name]
+		}
+		query, err := planner.PlanUpdate(remoteTable, setValues, pkValues)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query.SQL, query.Args...); err != nil {
+			return normalizeMutationError(err)
+		}
+	}
+	return nil
+}
+
+// executeM2MDisconnect deletes junction rows linking the parent to each referenced remote row.
+// Disconnecting a row that is not connected is a no-op.
+func (r *Resolver) executeM2MDisconnect(
+	ctx context.Context,
+	tx dbexec.TxExecutor,
+	parentTable introspection.Table,
+	rel introspection.Relationship,
+	parentRow map[string]interface{},
+	connectRows []map[string]interface{},
+) error {
+	if len(rel.LocalColumns) == 0 || len(rel.LocalColumns) != len(rel.JunctionLocalFKColumns) {
+		return fmt.Errorf("invalid many-to-many local mapping for %s", rel.GraphQLFieldName)
+	}
+	if len(rel.RemoteColumns) == 0 || len(rel.RemoteColumns) != len(rel.JunctionRemoteFKColumns) {
+		return fmt.Errorf("invalid many-to-many remote mapping for %s", rel.GraphQLFieldName)
+	}
+
+	junctionTable, err := r.findRelationshipJunctionTable(rel)
+	if err != nil {
+		return err
+	}
+	remoteTable, err := r.findRelationshipRemoteTable(rel)
+	if err != nil {
+		return err
+	}
+
+	for _, connectInput := range connectRows {
+		if len(connectInput) == 0 {
+			return newMutationError("many-to-many disconnect item must not be empty", "invalid_input", 0)
+		}
+		remoteValues, err := r.resolveConnectField(ctx, tx, remoteTable, rel.RemoteColumns, rel.RemoteColumns, connectInput)
+		if err != nil {
+			return err
+		}
+
+		match := make(map[string]interface{}, len(rel.JunctionLocalFKColumns)+len(rel.JunctionRemoteFKColumns))
+		for i, parentCol := range rel.LocalColumns {
+			parentValue, ok := parentRow[graphQLFieldNameForColumn(parentTable, parentCol)]
+			if !ok {
+				return fmt.Errorf("missing parent value for column %s while building many-to-many disconnect", parentCol)
+			}
+			match[rel.JunctionLocalFKColumns[i]] = parentValue
+		}
+		for i, remoteCol := range rel.RemoteColumns {
+			match[rel.JunctionRemoteFKColumns[i]] = remoteValues[remoteCol]
+		}
+
+		query, err := planner.PlanDeleteMatching(junctionTable, match)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query.SQL, query.Args...); err != nil {
+			return normalizeMutationError(err)
+		}
+	}
+	return nil
+}
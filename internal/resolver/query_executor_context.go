@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/explain"
 )
 
 // queryContextExecutor is the minimal query surface used by read paths.
@@ -13,10 +14,15 @@ type queryContextExecutor interface {
 }
 
 // queryExecutorForContext returns the active mutation transaction when present,
-// otherwise it falls back to the resolver's base executor.
+// otherwise it falls back to the resolver's base executor. Under an explain
+// recorder the executor also records every statement it runs.
 func (r *Resolver) queryExecutorForContext(ctx context.Context) queryContextExecutor {
+	var executor queryContextExecutor = r.executor
 	if mc := MutationContextFromContext(ctx); mc != nil && mc.Tx() != nil {
-		return mc.Tx()
+		executor = mc.Tx()
 	}
-	return r.executor
+	if explain.RecorderFromContext(ctx) != nil {
+		return explainingExecutor{next: executor}
+	}
+	return executor
 }
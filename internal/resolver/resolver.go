@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"tidb-graphql/internal/authz"
 	"tidb-graphql/internal/cursor"
 	"tidb-graphql/internal/dbexec"
 	"tidb-graphql/internal/introspection"
@@ -57,9 +60,12 @@ type Resolver struct {
 	deleteResultCache      map[string]*graphql.Union
 	enumCache              map[string]*graphql.Enum
 	enumFilterCache        map[string]*graphql.InputObject
+	jsonObjectCache        map[string]*graphql.Object
+	jsonInputCache         map[string]*graphql.InputObject
 	setFilterCache         map[string]*graphql.InputObject
 	vectorEdgeCache        map[string]*graphql.Object
 	vectorConnCache        map[string]*graphql.Object
+	hierarchyEdgeCache     map[string]*graphql.Object
 	// tableIndex provides O(1) table lookup by TableKey.MapKey().
 	// Built once when the schema is loaded; keyed both by MapKey() (e.g.
 	// "mydb.users" in multi-db mode) and by bare table name (for single-db
@@ -80,6 +86,14 @@ type Resolver struct {
 	bytesType          *graphql.Scalar
 	uuidType           *graphql.Scalar
 	vectorType         *graphql.Scalar
+	emailType          *graphql.Scalar
+	urlType            *graphql.Scalar
+	ipAddressType      *graphql.Scalar
+	durationType       *graphql.Scalar
+	timestampType      *graphql.Scalar
+	geoJSONType        *graphql.Scalar
+	dateTimeType       *graphql.Scalar
+	moneyType          *graphql.Object
 	nodeInterface      *graphql.Interface
 	pageInfoType       *graphql.Object
 	vectorDistance     *graphql.Enum
@@ -97,7 +111,20 @@ type Resolver struct {
 	namespaceMap   map[string]string
 	namespacedRoot bool
 	vectorSearch   VectorSearchConfig
-	mu             sync.RWMutex
+	// transactions exposes the interactive transaction root mutations.
+	transactions bool
+	// explain instruments resolvers so the admin explain endpoint can attribute SQL to fields.
+	explain bool
+	// authorization guards fields with claim-based rules.
+	authorization *authz.Policy
+	// partialAuthorization tolerates rules naming fields this schema lacks.
+	partialAuthorization bool
+	// nestedWriteRules holds, per table and write kind, the rules of the root
+	// mutation that nested writes to that table must also satisfy.
+	nestedWriteRules map[string][]authz.Rule
+	// timeZone is the default request time zone.
+	timeZone *time.Location
+	mu       sync.RWMutex
 }
 
 // VectorSearchConfig controls generated vector-search fields.
@@ -114,6 +141,18 @@ type ResolverConfig struct {
 	NamespaceMap   map[string]string
 	NamespacedRoot bool
 	Naming         naming.Config
+	// Transactions adds beginTransaction/commitTransaction/rollbackTransaction.
+	Transactions bool
+	// Explain wraps field resolvers to record per-field SQL when an explain.Recorder is present.
+	Explain bool
+	// Authorization wraps guarded field resolvers to check the caller's authz.Principal.
+	Authorization *authz.Policy
+	// PartialAuthorization skips the unknown-field check for Authorization rules,
+	// for schemas built from a database role that sees only part of the tables.
+	PartialAuthorization bool
+	// TimeZone renders DateTime values and reads offset-less DateTime inputs
+	// when a request selects no zone. Nil means UTC.
+	TimeZone *time.Location
 }
 
 var staticMutationTypeNames = map[string]bool{
@@ -167,41 +206,49 @@ func NewResolverWithConfig(executor dbexec.QueryExecutor, dbSchema *introspectio
 		defaultLimit = planner.DefaultListLimit
 	}
 	return &Resolver{
-		executor:           executor,
-		dbSchema:           dbSchema,
-		tableIndex:         buildTableIndex(dbSchema),
-		typeCache:          make(map[string]*graphql.Object),
-		orderByClauseCache: make(map[string]*graphql.InputObject),
-		whereCache:         make(map[string]*graphql.InputObject),
-		filterCache:        make(map[string]*graphql.InputObject),
-		aggregateCache:     make(map[string]*graphql.Object),
-		createInputCache:   make(map[string]*graphql.InputObject),
-		updateInputCache:   make(map[string]*graphql.InputObject),
-		connectInputCache:  make(map[string]*graphql.InputObject),
-		nestedCreateCache:  make(map[string]*graphql.InputObject),
-		deletePayloadCache: make(map[string]*graphql.Object),
-		createSuccessCache: make(map[string]*graphql.Object),
-		updateSuccessCache: make(map[string]*graphql.Object),
-		deleteSuccessCache: make(map[string]*graphql.Object),
-		createResultCache:  make(map[string]*graphql.Union),
-		updateResultCache:  make(map[string]*graphql.Union),
-		deleteResultCache:  make(map[string]*graphql.Union),
-		enumCache:          make(map[string]*graphql.Enum),
-		enumFilterCache:    make(map[string]*graphql.InputObject),
-		setFilterCache:     make(map[string]*graphql.InputObject),
-		vectorEdgeCache:    make(map[string]*graphql.Object),
-		vectorConnCache:    make(map[string]*graphql.Object),
-		singularQueryCache: make(map[string]string),
-		singularTypeCache:  make(map[string]string),
-		singularNamer:      naming.New(cfg.Naming, nil),
-		edgeCache:          make(map[string]*graphql.Object),
-		connectionCache:    make(map[string]*graphql.Object),
-		limits:             limits,
-		defaultLimit:       defaultLimit,
-		filters:            cfg.Filters,
-		filtersPerDB:       cloneSchemaFilterMap(cfg.FiltersPerDB),
-		namespaceMap:       cloneStringMap(cfg.NamespaceMap),
-		namespacedRoot:     cfg.NamespacedRoot,
+		executor:             executor,
+		dbSchema:             dbSchema,
+		tableIndex:           buildTableIndex(dbSchema),
+		typeCache:            make(map[string]*graphql.Object),
+		orderByClauseCache:   make(map[string]*graphql.InputObject),
+		whereCache:           make(map[string]*graphql.InputObject),
+		filterCache:          make(map[string]*graphql.InputObject),
+		aggregateCache:       make(map[string]*graphql.Object),
+		createInputCache:     make(map[string]*graphql.InputObject),
+		updateInputCache:     make(map[string]*graphql.InputObject),
+		connectInputCache:    make(map[string]*graphql.InputObject),
+		nestedCreateCache:    make(map[string]*graphql.InputObject),
+		deletePayloadCache:   make(map[string]*graphql.Object),
+		createSuccessCache:   make(map[string]*graphql.Object),
+		updateSuccessCache:   make(map[string]*graphql.Object),
+		deleteSuccessCache:   make(map[string]*graphql.Object),
+		createResultCache:    make(map[string]*graphql.Union),
+		updateResultCache:    make(map[string]*graphql.Union),
+		deleteResultCache:    make(map[string]*graphql.Union),
+		enumCache:            make(map[string]*graphql.Enum),
+		enumFilterCache:      make(map[string]*graphql.InputObject),
+		jsonObjectCache:      make(map[string]*graphql.Object),
+		jsonInputCache:       make(map[string]*graphql.InputObject),
+		setFilterCache:       make(map[string]*graphql.InputObject),
+		vectorEdgeCache:      make(map[string]*graphql.Object),
+		vectorConnCache:      make(map[string]*graphql.Object),
+		hierarchyEdgeCache:   make(map[string]*graphql.Object),
+		singularQueryCache:   make(map[string]string),
+		singularTypeCache:    make(map[string]string),
+		singularNamer:        naming.New(cfg.Naming, nil),
+		edgeCache:            make(map[string]*graphql.Object),
+		connectionCache:      make(map[string]*graphql.Object),
+		limits:               limits,
+		defaultLimit:         defaultLimit,
+		filters:              cfg.Filters,
+		filtersPerDB:         cloneSchemaFilterMap(cfg.FiltersPerDB),
+		namespaceMap:         cloneStringMap(cfg.NamespaceMap),
+		namespacedRoot:       cfg.NamespacedRoot,
+		transactions:         cfg.Transactions,
+		explain:              cfg.Explain,
+		authorization:        cfg.Authorization,
+		partialAuthorization: cfg.PartialAuthorization,
+		timeZone:             cfg.TimeZone,
 		vectorSearch: normalizeVectorSearchConfig(VectorSearchConfig{
 			RequireIndex: true,
 		}),
@@ -329,12 +376,12 @@ func (r *Resolver) BuildGraphQLSchema() (graphql.Schema, error) {
 	for _, group := range groups {
 		groupQueryFields := graphql.Fields{}
 		for _, table := range group.tables {
-			groupQueryFields = r.addTableQueries(groupQueryFields, table)
+			groupQueryFields = addDeprecatedTableFields(groupQueryFields, table, r.addTableQueries)
 		}
 
 		groupMutationFields := graphql.Fields{}
 		for _, table := range group.tables {
-			groupMutationFields = r.addTableMutations(groupMutationFields, table)
+			groupMutationFields = addDeprecatedTableFields(groupMutationFields, table, r.addTableMutations)
 		}
 
 		if !group.wrapper {
@@ -413,12 +460,16 @@ func (r *Resolver) BuildGraphQLSchema() (graphql.Schema, error) {
 		}
 	}
 
+	if r.transactions {
+		r.addTransactionMutations(rootMutationFields)
+	}
+
 	schemaConfig := graphql.SchemaConfig{
 		Query: graphql.NewObject(graphql.ObjectConfig{
 			Name:   "Query",
 			Fields: rootQueryFields,
 		}),
-		Directives: append(graphql.SpecifiedDirectives, asOfDirective()),
+		Directives: append(graphql.SpecifiedDirectives, asOfDirective(r.dateTimeScalar()), dryRunDirective(), timeZoneDirective()),
 	}
 	if len(rootMutationFields) > 0 {
 		schemaConfig.Mutation = graphql.NewObject(graphql.ObjectConfig{
@@ -430,7 +481,18 @@ func (r *Resolver) BuildGraphQLSchema() (graphql.Schema, error) {
 		schemaConfig.Types = types
 	}
 
-	return graphql.NewSchema(schemaConfig)
+	schema, err := graphql.NewSchema(schemaConfig)
+	if err != nil {
+		return schema, err
+	}
+	if err := r.applyFieldAuthorization(&schema, groups); err != nil {
+		return schema, err
+	}
+	r.applyRequestTimeZone(&schema)
+	if r.explain {
+		instrumentExplainResolvers(&schema)
+	}
+	return schema, nil
 }
 
 func (r *Resolver) checkMutationTypeNameCollisions() error {
@@ -441,6 +503,9 @@ func (r *Resolver) checkMutationTypeNameCollisions() error {
 	for name := range staticMutationTypeNames {
 		seen[name] = "<reserved mutation type>"
 	}
+	if r.transactions {
+		seen[beginTransactionPayloadTypeName] = "<reserved mutation type>"
+	}
 	for _, table := range r.dbSchema.Tables {
 		single := table.GraphQLSingleTypeName
 		if single == "" {
@@ -490,7 +555,7 @@ func (r *Resolver) makeSingleRowResolver(table introspection.Table) graphql.Fiel
 
 		planned, err := r.planFromParams(p)
 		if err != nil {
-			return nil, fmt.Errorf("failed to build query: %w", err)
+			return nil, planError("failed to build query", err)
 		}
 
 		if planned.Table.Name != table.Name {
@@ -679,8 +744,8 @@ func (r *Resolver) planFromParams(p graphql.ResolveParams) (*planner.Plan, error
 		return nil, fmt.Errorf("missing field AST")
 	}
 
-	if r.limits != nil {
-		return planner.PlanQuery(r.dbSchema, field, p.Args, planner.WithFragments(p.Info.Fragments), planner.WithLimits(*r.limits), planner.WithDefaultListLimit(r.defaultLimit))
+	if limits := r.limitsFor(p.Context); limits != nil {
+		return planner.PlanQuery(r.dbSchema, field, p.Args, planner.WithFragments(p.Info.Fragments), planner.WithLimits(*limits), planner.WithDefaultListLimit(r.defaultLimit))
 	}
 	return planner.PlanQuery(r.dbSchema, field, p.Args, planner.WithFragments(p.Info.Fragments), planner.WithDefaultListLimit(r.defaultLimit))
 }
@@ -818,13 +883,13 @@ func (r *Resolver) makeConnectionResolver(table introspection.Table) graphql.Fie
 		opts = append(opts, planner.WithFragments(p.Info.Fragments))
 		opts = append(opts, planner.WithDefaultListLimit(r.defaultLimit))
 		opts = append(opts, planner.WithSchema(r.dbSchema))
-		if r.limits != nil {
-			opts = append(opts, planner.WithLimits(*r.limits))
+		if limits := r.limitsFor(p.Context); limits != nil {
+			opts = append(opts, planner.WithLimits(*limits))
 		}
 
 		plan, err := planner.PlanConnection(r.dbSchema, table, field, p.Args, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to plan connection: %w", err)
+			return nil, planError("failed to plan connection", err)
 		}
 
 		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, plan.Root.SQL, plan.Root.Args...)
@@ -866,8 +931,8 @@ func (r *Resolver) makeVectorConnectionResolver(table introspection.Table, vecto
 		var opts []planner.PlanOption
 		opts = append(opts, planner.WithFragments(p.Info.Fragments))
 		opts = append(opts, planner.WithSchema(r.dbSchema))
-		if r.limits != nil {
-			opts = append(opts, planner.WithLimits(*r.limits))
+		if limits := r.limitsFor(p.Context); limits != nil {
+			opts = append(opts, planner.WithLimits(*limits))
 		}
 
 		plan, err := planner.PlanVectorSearchConnection(
@@ -881,7 +946,7 @@ func (r *Resolver) makeVectorConnectionResolver(table introspection.Table, vecto
 			opts...,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to plan vector search connection: %w", err)
+			return nil, planError("failed to plan vector search connection", err)
 		}
 
 		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, plan.Root.SQL, plan.Root.Args...)
@@ -1008,20 +1073,19 @@ func (r *Resolver) makeOneToManyConnectionResolver(parentTable introspection.Tab
 		if !ok {
 			return nil, fmt.Errorf("invalid source type")
 		}
-		localColumn, remoteColumn, err := oneToManyMappingColumns(rel)
+		localColumns, remoteColumns, err := oneToManyMappingColumns(rel)
 		if err != nil {
 			return nil, err
 		}
 
-		pkFieldName := graphQLFieldNameForColumn(parentTable, localColumn)
-		pkValue := source[pkFieldName]
-		if pkValue == nil {
+		pkValues, ok := sourceValuesForColumns(parentTable, source, localColumns)
+		if !ok {
 			return r.buildConnectionResult(p.Context, nil, nil, false, false), nil
 		}
 
 		// Batch only for forward first-page connection requests.
 		if shouldBatchForwardConnection(p.Args) {
-			if result, ok, err := r.tryBatchOneToManyConnection(p, parentTable, rel, pkValue); ok || err != nil {
+			if result, ok, err := r.tryBatchOneToManyConnection(p, parentTable, rel, pkValues); ok || err != nil {
 				return result, err
 			}
 		}
@@ -1040,13 +1104,13 @@ func (r *Resolver) makeOneToManyConnectionResolver(parentTable introspection.Tab
 		opts = append(opts, planner.WithFragments(p.Info.Fragments))
 		opts = append(opts, planner.WithDefaultListLimit(r.defaultLimit))
 		opts = append(opts, planner.WithSchema(r.dbSchema))
-		if r.limits != nil {
-			opts = append(opts, planner.WithLimits(*r.limits))
+		if limits := r.limitsFor(p.Context); limits != nil {
+			opts = append(opts, planner.WithLimits(*limits))
 		}
 
-		plan, err := planner.PlanOneToManyConnection(relatedTable, remoteColumn, pkValue, field, p.Args, opts...)
+		plan, err := planner.PlanOneToManyConnection(relatedTable, remoteColumns, pkValues, field, p.Args, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to plan connection: %w", err)
+			return nil, planError("failed to plan connection", err)
 		}
 
 		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, plan.Root.SQL, plan.Root.Args...)
@@ -1116,8 +1180,8 @@ func (r *Resolver) makeManyToManyConnectionResolver(parentTable introspection.Ta
 		opts = append(opts, planner.WithFragments(p.Info.Fragments))
 		opts = append(opts, planner.WithDefaultListLimit(r.defaultLimit))
 		opts = append(opts, planner.WithSchema(r.dbSchema))
-		if r.limits != nil {
-			opts = append(opts, planner.WithLimits(*r.limits))
+		if limits := r.limitsFor(p.Context); limits != nil {
+			opts = append(opts, planner.WithLimits(*limits))
 		}
 
 		plan, err := planner.PlanManyToManyConnection(
@@ -1132,7 +1196,7 @@ func (r *Resolver) makeManyToManyConnectionResolver(parentTable introspection.Ta
 			opts...,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to plan connection: %w", err)
+			return nil, planError("failed to plan connection", err)
 		}
 
 		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, plan.Root.SQL, plan.Root.Args...)
@@ -1195,8 +1259,8 @@ func (r *Resolver) makeEdgeListConnectionResolver(parentTable introspection.Tabl
 		opts = append(opts, planner.WithFragments(p.Info.Fragments))
 		opts = append(opts, planner.WithDefaultListLimit(r.defaultLimit))
 		opts = append(opts, planner.WithSchema(r.dbSchema))
-		if r.limits != nil {
-			opts = append(opts, planner.WithLimits(*r.limits))
+		if limits := r.limitsFor(p.Context); limits != nil {
+			opts = append(opts, planner.WithLimits(*limits))
 		}
 
 		plan, err := planner.PlanEdgeListConnection(
@@ -1208,7 +1272,7 @@ func (r *Resolver) makeEdgeListConnectionResolver(parentTable introspection.Tabl
 			opts...,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to plan connection: %w", err)
+			return nil, planError("failed to plan connection", err)
 		}
 
 		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, plan.Root.SQL, plan.Root.Args...)
@@ -1291,6 +1355,11 @@ func (r *Resolver) scalarWhereFields(table introspection.Table) graphql.InputObj
 			continue
 		}
 
+		// Filtering on a guarded column would reveal its values.
+		if r.columnGuarded(table, col) {
+			continue
+		}
+
 		fieldName := introspection.GraphQLFieldName(col)
 		filterType := r.getFilterInputType(table, col)
 		if filterType != nil {
@@ -1406,7 +1475,7 @@ func (r *Resolver) toOneRelationshipWhereInput(relatedTable introspection.Table)
 }
 
 func (r *Resolver) enumTypeName(table introspection.Table, col introspection.Column) string {
-	singularTable := r.singularNamer.Singularize(table.Name)
+	singularTable := r.singularNamer.Singularize(r.singularNamer.StripTablePrefix(table.Name))
 	return r.singularNamer.ToGraphQLTypeName(singularTable) + r.singularNamer.ToGraphQLTypeName(col.Name)
 }
 
@@ -1456,6 +1525,22 @@ func normalizeEnumValueName(value string) string {
 	return name
 }
 
+var enumValueNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// configuredEnumValueName returns the configured GraphQL name for an enum
+// value. Names loaded from lookup table rows may be free text and are
+// normalized like stored values.
+func configuredEnumValueName(meta introspection.EnumValueMeta) string {
+	switch {
+	case meta.Name == "":
+		return ""
+	case enumValueNamePattern.MatchString(meta.Name) && meta.Name != "true" && meta.Name != "false" && meta.Name != "null":
+		return meta.Name
+	default:
+		return normalizeEnumValueName(meta.Name)
+	}
+}
+
 func uniqueEnumValueName(base string, used map[string]int) string {
 	name := base
 	for {
@@ -1476,6 +1561,12 @@ func (r *Resolver) enumTypeForColumn(table introspection.Table, col *introspecti
 		return nil
 	}
 	enumName := r.enumTypeName(table, *col)
+	if lookup := col.EnumLookup; lookup != nil {
+		enumName = lookup.TypeName
+		if enumName == "" {
+			enumName = r.enumTypeName(introspection.Table{Name: lookup.Table}, introspection.Column{Name: lookup.Column})
+		}
+	}
 	r.mu.RLock()
 	cached := r.enumCache[enumName]
 	r.mu.RUnlock()
@@ -1483,12 +1574,25 @@ func (r *Resolver) enumTypeForColumn(table introspection.Table, col *introspecti
 		return cached
 	}
 
+	// Configured names are reserved first so derived names yield to them.
 	used := make(map[string]int)
+	for _, value := range col.EnumValues {
+		if name := configuredEnumValueName(col.EnumValueMeta[value]); name != "" {
+			used[name] = 1
+		}
+	}
 	values := graphql.EnumValueConfigMap{}
 	for _, value := range col.EnumValues {
-		enumValueName := normalizeEnumValueName(value)
-		enumValueName = uniqueEnumValueName(enumValueName, used)
-		values[enumValueName] = &graphql.EnumValueConfig{Value: value}
+		meta := col.EnumValueMeta[value]
+		enumValueName := configuredEnumValueName(meta)
+		if enumValueName == "" || values[enumValueName] != nil {
+			enumValueName = uniqueEnumValueName(normalizeEnumValueName(value), used)
+		}
+		values[enumValueName] = &graphql.EnumValueConfig{
+			Value:             value,
+			Description:       meta.Description,
+			DeprecationReason: meta.DeprecationReason,
+		}
 	}
 
 	enumType := graphql.NewEnum(graphql.EnumConfig{
@@ -1713,6 +1817,112 @@ func (r *Resolver) getFilterInputType(table introspection.Table, col introspecti
 				"isNull": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
 			},
 		})
+	case "EmailFilter":
+		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: "EmailFilter",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"eq":      &graphql.InputObjectFieldConfig{Type: r.emailScalar()},
+				"ne":      &graphql.InputObjectFieldConfig{Type: r.emailScalar()},
+				"in":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.emailScalar()))},
+				"notIn":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.emailScalar()))},
+				"like":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"notLike": &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"domain":  &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Matches addresses at this domain, case-insensitively."},
+				"isNull":  &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			},
+		})
+	case "URLFilter":
+		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: "URLFilter",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"eq":         &graphql.InputObjectFieldConfig{Type: r.urlScalar()},
+				"ne":         &graphql.InputObjectFieldConfig{Type: r.urlScalar()},
+				"in":         &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.urlScalar()))},
+				"notIn":      &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.urlScalar()))},
+				"like":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"notLike":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"startsWith": &graphql.InputObjectFieldConfig{Type: graphql.String},
+				"isNull":     &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			},
+		})
+	case "IPAddressFilter":
+		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: "IPAddressFilter",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"eq":       &graphql.InputObjectFieldConfig{Type: r.ipAddressScalar()},
+				"ne":       &graphql.InputObjectFieldConfig{Type: r.ipAddressScalar()},
+				"in":       &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.ipAddressScalar()))},
+				"notIn":    &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.ipAddressScalar()))},
+				"inSubnet": &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Matches addresses inside a CIDR block such as 10.0.0.0/8."},
+				"isNull":   &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			},
+		})
+	case "DurationFilter":
+		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: "DurationFilter",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"eq":     &graphql.InputObjectFieldConfig{Type: r.durationScalar()},
+				"ne":     &graphql.InputObjectFieldConfig{Type: r.durationScalar()},
+				"lt":     &graphql.InputObjectFieldConfig{Type: r.durationScalar()},
+				"lte":    &graphql.InputObjectFieldConfig{Type: r.durationScalar()},
+				"gt":     &graphql.InputObjectFieldConfig{Type: r.durationScalar()},
+				"gte":    &graphql.InputObjectFieldConfig{Type: r.durationScalar()},
+				"in":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.durationScalar()))},
+				"notIn":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.durationScalar()))},
+				"isNull": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			},
+		})
+	case "GeoJSONFilter":
+		fields := graphql.InputObjectConfigFieldMap{
+			"isNull": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+		}
+		// Spatial operators are only offered when the server accepted the
+		// functions they compile to (see introspection.DetectSpatialFunctions).
+		if r.dbSchema != nil && r.dbSchema.Spatial.Distance {
+			fields["distanceWithin"] = &graphql.InputObjectFieldConfig{
+				Type: graphql.NewInputObject(graphql.InputObjectConfig{
+					Name: "GeoJSONDistanceFilter",
+					Fields: graphql.InputObjectConfigFieldMap{
+						"from":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(r.geoJSONScalar())},
+						"distance": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+					},
+				}),
+				Description: "Matches geometries within distance of from, in the units of the column's spatial reference system.",
+			}
+		}
+		if r.dbSchema != nil && r.dbSchema.Spatial.BoundingBox {
+			fields["intersectsBox"] = &graphql.InputObjectFieldConfig{
+				Type: graphql.NewInputObject(graphql.InputObjectConfig{
+					Name: "BoundingBoxInput",
+					Fields: graphql.InputObjectConfigFieldMap{
+						"minX": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+						"minY": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+						"maxX": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+						"maxY": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Float)},
+					},
+				}),
+				Description: "Matches geometries whose bounding rectangle intersects the box.",
+			}
+		}
+		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name:   "GeoJSONFilter",
+			Fields: fields,
+		})
+	case "TimestampFilter":
+		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: "TimestampFilter",
+			Fields: graphql.InputObjectConfigFieldMap{
+				"eq":     &graphql.InputObjectFieldConfig{Type: r.timestampScalar()},
+				"ne":     &graphql.InputObjectFieldConfig{Type: r.timestampScalar()},
+				"lt":     &graphql.InputObjectFieldConfig{Type: r.timestampScalar()},
+				"lte":    &graphql.InputObjectFieldConfig{Type: r.timestampScalar()},
+				"gt":     &graphql.InputObjectFieldConfig{Type: r.timestampScalar()},
+				"gte":    &graphql.InputObjectFieldConfig{Type: r.timestampScalar()},
+				"in":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.timestampScalar()))},
+				"notIn":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.timestampScalar()))},
+				"isNull": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			},
+		})
 	case "DateFilter":
 		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
 			Name: "DateFilter",
@@ -1732,14 +1942,14 @@ func (r *Resolver) getFilterInputType(table introspection.Table, col introspecti
 		filterType = graphql.NewInputObject(graphql.InputObjectConfig{
 			Name: "DateTimeFilter",
 			Fields: graphql.InputObjectConfigFieldMap{
-				"eq":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
-				"ne":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
-				"lt":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
-				"lte":    &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
-				"gt":     &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
-				"gte":    &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
-				"in":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.DateTime))},
-				"notIn":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.DateTime))},
+				"eq":     &graphql.InputObjectFieldConfig{Type: r.dateTimeScalar()},
+				"ne":     &graphql.InputObjectFieldConfig{Type: r.dateTimeScalar()},
+				"lt":     &graphql.InputObjectFieldConfig{Type: r.dateTimeScalar()},
+				"lte":    &graphql.InputObjectFieldConfig{Type: r.dateTimeScalar()},
+				"gt":     &graphql.InputObjectFieldConfig{Type: r.dateTimeScalar()},
+				"gte":    &graphql.InputObjectFieldConfig{Type: r.dateTimeScalar()},
+				"in":     &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.dateTimeScalar()))},
+				"notIn":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(r.dateTimeScalar()))},
 				"isNull": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
 			},
 		})
@@ -2113,8 +2323,14 @@ func convertColumnValue(col introspection.Column, val interface{}) interface{} {
 		// UUID normalization/validation is enforced by uuidColumnResolver so it can
 		// return field-level GraphQL errors on malformed stored values.
 		return val
+	case sqltype.TypeIPAddress:
+		// INET6_ATON bytes are formatted by scalarColumnResolver.
+		return val
 	case sqltype.TypeVector:
 		return val
+	case sqltype.TypeGeometry:
+		// Stored geometry bytes are decoded by scalarColumnResolver.
+		return val
 	default:
 		return convertValue(val)
 	}
@@ -2311,6 +2527,9 @@ func (r *Resolver) mapColumnTypeToGraphQL(table introspection.Table, col *intros
 	}
 	switch effectiveType {
 	case sqltype.TypeJSON:
+		if col.JSONSchema != nil {
+			return r.jsonSchemaObjectType(table, col)
+		}
 		return r.jsonScalar()
 	case sqltype.TypeVector:
 		return r.vectorScalar()
@@ -2327,7 +2546,7 @@ func (r *Resolver) mapColumnTypeToGraphQL(table introspection.Table, col *intros
 	case sqltype.TypeDate:
 		return r.dateScalar()
 	case sqltype.TypeDateTime:
-		return graphql.DateTime
+		return r.dateTimeScalar()
 	case sqltype.TypeTime:
 		return r.timeScalar()
 	case sqltype.TypeYear:
@@ -2336,6 +2555,20 @@ func (r *Resolver) mapColumnTypeToGraphQL(table introspection.Table, col *intros
 		return r.bytesScalar()
 	case sqltype.TypeSet:
 		return graphql.NewList(graphql.NewNonNull(graphql.String))
+	case sqltype.TypeEmail:
+		return r.emailScalar()
+	case sqltype.TypeURL:
+		return r.urlScalar()
+	case sqltype.TypeIPAddress:
+		return r.ipAddressScalar()
+	case sqltype.TypeMoney:
+		return r.moneyObject()
+	case sqltype.TypeDuration:
+		return r.durationScalar()
+	case sqltype.TypeTimestamp:
+		return r.timestampScalar()
+	case sqltype.TypeGeometry:
+		return r.geoJSONScalar()
 	default:
 		return graphql.String
 	}
@@ -2357,6 +2590,9 @@ func (r *Resolver) mapColumnTypeToGraphQLInput(table introspection.Table, col *i
 	}
 	switch effectiveType {
 	case sqltype.TypeJSON:
+		if col.JSONSchema != nil {
+			return r.jsonSchemaInputType(table, col)
+		}
 		return r.jsonScalar()
 	case sqltype.TypeVector:
 		return r.vectorScalar()
@@ -2373,7 +2609,7 @@ func (r *Resolver) mapColumnTypeToGraphQLInput(table introspection.Table, col *i
 	case sqltype.TypeDate:
 		return r.dateScalar()
 	case sqltype.TypeDateTime:
-		return graphql.DateTime
+		return r.dateTimeScalar()
 	case sqltype.TypeTime:
 		return r.timeScalar()
 	case sqltype.TypeYear:
@@ -2382,6 +2618,21 @@ func (r *Resolver) mapColumnTypeToGraphQLInput(table introspection.Table, col *i
 		return r.bytesScalar()
 	case sqltype.TypeSet:
 		return graphql.NewList(graphql.NewNonNull(graphql.String))
+	case sqltype.TypeEmail:
+		return r.emailScalar()
+	case sqltype.TypeURL:
+		return r.urlScalar()
+	case sqltype.TypeIPAddress:
+		return r.ipAddressScalar()
+	case sqltype.TypeMoney:
+		// Money inputs set the amount; the currency column is its own field.
+		return r.decimalScalar()
+	case sqltype.TypeDuration:
+		return r.durationScalar()
+	case sqltype.TypeTimestamp:
+		return r.timestampScalar()
+	case sqltype.TypeGeometry:
+		return r.geoJSONScalar()
 	default:
 		return graphql.String
 	}
@@ -2432,7 +2683,7 @@ func (r *Resolver) makeManyToOneResolver(table introspection.Table, rel introspe
 			IsManyToOne:   true,
 		}))
 		if err != nil {
-			return nil, fmt.Errorf("failed to build query: %w", err)
+			return nil, planError("failed to build query", err)
 		}
 
 		rows, err := r.queryExecutorForContext(p.Context).QueryContext(p.Context, planned.Root.SQL, planned.Root.Args...)
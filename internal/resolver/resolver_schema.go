@@ -242,9 +242,10 @@ func (r *Resolver) addVectorSearchQueries(fields graphql.Fields, table introspec
 }
 
 func (r *Resolver) vectorSearchFieldName(table introspection.Table, vectorCol introspection.Column) string {
-	tablePart := r.singularNamer.ToGraphQLTypeName(introspection.GraphQLQueryName(table))
-	columnPart := r.singularNamer.ToGraphQLTypeName(introspection.GraphQLFieldName(vectorCol))
-	return "search" + tablePart + "By" + columnPart + "Vector"
+	n := r.singularNamer
+	tablePart := n.FieldNamePart(n.ToGraphQLTypeName(introspection.GraphQLQueryName(table)))
+	columnPart := n.FieldNamePart(n.ToGraphQLTypeName(introspection.GraphQLFieldName(vectorCol)))
+	return n.JoinFieldName(n.JoinFieldName(n.JoinFieldName(n.JoinFieldName("search", tablePart), "by"), columnPart), "vector")
 }
 
 func uniqueRootFieldName(fields graphql.Fields, base string) string {
@@ -310,11 +311,17 @@ func (r *Resolver) buildFieldsForTable(table introspection.Table) graphql.Fields
 		}
 
 		field := &graphql.Field{
-			Type:        fieldType,
-			Description: col.Comment,
+			Type:              fieldType,
+			Description:       col.Comment,
+			DeprecationReason: col.DeprecationReason,
 		}
-		if introspection.EffectiveGraphQLType(col) == sqltype.TypeUUID {
+		switch introspection.EffectiveGraphQLType(col) {
+		case sqltype.TypeUUID:
 			field.Resolve = r.uuidColumnResolver(col)
+		case sqltype.TypeDateTime:
+			field.Resolve = r.dateTimeColumnResolver(col)
+		default:
+			field.Resolve = r.scalarColumnResolver(table, col)
 		}
 		fields[introspection.GraphQLFieldName(col)] = field
 	}
@@ -343,8 +350,9 @@ func (r *Resolver) buildFieldsForTable(table introspection.Table) graphql.Fields
 			// Row-level/table-level security can hide the related row; non-null would
 			// bubble errors and null out parent objects/lists.
 			fields[rel.GraphQLFieldName] = &graphql.Field{
-				Type:    relatedType,
-				Resolve: r.makeManyToOneResolver(table, rel),
+				Type:              relatedType,
+				Resolve:           r.makeManyToOneResolver(table, rel),
+				DeprecationReason: relatedTable.DeprecationReason,
 			}
 		} else if rel.IsOneToMany {
 			// One-to-many: returns connection (only when related table has PK)
@@ -358,7 +366,7 @@ func (r *Resolver) buildFieldsForTable(table introspection.Table) graphql.Fields
 			relPKCols := introspection.PrimaryKeyColumns(relatedTable)
 			if len(relPKCols) > 0 {
 				connType := r.buildConnectionType(relatedTable, relatedType)
-				addRelConnectionField(fields, rel.GraphQLFieldName, connType, r.connectionFieldArgs(relatedTable), r.makeOneToManyConnectionResolver(table, rel))
+				addRelConnectionField(fields, rel.GraphQLFieldName, connType, r.connectionFieldArgs(relatedTable), r.makeOneToManyConnectionResolver(table, rel), relatedTable.DeprecationReason)
 			}
 		} else if rel.IsManyToMany {
 			// Many-to-many through pure junction: returns connection (only when related table has PK)
@@ -370,7 +378,7 @@ func (r *Resolver) buildFieldsForTable(table introspection.Table) graphql.Fields
 			relPKCols := introspection.PrimaryKeyColumns(relatedTable)
 			if len(relPKCols) > 0 {
 				connType := r.buildConnectionType(relatedTable, relatedType)
-				addRelConnectionField(fields, rel.GraphQLFieldName, connType, r.connectionFieldArgs(relatedTable), r.makeManyToManyConnectionResolver(table, rel))
+				addRelConnectionField(fields, rel.GraphQLFieldName, connType, r.connectionFieldArgs(relatedTable), r.makeManyToManyConnectionResolver(table, rel), relatedTable.DeprecationReason)
 			}
 		} else if rel.IsEdgeList {
 			// Edge list through attribute junction: returns connection (only when junction table has PK)
@@ -382,20 +390,42 @@ func (r *Resolver) buildFieldsForTable(table introspection.Table) graphql.Fields
 			relPKCols := introspection.PrimaryKeyColumns(junctionTable)
 			if len(relPKCols) > 0 {
 				connType := r.buildConnectionType(junctionTable, edgeType)
-				addRelConnectionField(fields, rel.GraphQLFieldName, connType, r.connectionFieldArgs(junctionTable), r.makeEdgeListConnectionResolver(table, rel))
+				addRelConnectionField(fields, rel.GraphQLFieldName, connType, r.connectionFieldArgs(junctionTable), r.makeEdgeListConnectionResolver(table, rel), junctionTable.DeprecationReason)
 			}
 		}
 	}
 
+	r.addHierarchyFields(fields, table)
+
 	return fields
 }
 
-func addRelConnectionField(fields graphql.Fields, name string, connType *graphql.Object, args graphql.FieldConfigArgument, resolve graphql.FieldResolveFn) {
+func addRelConnectionField(fields graphql.Fields, name string, connType *graphql.Object, args graphql.FieldConfigArgument, resolve graphql.FieldResolveFn, deprecationReason string) {
 	fields[name] = &graphql.Field{
-		Type:    graphql.NewNonNull(connType),
-		Args:    args,
-		Resolve: resolve,
+		Type:              graphql.NewNonNull(connType),
+		Args:              args,
+		Resolve:           resolve,
+		DeprecationReason: deprecationReason,
+	}
+}
+
+// addDeprecatedTableFields runs add and marks the root fields it created with
+// the table's deprecation reason.
+func addDeprecatedTableFields(fields graphql.Fields, table introspection.Table, add func(graphql.Fields, introspection.Table) graphql.Fields) graphql.Fields {
+	if table.DeprecationReason == "" {
+		return add(fields, table)
 	}
+	existing := make(map[string]bool, len(fields))
+	for name := range fields {
+		existing[name] = true
+	}
+	fields = add(fields, table)
+	for name, field := range fields {
+		if !existing[name] {
+			field.DeprecationReason = table.DeprecationReason
+		}
+	}
+	return fields
 }
 
 func (r *Resolver) connectionFieldArgs(table introspection.Table) graphql.FieldConfigArgument {
@@ -510,7 +540,7 @@ func (r *Resolver) buildAggregateFieldsType(table introspection.Table) *graphql.
 	}
 
 	// Add avg/sum fields if table has numeric columns
-	numericCols := introspection.NumericColumns(table)
+	numericCols := r.unguardedColumns(table, introspection.NumericColumns(table))
 	if len(numericCols) > 0 {
 		fields["avg"] = &graphql.Field{
 			Type: r.buildNumericAggregateFieldsType(table, "Avg"),
@@ -521,7 +551,7 @@ func (r *Resolver) buildAggregateFieldsType(table introspection.Table) *graphql.
 	}
 
 	// Add min/max fields for comparable columns
-	comparableCols := introspection.ComparableColumns(table)
+	comparableCols := r.unguardedColumns(table, introspection.ComparableColumns(table))
 	if len(comparableCols) > 0 {
 		fields["countDistinct"] = &graphql.Field{
 			Type: r.buildCountDistinctFieldsType(table),
@@ -563,7 +593,7 @@ func (r *Resolver) buildNumericAggregateFieldsType(table introspection.Table, su
 	r.mu.RUnlock()
 
 	fields := graphql.Fields{}
-	for _, col := range introspection.NumericColumns(table) {
+	for _, col := range r.unguardedColumns(table, introspection.NumericColumns(table)) {
 		fieldName := introspection.GraphQLFieldName(col)
 		fields[fieldName] = &graphql.Field{
 			Type: graphql.Float, // AVG/SUM always returns Float
@@ -599,12 +629,15 @@ func (r *Resolver) buildComparableAggregateFieldsType(table introspection.Table,
 	r.mu.RUnlock()
 
 	fields := graphql.Fields{}
-	for _, col := range introspection.ComparableColumns(table) {
+	for _, col := range r.unguardedColumns(table, introspection.ComparableColumns(table)) {
 		fieldName := introspection.GraphQLFieldName(col)
 		// MIN/MAX preserve the original column type
 		fields[fieldName] = &graphql.Field{
 			Type: r.mapColumnTypeToGraphQL(table, &col),
 		}
+		if introspection.EffectiveGraphQLType(col) == sqltype.TypeDateTime {
+			fields[fieldName].Resolve = r.dateTimeColumnResolver(col)
+		}
 	}
 
 	objType := graphql.NewObject(graphql.ObjectConfig{
@@ -634,7 +667,7 @@ func (r *Resolver) buildCountDistinctFieldsType(table introspection.Table) *grap
 	r.mu.RUnlock()
 
 	fields := graphql.Fields{}
-	for _, col := range introspection.ComparableColumns(table) {
+	for _, col := range r.unguardedColumns(table, introspection.ComparableColumns(table)) {
 		fieldName := introspection.GraphQLFieldName(col)
 		fields[fieldName] = &graphql.Field{
 			Type: graphql.NewNonNull(graphql.Int),
@@ -17,6 +17,7 @@ import (
 	"tidb-graphql/internal/planner"
 	"tidb-graphql/internal/scalars"
 	"tidb-graphql/internal/schemafilter"
+	"tidb-graphql/internal/schemanaming"
 	"tidb-graphql/internal/sqltype"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
@@ -754,9 +755,9 @@ func TestConnectionResultAggregate_CountOnlyUsesCountQuery(t *testing.T) {
 			{Name: "id", DataType: "int", IsPrimaryKey: true},
 		},
 	}
-	countQuery, err := planner.BuildOneToManyCountSQL(table, "id", int64(1), nil)
+	countQuery, err := planner.BuildOneToManyCountSQL(table, []string{"id"}, []interface{}{int64(1)}, nil)
 	require.NoError(t, err)
-	aggregateBase, err := planner.BuildOneToManyAggregateBaseSQL(table, "id", int64(1), nil)
+	aggregateBase, err := planner.BuildOneToManyAggregateBaseSQL(table, []string{"id"}, []interface{}{int64(1)}, nil)
 	require.NoError(t, err)
 
 	rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
@@ -786,9 +787,9 @@ func TestConnectionResultAggregate_NonCountSeedsTotalCount(t *testing.T) {
 			{Name: "amount", DataType: "decimal"},
 		},
 	}
-	countQuery, err := planner.BuildOneToManyCountSQL(table, "id", int64(1), nil)
+	countQuery, err := planner.BuildOneToManyCountSQL(table, []string{"id"}, []interface{}{int64(1)}, nil)
 	require.NoError(t, err)
-	aggregateBase, err := planner.BuildOneToManyAggregateBaseSQL(table, "id", int64(1), nil)
+	aggregateBase, err := planner.BuildOneToManyAggregateBaseSQL(table, []string{"id"}, []interface{}{int64(1)}, nil)
 	require.NoError(t, err)
 
 	selection := planner.AggregateSelection{SumColumns: []string{"amount"}}
@@ -1624,6 +1625,343 @@ func TestCreateResolver_PureM2MConnect_InsertsJunctionRows(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func updateRelationsTestSchema() (introspection.Table, introspection.Table, introspection.Table, introspection.Table) {
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "username", DataType: "varchar", IsNullable: false},
+		},
+	}
+	groups := introspection.Table{
+		Name: "groups",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "name", DataType: "varchar", IsNullable: false},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "uq_groups_name", Unique: true, Columns: []string{"name"}},
+		},
+	}
+	userGroups := introspection.Table{
+		Name: "user_groups",
+		Columns: []introspection.Column{
+			{Name: "user_id", DataType: "int", IsPrimaryKey: true},
+			{Name: "group_id", DataType: "int", IsPrimaryKey: true},
+		},
+	}
+	sessions := introspection.Table{
+		Name: "sessions",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "user_id", DataType: "int", IsNullable: false},
+			{Name: "token", DataType: "varchar", IsNullable: false},
+		},
+	}
+	users.Relationships = []introspection.Relationship{
+		{
+			IsManyToMany:            true,
+			LocalColumns:            []string{"id"},
+			RemoteTable:             "groups",
+			RemoteColumns:           []string{"id"},
+			JunctionTable:           "user_groups",
+			JunctionLocalFKColumns:  []string{"user_id"},
+			JunctionRemoteFKColumns: []string{"group_id"},
+			GraphQLFieldName:        "groups",
+		},
+		{
+			IsOneToMany:      true,
+			LocalColumns:     []string{"id"},
+			RemoteTable:      "sessions",
+			RemoteColumns:    []string{"user_id"},
+			GraphQLFieldName: "sessions",
+		},
+	}
+	sessions.Relationships = []introspection.Relationship{
+		{
+			IsManyToOne:      true,
+			LocalColumns:     []string{"user_id"},
+			RemoteTable:      "users",
+			RemoteColumns:    []string{"id"},
+			GraphQLFieldName: "user",
+		},
+	}
+	renamePrimaryKeyID(&users)
+	renamePrimaryKeyID(&groups)
+	renamePrimaryKeyID(&sessions)
+	return users, groups, userGroups, sessions
+}
+
+func TestUpdateSetInput_IncludesRelationshipOperations(t *testing.T) {
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	r := NewResolver(nil, dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	userSet := r.updateSetInputType(users, r.mutationUpdatableColumns(users)).Fields()
+	for _, name := range []string{"username", "groupsConnect", "groupsDisconnect", "sessionsCreate", "sessionsUpdate", "sessionsDelete"} {
+		assert.Contains(t, userSet, name)
+	}
+	assert.Equal(t, "[UpdateUserSessionsNestedInput!]", userSet["sessionsUpdate"].Type.String())
+	assert.Equal(t, "[ID!]", userSet["sessionsDelete"].Type.String())
+
+	nestedSet := r.nestedUpdateInputForRel(users, users.Relationships[1]).Fields()["set"].Type.(*graphql.NonNull).OfType.(*graphql.InputObject).Fields()
+	assert.Contains(t, nestedSet, "token")
+	assert.NotContains(t, nestedSet, "userId")
+
+	sessionSet := r.updateSetInputType(sessions, r.mutationUpdatableColumns(sessions)).Fields()
+	assert.Contains(t, sessionSet, "userConnect")
+}
+
+func TestMutationSchema_SnakeFieldCase(t *testing.T) {
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	cfg := naming.DefaultConfig()
+	cfg.FieldCase = naming.FieldCaseSnake
+	schemanaming.Apply(dbSchema, naming.New(cfg, nil))
+	r := NewResolver(nil, dbSchema, nil, 0, schemafilter.Config{}, cfg)
+
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+	fields := schema.MutationType().Fields()
+	for _, name := range []string{"create_user", "update_user", "delete_user", "create_session", "update_group_by_name", "delete_group_by_name"} {
+		assert.Contains(t, fields, name)
+	}
+	assert.NotContains(t, fields, "createUser")
+	assert.NotContains(t, fields, "updateGroupByName")
+
+	userCreate := schema.Type("CreateUserInput").(*graphql.InputObject).Fields()
+	for _, name := range []string{"groups_connect", "sessions_create"} {
+		assert.Contains(t, userCreate, name)
+	}
+	userSet := schema.Type("UpdateUserSetInput").(*graphql.InputObject).Fields()
+	for _, name := range []string{"groups_connect", "groups_disconnect", "sessions_create", "sessions_update", "sessions_delete"} {
+		assert.Contains(t, userSet, name)
+	}
+	sessionCreate := schema.Type("CreateSessionInput").(*graphql.InputObject).Fields()
+	assert.Contains(t, sessionCreate, "user_connect")
+	assert.NotContains(t, sessionCreate, "userConnect")
+}
+
+func TestUpdateResolver_M2MDisconnectAndConnect(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	updatable := columnNameSet(r.mutationUpdatableColumns(users))
+	pkCols := introspection.PrimaryKeyColumns(users)
+
+	field := &ast.Field{
+		Name: &ast.Name{Value: "updateUser"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "username"}},
+		}},
+	}
+	selected := planner.SelectedColumns(users, field, nil)
+	selected = planner.EnsureColumns(users, selected, []string{"id"})
+	selectPlan, err := planner.PlanTableByPK(users, selected, &pkCols[0], int64(1))
+	require.NoError(t, err)
+	oldGroup, err := planner.PlanUniqueKeyLookup(groups, groups.Columns, groups.Indexes[1], map[string]interface{}{"name": "old"})
+	require.NoError(t, err)
+	unlink, err := planner.PlanDeleteMatching(userGroups, map[string]interface{}{"user_id": int64(1), "group_id": int64(10)})
+	require.NoError(t, err)
+	newGroup, err := planner.PlanUniqueKeyLookup(groups, groups.Columns, groups.Indexes[1], map[string]interface{}{"name": "new"})
+	require.NoError(t, err)
+	link, err := planner.PlanInsert(userGroups, []string{"user_id", "group_id"}, []interface{}{int64(1), int64(20)})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	expectQuery(t, mock, selectPlan.SQL, selectPlan.Args, sqlmock.NewRows([]string{"id", "username"}).AddRow(int64(1), "alice"))
+	expectQuery(t, mock, oldGroup.SQL, oldGroup.Args, sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(10), "old"))
+	mock.ExpectExec(regexp.QuoteMeta(unlink.SQL)).
+		WithArgs(toDriverValues(unlink.Args)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectQuery(t, mock, newGroup.SQL, newGroup.Args, sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(20), "new"))
+	mock.ExpectExec(regexp.QuoteMeta(link.SQL)).
+		WithArgs(toDriverValues(link.Args)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+	require.NoError(t, err)
+	mc := NewMutationContext(tx)
+	ctx := WithMutationContext(context.Background(), mc)
+
+	resolverFn := r.makeUpdateResolver(users, updatable, pkCols, r.updateSuccessType(users, r.buildGraphQLType(users)))
+	result, err := resolverFn(graphql.ResolveParams{
+		Args: map[string]interface{}{
+			"id": nodeid.Encode(introspection.GraphQLTypeName(users), 1),
+			"set": map[string]interface{}{
+				"groupsDisconnect": []interface{}{
+					map[string]interface{}{"byName": map[string]interface{}{"name": "old"}},
+				},
+				"groupsConnect": []interface{}{
+					map[string]interface{}{"byName": map[string]interface{}{"name": "new"}},
+				},
+			},
+		},
+		Context: ctx,
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	})
+	require.NoError(t, err)
+
+	payload, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	user, ok := payload["user"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "alice", user["username"])
+
+	require.NoError(t, mc.Finalize())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateResolver_NestedDeleteOfForeignChild_NotFoundError(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	updatable := columnNameSet(r.mutationUpdatableColumns(users))
+	pkCols := introspection.PrimaryKeyColumns(users)
+
+	field := &ast.Field{
+		Name: &ast.Name{Value: "updateUser"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "username"}},
+		}},
+	}
+	selected := planner.SelectedColumns(users, field, nil)
+	selected = planner.EnsureColumns(users, selected, []string{"id"})
+	selectPlan, err := planner.PlanTableByPK(users, selected, &pkCols[0], int64(1))
+	require.NoError(t, err)
+	scopedDelete, err := planner.PlanDeleteMatching(sessions, map[string]interface{}{"id": int64(99), "user_id": int64(1)})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	expectQuery(t, mock, selectPlan.SQL, selectPlan.Args, sqlmock.NewRows([]string{"id", "username"}).AddRow(int64(1), "alice"))
+	mock.ExpectExec(regexp.QuoteMeta(scopedDelete.SQL)).
+		WithArgs(toDriverValues(scopedDelete.Args)...).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+	require.NoError(t, err)
+	mc := NewMutationContext(tx)
+	ctx := WithMutationContext(context.Background(), mc)
+
+	resolverFn := r.makeUpdateResolver(users, updatable, pkCols, r.updateSuccessType(users, r.buildGraphQLType(users)))
+	result, err := resolverFn(graphql.ResolveParams{
+		Args: map[string]interface{}{
+			"id": nodeid.Encode(introspection.GraphQLTypeName(users), 1),
+			"set": map[string]interface{}{
+				"sessionsDelete": []interface{}{nodeid.Encode(introspection.GraphQLTypeName(sessions), 99)},
+			},
+		},
+		Context: ctx,
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	})
+	require.NoError(t, err)
+
+	payload, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "NotFoundError", payload["__typename"])
+
+	require.NoError(t, mc.Finalize())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecuteNestedUpdate_EmptySetStillChecksOwnership(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	lookup, err := planner.PlanRowMatching(sessions, introspection.PrimaryKeyColumns(sessions), map[string]interface{}{"id": int64(99), "user_id": int64(1)})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	expectQuery(t, mock, lookup.SQL, lookup.Args, sqlmock.NewRows([]string{"id"}))
+
+	tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+	require.NoError(t, err)
+	err = r.executeNestedUpdate(context.Background(), tx, users, users.Relationships[1], map[string]interface{}{"databaseId": int64(1)}, []map[string]interface{}{
+		{"id": nodeid.Encode(introspection.GraphQLTypeName(sessions), 99), "set": map[string]interface{}{}},
+	})
+	var mutErr *mutationError
+	require.True(t, errors.As(err, &mutErr), "expected mutation error, got %v", err)
+	assert.Equal(t, "not_found", mutErr.code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecuteUpdateRelationOps_FixedOrder(t *testing.T) {
+	users, groups, userGroups, sessions := updateRelationsTestSchema()
+	users.Relationships = append(users.Relationships, introspection.Relationship{
+		IsOneToMany:      true,
+		LocalColumns:     []string{"id"},
+		RemoteTable:      "sessions",
+		RemoteColumns:    []string{"user_id"},
+		GraphQLFieldName: "apiSessions",
+	})
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{users, groups, userGroups, sessions}}
+	r := NewResolver(nil, dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	plan := r.buildUpdateMutationPlan(users)
+
+	apiDelete, err := planner.PlanDeleteMatching(sessions, map[string]interface{}{"id": int64(7), "user_id": int64(1)})
+	require.NoError(t, err)
+	sessionDelete, err := planner.PlanDeleteMatching(sessions, map[string]interface{}{"id": int64(8), "user_id": int64(1)})
+	require.NoError(t, err)
+
+	// Map iteration order is random; repeat so an unordered loop would fail.
+	for i := 0; i < 20; i++ {
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(apiDelete.SQL)).
+			WithArgs(toDriverValues(apiDelete.Args)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(sessionDelete.SQL)).
+			WithArgs(toDriverValues(sessionDelete.Args)...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+		require.NoError(t, err)
+		in := partitionedUpdateInput{nestedDeletes: map[string][]interface{}{
+			"sessionsDelete":    {nodeid.Encode(introspection.GraphQLTypeName(sessions), 8)},
+			"apiSessionsDelete": {nodeid.Encode(introspection.GraphQLTypeName(sessions), 7)},
+		}}
+		err = r.executeUpdateRelationOps(context.Background(), tx, users, plan, in, map[string]interface{}{"databaseId": int64(1)})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		_ = db.Close()
+	}
+}
+
+func TestUpdateResultUnion_IncludesNotFound(t *testing.T) {
+	table := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", IsPrimaryKey: true},
+		},
+	}
+	renamePrimaryKeyID(&table)
+	r := NewResolver(nil, &introspection.Schema{Tables: []introspection.Table{table}}, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+	union := r.updateResultUnion(table, r.updateSuccessType(table, r.buildGraphQLType(table)))
+
+	names := make([]string, 0, len(union.Types()))
+	for _, typ := range union.Types() {
+		names = append(names, typ.Name())
+	}
+	assert.Contains(t, names, "NotFoundError")
+}
+
 func TestUpdateResolver_NotFound_SuccessWithNullEntity(t *testing.T) {
 	db, mock := newMockDB(t)
 	defer db.Close()
@@ -1772,6 +2110,164 @@ func TestDeleteResolver_NotFound_NotFoundError(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUniqueKeyMutations_Generated(t *testing.T) {
+	table := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "email", DataType: "varchar"},
+			{Name: "username", DataType: "varchar"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "uk_email", Unique: true, Columns: []string{"email"}},
+		},
+	}
+	renamePrimaryKeyID(&table)
+	r := NewResolver(nil, &introspection.Schema{Tables: []introspection.Table{table}}, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+	fields := schema.MutationType().Fields()
+
+	update, ok := fields["updateUserByEmail"]
+	require.True(t, ok)
+	assert.Equal(t, "UpdateUserResult!", update.Type.String())
+	argNames := make([]string, 0, len(update.Args))
+	for _, arg := range update.Args {
+		argNames = append(argNames, arg.Name())
+	}
+	assert.ElementsMatch(t, []string{"email", "set"}, argNames)
+
+	del, ok := fields["deleteUserByEmail"]
+	require.True(t, ok)
+	assert.Equal(t, "DeleteUserResult!", del.Type.String())
+	require.Len(t, del.Args, 1)
+	assert.Equal(t, "email", del.Args[0].Name())
+	assert.Equal(t, "String!", del.Args[0].Type.String())
+}
+
+func TestUpdateByUniqueKeyResolver_UpdatesByResolvedPK(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	table := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "email", DataType: "varchar"},
+			{Name: "username", DataType: "varchar"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "uk_email", Unique: true, Columns: []string{"email"}},
+		},
+	}
+	renamePrimaryKeyID(&table)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{table}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	updatable := columnNameSet(r.mutationUpdatableColumns(table))
+	pkCols := introspection.PrimaryKeyColumns(table)
+	lookup, err := planner.PlanUniqueKeyLookup(table, pkCols, table.Indexes[1], map[string]interface{}{"email": "a@example.com"})
+	require.NoError(t, err)
+	update, err := planner.PlanUpdate(table, map[string]interface{}{"username": "alice"}, map[string]interface{}{"id": int64(5)})
+	require.NoError(t, err)
+
+	field := &ast.Field{
+		Name: &ast.Name{Value: "updateUserByEmail"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{Name: &ast.Name{Value: "username"}},
+		}},
+	}
+	selected := planner.SelectedColumns(table, field, nil)
+	selectPlan, err := planner.PlanTableByPK(table, selected, &pkCols[0], int64(5))
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	expectQuery(t, mock, lookup.SQL, lookup.Args, sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectExec(regexp.QuoteMeta(update.SQL)).
+		WithArgs(toDriverValues(update.Args)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectQuery(t, mock, selectPlan.SQL, selectPlan.Args, sqlmock.NewRows([]string{"id", "username"}).AddRow(int64(5), "alice"))
+	mock.ExpectCommit()
+
+	tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+	require.NoError(t, err)
+	mc := NewMutationContext(tx)
+	ctx := WithMutationContext(context.Background(), mc)
+
+	successType := r.updateSuccessType(table, r.buildGraphQLType(table))
+	resolverFn := r.makeUpdateResolverWithLocator(table, updatable, pkCols, successType, r.uniqueKeyRowLocator(table, pkCols, table.Indexes[1]))
+	result, err := resolverFn(graphql.ResolveParams{
+		Args: map[string]interface{}{
+			"email": "a@example.com",
+			"set":   map[string]interface{}{"username": "alice"},
+		},
+		Context: ctx,
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	})
+	require.NoError(t, err)
+
+	payload, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	user, ok := payload[r.mutationEntityFieldName(table)].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "alice", user["username"])
+
+	require.NoError(t, mc.Finalize())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteByUniqueKeyResolver_NotFound_NotFoundError(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	table := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "email", DataType: "varchar"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "uk_email", Unique: true, Columns: []string{"email"}},
+		},
+	}
+	renamePrimaryKeyID(&table)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{table}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	pkCols := introspection.PrimaryKeyColumns(table)
+	lookup, err := planner.PlanUniqueKeyLookup(table, pkCols, table.Indexes[1], map[string]interface{}{"email": "missing@example.com"})
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	expectQuery(t, mock, lookup.SQL, lookup.Args, sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	tx, err := dbexec.NewStandardExecutor(db).BeginTx(context.Background())
+	require.NoError(t, err)
+	mc := NewMutationContext(tx)
+	ctx := WithMutationContext(context.Background(), mc)
+
+	resolverFn := r.makeDeleteResolverWithLocator(table, pkCols, r.deleteSuccessType(table, pkCols), r.uniqueKeyRowLocator(table, pkCols, table.Indexes[1]))
+	result, err := resolverFn(graphql.ResolveParams{
+		Args:    map[string]interface{}{"email": "missing@example.com"},
+		Context: ctx,
+	})
+	require.NoError(t, err)
+
+	payload, ok := result.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "NotFoundError", payload["__typename"])
+
+	require.NoError(t, mc.Finalize())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteResolver_BadNodeID_ValidationError(t *testing.T) {
 	db, mock := newMockDB(t)
 	defer db.Close()
@@ -2069,6 +2565,7 @@ func TestMutationErrToPayload_AllCodes(t *testing.T) {
 		{name: "foreign key", err: newMutationError("fk", "foreign_key_violation", 1451), want: "ConstraintError"},
 		{name: "not null", err: newMutationError("null", "not_null_violation", 1048), want: "ConstraintError"},
 		{name: "access denied", err: newMutationError("denied", "access_denied", 1142), want: "PermissionError"},
+		{name: "not found", err: newMutationError("missing", "not_found", 0), want: "NotFoundError"},
 		{name: "plain", err: errors.New("boom"), want: "InternalError"},
 	}
 	for _, tc := range tests {
@@ -2998,7 +3495,7 @@ func TestTryBatchOneToManyConnection_InvalidMapping(t *testing.T) {
 		Info: graphql.ResolveInfo{
 			FieldASTs: []*ast.Field{field},
 		},
-	}, users, rel, 1)
+	}, users, rel, []interface{}{1})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid one-to-many mapping")
 	assert.True(t, ok)
@@ -3066,7 +3563,7 @@ func TestTryBatchOneToManyConnection_CachesResults(t *testing.T) {
 
 	orderBy := &planner.OrderBy{Columns: []string{"id"}, Directions: []string{"ASC"}}
 	selection := planner.SelectedColumnsForConnection(posts, field, nil, orderBy)
-	batchPlan, err := planner.PlanOneToManyConnectionBatch(posts, rel.RemoteColumns[0], selection, []interface{}{1, 2}, 1, orderBy, nil)
+	batchPlan, err := planner.PlanOneToManyConnectionBatch(posts, rel.RemoteColumns, selection, []planner.ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 1, orderBy, nil)
 	require.NoError(t, err)
 	batchRows := sqlmock.NewRows([]string{"id", "user_id", "title", "__batch_parent_id"}).
 		AddRow(101, 1, "first", 1).
@@ -3082,7 +3579,7 @@ func TestTryBatchOneToManyConnection_CachesResults(t *testing.T) {
 		Info: graphql.ResolveInfo{
 			FieldASTs: []*ast.Field{field},
 		},
-	}, users, rel, 1)
+	}, users, rel, []interface{}{1})
 	require.NoError(t, err)
 	require.True(t, ok)
 	firstNodes, ok := firstResult["nodes"].([]map[string]interface{})
@@ -3100,7 +3597,7 @@ func TestTryBatchOneToManyConnection_CachesResults(t *testing.T) {
 		Info: graphql.ResolveInfo{
 			FieldASTs: []*ast.Field{field},
 		},
-	}, users, rel, 2)
+	}, users, rel, []interface{}{2})
 	require.NoError(t, err)
 	require.True(t, ok)
 	secondNodes, ok := secondResult["nodes"].([]map[string]interface{})
@@ -3113,6 +3610,89 @@ func TestTryBatchOneToManyConnection_CachesResults(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestTryBatchOneToManyConnection_CompositeKey(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	customers := introspection.Table{
+		Name: "customers",
+		Columns: []introspection.Column{
+			{Name: "tenant_id", IsPrimaryKey: true, GraphQLFieldName: "tenantId"},
+			{Name: "id", IsPrimaryKey: true, GraphQLFieldName: "databaseId"},
+		},
+	}
+	orders := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "tenant_id", IsPrimaryKey: true, GraphQLFieldName: "tenantId"},
+			{Name: "id", IsPrimaryKey: true, GraphQLFieldName: "databaseId"},
+			{Name: "customer_id", GraphQLFieldName: "customerId"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"tenant_id", "id"}},
+		},
+	}
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{customers, orders}}
+	r := NewResolver(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, schemafilter.Config{}, naming.DefaultConfig())
+
+	ctx := NewBatchingContext(context.Background())
+	state, ok := GetBatchState(ctx)
+	require.True(t, ok)
+
+	parentKey := "customers|list|"
+	parentRows := []map[string]interface{}{
+		{"tenantId": 7, "databaseId": 1, batchParentKeyField: parentKey},
+		{"tenantId": 8, "databaseId": 1, batchParentKeyField: parentKey},
+	}
+	state.setParentRows(parentKey, parentRows)
+
+	rel := introspection.Relationship{
+		IsOneToMany:      true,
+		LocalColumns:     []string{"tenant_id", "id"},
+		RemoteTable:      "orders",
+		RemoteColumns:    []string{"tenant_id", "customer_id"},
+		GraphQLFieldName: "orders",
+	}
+	field := &ast.Field{
+		Name: &ast.Name{Value: "orders"},
+		SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+			&ast.Field{
+				Name: &ast.Name{Value: "nodes"},
+				SelectionSet: &ast.SelectionSet{Selections: []ast.Selection{
+					&ast.Field{Name: &ast.Name{Value: "databaseId"}},
+				}},
+			},
+		}},
+	}
+
+	orderBy := &planner.OrderBy{Columns: []string{"tenant_id", "id"}, Directions: []string{"ASC", "ASC"}}
+	selection := planner.SelectedColumnsForConnection(orders, field, nil, orderBy)
+	batchPlan, err := planner.PlanOneToManyConnectionBatch(orders, rel.RemoteColumns, selection, []planner.ParentTuple{{Values: []interface{}{7, 1}}, {Values: []interface{}{8, 1}}}, 25, orderBy, nil)
+	require.NoError(t, err)
+	batchRows := sqlmock.NewRows([]string{"tenant_id", "id", "__batch_parent_0", "__batch_parent_1"}).
+		AddRow(7, 101, 7, 1).
+		AddRow(8, 201, 8, 1).
+		AddRow(8, 202, 8, 1)
+	expectQuery(t, mock, batchPlan.SQL, batchPlan.Args, batchRows)
+
+	result, ok, err := r.tryBatchOneToManyConnection(graphql.ResolveParams{
+		Source:  parentRows[1],
+		Args:    map[string]interface{}{"first": 25},
+		Context: ctx,
+		Info: graphql.ResolveInfo{
+			FieldASTs: []*ast.Field{field},
+		},
+	}, customers, rel, []interface{}{8, 1})
+	require.NoError(t, err)
+	require.True(t, ok)
+	nodes, ok := result["nodes"].([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, nodes, 2)
+	assert.EqualValues(t, 201, nodes[0]["databaseId"])
+	assert.EqualValues(t, 202, nodes[1]["databaseId"])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTryBatchOneToManyConnection_FirstZero(t *testing.T) {
 	db, mock := newMockDB(t)
 	defer db.Close()
@@ -3170,7 +3750,7 @@ func TestTryBatchOneToManyConnection_FirstZero(t *testing.T) {
 
 	orderBy := &planner.OrderBy{Columns: []string{"id"}, Directions: []string{"ASC"}}
 	selection := planner.SelectedColumnsForConnection(posts, field, nil, orderBy)
-	batchPlan, err := planner.PlanOneToManyConnectionBatch(posts, rel.RemoteColumns[0], selection, []interface{}{1, 2}, 0, orderBy, nil)
+	batchPlan, err := planner.PlanOneToManyConnectionBatch(posts, rel.RemoteColumns, selection, []planner.ParentTuple{{Values: []interface{}{1}}, {Values: []interface{}{2}}}, 0, orderBy, nil)
 	require.NoError(t, err)
 	batchRows := sqlmock.NewRows([]string{"id", "__batch_parent_id"}).
 		AddRow(101, 1).
@@ -3184,7 +3764,7 @@ func TestTryBatchOneToManyConnection_FirstZero(t *testing.T) {
 		Info: graphql.ResolveInfo{
 			FieldASTs: []*ast.Field{field},
 		},
-	}, users, rel, 1)
+	}, users, rel, []interface{}{1})
 	require.NoError(t, err)
 	require.True(t, ok)
 
@@ -3261,7 +3841,7 @@ func TestOneToManyConnectionResolver_WithAfterSkipsBatching(t *testing.T) {
 		"first": 1,
 		"after": after,
 	}
-	plan, err := planner.PlanOneToManyConnection(posts, rel.RemoteColumns[0], 1, field, args)
+	plan, err := planner.PlanOneToManyConnection(posts, rel.RemoteColumns, []interface{}{1}, field, args)
 	require.NoError(t, err)
 	rows := sqlmock.NewRows([]string{"id"}).AddRow(101)
 	expectQuery(t, mock, plan.Root.SQL, plan.Root.Args, rows)
@@ -3340,7 +3920,7 @@ func TestOneToManyConnectionResolver_WithLastSkipsBatching(t *testing.T) {
 	args := map[string]interface{}{
 		"last": 1,
 	}
-	plan, err := planner.PlanOneToManyConnection(posts, rel.RemoteColumns[0], 1, field, args)
+	plan, err := planner.PlanOneToManyConnection(posts, rel.RemoteColumns, []interface{}{1}, field, args)
 	require.NoError(t, err)
 	rows := sqlmock.NewRows([]string{"id"}).AddRow(101)
 	expectQuery(t, mock, plan.Root.SQL, plan.Root.Args, rows)
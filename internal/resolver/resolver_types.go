@@ -25,7 +25,7 @@ func (r *Resolver) orderByArgType(table introspection.Table) graphql.Input {
 }
 
 func (r *Resolver) orderByClauseInput(table introspection.Table) *graphql.InputObject {
-	fields := planner.OrderByIndexedFields(table)
+	fields := r.unguardedOrderByFields(table, planner.OrderByIndexedFields(table))
 	if len(fields) == 0 {
 		return nil
 	}
@@ -312,6 +312,175 @@ func (r *Resolver) vectorScalar() *graphql.Scalar {
 	return cached
 }
 
+func (r *Resolver) emailScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.emailType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.Email()
+
+	r.mu.Lock()
+	if r.emailType == nil {
+		r.emailType = scalar
+	}
+	cached = r.emailType
+	r.mu.Unlock()
+
+	return cached
+}
+
+func (r *Resolver) urlScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.urlType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.URL()
+
+	r.mu.Lock()
+	if r.urlType == nil {
+		r.urlType = scalar
+	}
+	cached = r.urlType
+	r.mu.Unlock()
+
+	return cached
+}
+
+func (r *Resolver) ipAddressScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.ipAddressType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.IPAddress()
+
+	r.mu.Lock()
+	if r.ipAddressType == nil {
+		r.ipAddressType = scalar
+	}
+	cached = r.ipAddressType
+	r.mu.Unlock()
+
+	return cached
+}
+
+func (r *Resolver) geoJSONScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.geoJSONType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.GeoJSON()
+
+	r.mu.Lock()
+	if r.geoJSONType == nil {
+		r.geoJSONType = scalar
+	}
+	cached = r.geoJSONType
+	r.mu.Unlock()
+
+	return cached
+}
+
+func (r *Resolver) durationScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.durationType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.Duration()
+
+	r.mu.Lock()
+	if r.durationType == nil {
+		r.durationType = scalar
+	}
+	cached = r.durationType
+	r.mu.Unlock()
+
+	return cached
+}
+
+func (r *Resolver) timestampScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.timestampType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.Timestamp()
+
+	r.mu.Lock()
+	if r.timestampType == nil {
+		r.timestampType = scalar
+	}
+	cached = r.timestampType
+	r.mu.Unlock()
+
+	return cached
+}
+
+func (r *Resolver) dateTimeScalar() *graphql.Scalar {
+	r.mu.RLock()
+	cached := r.dateTimeType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	scalar := scalars.DateTime()
+
+	r.mu.Lock()
+	if r.dateTimeType == nil {
+		r.dateTimeType = scalar
+	}
+	cached = r.dateTimeType
+	r.mu.Unlock()
+
+	return cached
+}
+
+// moneyObject is the output type of Money columns: the amount column paired
+// with the value of its currency column.
+func (r *Resolver) moneyObject() *graphql.Object {
+	r.mu.RLock()
+	cached := r.moneyType
+	r.mu.RUnlock()
+	if cached != nil {
+		return cached
+	}
+
+	obj := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Money",
+		Description: "A decimal amount and its currency.",
+		Fields: graphql.Fields{
+			"amount":   &graphql.Field{Type: graphql.NewNonNull(r.decimalScalar())},
+			"currency": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	r.mu.Lock()
+	if r.moneyType == nil {
+		r.moneyType = obj
+	}
+	cached = r.moneyType
+	r.mu.Unlock()
+
+	return cached
+}
+
 func (r *Resolver) vectorDistanceMetricEnum() *graphql.Enum {
 	r.mu.RLock()
 	cached := r.vectorDistance
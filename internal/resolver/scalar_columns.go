@@ -0,0 +1,97 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tidb-graphql/internal/geojson"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/scalarutil"
+	"tidb-graphql/internal/sqltype"
+
+	"github.com/graphql-go/graphql"
+)
+
+// scalarColumnResolver converts stored values of columns mapped to IPAddress,
+// Duration, Timestamp or Money, of spatial columns, and of JSON columns with a
+// JSON Schema, into their GraphQL form. It returns nil for columns that need
+// no conversion.
+func (r *Resolver) scalarColumnResolver(table introspection.Table, col introspection.Column) graphql.FieldResolveFn {
+	fieldName := introspection.GraphQLFieldName(col)
+	effectiveType := introspection.EffectiveGraphQLType(col)
+
+	var convert func(source map[string]interface{}, raw interface{}) (interface{}, error)
+	switch {
+	case col.EnumLookup != nil:
+		// Lookup enum values are the string form of the stored codes.
+		convert = func(_ map[string]interface{}, raw interface{}) (interface{}, error) {
+			return fmt.Sprint(convertValue(raw)), nil
+		}
+	case effectiveType == sqltype.TypeIPAddress:
+		convert = func(_ map[string]interface{}, raw interface{}) (interface{}, error) {
+			b, ok := raw.([]byte)
+			if !ok {
+				return nil, fmt.Errorf("unsupported IP address value type %T", raw)
+			}
+			return scalarutil.IPFromBytes(b)
+		}
+	case effectiveType == sqltype.TypeDuration:
+		convert = func(_ map[string]interface{}, raw interface{}) (interface{}, error) {
+			return scalarutil.DurationFromUnit(raw, col.OverrideUnit)
+		}
+	case effectiveType == sqltype.TypeTimestamp:
+		convert = func(_ map[string]interface{}, raw interface{}) (interface{}, error) {
+			if t, ok := raw.(time.Time); ok {
+				raw = storedInstant(col, t)
+			}
+			return scalarutil.TimestampFromStored(raw, col.OverrideUnit)
+		}
+	case effectiveType == sqltype.TypeMoney:
+		currencyField := graphQLFieldNameForColumn(table, col.CurrencyColumn)
+		convert = func(source map[string]interface{}, raw interface{}) (interface{}, error) {
+			money := map[string]interface{}{"amount": convertValue(raw), "currency": nil}
+			if currency := source[currencyField]; currency != nil {
+				money["currency"] = strings.TrimSpace(fmt.Sprint(convertValue(currency)))
+			}
+			return money, nil
+		}
+	case effectiveType == sqltype.TypeGeometry:
+		convert = func(_ map[string]interface{}, raw interface{}) (interface{}, error) {
+			b, ok := raw.([]byte)
+			if !ok {
+				return nil, fmt.Errorf("unsupported geometry value type %T", raw)
+			}
+			g, _, err := geojson.FromStored(b)
+			if err != nil {
+				return nil, err
+			}
+			return g.Object(), nil
+		}
+	case effectiveType == sqltype.TypeJSON:
+		if col.JSONSchema == nil {
+			return nil
+		}
+		convert = func(_ map[string]interface{}, raw interface{}) (interface{}, error) {
+			return decodeJSONDocument(raw)
+		}
+	default:
+		return nil
+	}
+
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source, ok := p.Source.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid source type for %s field %s", effectiveType, fieldName)
+		}
+		raw := source[fieldName]
+		if raw == nil {
+			return nil, nil
+		}
+		value, err := convert(source, raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value for field %s: %w", effectiveType, fieldName, err)
+		}
+		return value, nil
+	}
+}
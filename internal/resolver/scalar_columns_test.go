@@ -0,0 +1,61 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/sqltype"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarColumnMappingsResolveAndFilter(t *testing.T) {
+	sessions := introspection.Table{
+		Name: "sessions",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "client_ip", DataType: "varbinary", ColumnType: "varbinary(16)", IsNullable: true, HasOverrideType: true, OverrideType: sqltype.TypeIPAddress},
+			{Name: "timeout_ms", DataType: "int", IsNullable: true, HasOverrideType: true, OverrideType: sqltype.TypeDuration, OverrideUnit: "ms"},
+			{Name: "amount", DataType: "decimal", IsNullable: true, HasOverrideType: true, OverrideType: sqltype.TypeMoney, CurrencyColumn: "currency"},
+			{Name: "currency", DataType: "char", IsNullable: true},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "idx_sessions_client_ip", Columns: []string{"client_ip"}},
+		},
+	}
+	renamePrimaryKeyID(&sessions)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{sessions}}
+
+	db, mock := newMockDB(t)
+	defer db.Close()
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	rows := sqlmock.NewRows([]string{"id", "client_ip", "timeout_ms", "amount", "currency"}).
+		AddRow(1, []byte{192, 168, 1, 10}, int64(90500), []byte("12.50"), []byte("EUR"))
+	mock.ExpectQuery("`client_ip` BETWEEN").
+		WithArgs(4, []byte{192, 168, 0, 0}, []byte{192, 168, 255, 255}).
+		WillReturnRows(rows)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ sessions(where: { clientIp: { inSubnet: "192.168.0.0/16" } }) { nodes { clientIp timeoutMs amount { amount currency } } } }`,
+		Context:       NewBatchingContext(context.Background()),
+	})
+	require.Empty(t, result.Errors)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	nodes := result.Data.(map[string]interface{})["sessions"].(map[string]interface{})["nodes"].([]interface{})
+	require.Len(t, nodes, 1)
+	node := nodes[0].(map[string]interface{})
+	assert.Equal(t, "192.168.1.10", node["clientIp"])
+	assert.Equal(t, "PT1M30.5S", node["timeoutMs"])
+	assert.Equal(t, map[string]interface{}{"amount": "12.50", "currency": "EUR"}, node["amount"])
+}
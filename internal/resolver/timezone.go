@@ -0,0 +1,111 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/scalars"
+
+	"github.com/graphql-go/graphql"
+)
+
+// timeZoneDirective declares @timezone. Request analysis reads it (or the
+// X-Timezone header) so resolvers see the zone through requestTimeZone.
+func timeZoneDirective() *graphql.Directive {
+	return graphql.NewDirective(graphql.DirectiveConfig{
+		Name:        gqlrequest.TimeZoneDirectiveName,
+		Description: "Renders DateTime values and reads DateTime inputs without a UTC offset in the named IANA time zone.",
+		Locations:   []string{graphql.DirectiveLocationQuery, graphql.DirectiveLocationMutation},
+		Args: graphql.FieldConfigArgument{
+			gqlrequest.TimeZoneDirectiveArg: &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+		},
+	})
+}
+
+// requestTimeZone returns the zone selected by the request, falling back to
+// the configured default and then UTC.
+func (r *Resolver) requestTimeZone(ctx context.Context) *time.Location {
+	if analysis := gqlrequest.AnalysisFromContext(ctx); analysis != nil && analysis.TimeZone != nil {
+		return analysis.TimeZone
+	}
+	if r.timeZone != nil {
+		return r.timeZone
+	}
+	return time.UTC
+}
+
+// applyRequestTimeZone wraps every resolver that takes arguments so DateTime
+// inputs written without an offset reach the planner as instants in the
+// request time zone.
+func (r *Resolver) applyRequestTimeZone(schema *graphql.Schema) {
+	for name, t := range schema.TypeMap() {
+		obj, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for _, field := range obj.Fields() {
+			if field.Resolve == nil || len(field.Args) == 0 {
+				continue
+			}
+			next := field.Resolve
+			field.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+				if len(p.Args) > 0 {
+					loc := r.requestTimeZone(p.Context)
+					for key, value := range p.Args {
+						p.Args[key] = resolveLocalDateTimes(value, loc)
+					}
+				}
+				return next(p)
+			}
+		}
+	}
+}
+
+func resolveLocalDateTimes(value interface{}, loc *time.Location) interface{} {
+	switch v := value.(type) {
+	case scalars.LocalDateTime:
+		return v.In(loc)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = resolveLocalDateTimes(item, loc)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = resolveLocalDateTimes(item, loc)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// dateTimeColumnResolver renders DATETIME and TIMESTAMP values in the request
+// time zone. DATETIME wall clocks are first placed in the column's configured
+// zone; TIMESTAMP values are already instants.
+func (r *Resolver) dateTimeColumnResolver(col introspection.Column) graphql.FieldResolveFn {
+	fieldName := introspection.GraphQLFieldName(col)
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source, ok := p.Source.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid source type for DateTime field %s", fieldName)
+		}
+		t, ok := source[fieldName].(time.Time)
+		if !ok {
+			return source[fieldName], nil
+		}
+		return storedInstant(col, t).In(r.requestTimeZone(p.Context)), nil
+	}
+}
+
+// storedInstant reverses planner.StorageValue for time values: it reads the
+// UTC-labeled wall clock returned by the driver in the column's zone.
+func storedInstant(col introspection.Column, t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), introspection.WallClockZone(col))
+}
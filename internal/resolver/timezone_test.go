@@ -0,0 +1,75 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/introspection"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDateTimeColumnsUseRequestTimeZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	orders := introspection.Table{
+		Name: "orders",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "placed_at", DataType: "datetime", ColumnType: "datetime", TimeZone: berlin},
+			{Name: "updated_at", DataType: "timestamp", ColumnType: "timestamp"},
+		},
+		Indexes: []introspection.Index{
+			{Name: "PRIMARY", Unique: true, Columns: []string{"id"}},
+			{Name: "idx_orders_placed_at", Columns: []string{"placed_at"}},
+		},
+	}
+	renamePrimaryKeyID(&orders)
+	dbSchema := &introspection.Schema{Tables: []introspection.Table{orders}}
+
+	db, mock := newMockDB(t)
+	defer db.Close()
+	r := NewResolverWithConfig(dbexec.NewStandardExecutor(db), dbSchema, nil, 0, ResolverConfig{TimeZone: berlin})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+
+	// DATETIME holds Berlin wall clocks; TIMESTAMP is read as UTC.
+	rows := sqlmock.NewRows([]string{"id", "placed_at", "updated_at"}).
+		AddRow(1, time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC))
+	mock.ExpectQuery("`placed_at` >= ?").
+		WithArgs(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)).
+		WillReturnRows(rows)
+
+	ctx := gqlrequest.WithAnalysis(NewBatchingContext(context.Background()), &gqlrequest.Analysis{TimeZone: newYork})
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `query @timezone(name: "America/New_York") { orders(where: { placedAt: { gte: "2024-07-01T06:00:00" } }) { nodes { placedAt updatedAt } } }`,
+		Context:       ctx,
+	})
+	require.Empty(t, result.Errors)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	nodes := result.Data.(map[string]interface{})["orders"].(map[string]interface{})["nodes"].([]interface{})
+	require.Len(t, nodes, 1)
+	node := nodes[0].(map[string]interface{})
+	assert.Equal(t, "2024-07-01T06:00:00-04:00", node["placedAt"])
+	assert.Equal(t, "2024-07-01T06:00:00-04:00", node["updatedAt"])
+}
+
+func TestRequestTimeZoneFallsBackToConfiguredZone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	r := &Resolver{timeZone: berlin}
+	assert.Equal(t, berlin, r.requestTimeZone(context.Background()))
+	assert.Equal(t, time.UTC, (&Resolver{}).requestTimeZone(context.Background()))
+}
@@ -0,0 +1,130 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tidb-graphql/internal/gqlrequest"
+
+	"github.com/graphql-go/graphql"
+)
+
+const beginTransactionPayloadTypeName = "BeginTransactionPayload"
+
+// TransactionController manages interactive transactions that span requests.
+// The HTTP layer attaches an implementation to the request context.
+type TransactionController interface {
+	Begin(ctx context.Context) (token string, expiresAt time.Time, err error)
+	Commit(ctx context.Context, token string) error
+	Rollback(ctx context.Context, token string) error
+}
+
+type transactionControllerKey struct{}
+
+// WithTransactionController attaches the interactive transaction controller to ctx.
+func WithTransactionController(ctx context.Context, controller TransactionController) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, transactionControllerKey{}, controller)
+}
+
+// TransactionControllerFromContext returns the interactive transaction controller, if any.
+func TransactionControllerFromContext(ctx context.Context) TransactionController {
+	if ctx == nil {
+		return nil
+	}
+	controller, _ := ctx.Value(transactionControllerKey{}).(TransactionController)
+	return controller
+}
+
+// addTransactionMutations adds beginTransaction, commitTransaction and rollbackTransaction
+// to the root Mutation type. They always live on the root, even in namespaced mode,
+// because one transaction can span every namespace.
+func (r *Resolver) addTransactionMutations(fields graphql.Fields) {
+	payload := graphql.NewObject(graphql.ObjectConfig{
+		Name:        beginTransactionPayloadTypeName,
+		Description: "An open interactive transaction.",
+		Fields: graphql.Fields{
+			"token": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Send this value in the transaction header to run requests inside the transaction.",
+			},
+			"expiresAt": &graphql.Field{
+				Type:        graphql.NewNonNull(r.dateTimeScalar()),
+				Description: "Hard deadline after which the transaction is rolled back.",
+			},
+		},
+	})
+	tokenArgs := graphql.FieldConfigArgument{
+		"token": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	}
+
+	fields["beginTransaction"] = &graphql.Field{
+		Type:        graphql.NewNonNull(payload),
+		Description: "Opens an interactive transaction that later requests can join via the transaction header.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			controller, err := transactionControllerFor(p.Context)
+			if err != nil {
+				return nil, err
+			}
+			token, expiresAt, err := controller.Begin(p.Context)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				"token":     token,
+				"expiresAt": expiresAt.UTC(),
+			}, nil
+		},
+	}
+	fields["commitTransaction"] = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Args:        tokenArgs,
+		Description: "Commits an interactive transaction. Send it without the transaction header.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			controller, err := transactionControllerFor(p.Context)
+			if err != nil {
+				return nil, err
+			}
+			token, _ := p.Args["token"].(string)
+			if err := controller.Commit(p.Context, token); err != nil {
+				return nil, err
+			}
+			return true, nil
+		},
+	}
+	fields["rollbackTransaction"] = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Args:        tokenArgs,
+		Description: "Rolls back an interactive transaction. Send it without the transaction header.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			controller, err := transactionControllerFor(p.Context)
+			if err != nil {
+				return nil, err
+			}
+			token, _ := p.Args["token"].(string)
+			if err := controller.Rollback(p.Context, token); err != nil {
+				return nil, err
+			}
+			return true, nil
+		},
+	}
+}
+
+// transactionControllerFor returns the request's controller. Dry-run operations are
+// refused: beginning, committing or rolling back changes session state that outlives
+// the request and could commit work from earlier requests.
+func transactionControllerFor(ctx context.Context) (TransactionController, error) {
+	if analysis := gqlrequest.AnalysisFromContext(ctx); analysis != nil && analysis.DryRun {
+		return nil, fmt.Errorf("transaction mutations cannot run in a dry-run operation")
+	}
+	controller := TransactionControllerFromContext(ctx)
+	if controller == nil {
+		return nil, fmt.Errorf("interactive transactions are not available")
+	}
+	return controller, nil
+}
@@ -0,0 +1,138 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/schemafilter"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTransactionController struct {
+	expiresAt  time.Time
+	committed  []string
+	rolledBack []string
+}
+
+func (f *fakeTransactionController) Begin(ctx context.Context) (string, time.Time, error) {
+	return "tok-1", f.expiresAt, nil
+}
+
+func (f *fakeTransactionController) Commit(ctx context.Context, token string) error {
+	if token != "tok-1" {
+		return errors.New("transaction not found or expired")
+	}
+	f.committed = append(f.committed, token)
+	return nil
+}
+
+func (f *fakeTransactionController) Rollback(ctx context.Context, token string) error {
+	f.rolledBack = append(f.rolledBack, token)
+	return nil
+}
+
+func transactionTestSchema(t *testing.T, enabled bool) graphql.Schema {
+	t.Helper()
+	users := introspection.Table{
+		Name: "users",
+		Columns: []introspection.Column{
+			{Name: "id", DataType: "int", IsPrimaryKey: true},
+			{Name: "name", DataType: "varchar"},
+		},
+	}
+	renamePrimaryKeyID(&users)
+	r := NewResolverWithConfig(nil, &introspection.Schema{Tables: []introspection.Table{users}}, nil, 0, ResolverConfig{
+		Filters:      schemafilter.Config{},
+		Transactions: enabled,
+	})
+	schema, err := r.BuildGraphQLSchema()
+	require.NoError(t, err)
+	return schema
+}
+
+func TestTransactionMutations_OnlyWhenEnabled(t *testing.T) {
+	disabledSchema := transactionTestSchema(t, false)
+	assert.NotContains(t, disabledSchema.MutationType().Fields(), "beginTransaction")
+
+	schema := transactionTestSchema(t, true)
+	fields := schema.MutationType().Fields()
+	require.Contains(t, fields, "beginTransaction")
+	assert.Equal(t, "BeginTransactionPayload!", fields["beginTransaction"].Type.String())
+	require.Contains(t, fields, "commitTransaction")
+	assert.Equal(t, "Boolean!", fields["commitTransaction"].Type.String())
+	require.Contains(t, fields, "rollbackTransaction")
+	require.Len(t, fields["rollbackTransaction"].Args, 1)
+	assert.Equal(t, "String!", fields["rollbackTransaction"].Args[0].Type.String())
+}
+
+func TestTransactionMutations_DelegateToController(t *testing.T) {
+	schema := transactionTestSchema(t, true)
+	controller := &fakeTransactionController{expiresAt: time.Date(2026, 5, 1, 12, 2, 0, 0, time.UTC)}
+	ctx := WithTransactionController(context.Background(), controller)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { beginTransaction { token expiresAt } }`,
+		Context:       ctx,
+	})
+	require.Empty(t, result.Errors)
+	payload := result.Data.(map[string]interface{})["beginTransaction"].(map[string]interface{})
+	assert.Equal(t, "tok-1", payload["token"])
+	assert.Equal(t, "2026-05-01T12:02:00Z", payload["expiresAt"])
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { commitTransaction(token: "tok-1") }`,
+		Context:       ctx,
+	})
+	require.Empty(t, result.Errors)
+	assert.Equal(t, []string{"tok-1"}, controller.committed)
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { commitTransaction(token: "other") }`,
+		Context:       ctx,
+	})
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "not found")
+}
+
+func TestTransactionMutations_WithoutControllerReturnError(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        transactionTestSchema(t, true),
+		RequestString: `mutation { beginTransaction { token } }`,
+		Context:       context.Background(),
+	})
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "not available")
+}
+
+func TestTransactionMutations_RejectedInDryRun(t *testing.T) {
+	schema := transactionTestSchema(t, true)
+	controller := &fakeTransactionController{expiresAt: time.Now()}
+	ctx := WithTransactionController(context.Background(), controller)
+	ctx = gqlrequest.WithAnalysis(ctx, &gqlrequest.Analysis{OperationType: "mutation", DryRun: true})
+
+	for _, query := range []string{
+		`mutation @dryRun { beginTransaction { token } }`,
+		`mutation @dryRun { commitTransaction(token: "tok-1") }`,
+		`mutation @dryRun { rollbackTransaction(token: "tok-1") }`,
+	} {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+			Context:       ctx,
+		})
+		require.Len(t, result.Errors, 1, query)
+		assert.Contains(t, result.Errors[0].Message, "dry-run", query)
+	}
+	assert.Empty(t, controller.committed)
+	assert.Empty(t, controller.rolledBack)
+}
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"time"
 
+	"tidb-graphql/internal/geojson"
+	"tidb-graphql/internal/scalarutil"
 	"tidb-graphql/internal/uuidutil"
 
 	"github.com/graphql-go/graphql"
@@ -489,6 +491,59 @@ func Date() *graphql.Scalar {
 	})
 }
 
+// LocalDateTime is a DateTime input written without a UTC offset. Its fields
+// hold the wall clock in UTC; resolvers place it in the request time zone.
+type LocalDateTime struct {
+	Wall time.Time
+}
+
+// In returns the instant the wall clock denotes in loc.
+func (l LocalDateTime) In(loc *time.Location) time.Time {
+	w := l.Wall
+	return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), loc)
+}
+
+// localDateTimeLayouts are the accepted offset-less DateTime forms.
+var localDateTimeLayouts = []string{"2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"}
+
+// DateTime replaces graphql.DateTime. Values serialize as RFC 3339 in their own
+// location. Inputs with an offset parse to time.Time; inputs without one parse
+// to LocalDateTime and are interpreted in the request time zone.
+func DateTime() *graphql.Scalar {
+	parse := func(raw string) interface{} {
+		if parsed, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			return parsed
+		}
+		for _, layout := range localDateTimeLayouts {
+			if parsed, err := time.Parse(layout, raw); err == nil {
+				return LocalDateTime{Wall: parsed}
+			}
+		}
+		return nil
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "DateTime",
+		Description: "Date and time serialized as RFC 3339. Inputs without a UTC offset are read in the request time zone.",
+		Serialize:   graphql.DateTime.Serialize,
+		ParseValue: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case time.Time, LocalDateTime:
+				return v
+			case string:
+				return parse(v)
+			default:
+				return nil
+			}
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			if sv, ok := valueAST.(*ast.StringValue); ok {
+				return parse(sv.Value)
+			}
+			return nil
+		},
+	})
+}
+
 func Time() *graphql.Scalar {
 	return graphql.NewScalar(graphql.ScalarConfig{
 		Name:        "Time",
@@ -667,6 +722,246 @@ func UUID() *graphql.Scalar {
 	})
 }
 
+// stringScalar builds a string-valued scalar whose inputs and outputs must pass parse.
+func stringScalar(name, description string, parse func(string) (string, error)) *graphql.Scalar {
+	coerce := func(value interface{}) interface{} {
+		var raw string
+		switch v := value.(type) {
+		case string:
+			raw = v
+		case []byte:
+			raw = string(v)
+		default:
+			return nil
+		}
+		parsed, err := parse(raw)
+		if err != nil {
+			return nil
+		}
+		return parsed
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize:   coerce,
+		ParseValue:  coerce,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			sv, ok := valueAST.(*ast.StringValue)
+			if !ok {
+				return nil
+			}
+			return coerce(sv.Value)
+		},
+	})
+}
+
+func Email() *graphql.Scalar {
+	return stringScalar("Email", "An email address such as ada@example.com.", scalarutil.ParseEmail)
+}
+
+func URL() *graphql.Scalar {
+	return stringScalar("URL", "An absolute URL with a scheme and host.", scalarutil.ParseURL)
+}
+
+func IPAddress() *graphql.Scalar {
+	coerce := func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case []byte:
+			// Stored INET6_ATON bytes.
+			formatted, err := scalarutil.IPFromBytes(v)
+			if err != nil {
+				return nil
+			}
+			return formatted
+		case string:
+			addr, err := scalarutil.ParseIP(v)
+			if err != nil {
+				return nil
+			}
+			return addr.Unmap().String()
+		default:
+			return nil
+		}
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "IPAddress",
+		Description: "An IPv4 or IPv6 address, stored as INET6_ATON bytes.",
+		Serialize:   coerce,
+		ParseValue: func(value interface{}) interface{} {
+			if _, ok := value.(string); !ok {
+				return nil
+			}
+			return coerce(value)
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			sv, ok := valueAST.(*ast.StringValue)
+			if !ok {
+				return nil
+			}
+			return coerce(sv.Value)
+		},
+	})
+}
+
+func Duration() *graphql.Scalar {
+	parse := func(value interface{}) interface{} {
+		raw, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		parsed, err := scalarutil.ParseDuration(raw)
+		if err != nil {
+			return nil
+		}
+		return parsed
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "Duration",
+		Description: "A length of time as an ISO 8601 duration such as PT1H30M.",
+		Serialize: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case time.Duration:
+				return scalarutil.FormatDuration(v)
+			case string:
+				if parsed, ok := parse(v).(time.Duration); ok {
+					return scalarutil.FormatDuration(parsed)
+				}
+				return nil
+			default:
+				return nil
+			}
+		},
+		ParseValue: parse,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			sv, ok := valueAST.(*ast.StringValue)
+			if !ok {
+				return nil
+			}
+			return parse(sv.Value)
+		},
+	})
+}
+
+func Timestamp() *graphql.Scalar {
+	coerce := func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case time.Time:
+			return v.UnixMilli()
+		case int, int32, int64, float64, string:
+			millis, err := scalarutil.ToInt64(v)
+			if err != nil {
+				return nil
+			}
+			return millis
+		default:
+			return nil
+		}
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "Timestamp",
+		Description: "An instant as integer milliseconds since the Unix epoch.",
+		Serialize:   coerce,
+		ParseValue:  coerce,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			switch v := valueAST.(type) {
+			case *ast.IntValue:
+				return coerce(v.Value)
+			case *ast.StringValue:
+				return coerce(v.Value)
+			default:
+				return nil
+			}
+		},
+	})
+}
+
+// GeoJSON is a spatial value written as a GeoJSON geometry object. Inputs may
+// also be a string holding the object; they parse to geojson.Geometry.
+func GeoJSON() *graphql.Scalar {
+	parse := func(value interface{}) interface{} {
+		g, err := geojson.Parse(value)
+		if err != nil {
+			return nil
+		}
+		return g
+	}
+	return graphql.NewScalar(graphql.ScalarConfig{
+		Name:        "GeoJSON",
+		Description: "A GeoJSON geometry object (RFC 7946) with two-dimensional coordinates.",
+		Serialize: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case geojson.Geometry:
+				return v.Object()
+			case map[string]interface{}:
+				return v
+			default:
+				return nil
+			}
+		},
+		ParseValue: func(value interface{}) interface{} {
+			switch value.(type) {
+			case map[string]interface{}, string:
+				return parse(value)
+			default:
+				return nil
+			}
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			switch v := valueAST.(type) {
+			case *ast.ObjectValue:
+				decoded, ok := literalValue(v)
+				if !ok {
+					return nil
+				}
+				return parse(decoded)
+			case *ast.StringValue:
+				return parse(v.Value)
+			default:
+				return nil
+			}
+		},
+	})
+}
+
+// literalValue converts a constant GraphQL literal into its JSON-like Go form.
+// Literals containing variables are rejected.
+func literalValue(valueAST ast.Value) (interface{}, bool) {
+	switch v := valueAST.(type) {
+	case *ast.ObjectValue:
+		obj := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			item, ok := literalValue(field.Value)
+			if !ok {
+				return nil, false
+			}
+			obj[field.Name.Value] = item
+		}
+		return obj, true
+	case *ast.ListValue:
+		items := make([]interface{}, 0, len(v.Values))
+		for _, value := range v.Values {
+			item, ok := literalValue(value)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, item)
+		}
+		return items, true
+	case *ast.IntValue:
+		n, err := strconv.ParseFloat(v.Value, 64)
+		return n, err == nil
+	case *ast.FloatValue:
+		n, err := strconv.ParseFloat(v.Value, 64)
+		return n, err == nil
+	case *ast.StringValue:
+		return v.Value, true
+	case *ast.BooleanValue:
+		return v.Value, true
+	default:
+		return nil, false
+	}
+}
+
 func coerceNonNegativeInt(value interface{}) (int, bool) {
 	switch v := value.(type) {
 	case int:
@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"tidb-graphql/internal/geojson"
+
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -77,6 +79,54 @@ func TestDateScalar(t *testing.T) {
 	assert.Nil(t, scalar.ParseValue("10000-01-01"))
 }
 
+func TestDateTimeScalar(t *testing.T) {
+	scalar := DateTime()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-07-01T12:00:00+02:00", scalar.Serialize(time.Date(2024, 7, 1, 12, 0, 0, 0, berlin)))
+
+	withOffset := scalar.ParseValue("2024-07-01T12:00:00+02:00")
+	require.IsType(t, time.Time{}, withOffset)
+	assert.True(t, withOffset.(time.Time).Equal(time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)))
+
+	local := scalar.ParseValue("2024-07-01T12:00:00")
+	require.IsType(t, LocalDateTime{}, local)
+	assert.True(t, local.(LocalDateTime).In(berlin).Equal(time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)))
+
+	spaced := scalar.ParseLiteral(&ast.StringValue{Value: "2024-07-01 12:00:00.5"})
+	require.IsType(t, LocalDateTime{}, spaced)
+	assert.Equal(t, 500000000, spaced.(LocalDateTime).Wall.Nanosecond())
+
+	assert.Nil(t, scalar.ParseValue("July 1st"))
+}
+
+func TestGeoJSONScalar(t *testing.T) {
+	scalar := GeoJSON()
+
+	point := geojson.Geometry{Type: geojson.TypePoint, Point: geojson.Position{1.5, 2}}
+	assert.Equal(t, map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.5, 2.0}}, scalar.Serialize(point))
+
+	assert.Equal(t, point, scalar.ParseValue(map[string]interface{}{"type": "Point", "coordinates": []interface{}{1.5, 2.0}}))
+	assert.Equal(t, point, scalar.ParseValue(`{"type":"Point","coordinates":[1.5,2]}`))
+	assert.Nil(t, scalar.ParseValue(map[string]interface{}{"type": "Point"}))
+	assert.Nil(t, scalar.ParseValue(42))
+
+	literal := &ast.ObjectValue{Fields: []*ast.ObjectField{
+		{Name: &ast.Name{Value: "type"}, Value: &ast.StringValue{Value: "Point"}},
+		{Name: &ast.Name{Value: "coordinates"}, Value: &ast.ListValue{Values: []ast.Value{
+			&ast.FloatValue{Value: "1.5"},
+			&ast.IntValue{Value: "2"},
+		}}},
+	}}
+	assert.Equal(t, point, scalar.ParseLiteral(literal))
+
+	withVariable := &ast.ObjectValue{Fields: []*ast.ObjectField{
+		{Name: &ast.Name{Value: "type"}, Value: &ast.Variable{Name: &ast.Name{Value: "t"}}},
+	}}
+	assert.Nil(t, scalar.ParseLiteral(withVariable))
+}
+
 func TestJSONScalar(t *testing.T) {
 	scalar := JSON()
 
@@ -194,3 +244,43 @@ func TestVectorScalar(t *testing.T) {
 	assert.Nil(t, scalar.ParseValue("not-json"))
 	assert.Nil(t, scalar.ParseLiteral(&ast.StringValue{Value: "nope"}))
 }
+
+func TestEmailAndURLScalars(t *testing.T) {
+	email := Email()
+	assert.Equal(t, "ada@example.com", email.ParseValue("ada@example.com"))
+	assert.Nil(t, email.ParseValue("not-an-email"))
+	assert.Nil(t, email.ParseLiteral(&ast.IntValue{Value: "1"}))
+
+	url := URL()
+	assert.Equal(t, "https://example.com/a", url.ParseLiteral(&ast.StringValue{Value: "https://example.com/a"}))
+	assert.Nil(t, url.ParseValue("example.com"))
+	assert.Equal(t, "https://example.com", url.Serialize([]byte("https://example.com")))
+}
+
+func TestIPAddressScalar(t *testing.T) {
+	scalar := IPAddress()
+	assert.Equal(t, "10.0.0.1", scalar.Serialize([]byte{10, 0, 0, 1}))
+	assert.Equal(t, "2001:db8::1", scalar.ParseValue("2001:DB8::1"))
+	assert.Equal(t, "10.0.0.1", scalar.ParseValue("::ffff:10.0.0.1"))
+	assert.Nil(t, scalar.ParseValue("10.0.0.256"))
+	assert.Nil(t, scalar.ParseValue([]byte{10, 0, 0, 1}))
+}
+
+func TestDurationScalar(t *testing.T) {
+	scalar := Duration()
+	assert.Equal(t, "PT1H30M", scalar.Serialize(90*time.Minute))
+	assert.Equal(t, 90*time.Minute, scalar.ParseValue("PT90M"))
+	assert.Equal(t, 2*time.Second, scalar.ParseLiteral(&ast.StringValue{Value: "PT2S"}))
+	assert.Nil(t, scalar.ParseValue("P1Y"))
+	assert.Nil(t, scalar.ParseValue(90))
+}
+
+func TestTimestampScalar(t *testing.T) {
+	scalar := Timestamp()
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.UnixMilli(), scalar.Serialize(at))
+	assert.Equal(t, int64(1709294400000), scalar.ParseValue(float64(1709294400000)))
+	assert.Equal(t, int64(1709294400000), scalar.ParseLiteral(&ast.IntValue{Value: "1709294400000"}))
+	assert.Nil(t, scalar.ParseValue(1.5))
+	assert.Nil(t, scalar.ParseValue("yesterday"))
+}
@@ -0,0 +1,273 @@
+// Package scalarutil parses and converts values of the semantic scalars that
+// type mappings can assign to generic SQL columns: Email, URL, IPAddress,
+// Duration and Timestamp. It is shared by the GraphQL scalars, the where
+// builder and mutation input handling.
+package scalarutil
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseEmail validates a bare email address such as "ada@example.com".
+// Display names and angle brackets are rejected.
+func ParseEmail(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	addr, err := mail.ParseAddress(trimmed)
+	if err != nil || addr.Name != "" || addr.Address != trimmed {
+		return "", fmt.Errorf("invalid email address")
+	}
+	return trimmed, nil
+}
+
+// ParseURL validates an absolute URL with a scheme and host.
+func ParseURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("invalid URL")
+	}
+	return trimmed, nil
+}
+
+// ParseIP parses an IPv4 or IPv6 address without a zone.
+func ParseIP(raw string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(raw))
+	if err != nil || addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("invalid IP address")
+	}
+	return addr, nil
+}
+
+// IPToBytes returns addr in INET6_ATON form: 4 bytes for IPv4 and 16 for IPv6.
+func IPToBytes(addr netip.Addr) []byte {
+	addr = addr.Unmap()
+	return addr.AsSlice()
+}
+
+// IPFromBytes formats INET6_ATON bytes as an address string.
+func IPFromBytes(raw []byte) (string, error) {
+	if len(raw) != 4 && len(raw) != 16 {
+		return "", fmt.Errorf("invalid IP address bytes")
+	}
+	addr, _ := netip.AddrFromSlice(raw)
+	return addr.String(), nil
+}
+
+// SubnetRange returns the first and last address of a CIDR block in
+// INET6_ATON form, for a BETWEEN comparison on the stored bytes.
+func SubnetRange(cidr string) ([]byte, []byte, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CIDR block %q", cidr)
+	}
+	prefix = prefix.Masked()
+	first := IPToBytes(prefix.Addr())
+	last := make([]byte, len(first))
+	copy(last, first)
+	bits := prefix.Bits()
+	if prefix.Addr().Is4In6() {
+		bits -= 96
+	}
+	for i := range last {
+		hostBits := len(last)*8 - bits - (len(last)-1-i)*8
+		switch {
+		case hostBits >= 8:
+			last[i] = 0xff
+		case hostBits > 0:
+			last[i] |= byte(1<<hostBits) - 1
+		}
+	}
+	return first, last, nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:\.(\d{1,9}))?S)?)?$`)
+
+// ParseDuration parses an ISO 8601 duration such as "PT1H30M" or "P2DT0.5S".
+// Days count as 24 hours; years and months are rejected as ambiguous.
+func ParseDuration(raw string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(raw)
+	match := isoDurationPattern.FindStringSubmatch(trimmed)
+	if match == nil || strings.HasSuffix(trimmed, "P") || strings.HasSuffix(trimmed, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration")
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(match[i+2], 10, 64)
+		if err != nil || n > int64(math.MaxInt64/unit) || total > math.MaxInt64-time.Duration(n)*unit {
+			return 0, fmt.Errorf("duration out of range")
+		}
+		total += time.Duration(n) * unit
+	}
+	if frac := match[7]; frac != "" {
+		nanos, _ := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if total > math.MaxInt64-time.Duration(nanos) {
+			return 0, fmt.Errorf("duration out of range")
+		}
+		total += time.Duration(nanos)
+	}
+	if match[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
+
+// FormatDuration renders d as an ISO 8601 duration using hours, minutes and
+// seconds, e.g. "PT1H30M" or "-PT0.25S".
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteString("PT")
+	if hours := d / time.Hour; hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		seconds, nanos := d/time.Second, d%time.Second
+		if nanos == 0 {
+			fmt.Fprintf(&b, "%dS", seconds)
+		} else {
+			fmt.Fprintf(&b, "%d.%sS", seconds, strings.TrimRight(fmt.Sprintf("%09d", nanos), "0"))
+		}
+	}
+	return b.String()
+}
+
+// unitDuration returns the duration of one stored unit, "s" or "ms".
+func unitDuration(unit string) time.Duration {
+	if unit == "ms" {
+		return time.Millisecond
+	}
+	return time.Second
+}
+
+// DurationToUnit converts d to whole stored units. Durations finer than the
+// unit are rejected rather than truncated.
+func DurationToUnit(d time.Duration, unit string) (int64, error) {
+	step := unitDuration(unit)
+	if d%step != 0 {
+		return 0, fmt.Errorf("duration must be a whole number of %s", unitName(unit))
+	}
+	return int64(d / step), nil
+}
+
+// DurationFromUnit converts a stored integer in unit to a duration.
+func DurationFromUnit(raw interface{}, unit string) (time.Duration, error) {
+	n, err := ToInt64(raw)
+	if err != nil {
+		return 0, err
+	}
+	step := unitDuration(unit)
+	if n > int64(math.MaxInt64/step) || n < int64(math.MinInt64/step) {
+		return 0, fmt.Errorf("duration out of range")
+	}
+	return time.Duration(n) * step, nil
+}
+
+// TimestampToStored converts epoch milliseconds to the stored form: a UTC
+// time for DATETIME/TIMESTAMP columns (unit ""), or whole units since the epoch.
+func TimestampToStored(millis int64, unit string) (interface{}, error) {
+	switch unit {
+	case "":
+		return time.UnixMilli(millis).UTC(), nil
+	case "s":
+		if millis%1000 != 0 {
+			return nil, fmt.Errorf("timestamp must be a whole number of seconds")
+		}
+		return millis / 1000, nil
+	default:
+		return millis, nil
+	}
+}
+
+// TimestampFromStored converts a stored DATETIME/TIMESTAMP value or an integer
+// in unit to epoch milliseconds.
+func TimestampFromStored(raw interface{}, unit string) (int64, error) {
+	if t, ok := raw.(time.Time); ok {
+		return t.UnixMilli(), nil
+	}
+	if unit == "" {
+		return 0, fmt.Errorf("unsupported timestamp value type %T", raw)
+	}
+	n, err := ToInt64(raw)
+	if err != nil {
+		return 0, err
+	}
+	if unit == "s" {
+		if n > math.MaxInt64/1000 || n < math.MinInt64/1000 {
+			return 0, fmt.Errorf("timestamp out of range")
+		}
+		return n * 1000, nil
+	}
+	return n, nil
+}
+
+// ToInt64 converts integer driver values, including numeric text, to int64.
+func ToInt64(raw interface{}) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("integer value out of range")
+		}
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+			return 0, fmt.Errorf("value must be an integer")
+		}
+		return int64(v), nil
+	case []byte:
+		return ToInt64(string(v))
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value must be an integer")
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("unsupported integer value type %T", raw)
+	}
+}
+
+func unitName(unit string) string {
+	if unit == "ms" {
+		return "milliseconds"
+	}
+	return "seconds"
+}
@@ -0,0 +1,110 @@
+package scalarutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEmailAndURL(t *testing.T) {
+	email, err := ParseEmail(" ada@example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", email)
+	for _, raw := range []string{"ada", "Ada <ada@example.com>", "ada@"} {
+		_, err := ParseEmail(raw)
+		assert.Error(t, err, raw)
+	}
+
+	_, err = ParseURL("https://example.com/docs?q=1")
+	require.NoError(t, err)
+	for _, raw := range []string{"example.com", "/relative", "mailto:ada@example.com"} {
+		_, err := ParseURL(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestIPConversions(t *testing.T) {
+	addr, err := ParseIP("192.168.1.10")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{192, 168, 1, 10}, IPToBytes(addr))
+
+	addr, err = ParseIP("2001:db8::1")
+	require.NoError(t, err)
+	raw := IPToBytes(addr)
+	require.Len(t, raw, 16)
+	formatted, err := IPFromBytes(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", formatted)
+
+	_, err = IPFromBytes([]byte{1, 2, 3})
+	assert.Error(t, err)
+	_, err = ParseIP("fe80::1%eth0")
+	assert.Error(t, err)
+
+	first, last, err := SubnetRange("10.1.2.3/20")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{10, 1, 0, 0}, first)
+	assert.Equal(t, []byte{10, 1, 15, 255}, last)
+
+	first, last, err = SubnetRange("2001:db8::/32")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, first)
+	assert.Equal(t, []byte{0x20, 0x01, 0x0d, 0xb8, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, last)
+}
+
+func TestDurations(t *testing.T) {
+	for raw, want := range map[string]time.Duration{
+		"PT1H30M":  90 * time.Minute,
+		"P1DT2S":   24*time.Hour + 2*time.Second,
+		"P1W":      7 * 24 * time.Hour,
+		"PT0.25S":  250 * time.Millisecond,
+		"-PT5S":    -5 * time.Second,
+		"PT0S":     0,
+		"PT90M20S": 90*time.Minute + 20*time.Second,
+	} {
+		got, err := ParseDuration(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, got, raw)
+	}
+	for _, raw := range []string{"", "P", "PT", "P1Y", "1h30m", "P1DT"} {
+		_, err := ParseDuration(raw)
+		assert.Error(t, err, raw)
+	}
+
+	assert.Equal(t, "PT1H30M", FormatDuration(90*time.Minute))
+	assert.Equal(t, "PT26H0.5S", FormatDuration(26*time.Hour+500*time.Millisecond))
+	assert.Equal(t, "-PT5S", FormatDuration(-5*time.Second))
+	assert.Equal(t, "PT0S", FormatDuration(0))
+
+	stored, err := DurationToUnit(1500*time.Millisecond, "ms")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), stored)
+	_, err = DurationToUnit(1500*time.Millisecond, "s")
+	assert.Error(t, err)
+
+	d, err := DurationFromUnit([]byte("90"), "s")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+}
+
+func TestTimestamps(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 250_000_000, time.UTC)
+
+	millis, err := TimestampFromStored(at, "")
+	require.NoError(t, err)
+	assert.Equal(t, at.UnixMilli(), millis)
+	stored, err := TimestampToStored(millis, "")
+	require.NoError(t, err)
+	assert.Equal(t, at, stored)
+
+	millis, err = TimestampFromStored(int64(1_700_000_000), "s")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_700_000_000_000), millis)
+	stored, err = TimestampToStored(millis, "s")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_700_000_000), stored)
+	_, err = TimestampToStored(1_700_000_000_001, "s")
+	assert.Error(t, err)
+}
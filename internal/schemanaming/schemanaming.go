@@ -4,6 +4,8 @@ package schemanaming
 import (
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 
 	"tidb-graphql/internal/introspection"
@@ -78,7 +80,7 @@ func applyCore(schema *introspection.Schema, namer *naming.Namer, namespaceMap m
 			if hasTypeOverride {
 				baseTypeName = overrideTypeName
 			} else {
-				baseTypeName = namer.ToGraphQLTypeName(singularName)
+				baseTypeName = namer.ToGraphQLTypeName(namer.StripTablePrefix(singularName))
 			}
 			fullTypeName := nsPrefix + "_" + baseTypeName
 			typeName = namer.RegisterTypeName(fullTypeName, table.Name)
@@ -117,8 +119,9 @@ func applyCore(schema *introspection.Schema, namer *naming.Namer, namespaceMap m
 		for ci := range table.Columns {
 			col := &table.Columns[ci]
 			if col.IsPrimaryKey && strings.EqualFold(col.Name, "id") {
-				desiredName := uniqueDatabaseIDName(table.Columns, ci)
-				if desiredName != "databaseId" {
+				databaseID := namer.ToGraphQLFieldName("database_id")
+				desiredName := uniqueDatabaseIDName(table.Columns, ci, databaseID)
+				if desiredName != databaseID {
 					slog.Default().Warn("GraphQL name collision for databaseId; using fallback name",
 						"table", table.Name,
 						"column", col.Name,
@@ -133,7 +136,7 @@ func applyCore(schema *introspection.Schema, namer *naming.Namer, namespaceMap m
 			rel := &table.Relationships[ri]
 			baseName := rel.GraphQLFieldName
 			if baseName == "" {
-				baseName = namer.ToGraphQLFieldName(rel.RemoteTable)
+				baseName = namer.ToGraphQLFieldName(namer.StripTablePrefix(rel.RemoteTable))
 			}
 			localCols := rel.EffectiveLocalColumns()
 			remoteCols := rel.EffectiveRemoteColumns()
@@ -141,12 +144,72 @@ func applyCore(schema *introspection.Schema, namer *naming.Namer, namespaceMap m
 			// For collision suffix: ManyToOne uses "Ref", all others use "Rel".
 			useRefSuffix := rel.IsManyToOne
 			rel.GraphQLFieldName = namer.RegisterRelationshipField(typeName, baseName, source, useRefSuffix)
+			if rel.NameOverridden && rel.GraphQLFieldName != baseName {
+				slog.Default().Warn("relationship override name collides with another field; using fallback name",
+					"table", table.Name,
+					"override", baseName,
+					"resolved_name", rel.GraphQLFieldName,
+				)
+			}
 		}
 	}
 
+	warnUnmatchedRelationshipOverrides(schema, namer.Config().RelationshipOverrides)
+
 	schema.NamesApplied = true
 }
 
+// unmatchedRelationshipOverride is a configured relationship override whose
+// table or foreign key constraint does not exist.
+type unmatchedRelationshipOverride struct {
+	table      string
+	constraint string
+	reason     string
+}
+
+// warnUnmatchedRelationshipOverrides reports relationship overrides that match
+// no foreign key, since they would otherwise be ignored silently.
+func warnUnmatchedRelationshipOverrides(schema *introspection.Schema, overrides map[string]map[string]naming.RelationshipOverride) {
+	for _, unmatched := range unmatchedRelationshipOverrides(schema, overrides) {
+		slog.Default().Warn("relationship override matches no foreign key; ignoring it",
+			"table", unmatched.table,
+			"constraint", unmatched.constraint,
+			"reason", unmatched.reason,
+		)
+	}
+}
+
+// unmatchedRelationshipOverrides returns the overrides whose table or constraint
+// is not in schema, sorted by table and constraint. Names match
+// case-insensitively, like Namer.RelationshipOverride.
+func unmatchedRelationshipOverrides(schema *introspection.Schema, overrides map[string]map[string]naming.RelationshipOverride) []unmatchedRelationshipOverride {
+	var result []unmatchedRelationshipOverride
+	for _, tableName := range slices.Sorted(maps.Keys(overrides)) {
+		constraints := map[string]bool{}
+		tableFound := false
+		for _, table := range schema.Tables {
+			if !strings.EqualFold(table.Name, tableName) {
+				continue
+			}
+			tableFound = true
+			for _, fk := range introspection.ForeignKeyConstraints(table) {
+				constraints[strings.ToLower(fk.ConstraintName)] = true
+			}
+		}
+		for _, constraintName := range slices.Sorted(maps.Keys(overrides[tableName])) {
+			if constraints[strings.ToLower(constraintName)] {
+				continue
+			}
+			reason := "foreign key constraint not found"
+			if !tableFound {
+				reason = "table not found"
+			}
+			result = append(result, unmatchedRelationshipOverride{table: tableName, constraint: constraintName, reason: reason})
+		}
+	}
+	return result
+}
+
 // resolveNamespacePrefix returns the PascalCase namespace prefix for a database,
 // or "" when the namespace map is nil/empty or the database has no entry.
 func resolveNamespacePrefix(namer *naming.Namer, namespaceMap map[string]string, database string) string {
@@ -172,12 +235,12 @@ func hasColumnFieldName(columns []introspection.Column, name string, skipIndex i
 	return false
 }
 
-func uniqueDatabaseIDName(columns []introspection.Column, skipIndex int) string {
-	if !hasColumnFieldName(columns, "databaseId", skipIndex) {
-		return "databaseId"
+func uniqueDatabaseIDName(columns []introspection.Column, skipIndex int, databaseID string) string {
+	if !hasColumnFieldName(columns, databaseID, skipIndex) {
+		return databaseID
 	}
 
-	base := "databaseId_raw"
+	base := databaseID + "_raw"
 	candidate := base
 	suffix := 2
 	for hasColumnFieldName(columns, candidate, skipIndex) {
@@ -228,8 +291,12 @@ func findTypeOverride(globalOverrides map[string]string, namingPerDB map[string]
 	return "", false
 }
 
+// mergedNamingConfig layers per-db dictionaries over the global config. The
+// field case and table prefixes are global so every database shares one style.
 func mergedNamingConfig(base, override naming.Config) naming.Config {
 	merged := naming.DefaultConfig()
+	merged.FieldCase = base.FieldCase
+	merged.StripTablePrefixes = base.StripTablePrefixes
 	for key, value := range base.PluralOverrides {
 		merged.PluralOverrides[key] = value
 	}
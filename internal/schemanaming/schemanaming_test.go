@@ -95,7 +95,7 @@ func TestUniqueDatabaseIDName_MultipleFallbacks(t *testing.T) {
 		{GraphQLFieldName: "databaseId_raw"},
 		{GraphQLFieldName: "databaseId_raw2"},
 	}
-	assert.Equal(t, "databaseId_raw3", uniqueDatabaseIDName(columns, -1))
+	assert.Equal(t, "databaseId_raw3", uniqueDatabaseIDName(columns, -1, "databaseId"))
 }
 
 func TestApply_TypeOverrides(t *testing.T) {
@@ -287,3 +287,54 @@ func TestApplyWithNamespaces_TypeOverridesDoNotReserveDefaultNames(t *testing.T)
 	assert.Equal(t, "User", schema.Tables[1].GraphQLTypeName)
 	assert.Equal(t, "User", schema.Tables[1].GraphQLSingleTypeName)
 }
+
+func TestApply_SnakeCaseWithStrippedPrefixes(t *testing.T) {
+	schema := &introspection.Schema{
+		Tables: []introspection.Table{
+			{
+				Name: "tbl_user_accounts",
+				Columns: []introspection.Column{
+					{Name: "id", IsPrimaryKey: true},
+					{Name: "createdAt"},
+				},
+				Relationships: []introspection.Relationship{
+					{IsOneToMany: true, RemoteTable: "tbl_orders"},
+				},
+			},
+		},
+	}
+
+	cfg := naming.DefaultConfig()
+	cfg.FieldCase = naming.FieldCaseSnake
+	cfg.StripTablePrefixes = []string{"tbl_"}
+	Apply(schema, naming.New(cfg, nil))
+
+	table := schema.Tables[0]
+	assert.Equal(t, "UserAccount", table.GraphQLSingleTypeName)
+	assert.Equal(t, "user_accounts", table.GraphQLQueryName)
+	assert.Equal(t, "database_id", table.Columns[0].GraphQLFieldName)
+	assert.Equal(t, "created_at", table.Columns[1].GraphQLFieldName)
+	assert.Equal(t, "orders", table.Relationships[0].GraphQLFieldName)
+}
+
+func TestUnmatchedRelationshipOverrides(t *testing.T) {
+	schema := &introspection.Schema{
+		Tables: []introspection.Table{
+			{
+				Name: "orders",
+				ForeignKeys: []introspection.ForeignKey{
+					{ColumnName: "address_id", ReferencedTable: "addresses", ReferencedColumn: "id", ConstraintName: "orders_ibfk_1", OrdinalPosition: 1},
+				},
+			},
+		},
+	}
+	overrides := map[string]map[string]naming.RelationshipOverride{
+		"Orders":   {"ORDERS_IBFK_1": {Forward: "shippingAddress"}, "orders_ibfk_9": {Forward: "billingAddress"}},
+		"invoices": {"invoices_ibfk_1": {Reverse: "invoices"}},
+	}
+
+	assert.Equal(t, []unmatchedRelationshipOverride{
+		{table: "Orders", constraint: "orders_ibfk_9", reason: "foreign key constraint not found"},
+		{table: "invoices", constraint: "invoices_ibfk_1", reason: "table not found"},
+	}, unmatchedRelationshipOverrides(schema, overrides))
+}
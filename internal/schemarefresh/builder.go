@@ -3,7 +3,9 @@ package schemarefresh
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"tidb-graphql/internal/authz"
 	"tidb-graphql/internal/dbexec"
 	"tidb-graphql/internal/introspection"
 	"tidb-graphql/internal/junction"
@@ -50,11 +52,24 @@ type BuildSchemaConfig struct {
 	UUIDColumns            map[string][]string
 	TinyInt1BooleanColumns map[string][]string
 	TinyInt1IntColumns     map[string][]string
+	ScalarColumns          []introspection.ScalarMapping
+	JSONSchemaColumns      []introspection.JSONSchemaMapping
+	EnumValues             []introspection.EnumValueMapping
+	LookupEnums            []introspection.LookupEnum
+	Deprecations           []introspection.Deprecation
+	DateTimeZone           *time.Location
+	TimeZone               *time.Location
 	Naming                 naming.Config
 	Limits                 *planner.PlanLimits
 	DefaultLimit           int
 	VectorRequireIndex     bool
 	VectorMaxTopK          int
+	Transactions           bool
+	Explain                bool
+	Authorization          *authz.Policy
+	// PartialAuthorization marks a role-specific build, whose schema may lack
+	// fields that Authorization rules name.
+	PartialAuthorization bool
 }
 
 // BuildSchemaResult contains schema artifacts produced by BuildSchema.
@@ -130,6 +145,20 @@ func BuildSchema(ctx context.Context, cfg BuildSchemaConfig) (*BuildSchemaResult
 		if err := introspection.ApplyUUIDTypeOverrides(dbSchema, cfg.UUIDColumns); err != nil {
 			return nil, fmt.Errorf("failed to apply UUID type mappings for %q: %w", entry.Name, err)
 		}
+		if err := introspection.ApplyScalarTypeOverrides(dbSchema, cfg.ScalarColumns); err != nil {
+			return nil, fmt.Errorf("failed to apply scalar type mappings for %q: %w", entry.Name, err)
+		}
+		if err := introspection.ApplyJSONSchemaMappings(dbSchema, cfg.JSONSchemaColumns); err != nil {
+			return nil, fmt.Errorf("failed to apply JSON schema mappings for %q: %w", entry.Name, err)
+		}
+		if err := introspection.ApplyLookupEnums(ctx, cfg.Queryer, dbSchema, entry.Name, cfg.LookupEnums); err != nil {
+			return nil, fmt.Errorf("failed to apply lookup enums for %q: %w", entry.Name, err)
+		}
+		if err := introspection.ApplyEnumValueMappings(dbSchema, cfg.EnumValues); err != nil {
+			return nil, fmt.Errorf("failed to apply enum value mappings for %q: %w", entry.Name, err)
+		}
+		introspection.ApplyDeprecations(dbSchema, cfg.Deprecations)
+		introspection.ApplyDateTimeZone(dbSchema, cfg.DateTimeZone)
 
 		// 4. Intra-db junction classification + relationship building.
 		// Cross-db FKs produce many-to-one relationships (IsCrossDatabase=true);
@@ -152,6 +181,10 @@ func BuildSchema(ctx context.Context, cfg BuildSchemaConfig) (*BuildSchemaResult
 		Junctions: mergedJunctions,
 	}
 
+	if introspection.HasGeometryColumns(merged) {
+		merged.Spatial = introspection.DetectSpatialFunctions(ctx, cfg.Queryer)
+	}
+
 	// 6. Cross-database one-to-many relationships.
 	if err := introspection.ResolveCrossDatabaseRelationships(merged, namer); err != nil {
 		return nil, fmt.Errorf("failed to resolve cross-database relationships: %w", err)
@@ -166,11 +199,16 @@ func BuildSchema(ctx context.Context, cfg BuildSchemaConfig) (*BuildSchemaResult
 
 	// 8. Build resolver + GraphQL schema.
 	res := resolver.NewResolverWithConfig(cfg.Executor, merged, cfg.Limits, cfg.DefaultLimit, resolver.ResolverConfig{
-		Filters:        cfg.GlobalFilters,
-		FiltersPerDB:   filtersPerDB,
-		NamespaceMap:   namespaceMap,
-		NamespacedRoot: namespacedRoot,
-		Naming:         cfg.Naming,
+		Filters:              cfg.GlobalFilters,
+		FiltersPerDB:         filtersPerDB,
+		NamespaceMap:         namespaceMap,
+		NamespacedRoot:       namespacedRoot,
+		Naming:               cfg.Naming,
+		Transactions:         cfg.Transactions,
+		Explain:              cfg.Explain,
+		Authorization:        cfg.Authorization,
+		PartialAuthorization: cfg.PartialAuthorization,
+		TimeZone:             cfg.TimeZone,
 	})
 	if cfg.VectorRequireIndex || cfg.VectorMaxTopK > 0 {
 		res.SetVectorSearchConfig(resolver.VectorSearchConfig{
@@ -16,6 +16,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"tidb-graphql/internal/authz"
 	"tidb-graphql/internal/dbexec"
 	"tidb-graphql/internal/introspection"
 	"tidb-graphql/internal/logging"
@@ -60,9 +61,19 @@ type Config struct {
 	UUIDColumns            map[string][]string
 	TinyInt1BooleanColumns map[string][]string
 	TinyInt1IntColumns     map[string][]string
+	ScalarColumns          []introspection.ScalarMapping
+	JSONSchemaColumns      []introspection.JSONSchemaMapping
+	EnumValues             []introspection.EnumValueMapping
+	LookupEnums            []introspection.LookupEnum
+	Deprecations           []introspection.Deprecation
+	DateTimeZone           *time.Location
+	TimeZone               *time.Location
 	Naming                 naming.Config
 	VectorRequireIndex     bool
 	VectorMaxTopK          int
+	Transactions           bool
+	Explain                bool
+	Authorization          *authz.Policy
 	Executor               dbexec.QueryExecutor
 	IntrospectionRole      string
 	RoleSchemas            []string
@@ -87,14 +98,25 @@ type Manager struct {
 	uuidColumns            map[string][]string
 	tinyInt1BooleanColumns map[string][]string
 	tinyInt1IntColumns     map[string][]string
+	jsonSchemaColumns      []introspection.JSONSchemaMapping
+	enumValues             []introspection.EnumValueMapping
+	lookupEnums            []introspection.LookupEnum
+	deprecations           []introspection.Deprecation
+	scalarColumns          []introspection.ScalarMapping
+	dateTimeZone           *time.Location
+	timeZone               *time.Location
 	namingConfig           naming.Config
 	vectorRequireIndex     bool
 	vectorMaxTopK          int
+	transactions           bool
+	explain                bool
+	authorization          *authz.Policy
 	executor               dbexec.QueryExecutor
 	introspectionRole      string
 	roleSchemas            []string
 	roleFromCtx            func(context.Context) (string, bool)
 	active                 atomic.Value
+	lastRefresh            atomic.Value
 	wg                     sync.WaitGroup
 }
 
@@ -173,9 +195,19 @@ func NewManager(ctx context.Context, cfg Config) (*Manager, error) {
 		uuidColumns:            cfg.UUIDColumns,
 		tinyInt1BooleanColumns: cfg.TinyInt1BooleanColumns,
 		tinyInt1IntColumns:     cfg.TinyInt1IntColumns,
+		scalarColumns:          cfg.ScalarColumns,
+		jsonSchemaColumns:      cfg.JSONSchemaColumns,
+		enumValues:             cfg.EnumValues,
+		lookupEnums:            cfg.LookupEnums,
+		deprecations:           cfg.Deprecations,
+		dateTimeZone:           cfg.DateTimeZone,
+		timeZone:               cfg.TimeZone,
 		namingConfig:           cfg.Naming,
 		vectorRequireIndex:     cfg.VectorRequireIndex,
 		vectorMaxTopK:          cfg.VectorMaxTopK,
+		transactions:           cfg.Transactions,
+		explain:                cfg.Explain,
+		authorization:          cfg.Authorization,
 		executor:               cfg.Executor,
 		introspectionRole:      cfg.IntrospectionRole,
 		roleSchemas:            append([]string(nil), cfg.RoleSchemas...),
@@ -203,12 +235,12 @@ func NewManager(ctx context.Context, cfg Config) (*Manager, error) {
 
 	state, err := manager.buildSnapshotSet(startupCtx, fingerprint)
 	if err != nil {
-		manager.recordRefresh(startupCtx, time.Since(start), false, "startup", fingerprint.Mode)
+		manager.recordRefresh(startupCtx, time.Since(start), err, "startup", fingerprint.Mode)
 		recordRefreshSpanError(startupSpan, err, fingerprint.Mode)
 		return nil, err
 	}
 	manager.active.Store(state)
-	manager.recordRefresh(startupCtx, time.Since(start), true, "startup", state.FingerprintMode)
+	manager.recordRefresh(startupCtx, time.Since(start), nil, "startup", state.FingerprintMode)
 	recordRefreshSpanSuccess(startupSpan, state.FingerprintMode, "rebuilt")
 
 	return manager, nil
@@ -334,20 +366,20 @@ func (m *Manager) RefreshNowContext(ctx context.Context) error {
 	start := time.Now()
 	fingerprint, err := m.computeFingerprintDetails(ctx)
 	if err != nil {
-		m.recordRefresh(ctx, time.Since(start), false, "manual", fingerprint.Mode)
+		m.recordRefresh(ctx, time.Since(start), err, "manual", fingerprint.Mode)
 		recordRefreshSpanError(span, err, fingerprint.Mode)
 		return err
 	}
 
 	state, err := m.buildSnapshotSet(ctx, fingerprint)
 	if err != nil {
-		m.recordRefresh(ctx, time.Since(start), false, "manual", fingerprint.Mode)
+		m.recordRefresh(ctx, time.Since(start), err, "manual", fingerprint.Mode)
 		recordRefreshSpanError(span, err, fingerprint.Mode)
 		return err
 	}
 
 	m.active.Store(state)
-	m.recordRefresh(ctx, time.Since(start), true, "manual", state.FingerprintMode)
+	m.recordRefresh(ctx, time.Since(start), nil, "manual", state.FingerprintMode)
 	recordRefreshSpanSuccess(span, state.FingerprintMode, "rebuilt")
 	return nil
 }
@@ -440,7 +472,7 @@ func (m *Manager) refreshOnce(ctx context.Context, interval *time.Duration) {
 	fingerprint, err := m.computeFingerprintDetails(ctx)
 	if err != nil {
 		m.logger.Warn("schema fingerprint check failed", slog.String("error", err.Error()))
-		m.recordRefresh(ctx, time.Since(start), false, "poll", fingerprint.Mode)
+		m.recordRefresh(ctx, time.Since(start), err, "poll", fingerprint.Mode)
 		recordRefreshSpanError(span, err, fingerprint.Mode)
 		*interval = m.minInterval
 		return
@@ -448,7 +480,7 @@ func (m *Manager) refreshOnce(ctx context.Context, interval *time.Duration) {
 
 	current := m.currentState()
 	if current != nil && fingerprint.Value == current.Fingerprint {
-		m.recordRefresh(ctx, time.Since(start), true, "poll_no_change", fingerprint.Mode)
+		m.recordRefresh(ctx, time.Since(start), nil, "poll_no_change", fingerprint.Mode)
 		recordRefreshSpanSuccess(span, fingerprint.Mode, "no_change")
 		*interval = nextInterval(*interval, m.minInterval, m.maxInterval)
 		return
@@ -468,7 +500,7 @@ func (m *Manager) refreshOnce(ctx context.Context, interval *time.Duration) {
 	state, err := m.buildSnapshotSet(ctx, fingerprint)
 	if err != nil {
 		m.logger.Error("failed to rebuild schema", slog.String("error", err.Error()))
-		m.recordRefresh(ctx, time.Since(start), false, "poll", fingerprint.Mode)
+		m.recordRefresh(ctx, time.Since(start), err, "poll", fingerprint.Mode)
 		recordRefreshSpanError(span, err, fingerprint.Mode)
 		*interval = m.minInterval
 		return
@@ -476,7 +508,7 @@ func (m *Manager) refreshOnce(ctx context.Context, interval *time.Duration) {
 
 	m.active.Store(state)
 	*interval = m.minInterval
-	m.recordRefresh(ctx, time.Since(start), true, "poll", state.FingerprintMode)
+	m.recordRefresh(ctx, time.Since(start), nil, "poll", state.FingerprintMode)
 	recordRefreshSpanSuccess(span, state.FingerprintMode, "rebuilt")
 	m.logger.Info("schema refresh complete",
 		slog.String("fingerprint", state.Fingerprint),
@@ -575,11 +607,22 @@ func (m *Manager) buildSnapshotWithQueryer(ctx context.Context, role string, fin
 		UUIDColumns:            m.uuidColumns,
 		TinyInt1BooleanColumns: m.tinyInt1BooleanColumns,
 		TinyInt1IntColumns:     m.tinyInt1IntColumns,
+		ScalarColumns:          m.scalarColumns,
+		JSONSchemaColumns:      m.jsonSchemaColumns,
+		EnumValues:             m.enumValues,
+		LookupEnums:            m.lookupEnums,
+		Deprecations:           m.deprecations,
+		DateTimeZone:           m.dateTimeZone,
+		TimeZone:               m.timeZone,
 		Naming:                 m.namingConfig,
 		Limits:                 m.limits,
 		DefaultLimit:           m.defaultLimit,
 		VectorRequireIndex:     m.vectorRequireIndex,
 		VectorMaxTopK:          m.vectorMaxTopK,
+		Transactions:           m.transactions,
+		Explain:                m.explain,
+		Authorization:          m.authorization,
+		PartialAuthorization:   role != "",
 	})
 	if err != nil {
 		return nil, err
@@ -708,7 +751,8 @@ func (m *Manager) schemaInClause() (string, []any) {
 
 func (m *Manager) computeTiDBStructuralFingerprint(ctx context.Context, queryer introspection.Queryer) (fingerprintDetails, error) {
 	// Structural mode fingerprints only behavior-relevant metadata.
-	// Comments are intentionally excluded to avoid churn without API/runtime impact.
+	// Comments are intentionally excluded to avoid churn without API/runtime
+	// impact, except those carrying deprecation markers.
 	//
 	// In multi-db mode TABLE_SCHEMA is included as the first selected column so
 	// rows from different databases are distinguished in the hash, and ORDER BY
@@ -795,6 +839,28 @@ func (m *Manager) computeTiDBStructuralFingerprint(ctx context.Context, queryer
 				ORDER BY TABLE_SCHEMA, TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX, COLUMN_NAME
 			`,
 		},
+		// Comments carrying "@deprecated" markers change the API, so only
+		// those comments are fingerprinted.
+		{
+			name: "table_deprecations",
+			query: `
+				SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_COMMENT
+				FROM INFORMATION_SCHEMA.TABLES
+				WHERE TABLE_SCHEMA IN (` + inClause + `)
+					AND TABLE_COMMENT LIKE '%@deprecated%'
+				ORDER BY TABLE_SCHEMA, TABLE_NAME
+			`,
+		},
+		{
+			name: "column_deprecations",
+			query: `
+				SELECT TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, COLUMN_COMMENT
+				FROM INFORMATION_SCHEMA.COLUMNS
+				WHERE TABLE_SCHEMA IN (` + inClause + `)
+					AND COLUMN_COMMENT LIKE '%@deprecated%'
+				ORDER BY TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME
+			`,
+		},
 	}
 
 	componentHashes := make(map[string]string, len(components))
@@ -805,6 +871,7 @@ func (m *Manager) computeTiDBStructuralFingerprint(ctx context.Context, queryer
 		}
 		componentHashes[component.name] = hash
 	}
+	m.hashLookupEnums(ctx, queryer, componentHashes)
 
 	return fingerprintDetails{
 		Value:      combineComponentHashes(componentHashes),
@@ -813,6 +880,21 @@ func (m *Manager) computeTiDBStructuralFingerprint(ctx context.Context, queryer
 	}, nil
 }
 
+// hashLookupEnums adds the rows of configured lookup tables to the
+// fingerprint, since lookup enums change without any DDL. Databases without
+// the lookup table are skipped.
+func (m *Manager) hashLookupEnums(ctx context.Context, queryer introspection.Queryer, componentHashes map[string]string) {
+	for _, entry := range m.schemaEntries {
+		for _, lookup := range m.lookupEnums {
+			hash, _, err := m.hashComponentQuery(ctx, queryer, introspection.LookupEnumQuery(entry.Name, lookup))
+			if err != nil {
+				continue
+			}
+			componentHashes["lookup_enum:"+entry.Name+"."+lookup.Table] = hash
+		}
+	}
+}
+
 func (m *Manager) computeTiDBLightweightFingerprint(ctx context.Context, queryer introspection.Queryer) (fingerprintDetails, error) {
 	inClause, args := m.schemaInClause()
 	query := `
@@ -894,14 +976,25 @@ func nextInterval(current, minInterval, maxInterval time.Duration) time.Duration
 	return next
 }
 
-func (m *Manager) recordRefresh(ctx context.Context, duration time.Duration, success bool, trigger string, fingerprintMode string) {
+func (m *Manager) recordRefresh(ctx context.Context, duration time.Duration, err error, trigger string, fingerprintMode string) {
+	outcome := RefreshOutcome{
+		Trigger:    trigger,
+		Success:    err == nil,
+		At:         time.Now().UTC(),
+		DurationMS: duration.Milliseconds(),
+	}
+	if err != nil {
+		outcome.Error = err.Error()
+	}
+	m.lastRefresh.Store(outcome)
+
 	if m.recordRefreshFn == nil {
 		return
 	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	m.recordRefreshFn(ctx, duration, success, trigger, defaultOrUnknownMode(fingerprintMode))
+	m.recordRefreshFn(ctx, duration, err == nil, trigger, defaultOrUnknownMode(fingerprintMode))
 }
 
 func startRefreshSpan(ctx context.Context, name, trigger string) (context.Context, trace.Span) {
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/introspection"
 	"tidb-graphql/internal/logging"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -30,6 +31,9 @@ type structuralFingerprintFixture struct {
 	primaryKeys [][]string
 	foreignKeys [][]string
 	indexes     [][]string
+	// tableDeprecations and columnDeprecations hold comments with "@deprecated" markers.
+	tableDeprecations  [][]string
+	columnDeprecations [][]string
 }
 
 func hashRows(rows [][]string) string {
@@ -45,11 +49,13 @@ func hashRows(rows [][]string) string {
 
 func expectedStructuralFingerprint(fixture structuralFingerprintFixture) string {
 	return combineComponentHashes(map[string]string{
-		"tables":       hashRows(fixture.tables),
-		"columns":      hashRows(fixture.columns),
-		"primary_keys": hashRows(fixture.primaryKeys),
-		"foreign_keys": hashRows(fixture.foreignKeys),
-		"indexes":      hashRows(fixture.indexes),
+		"tables":              hashRows(fixture.tables),
+		"columns":             hashRows(fixture.columns),
+		"primary_keys":        hashRows(fixture.primaryKeys),
+		"foreign_keys":        hashRows(fixture.foreignKeys),
+		"indexes":             hashRows(fixture.indexes),
+		"table_deprecations":  hashRows(fixture.tableDeprecations),
+		"column_deprecations": hashRows(fixture.columnDeprecations),
 	})
 }
 
@@ -72,7 +78,7 @@ func rowsFromStrings(columns []string, values [][]string) *sqlmock.Rows {
 }
 
 // expectTiDBStructuralFingerprintQueries registers sqlmock expectations for the
-// five structural fingerprint queries. databaseNames is the list of schemas passed
+// structural fingerprint queries. databaseNames is the list of schemas passed
 // as IN-clause arguments; for single-db tests pass a one-element slice.
 // Fixture rows must already include TABLE_SCHEMA as their first column.
 func expectTiDBStructuralFingerprintQueries(mock sqlmock.Sqlmock, databaseNames []string, fixture structuralFingerprintFixture) {
@@ -115,6 +121,20 @@ func expectTiDBStructuralFingerprintQueries(mock sqlmock.Sqlmock, databaseNames
 			[]string{"TABLE_SCHEMA", "TABLE_NAME", "INDEX_NAME", "NON_UNIQUE", "SEQ_IN_INDEX", "COLUMN_NAME", "COLLATION", "SUB_PART", "NULLABLE", "INDEX_TYPE"},
 			fixture.indexes,
 		))
+
+	mock.ExpectQuery("TABLE_COMMENT LIKE").
+		WithArgs(args...).
+		WillReturnRows(rowsFromStrings(
+			[]string{"TABLE_SCHEMA", "TABLE_NAME", "TABLE_COMMENT"},
+			fixture.tableDeprecations,
+		))
+
+	mock.ExpectQuery("COLUMN_COMMENT LIKE").
+		WithArgs(args...).
+		WillReturnRows(rowsFromStrings(
+			[]string{"TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "COLUMN_COMMENT"},
+			fixture.columnDeprecations,
+		))
 }
 
 func testLogger() *logging.Logger {
@@ -183,6 +203,49 @@ func TestComputeFingerprint(t *testing.T) {
 	}
 }
 
+func TestComputeFingerprint_LookupEnumRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	manager := &Manager{
+		db:            db,
+		databaseName:  "testdb",
+		schemaEntries: testSchemaEntries("testdb"),
+		lookupEnums:   []introspection.LookupEnum{{Table: "order_statuses", ValueColumn: "code"}},
+		logger:        testLogger(),
+	}
+
+	fingerprintWithRows := func(codes ...string) fingerprintDetails {
+		t.Helper()
+		expectTiDBStructuralFingerprintQueries(mock, []string{"testdb"}, structuralFingerprintFixture{})
+		rows := sqlmock.NewRows([]string{"code", "name", "description"})
+		for _, code := range codes {
+			rows.AddRow(code, nil, nil)
+		}
+		mock.ExpectQuery("FROM `testdb`.`order_statuses`").WillReturnRows(rows)
+		details, err := manager.computeFingerprintDetails(t.Context())
+		if err != nil {
+			t.Fatalf("computeFingerprintDetails failed: %v", err)
+		}
+		return details
+	}
+
+	before := fingerprintWithRows("1", "2")
+	after := fingerprintWithRows("1", "2", "3")
+	if _, ok := before.Components["lookup_enum:testdb.order_statuses"]; !ok {
+		t.Fatalf("expected lookup enum component, got %v", before.Components)
+	}
+	if before.Value == after.Value {
+		t.Fatalf("expected lookup row changes to change the fingerprint")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
 func TestComputeFingerprint_FallsBackToLightweight(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -798,6 +861,42 @@ func TestHandlerForContext_StateNotReady(t *testing.T) {
 	}
 }
 
+func TestStatus_ReadyRequiresEveryRoleSnapshot(t *testing.T) {
+	manager := &Manager{roleSchemas: []string{"viewer", "editor"}}
+	if status := manager.Status(); status.Ready || len(status.Roles) != 2 || status.LastRefresh != nil {
+		t.Fatalf("expected not-ready status before the first snapshot, got %+v", status)
+	}
+
+	builtAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	manager.active.Store(&snapshotSet{
+		Default:         &Snapshot{BuiltAt: builtAt},
+		ByRole:          map[string]*Snapshot{"viewer": {BuiltAt: builtAt}},
+		Fingerprint:     "fp",
+		FingerprintMode: fingerprintModeTiDBStructural,
+		BuiltAt:         builtAt,
+	})
+	manager.recordRefresh(context.Background(), time.Second, fmt.Errorf("build failed"), "poll", fingerprintModeTiDBStructural)
+
+	status := manager.Status()
+	if status.Ready || manager.Ready() {
+		t.Fatalf("expected not ready while the editor snapshot is missing")
+	}
+	if status.Fingerprint != "fp" || !status.Roles[0].Ready || status.Roles[1].Ready {
+		t.Fatalf("unexpected role status: %+v", status)
+	}
+	if status.LastRefresh == nil || status.LastRefresh.Success || status.LastRefresh.Error != "build failed" || status.LastRefresh.DurationMS != 1000 {
+		t.Fatalf("unexpected last refresh: %+v", status.LastRefresh)
+	}
+
+	manager.active.Store(&snapshotSet{
+		Default: &Snapshot{},
+		ByRole:  map[string]*Snapshot{"viewer": {}, "editor": {}},
+	})
+	if !manager.Ready() {
+		t.Fatalf("expected ready once every role snapshot exists")
+	}
+}
+
 type refreshMetricsRecorder struct {
 	ctx          context.Context
 	calls        int
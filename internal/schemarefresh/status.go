@@ -0,0 +1,67 @@
+package schemarefresh
+
+import "time"
+
+// RefreshOutcome describes the most recent refresh attempt.
+type RefreshOutcome struct {
+	Trigger    string    `json:"trigger"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	At         time.Time `json:"at"`
+	DurationMS int64     `json:"durationMs"`
+}
+
+// RoleStatus reports whether the snapshot for one role-specific schema exists.
+type RoleStatus struct {
+	Role    string    `json:"role"`
+	Ready   bool      `json:"ready"`
+	BuiltAt time.Time `json:"builtAt,omitempty"`
+}
+
+// Status is a point-in-time view of the active snapshot set.
+type Status struct {
+	Ready           bool            `json:"ready"`
+	Fingerprint     string          `json:"fingerprint,omitempty"`
+	FingerprintMode string          `json:"fingerprintMode,omitempty"`
+	BuiltAt         time.Time       `json:"builtAt,omitempty"`
+	Roles           []RoleStatus    `json:"roles,omitempty"`
+	LastRefresh     *RefreshOutcome `json:"lastRefresh,omitempty"`
+}
+
+// Ready reports whether a snapshot exists for the default schema and for every
+// configured role, i.e. whether every caller can be served.
+func (m *Manager) Ready() bool {
+	return m.Status().Ready
+}
+
+// Status returns the active snapshot set and the outcome of the last refresh.
+func (m *Manager) Status() Status {
+	status := Status{}
+	if outcome, ok := m.lastRefresh.Load().(RefreshOutcome); ok {
+		status.LastRefresh = &outcome
+	}
+
+	state := m.currentState()
+	if state == nil {
+		for _, role := range m.roleSchemas {
+			status.Roles = append(status.Roles, RoleStatus{Role: role})
+		}
+		return status
+	}
+
+	status.Fingerprint = state.Fingerprint
+	status.FingerprintMode = state.FingerprintMode
+	status.BuiltAt = state.BuiltAt
+	status.Ready = state.Default != nil
+	for _, role := range m.roleSchemas {
+		roleStatus := RoleStatus{Role: role}
+		if snapshot := state.ByRole[role]; snapshot != nil {
+			roleStatus.Ready = true
+			roleStatus.BuiltAt = snapshot.BuiltAt
+		} else {
+			status.Ready = false
+		}
+		status.Roles = append(status.Roles, roleStatus)
+	}
+	return status
+}
@@ -0,0 +1,63 @@
+package serverapp
+
+import (
+	"log/slog"
+	"strings"
+
+	"tidb-graphql/internal/apikey"
+	"tidb-graphql/internal/config"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/middleware"
+	"tidb-graphql/internal/naming"
+)
+
+// buildAPIKeyStore loads the API key file and starts polling it for changes.
+// It returns nil when API keys are not configured.
+func buildAPIKeyStore(cfg *config.Config, logger *logging.Logger) (*apikey.Store, error) {
+	path := strings.TrimSpace(cfg.Server.Auth.APIKeyFile)
+	if path == "" {
+		return nil, nil
+	}
+	store, err := apikey.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("API key authentication enabled",
+		slog.String("file", path),
+		slog.Int("keys", store.Len()),
+		slog.Duration("reload_interval", cfg.Server.Auth.APIKeyReloadInterval),
+	)
+	store.Start(cfg.Server.Auth.APIKeyReloadInterval, logger.Logger)
+	return store, nil
+}
+
+// apiKeyScopeConfig maps root fields to the namespaces API keys are scoped by.
+// It mirrors how the schema builder decides between flat and namespaced roots.
+func apiKeyScopeConfig(cfg *config.Config) middleware.APIKeyScopeConfig {
+	entries := cfg.Database.SchemaEntries()
+	namespaced := len(entries) > 1
+	for _, entry := range entries {
+		if entry.Namespace != "" {
+			namespaced = true
+		}
+	}
+	if !namespaced {
+		scope := middleware.APIKeyScopeConfig{}
+		if len(entries) == 1 {
+			scope.DefaultNamespace = entries[0].EffectiveNamespace()
+		}
+		return scope
+	}
+
+	namer := naming.New(cfg.Naming, nil)
+	fields := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		namespace := entry.EffectiveNamespace()
+		fieldName := namer.ToGraphQLFieldName(namespace)
+		if fieldName == "" {
+			fieldName = namespace
+		}
+		fields[fieldName] = namespace
+	}
+	return middleware.APIKeyScopeConfig{NamespaceFields: fields}
+}
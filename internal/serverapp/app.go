@@ -10,6 +10,7 @@ import (
 
 	"tidb-graphql/internal/config"
 	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/drain"
 	"tidb-graphql/internal/logging"
 	"tidb-graphql/internal/observability"
 	"tidb-graphql/internal/planner"
@@ -46,6 +47,8 @@ type App struct {
 
 	graphqlHandler http.Handler
 	adminHandler   http.Handler
+	health         *healthState
+	drain          *drain.Controller
 	mux            *http.ServeMux
 	handler        http.Handler
 
@@ -0,0 +1,33 @@
+package serverapp
+
+import (
+	"context"
+
+	"tidb-graphql/internal/config"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/observability"
+	"tidb-graphql/internal/tlscert"
+)
+
+// buildCertificateWatcher creates the watcher that keeps file-based server and
+// database certificates current. With metrics enabled it also reports
+// certificate expiry and reload outcomes. Material is added as it is loaded;
+// the caller starts the watcher once the server is built.
+func buildCertificateWatcher(cfg *config.Config, logger *logging.Logger) (*tlscert.Watcher, error) {
+	var tlsMetrics *observability.TLSMetrics
+	watcher := tlscert.NewWatcher(cfg.Server.TLSReloadInterval, logger.Logger, func(source string, err error) {
+		if tlsMetrics != nil {
+			tlsMetrics.RecordReload(context.Background(), source, err == nil)
+		}
+	})
+	if !cfg.Observability.MetricsEnabled {
+		return watcher, nil
+	}
+
+	metrics, err := observability.InitTLSMetrics(watcher.Expiries)
+	if err != nil {
+		return nil, err
+	}
+	tlsMetrics = metrics
+	return watcher, nil
+}
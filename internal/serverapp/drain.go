@@ -0,0 +1,114 @@
+package serverapp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"tidb-graphql/internal/config"
+	"tidb-graphql/internal/drain"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/middleware"
+	"tidb-graphql/internal/observability"
+	"tidb-graphql/internal/txsession"
+)
+
+// buildDrainController creates the drain state shared by the GraphQL handler,
+// the health endpoints and Shutdown, and registers its gauges when metrics are
+// enabled.
+func buildDrainController(cfg *config.Config, txSessions *txsession.Registry) (*drain.Controller, interface{ Unregister() error }, error) {
+	var openTransactions func() int
+	if txSessions != nil {
+		openTransactions = txSessions.Open
+	}
+	drainer := drain.New(openTransactions)
+	if !cfg.Observability.MetricsEnabled {
+		return drainer, nil, nil
+	}
+	registration, err := observability.RegisterDrainMetrics(drainer)
+	if err != nil {
+		return nil, nil, err
+	}
+	return drainer, registration, nil
+}
+
+// Drain fails readiness, stops accepting new mutations and waits up to
+// server.drain_timeout for in-flight mutations and interactive transactions
+// to finish. It does not stop the server; Shutdown calls it before releasing
+// resources. A zero drain timeout skips the wait.
+func (a *App) Drain(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	a.stateMu.Lock()
+	drainer := a.drain
+	a.stateMu.Unlock()
+	if drainer == nil {
+		return nil
+	}
+
+	if drainer.Start() {
+		a.logger.Info("drain started",
+			slog.Int64("inflight_mutations", drainer.InFlight()),
+			slog.Int("open_transactions", drainer.OpenTransactions()),
+		)
+	}
+
+	timeout := a.cfg.Server.DrainTimeout
+	if timeout <= 0 {
+		return nil
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := drainer.Wait(waitCtx); err != nil {
+		status := drainer.Status()
+		a.logger.Warn("drain deadline reached; remaining work will be rolled back",
+			slog.Int64("inflight_mutations", status.InFlightMutations),
+			slog.Int("open_transactions", status.OpenTransactions),
+		)
+		return fmt.Errorf("drain did not complete: %w", err)
+	}
+	a.logger.Info("drain complete")
+	return nil
+}
+
+// adminDrainHandler starts drain mode on POST without exiting the process and
+// reports the drain state on GET. Draining cannot be undone; restart the
+// instance to accept mutations again.
+func adminDrainHandler(drainer *drain.Controller, securityMetrics *observability.SecurityMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodGet {
+			writeHealthJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		authCtx, authenticated := middleware.AuthFromContext(r.Context())
+
+		status := http.StatusOK
+		if r.Method == http.MethodPost {
+			logAttrs := []any{
+				slog.String("operation", "drain"),
+				slog.String("remote_addr", r.RemoteAddr),
+				slog.Bool("authenticated", authenticated),
+			}
+			if authenticated {
+				logAttrs = append(logAttrs,
+					slog.String("authenticated_user", authCtx.Subject),
+					slog.String("issuer", authCtx.Issuer),
+				)
+			}
+			reqLogger := logging.FromContext(r.Context())
+			reqLogger.Info("admin endpoint accessed", logAttrs...)
+			if drainer.Start() {
+				reqLogger.Info("drain started from admin endpoint")
+				status = http.StatusAccepted
+			}
+		}
+
+		if securityMetrics != nil {
+			securityMetrics.RecordAdminEndpointAccess(r.Context(), "drain", authenticated, true)
+		}
+		writeHealthJSON(w, status, drainer.Status())
+	}
+}
@@ -0,0 +1,76 @@
+package serverapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tidb-graphql/internal/config"
+	"tidb-graphql/internal/drain"
+)
+
+func TestDrain_WaitsForInFlightMutations(t *testing.T) {
+	drainer := drain.New(nil)
+	app := &App{
+		cfg:    &config.Config{Server: config.ServerConfig{DrainTimeout: time.Second}},
+		logger: testLogger(),
+		drain:  drainer,
+	}
+	release := drainer.Begin()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		release()
+	}()
+
+	if err := app.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+	if !drainer.Draining() || drainer.InFlight() != 0 {
+		t.Fatalf("unexpected drain state: %+v", drainer.Status())
+	}
+}
+
+func TestDrain_ReturnsErrorAtDeadline(t *testing.T) {
+	drainer := drain.New(func() int { return 1 })
+	app := &App{
+		cfg:    &config.Config{Server: config.ServerConfig{DrainTimeout: 20 * time.Millisecond}},
+		logger: testLogger(),
+		drain:  drainer,
+	}
+	if err := app.Drain(context.Background()); err == nil {
+		t.Fatal("expected drain to time out with an open transaction")
+	}
+}
+
+func TestAdminDrainHandler(t *testing.T) {
+	drainer := drain.New(nil)
+	handler := adminDrainHandler(drainer, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/drain", nil))
+	if rec.Code != http.StatusOK || drainer.Draining() {
+		t.Fatalf("GET must not start draining, status=%d draining=%v", rec.Code, drainer.Draining())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/drain", nil))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rec.Code)
+	}
+	var status drain.Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !status.Draining || status.Since.IsZero() {
+		t.Fatalf("unexpected drain status: %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/drain", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected repeated drain to return %d, got %d", http.StatusOK, rec.Code)
+	}
+}
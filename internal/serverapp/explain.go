@@ -0,0 +1,166 @@
+package serverapp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"tidb-graphql/internal/asof"
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/explain"
+	"tidb-graphql/internal/gqlrequest"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/middleware"
+	"tidb-graphql/internal/observability"
+	"tidb-graphql/internal/resolver"
+	"tidb-graphql/internal/schemarefresh"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+const (
+	// explainMaxQueries bounds how many statements one explain request plans.
+	explainMaxQueries = 200
+	explainTimeout    = 30 * time.Second
+)
+
+// explainResponse is the JSON body of /admin/explain: a regular GraphQL
+// result plus the recorded statements and plans under extensions.explain.
+type explainResponse struct {
+	Data       interface{}                `json:"data"`
+	Errors     []gqlerrors.FormattedError `json:"errors,omitempty"`
+	Extensions map[string]interface{}     `json:"extensions"`
+}
+
+type explainExtension struct {
+	Analyze        bool             `json:"analyze"`
+	Fields         []*explain.Field `json:"fields"`
+	DroppedQueries int              `json:"droppedQueries,omitempty"`
+}
+
+// explainHandler executes a GraphQL query against the caller's schema snapshot,
+// recording the SQL each field issues and its batching decisions, then runs
+// EXPLAIN (or EXPLAIN ANALYZE with ?analyze=true) for every statement through
+// the same executor, so plans reflect the caller's database role. Server-wide
+// plan limits and any matching limit override bound execution as on /graphql.
+func explainHandler(manager *schemarefresh.Manager, executor dbexec.QueryExecutor, securityMetrics *observability.SecurityMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		reqLogger := logging.FromContext(r.Context())
+		if r.Method != http.MethodPost {
+			writeExplainError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		authCtx, authenticated := middleware.AuthFromContext(r.Context())
+		logAttrs := []any{
+			slog.String("operation", "explain"),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.Bool("authenticated", authenticated),
+		}
+		if authenticated {
+			logAttrs = append(logAttrs,
+				slog.String("authenticated_user", authCtx.Subject),
+				slog.String("issuer", authCtx.Issuer),
+			)
+		}
+		reqLogger.Info("admin endpoint accessed", logAttrs...)
+
+		recordAccess := func(success bool) {
+			if securityMetrics != nil {
+				securityMetrics.RecordAdminEndpointAccess(r.Context(), "explain", authenticated, success)
+			}
+		}
+
+		analyze := false
+		if raw := r.URL.Query().Get("analyze"); raw != "" {
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				recordAccess(false)
+				writeExplainError(w, http.StatusBadRequest, "invalid analyze parameter")
+				return
+			}
+			analyze = parsed
+		}
+
+		analysis := gqlrequest.AnalysisFromContext(r.Context())
+		if analysis == nil {
+			analysis = gqlrequest.AnalyzeRequest(r)
+		}
+		switch {
+		case analysis.DecodeError != nil:
+			recordAccess(false)
+			writeExplainError(w, http.StatusBadRequest, "invalid request body")
+			return
+		case analysis.Document == nil && analysis.ParseError == nil:
+			recordAccess(false)
+			writeExplainError(w, http.StatusBadRequest, "query is required")
+			return
+		case analysis.Operation != nil && analysis.OperationType != "query":
+			recordAccess(false)
+			writeExplainError(w, http.StatusBadRequest, "explain supports query operations only")
+			return
+		}
+		variables, err := asof.DecodeVariables(analysis.Envelope.VariablesRaw)
+		if err != nil {
+			recordAccess(false)
+			writeExplainError(w, http.StatusBadRequest, "invalid variables")
+			return
+		}
+
+		var snapshot *schemarefresh.Snapshot
+		if manager != nil {
+			snapshot, _, _, _ = manager.SnapshotForContext(r.Context())
+		}
+		if snapshot == nil || snapshot.Schema == nil {
+			recordAccess(false)
+			writeExplainError(w, http.StatusServiceUnavailable, "schema not available")
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), explainTimeout)
+		defer cancel()
+
+		recorder := explain.NewRecorder(explainMaxQueries)
+		result := graphql.Do(graphql.Params{
+			Schema:         *snapshot.Schema,
+			RequestString:  analysis.Envelope.Query,
+			VariableValues: variables,
+			OperationName:  analysis.Envelope.OperationName,
+			Context:        explain.WithRecorder(resolver.NewBatchingContext(ctx), recorder),
+		})
+
+		if executor != nil {
+			if err := recorder.AttachPlans(ctx, executor, analyze); err != nil {
+				recordAccess(false)
+				reqLogger.Error("explain failed", slog.String("error", err.Error()))
+				writeExplainError(w, http.StatusGatewayTimeout, "explain timed out")
+				return
+			}
+		}
+		recordAccess(true)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(explainResponse{
+			Data:   result.Data,
+			Errors: result.Errors,
+			Extensions: map[string]interface{}{
+				"explain": explainExtension{
+					Analyze:        analyze,
+					Fields:         recorder.Fields(),
+					DroppedQueries: recorder.Dropped(),
+				},
+			},
+		})
+	}
+}
+
+func writeExplainError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"status": "error", "message": message})
+}
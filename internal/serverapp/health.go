@@ -0,0 +1,200 @@
+package serverapp
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"tidb-graphql/internal/drain"
+	"tidb-graphql/internal/logging"
+	"tidb-graphql/internal/middleware"
+	"tidb-graphql/internal/observability"
+	"tidb-graphql/internal/schemarefresh"
+	"tidb-graphql/internal/tlscert"
+)
+
+// healthState carries what the probe and status endpoints report on. It is
+// created before the router so handlers can capture it; the TLS manager is
+// attached once the server has been built.
+type healthState struct {
+	db      *sql.DB
+	manager *schemarefresh.Manager
+	drain   *drain.Controller
+	timeout time.Duration
+	tlsMode string
+	// clientAuth is the server.tls_client_auth mode reported by /admin/status.
+	clientAuth string
+
+	mu         sync.RWMutex
+	tlsManager tlscert.Manager
+}
+
+func newHealthState(db *sql.DB, manager *schemarefresh.Manager, drainer *drain.Controller, timeout time.Duration, tlsMode string) *healthState {
+	return &healthState{db: db, manager: manager, drain: drainer, timeout: timeout, tlsMode: tlsMode}
+}
+
+func (h *healthState) setTLSManager(manager tlscert.Manager) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.tlsManager = manager
+}
+
+func (h *healthState) currentTLSManager() tlscert.Manager {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.tlsManager
+}
+
+// readinessChecks evaluates each readiness condition. Values are short,
+// non-sensitive strings suitable for unauthenticated probe responses.
+func (h *healthState) readinessChecks(ctx context.Context) (map[string]string, bool) {
+	checks := map[string]string{"schema": "ok", "database": "ok", "draining": "false"}
+	ready := true
+
+	if h.drain != nil && h.drain.Draining() {
+		checks["draining"] = "true"
+		ready = false
+	}
+	if h.manager == nil || !h.manager.Ready() {
+		checks["schema"] = "not_ready"
+		ready = false
+	}
+	if err := h.pingDB(ctx); err != nil {
+		checks["database"] = "failed"
+		ready = false
+	}
+	return checks, ready
+}
+
+func (h *healthState) pingDB(ctx context.Context) error {
+	if h.db == nil {
+		return sql.ErrConnDone
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	return h.db.PingContext(pingCtx)
+}
+
+// livezHandler reports that the process is up and serving HTTP. It never
+// touches the database so a TiDB outage does not get pods restarted.
+func livezHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealthJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+	}
+}
+
+// readyzHandler reports whether this instance should receive traffic: schema
+// snapshots exist for every configured role, the database answers a ping and
+// the server is not draining.
+func readyzHandler(health *healthState) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checks, ready := health.readinessChecks(r.Context())
+		if !ready {
+			logging.FromContext(r.Context()).Warn("readiness check failed", slog.Any("checks", checks))
+			writeHealthJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "not_ready", "checks": checks})
+			return
+		}
+		writeHealthJSON(w, http.StatusOK, map[string]any{"status": "ready", "checks": checks})
+	}
+}
+
+type poolStatus struct {
+	MaxOpen           int   `json:"maxOpen"`
+	Open              int   `json:"open"`
+	InUse             int   `json:"inUse"`
+	Idle              int   `json:"idle"`
+	WaitCount         int64 `json:"waitCount"`
+	WaitDurationMS    int64 `json:"waitDurationMs"`
+	MaxIdleClosed     int64 `json:"maxIdleClosed"`
+	MaxIdleTimeClosed int64 `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed int64 `json:"maxLifetimeClosed"`
+}
+
+type databaseStatus struct {
+	Reachable bool        `json:"reachable"`
+	Pool      *poolStatus `json:"pool,omitempty"`
+}
+
+type tlsStatus struct {
+	Enabled      bool                      `json:"enabled"`
+	Mode         string                    `json:"mode,omitempty"`
+	ClientAuth   string                    `json:"clientAuth,omitempty"`
+	Certificates []tlscert.CertificateInfo `json:"certificates,omitempty"`
+	Error        string                    `json:"error,omitempty"`
+}
+
+type adminStatusResponse struct {
+	Status   string               `json:"status"`
+	Checks   map[string]string    `json:"checks"`
+	Schema   schemarefresh.Status `json:"schema"`
+	Database databaseStatus       `json:"database"`
+	Drain    drain.Status         `json:"drain"`
+	TLS      tlsStatus            `json:"tls"`
+}
+
+// adminStatusHandler returns the detailed view behind /readyz for operators.
+func adminStatusHandler(health *healthState, securityMetrics *observability.SecurityMetrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeHealthJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		_, authenticated := middleware.AuthFromContext(r.Context())
+
+		checks, ready := health.readinessChecks(r.Context())
+		resp := adminStatusResponse{
+			Status: "ready",
+			Checks: checks,
+			Database: databaseStatus{
+				Reachable: checks["database"] == "ok",
+			},
+			TLS: tlsStatus{Mode: health.tlsMode, ClientAuth: health.clientAuth},
+		}
+		if !ready {
+			resp.Status = "not_ready"
+		}
+		if health.manager != nil {
+			resp.Schema = health.manager.Status()
+		}
+		if health.drain != nil {
+			resp.Drain = health.drain.Status()
+		}
+		if health.db != nil {
+			stats := health.db.Stats()
+			resp.Database.Pool = &poolStatus{
+				MaxOpen:           stats.MaxOpenConnections,
+				Open:              stats.OpenConnections,
+				InUse:             stats.InUse,
+				Idle:              stats.Idle,
+				WaitCount:         stats.WaitCount,
+				WaitDurationMS:    stats.WaitDuration.Milliseconds(),
+				MaxIdleClosed:     stats.MaxIdleClosed,
+				MaxIdleTimeClosed: stats.MaxIdleTimeClosed,
+				MaxLifetimeClosed: stats.MaxLifetimeClosed,
+			}
+		}
+		if tlsManager := health.currentTLSManager(); tlsManager != nil {
+			resp.TLS.Enabled = true
+			certs, err := tlsManager.Certificates()
+			if err != nil {
+				resp.TLS.Error = err.Error()
+			}
+			resp.TLS.Certificates = certs
+		}
+
+		if securityMetrics != nil {
+			securityMetrics.RecordAdminEndpointAccess(r.Context(), "status", authenticated, true)
+		}
+		writeHealthJSON(w, http.StatusOK, resp)
+	}
+}
+
+func writeHealthJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
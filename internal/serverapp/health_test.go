@@ -0,0 +1,87 @@
+package serverapp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tidb-graphql/internal/drain"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLivez_DoesNotTouchDatabase(t *testing.T) {
+	rec := httptest.NewRecorder()
+	livezHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestReadyz_ReportsFailingChecks(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	drainer := drain.New(nil)
+	drainer.Start()
+	health := newHealthState(db, nil, drainer, time.Second, "")
+	mock.ExpectPing()
+
+	rec := httptest.NewRecorder()
+	readyzHandler(health).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+
+	var body struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := map[string]string{"schema": "not_ready", "database": "ok", "draining": "true"}
+	for key, value := range want {
+		if body.Checks[key] != value {
+			t.Fatalf("check %s = %q, want %q (body %s)", key, body.Checks[key], value, rec.Body.String())
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestAdminStatus_ReportsPoolAndChecks(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(7)
+	mock.ExpectPing()
+
+	rec := httptest.NewRecorder()
+	adminStatusHandler(newHealthState(db, nil, nil, time.Second, "off"), nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var body adminStatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Status != "not_ready" || !body.Database.Reachable {
+		t.Fatalf("unexpected status: %s", rec.Body.String())
+	}
+	if body.Database.Pool == nil || body.Database.Pool.MaxOpen != 7 {
+		t.Fatalf("expected pool stats, got %s", rec.Body.String())
+	}
+	if body.TLS.Enabled || body.TLS.Mode != "off" {
+		t.Fatalf("unexpected TLS status: %+v", body.TLS)
+	}
+}
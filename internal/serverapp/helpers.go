@@ -11,9 +11,14 @@ import (
 	"strings"
 	"time"
 
+	"tidb-graphql/internal/apikey"
+	"tidb-graphql/internal/authz"
 	"tidb-graphql/internal/config"
 	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/drain"
+	"tidb-graphql/internal/gqlrequest"
 	"tidb-graphql/internal/introspection"
+	"tidb-graphql/internal/jsonschema"
 	"tidb-graphql/internal/logging"
 	"tidb-graphql/internal/middleware"
 	"tidb-graphql/internal/observability"
@@ -22,6 +27,7 @@ import (
 	"tidb-graphql/internal/schemarefresh"
 	"tidb-graphql/internal/sqlutil"
 	"tidb-graphql/internal/tlscert"
+	"tidb-graphql/internal/txsession"
 
 	"github.com/XSAM/otelsql"
 	_ "github.com/go-sql-driver/mysql"
@@ -168,14 +174,16 @@ func initTracing(cfg *config.Config, logger *logging.Logger) (*observability.Tra
 	return tracerProvider, nil
 }
 
-func connectDB(cfg *config.Config, logger *logging.Logger) (*sql.DB, interface{ Unregister() error }, error) {
+func connectDB(cfg *config.Config, logger *logging.Logger, certWatcher *tlscert.Watcher) (*sql.DB, interface{ Unregister() error }, error) {
 	var db *sql.DB
 	var dbStatsReg interface{ Unregister() error }
 
 	// Register custom TLS configuration if needed (for verify-ca/verify-full modes)
-	if err := cfg.Database.RegisterTLS(); err != nil {
+	tlsReloadables, err := cfg.Database.RegisterTLS()
+	if err != nil {
 		return nil, nil, fmt.Errorf("failed to register database TLS config: %w", err)
 	}
+	certWatcher.Add(tlsReloadables...)
 
 	dsn := cfg.Database.DSN()
 	if cfg.Server.Auth.DBRoleEnabled {
@@ -200,7 +208,6 @@ func connectDB(cfg *config.Config, logger *logging.Logger) (*sql.DB, interface{
 			logger.Warn("SQLCommenter requires tracing to be enabled - skipping SQLCommenter")
 		}
 
-		var err error
 		db, err = otelsql.Open("mysql", dsn, opts...)
 		if err != nil {
 			return nil, nil, err
@@ -221,7 +228,7 @@ func connectDB(cfg *config.Config, logger *logging.Logger) (*sql.DB, interface{
 		return db, dbStatsReg, nil
 	}
 
-	db, err := sql.Open("mysql", dsn)
+	db, err = sql.Open("mysql", dsn)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -490,14 +497,44 @@ func startSchemaManager(ctx context.Context, cfg *config.Config, logger *logging
 		}
 	}
 
+	authorization, err := fieldAuthorizationPolicy(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonSchemaColumns, err := jsonSchemaColumnMappings(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	dateTimeZone, err := configTimeZone("type_mappings.datetime_time_zone", cfg.TypeMappings.DateTimeTimeZone)
+	if err != nil {
+		return nil, nil, err
+	}
+	timeZone, err := configTimeZone("server.graphql_time_zone", cfg.Server.GraphQLTimeZone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namingConfig, err := cfg.Naming.WithInflectionFile()
+	if err != nil {
+		return nil, nil, fmt.Errorf("naming.inflection_file: %w", err)
+	}
+
 	// Convert config database entries to schema builder entries.
 	var dbEntries []schemarefresh.DatabaseBuildEntry
 	for _, entry := range cfg.Database.SchemaEntries() {
+		entryNaming := entry.Naming
+		if entryNaming != nil {
+			loaded, err := entryNaming.WithInflectionFile()
+			if err != nil {
+				return nil, nil, fmt.Errorf("database %q naming.inflection_file: %w", entry.Name, err)
+			}
+			entryNaming = &loaded
+		}
 		dbEntries = append(dbEntries, schemarefresh.DatabaseBuildEntry{
 			Name:      entry.Name,
 			Namespace: entry.Namespace,
 			Filters:   entry.Filters,
-			Naming:    entry.Naming,
+			Naming:    entryNaming,
 		})
 	}
 
@@ -516,9 +553,19 @@ func startSchemaManager(ctx context.Context, cfg *config.Config, logger *logging
 		UUIDColumns:            cfg.TypeMappings.UUIDColumns,
 		TinyInt1BooleanColumns: cfg.TypeMappings.TinyInt1BooleanColumns,
 		TinyInt1IntColumns:     cfg.TypeMappings.TinyInt1IntColumns,
-		Naming:                 cfg.Naming,
+		ScalarColumns:          scalarColumnMappings(cfg),
+		JSONSchemaColumns:      jsonSchemaColumns,
+		EnumValues:             enumValueMappings(cfg),
+		LookupEnums:            lookupEnums(cfg),
+		Deprecations:           deprecations(cfg),
+		DateTimeZone:           dateTimeZone,
+		TimeZone:               timeZone,
+		Naming:                 namingConfig,
 		VectorRequireIndex:     cfg.Server.Search.VectorRequireIndex,
 		VectorMaxTopK:          cfg.Server.Search.VectorMaxTopK,
+		Transactions:           cfg.Server.Transactions.Enabled,
+		Explain:                cfg.Server.Admin.ExplainEnabled,
+		Authorization:          authorization,
 		Executor:               executor,
 		IntrospectionRole:      cfg.Server.Auth.DBRoleIntrospectionRole,
 		RoleSchemas:            availableRoles,
@@ -535,16 +582,194 @@ func startSchemaManager(ctx context.Context, cfg *config.Config, logger *logging
 }
 
 func oidcAuthConfig(cfg *config.Config) middleware.OIDCAuthConfig {
+	issuers := make([]middleware.OIDCIssuer, 0, len(cfg.Server.Auth.OIDCIssuers))
+	for _, issuer := range cfg.Server.Auth.OIDCIssuers {
+		issuers = append(issuers, middleware.OIDCIssuer{
+			IssuerURL:     issuer.IssuerURL,
+			Audience:      issuer.Audience,
+			CAFile:        issuer.CAFile,
+			JWKSURL:       issuer.JWKSURL,
+			JWKSFile:      issuer.JWKSFile,
+			RoleClaim:     issuer.DBRoleClaimName,
+			ClaimMappings: issuer.ClaimMappings,
+		})
+	}
 	return middleware.OIDCAuthConfig{
 		Enabled:   cfg.Server.Auth.OIDCEnabled,
 		IssuerURL: cfg.Server.Auth.OIDCIssuerURL,
 		Audience:  cfg.Server.Auth.OIDCAudience,
 		CAFile:    cfg.Server.Auth.OIDCCAFile,
+		JWKSURL:   cfg.Server.Auth.OIDCJWKSURL,
+		JWKSFile:  cfg.Server.Auth.OIDCJWKSFile,
 		ClockSkew: cfg.Server.Auth.OIDCClockSkew,
+		Issuers:   issuers,
+	}
+}
+
+func fieldAuthorizationPolicy(cfg *config.Config) (*authz.Policy, error) {
+	rules := make([]authz.Rule, 0, len(cfg.Server.Auth.FieldRules))
+	for _, rule := range cfg.Server.Auth.FieldRules {
+		rules = append(rules, authz.Rule{
+			Name:   rule.Name,
+			Field:  rule.Field,
+			Scopes: rule.Scopes,
+			Roles:  rule.Roles,
+			Claim:  rule.Claim,
+			Values: rule.Values,
+		})
+	}
+	return authz.NewPolicy(rules)
+}
+
+func scalarColumnMappings(cfg *config.Config) []introspection.ScalarMapping {
+	mappings := make([]introspection.ScalarMapping, 0, len(cfg.TypeMappings.ScalarColumns))
+	for _, mapping := range cfg.TypeMappings.ScalarColumns {
+		mappings = append(mappings, introspection.ScalarMapping{
+			Scalar:         mapping.Scalar,
+			Table:          mapping.Table,
+			Columns:        mapping.Columns,
+			CurrencyColumn: mapping.CurrencyColumn,
+			Unit:           mapping.Unit,
+		})
+	}
+	return mappings
+}
+
+func enumValueMappings(cfg *config.Config) []introspection.EnumValueMapping {
+	mappings := make([]introspection.EnumValueMapping, 0, len(cfg.TypeMappings.EnumValues))
+	for _, mapping := range cfg.TypeMappings.EnumValues {
+		values := make(map[string]introspection.EnumValueMeta, len(mapping.Values))
+		for _, value := range mapping.Values {
+			values[value.Value] = introspection.EnumValueMeta{
+				Name:              value.Name,
+				Description:       value.Description,
+				DeprecationReason: value.DeprecationReason,
+			}
+		}
+		mappings = append(mappings, introspection.EnumValueMapping{
+			Table:  mapping.Table,
+			Column: mapping.Column,
+			Values: values,
+		})
+	}
+	return mappings
+}
+
+func lookupEnums(cfg *config.Config) []introspection.LookupEnum {
+	lookups := make([]introspection.LookupEnum, 0, len(cfg.TypeMappings.LookupEnums))
+	for _, lookup := range cfg.TypeMappings.LookupEnums {
+		lookups = append(lookups, introspection.LookupEnum{
+			Table:             lookup.Table,
+			ValueColumn:       lookup.ValueColumn,
+			NameColumn:        lookup.NameColumn,
+			DescriptionColumn: lookup.DescriptionColumn,
+			TypeName:          lookup.TypeName,
+		})
+	}
+	return lookups
+}
+
+func deprecations(cfg *config.Config) []introspection.Deprecation {
+	out := make([]introspection.Deprecation, 0, len(cfg.Deprecations))
+	for _, deprecation := range cfg.Deprecations {
+		out = append(out, introspection.Deprecation{
+			Table:  deprecation.Table,
+			Column: deprecation.Column,
+			Reason: deprecation.Reason,
+		})
+	}
+	return out
+}
+
+// jsonSchemaColumnMappings loads the JSON Schema files attached to JSON columns.
+// Schemas are read once at startup; schema refreshes reuse them.
+func jsonSchemaColumnMappings(cfg *config.Config) ([]introspection.JSONSchemaMapping, error) {
+	mappings := make([]introspection.JSONSchemaMapping, 0, len(cfg.TypeMappings.JSONSchemaColumns))
+	for _, mapping := range cfg.TypeMappings.JSONSchemaColumns {
+		schema, err := jsonschema.Load(mapping.SchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("type_mappings.json_schema_columns %s.%s: %w", mapping.Table, mapping.Column, err)
+		}
+		mappings = append(mappings, introspection.JSONSchemaMapping{
+			Table:  mapping.Table,
+			Column: mapping.Column,
+			Schema: schema,
+		})
+	}
+	return mappings, nil
+}
+
+// configTimeZone loads a configured IANA zone; an empty name means UTC.
+func configTimeZone(field, name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := gqlrequest.LoadTimeZone(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return loc, nil
+}
+
+func limitOverrides(cfg *config.Config) []middleware.LimitOverride {
+	overrides := make([]middleware.LimitOverride, 0, len(cfg.Server.GraphQLLimitOverrides))
+	for _, override := range cfg.Server.GraphQLLimitOverrides {
+		overrides = append(overrides, middleware.LimitOverride{
+			Name:            override.Name,
+			Roles:           override.Roles,
+			Claim:           override.Claim,
+			Values:          override.Values,
+			Operations:      override.Operations,
+			OperationHashes: override.OperationHashes,
+			MaxDepth:        override.MaxDepth,
+			MaxComplexity:   override.MaxComplexity,
+			MaxRows:         override.MaxRows,
+		})
+	}
+	return overrides
+}
+
+func introspectionConfig(cfg *config.Config) middleware.IntrospectionConfig {
+	return middleware.IntrospectionConfig{
+		Enabled:         cfg.Server.Auth.IntrospectionEnabled,
+		URL:             cfg.Server.Auth.IntrospectionURL,
+		ClientID:        cfg.Server.Auth.IntrospectionClientID,
+		ClientSecret:    cfg.Server.Auth.IntrospectionClientSecret,
+		CAFile:          cfg.Server.Auth.IntrospectionCAFile,
+		Audience:        cfg.Server.Auth.IntrospectionAudience,
+		ClockSkew:       cfg.Server.Auth.OIDCClockSkew,
+		CacheTTL:        cfg.Server.Auth.IntrospectionCacheTTL,
+		CacheMaxEntries: cfg.Server.Auth.IntrospectionCacheMaxEntries,
+		SkipJWTs:        cfg.Server.Auth.OIDCEnabled,
+	}
+}
+
+func buildTransactionRegistry(cfg *config.Config, logger *logging.Logger, executor dbexec.QueryExecutor) (*txsession.Registry, error) {
+	if !cfg.Server.Transactions.Enabled || executor == nil {
+		return nil, nil
+	}
+	registry, err := txsession.New(txsession.Config{
+		Executor:      executor,
+		IdleTimeout:   cfg.Server.Transactions.IdleTimeout,
+		MaxLifetime:   cfg.Server.Transactions.MaxLifetime,
+		MaxPerSubject: cfg.Server.Transactions.MaxPerSubject,
+		MaxOpen:       cfg.Server.Transactions.MaxOpen,
+		OwnerFromCtx:  middleware.TransactionSubject,
+		Logger:        logger,
+	})
+	if err != nil {
+		return nil, err
 	}
+	logger.Info("interactive transactions enabled",
+		slog.Duration("idle_timeout", cfg.Server.Transactions.IdleTimeout),
+		slog.Duration("max_lifetime", cfg.Server.Transactions.MaxLifetime),
+		slog.Int("max_per_subject", cfg.Server.Transactions.MaxPerSubject),
+		slog.Int("max_open", cfg.Server.Transactions.MaxOpen),
+	)
+	return registry, nil
 }
 
-func buildGraphQLHandler(cfg *config.Config, logger *logging.Logger, manager *schemarefresh.Manager, graphqlMetrics *observability.GraphQLMetrics, securityMetrics *observability.SecurityMetrics, executor dbexec.QueryExecutor, availableRoles []string) (http.Handler, error) {
+func buildGraphQLHandler(cfg *config.Config, logger *logging.Logger, manager *schemarefresh.Manager, graphqlMetrics *observability.GraphQLMetrics, securityMetrics *observability.SecurityMetrics, executor dbexec.QueryExecutor, availableRoles []string, txSessions *txsession.Registry, drainer *drain.Controller, apiKeys *apikey.Store) (http.Handler, error) {
 	graphqlHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		manager.HandlerForContext(r.Context()).ServeHTTP(w, r)
 	})
@@ -562,23 +787,51 @@ func buildGraphQLHandler(cfg *config.Config, logger *logging.Logger, manager *sc
 		logger.Info("GraphQL metrics middleware enabled")
 	}
 
-	// Middleware order: OIDC auth runs outermost, then DB role extraction.
-	// DB role middleware must run after OIDC because it reads claims from the
-	// validated JWT token that OIDC places in context. The chain is:
-	//   request -> logging -> OIDC auth -> DB role -> request analysis -> request validation -> mutation tx -> metrics -> tracing -> batching -> graphql
+	// Middleware order: client cert, API key, token introspection and OIDC auth
+	// run outermost, then DB role extraction. DB role middleware must run after
+	// all of them because it reads the claims, API key or certificate identity
+	// they place in context. Introspection handles opaque tokens and leaves JWTs
+	// to OIDC when both are enabled. API key scopes and limit overrides are applied
+	// once the request is analyzed. The chain is:
+	//   request -> logging -> client cert auth -> API key auth -> introspection -> OIDC auth -> DB role -> authz principal -> request analysis -> limit overrides -> API key scope -> request validation -> drain -> tx session -> mutation tx -> metrics -> tracing -> batching -> graphql
 	baseHandler := metricsHandler
 	if executor != nil {
 		baseHandler = middleware.MutationTransactionMiddleware(executor)(baseHandler)
 		logger.Info("mutation transaction middleware enabled")
 	}
+	if txSessions != nil {
+		baseHandler = middleware.TransactionSessionMiddleware(txSessions)(baseHandler)
+	}
+	if drainer != nil {
+		baseHandler = middleware.DrainMiddleware(drainer)(baseHandler)
+	}
 
 	validationHandler := middleware.GraphQLRequestValidationMiddleware()(baseHandler)
-	analysisHandler := middleware.GraphQLRequestAnalysisMiddleware(manager)(validationHandler)
+	scopeHandler := validationHandler
+	if apiKeys != nil {
+		scopeHandler = middleware.APIKeyScopeMiddleware(apiKeyScopeConfig(cfg), securityMetrics)(validationHandler)
+	}
+	limitsHandler := scopeHandler
+	if overrides := limitOverrides(cfg); len(overrides) > 0 {
+		limitsHandler = middleware.GraphQLLimitsMiddleware(buildPlanLimits(cfg), overrides)(scopeHandler)
+		logger.Info("GraphQL limit overrides enabled", slog.Int("overrides", len(overrides)))
+	}
+	analysisHandler := middleware.GraphQLRequestAnalysisMiddleware(manager)(limitsHandler)
 
-	dbRoleHandler := analysisHandler
+	principalHandler := analysisHandler
+	if len(cfg.Server.Auth.FieldRules) > 0 {
+		principalHandler = middleware.AuthzPrincipalMiddleware()(analysisHandler)
+		logger.Info("field authorization rules enabled", slog.Int("rules", len(cfg.Server.Auth.FieldRules)))
+	}
+
+	dbRoleHandler := principalHandler
 	if cfg.Server.Auth.DBRoleEnabled {
-		dbRoleHandler = middleware.DBRoleMiddleware(cfg.Server.Auth.DBRoleClaimName, availableRoles)(analysisHandler)
-		logger.Info("database role middleware enabled")
+		dbRoleMiddleware, err := middleware.DBRoleMiddlewareWithConfig(dbRoleConfig(cfg, availableRoles), securityMetrics)
+		if err != nil {
+			return nil, err
+		}
+		dbRoleHandler = dbRoleMiddleware(principalHandler)
+		logger.Info("database role middleware enabled", slog.Int("role_rules", len(cfg.Server.Auth.DBRoleRules)))
 	}
 
 	authHandler := dbRoleHandler
@@ -590,24 +843,108 @@ func buildGraphQLHandler(cfg *config.Config, logger *logging.Logger, manager *sc
 		authHandler = authMiddleware(dbRoleHandler)
 		logger.Info("OIDC auth middleware enabled")
 	}
+	if cfg.Server.Auth.IntrospectionEnabled {
+		introspectionMiddleware, err := middleware.IntrospectionAuthMiddleware(introspectionConfig(cfg), logger, securityMetrics)
+		if err != nil {
+			return nil, err
+		}
+		authHandler = introspectionMiddleware(authHandler)
+		logger.Info("token introspection auth middleware enabled",
+			slog.Duration("cache_ttl", cfg.Server.Auth.IntrospectionCacheTTL),
+			slog.Bool("jwts_to_oidc", cfg.Server.Auth.OIDCEnabled))
+	}
+	if apiKeys != nil {
+		authHandler = middleware.APIKeyAuthMiddleware(apiKeys, logger, securityMetrics)(authHandler)
+		logger.Info("API key auth middleware enabled")
+	}
+	if tlscert.ClientAuthMode(cfg.Server.TLSClientAuth).Enabled() {
+		authHandler = middleware.ClientCertAuthMiddleware(logger, securityMetrics)(authHandler)
+		logger.Info("client certificate auth middleware enabled",
+			slog.String("client_auth", cfg.Server.TLSClientAuth))
+	}
 
 	return middleware.LoggingMiddleware(logger)(authHandler), nil
 }
 
-func buildAdminHandler(cfg *config.Config, logger *logging.Logger, manager *schemarefresh.Manager, securityMetrics *observability.SecurityMetrics) (http.Handler, error) {
+func dbRoleConfig(cfg *config.Config, availableRoles []string) middleware.DBRoleConfig {
+	rules := make([]middleware.RoleRule, 0, len(cfg.Server.Auth.DBRoleRules))
+	for _, rule := range cfg.Server.Auth.DBRoleRules {
+		rules = append(rules, middleware.RoleRule{
+			Name:     rule.Name,
+			Issuer:   rule.Issuer,
+			Claim:    rule.Claim,
+			Values:   rule.Values,
+			Pattern:  rule.Pattern,
+			Role:     rule.Role,
+			Priority: rule.Priority,
+		})
+	}
+	return middleware.DBRoleConfig{
+		ClaimName:       cfg.Server.Auth.DBRoleClaimName,
+		AvailableRoles:  availableRoles,
+		ClientCertRoles: clientCertRoles(cfg),
+		Rules:           rules,
+	}
+}
+
+func clientCertRoles(cfg *config.Config) []middleware.ClientCertRole {
+	roles := make([]middleware.ClientCertRole, 0, len(cfg.Server.Auth.ClientCertRoles))
+	for _, mapping := range cfg.Server.Auth.ClientCertRoles {
+		roles = append(roles, middleware.ClientCertRole{Identity: mapping.Identity, Role: mapping.Role})
+	}
+	return roles
+}
+
+func buildAdminHandler(cfg *config.Config, logger *logging.Logger, manager *schemarefresh.Manager, securityMetrics *observability.SecurityMetrics, executor dbexec.QueryExecutor, availableRoles []string, health *healthState) (http.Handler, error) {
 	if !cfg.Server.Admin.SchemaReloadEnabled {
 		logger.Info("admin schema reload endpoint disabled")
+	}
+	if !cfg.Server.Admin.SchemaReloadEnabled && !cfg.Server.Admin.ExplainEnabled && !cfg.Server.Admin.StatusEnabled && !cfg.Server.Admin.DrainEnabled {
 		return nil, nil
 	}
 
-	var adminHandler http.Handler = http.HandlerFunc(schemaReloadHandler(manager, securityMetrics))
+	adminMux := http.NewServeMux()
+	if cfg.Server.Admin.SchemaReloadEnabled {
+		adminMux.Handle("/admin/reload-schema", schemaReloadHandler(manager, securityMetrics))
+	}
+	if cfg.Server.Admin.ExplainEnabled {
+		// Explain runs queries as the caller, so it needs the same role
+		// resolution, request analysis, limit overrides and request validation
+		// as /graphql for plans to reflect real privileges and stay bounded.
+		var explainRoute http.Handler = explainHandler(manager, executor, securityMetrics)
+		explainRoute = middleware.GraphQLRequestValidationMiddleware()(explainRoute)
+		explainRoute = middleware.GraphQLLimitsMiddleware(buildPlanLimits(cfg), limitOverrides(cfg))(explainRoute)
+		explainRoute = middleware.GraphQLRequestAnalysisMiddleware(manager)(explainRoute)
+		if len(cfg.Server.Auth.FieldRules) > 0 {
+			explainRoute = middleware.AuthzPrincipalMiddleware()(explainRoute)
+		}
+		if cfg.Server.Auth.DBRoleEnabled {
+			dbRoleMiddleware, err := middleware.DBRoleMiddlewareWithConfig(dbRoleConfig(cfg, availableRoles), securityMetrics)
+			if err != nil {
+				return nil, err
+			}
+			explainRoute = dbRoleMiddleware(explainRoute)
+		}
+		adminMux.Handle("/admin/explain", explainRoute)
+		logger.Info("admin explain endpoint enabled")
+	}
+	if cfg.Server.Admin.StatusEnabled {
+		adminMux.Handle("/admin/status", adminStatusHandler(health, securityMetrics))
+		logger.Info("admin status endpoint enabled")
+	}
+	if cfg.Server.Admin.DrainEnabled {
+		adminMux.Handle("/admin/drain", adminDrainHandler(health.drain, securityMetrics))
+		logger.Info("admin drain endpoint enabled")
+	}
+
+	var adminHandler http.Handler = adminMux
 	if cfg.Server.Auth.OIDCEnabled {
 		adminAuthMiddleware, err := middleware.OIDCAuthMiddleware(oidcAuthConfig(cfg), logger, securityMetrics)
 		if err != nil {
 			return nil, err
 		}
 		adminHandler = adminAuthMiddleware(adminHandler)
-		logger.Info("admin schema reload endpoint enabled with OIDC authentication")
+		logger.Info("admin endpoints enabled with OIDC authentication")
 	} else {
 		adminAuthMiddleware, err := middleware.AdminTokenAuthMiddleware(middleware.AdminTokenAuthConfig{
 			Token: cfg.Server.Admin.AuthToken,
@@ -616,12 +953,12 @@ func buildAdminHandler(cfg *config.Config, logger *logging.Logger, manager *sche
 			return nil, err
 		}
 		adminHandler = adminAuthMiddleware(adminHandler)
-		logger.Info("admin schema reload endpoint enabled with shared token authentication")
+		logger.Info("admin endpoints enabled with shared token authentication")
 	}
 	return middleware.LoggingMiddleware(logger)(adminHandler), nil
 }
 
-func buildRouter(cfg *config.Config, logger *logging.Logger, db *sql.DB, graphqlHandler http.Handler, adminHandler http.Handler, meterProvider *observability.MeterProvider) *http.ServeMux {
+func buildRouter(cfg *config.Config, logger *logging.Logger, health *healthState, graphqlHandler http.Handler, adminHandler http.Handler, meterProvider *observability.MeterProvider) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.Handle("/graphql", graphqlHandler)
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -632,9 +969,22 @@ func buildRouter(cfg *config.Config, logger *logging.Logger, db *sql.DB, graphql
 		http.NotFound(w, r)
 	})
 
-	mux.HandleFunc("/health", healthHandler(db, cfg.Server.HealthCheckTimeout))
-	if cfg.Server.Admin.SchemaReloadEnabled && adminHandler != nil {
-		mux.Handle("/admin/reload-schema", adminHandler)
+	mux.HandleFunc("/health", healthHandler(health.db, cfg.Server.HealthCheckTimeout))
+	mux.HandleFunc("/livez", livezHandler())
+	mux.HandleFunc("/readyz", readyzHandler(health))
+	if adminHandler != nil {
+		if cfg.Server.Admin.SchemaReloadEnabled {
+			mux.Handle("/admin/reload-schema", adminHandler)
+		}
+		if cfg.Server.Admin.ExplainEnabled {
+			mux.Handle("/admin/explain", adminHandler)
+		}
+		if cfg.Server.Admin.StatusEnabled {
+			mux.Handle("/admin/status", adminHandler)
+		}
+		if cfg.Server.Admin.DrainEnabled {
+			mux.Handle("/admin/drain", adminHandler)
+		}
 	}
 
 	if cfg.Observability.MetricsEnabled && meterProvider != nil {
@@ -694,7 +1044,7 @@ func httpRootSpanName(r *http.Request) string {
 
 func normalizeHTTPSpanRoute(rawPath string) string {
 	switch rawPath {
-	case "/", "/graphql", "/health", "/metrics", "/admin/reload-schema":
+	case "/", "/graphql", "/health", "/livez", "/readyz", "/metrics", "/admin/reload-schema", "/admin/explain", "/admin/status", "/admin/drain":
 		return rawPath
 	default:
 		return "/*"
@@ -730,6 +1080,8 @@ func buildServer(cfg *config.Config, logger *logging.Logger, handler http.Handle
 			KeyFile:           cfg.Server.TLSKeyFile,
 			SelfSignedCertDir: cfg.Server.TLSAutoCertDir,
 			SelfSignedHosts:   []string{"localhost", "127.0.0.1", "::1"},
+			ClientAuth:        tlscert.ClientAuthMode(cfg.Server.TLSClientAuth),
+			ClientCAFile:      cfg.Server.TLSClientCAFile,
 		}
 
 		var err error
@@ -745,6 +1097,7 @@ func buildServer(cfg *config.Config, logger *logging.Logger, handler http.Handle
 
 		logger.Info("TLS enabled",
 			slog.String("mode", cfg.Server.TLSMode),
+			slog.String("client_auth", string(tlsConfig.ClientAuth)),
 			slog.String("cert_source", tlsManager.Description()))
 	}
 
@@ -765,6 +1118,7 @@ func startServer(cfg *config.Config, logger *logging.Logger, srv *http.Server, s
 			slog.String("address", serverAddr),
 			slog.String("graphql_endpoint", "/graphql"),
 			slog.String("health_endpoint", "/health"),
+			slog.String("readiness_endpoint", "/readyz"),
 			slog.Int("graphql_max_depth", cfg.Server.GraphQLMaxDepth),
 			slog.String("log_level", cfg.Observability.Logging.Level),
 			slog.String("log_format", cfg.Observability.Logging.Format),
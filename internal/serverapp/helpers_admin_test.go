@@ -26,7 +26,7 @@ func TestBuildRouter_AdminRouteDisabledReturnsNotFound(t *testing.T) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 
-	mux := buildRouter(cfg, testLogger(), nil, graphqlHandler, adminHandler, nil)
+	mux := buildRouter(cfg, testLogger(), newHealthState(nil, nil, nil, time.Second, ""), graphqlHandler, adminHandler, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/admin/reload-schema", nil)
 	rec := httptest.NewRecorder()
@@ -53,7 +53,7 @@ func TestBuildRouter_AdminRouteEnabledInvokesHandler(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 	})
 
-	mux := buildRouter(cfg, testLogger(), nil, graphqlHandler, adminHandler, nil)
+	mux := buildRouter(cfg, testLogger(), newHealthState(nil, nil, nil, time.Second, ""), graphqlHandler, adminHandler, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/admin/reload-schema", nil)
 	rec := httptest.NewRecorder()
@@ -77,7 +77,7 @@ func TestBuildAdminHandler_TokenModeMissingHeaderUnauthorized(t *testing.T) {
 		},
 	}
 
-	adminHandler, err := buildAdminHandler(cfg, testLogger(), nil, nil)
+	adminHandler, err := buildAdminHandler(cfg, testLogger(), nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected buildAdminHandler error: %v", err)
 	}
@@ -104,7 +104,7 @@ func TestBuildAdminHandler_TokenModeValidHeaderReachesHandler(t *testing.T) {
 		},
 	}
 
-	adminHandler, err := buildAdminHandler(cfg, testLogger(), nil, nil)
+	adminHandler, err := buildAdminHandler(cfg, testLogger(), nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("unexpected buildAdminHandler error: %v", err)
 	}
@@ -133,7 +133,7 @@ func TestBuildAdminHandler_OIDCModeUsesOIDCMiddlewarePath(t *testing.T) {
 		},
 	}
 
-	_, err := buildAdminHandler(cfg, testLogger(), nil, nil)
+	_, err := buildAdminHandler(cfg, testLogger(), nil, nil, nil, nil, nil)
 	if err == nil {
 		t.Fatalf("expected OIDC setup error, got nil")
 	}
@@ -141,3 +141,86 @@ func TestBuildAdminHandler_OIDCModeUsesOIDCMiddlewarePath(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestBuildAdminHandler_ExplainOnlyDoesNotExposeReload(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			HealthCheckTimeout: time.Second,
+			Admin: config.AdminConfig{
+				ExplainEnabled: true,
+				AuthToken:      "secret-token",
+			},
+		},
+	}
+
+	adminHandler, err := buildAdminHandler(cfg, testLogger(), nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected buildAdminHandler error: %v", err)
+	}
+	graphqlHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux := buildRouter(cfg, testLogger(), newHealthState(nil, nil, nil, time.Second, ""), graphqlHandler, adminHandler, nil)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{name: "explain requires token", method: http.MethodPost, path: "/admin/explain", want: http.StatusUnauthorized},
+		{name: "explain rejects GET", method: http.MethodGet, path: "/admin/explain", token: "secret-token", want: http.StatusMethodNotAllowed},
+		{name: "explain rejects mutations", method: http.MethodPost, path: "/admin/explain", token: "secret-token", want: http.StatusBadRequest},
+		{name: "reload stays disabled", method: http.MethodPost, path: "/admin/reload-schema", token: "secret-token", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"query":"mutation { deleteUser(id: 1) { id } }"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.token != "" {
+				req.Header.Set("X-Admin-Token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("expected status %d, got %d (%s)", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestBuildAdminHandler_ExplainAppliesRequestValidation(t *testing.T) {
+	cfg := &config.Config{
+		Server: config.ServerConfig{
+			HealthCheckTimeout: time.Second,
+			Admin: config.AdminConfig{
+				ExplainEnabled: true,
+				AuthToken:      "secret-token",
+			},
+		},
+	}
+
+	adminHandler, err := buildAdminHandler(cfg, testLogger(), nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected buildAdminHandler error: %v", err)
+	}
+	mux := buildRouter(cfg, testLogger(), newHealthState(nil, nil, nil, time.Second, ""), http.NotFoundHandler(), adminHandler, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/explain", strings.NewReader(`{"query":"{ users { id } }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Token", "secret-token")
+	req.Header.Set("X-Timezone", "Not/AZone")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	// Without validation the request would reach the handler and fail with 503
+	// because no schema is loaded.
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d (%s)", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "X-Timezone") {
+		t.Fatalf("expected time zone validation error, got %s", rec.Body.String())
+	}
+}
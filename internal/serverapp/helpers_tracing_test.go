@@ -58,6 +58,8 @@ func TestNormalizeHTTPSpanRoute(t *testing.T) {
 		{name: "health", input: "/health", expected: "/health"},
 		{name: "metrics", input: "/metrics", expected: "/metrics"},
 		{name: "admin", input: "/admin/reload-schema", expected: "/admin/reload-schema"},
+		{name: "admin explain", input: "/admin/explain", expected: "/admin/explain"},
+		{name: "readyz", input: "/readyz", expected: "/readyz"},
 		{name: "root", input: "/", expected: "/"},
 		{name: "unknown", input: "/users/123", expected: "/*"},
 		{name: "empty", input: "", expected: "/*"},
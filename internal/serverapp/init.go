@@ -82,11 +82,20 @@ func (a *App) Init(ctx context.Context) error {
 		slog.Bool("dsn_present", a.dsnPresent),
 	)
 
+	certWatcher, err := buildCertificateWatcher(a.cfg, a.logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize certificate watcher: %w", err)
+	}
+	cleanup.push("certificate watcher", func(_ context.Context) error {
+		certWatcher.Stop()
+		return nil
+	})
+
 	var db *sql.DB
 	var dbStatsReg interface{ Unregister() error }
 	if err := runStartupPhase(startupCtx, startupSpanDBConnect, func(context.Context) error {
 		var connectErr error
-		db, dbStatsReg, connectErr = connectDB(a.cfg, a.logger)
+		db, dbStatsReg, connectErr = connectDB(a.cfg, a.logger, certWatcher)
 		if connectErr != nil {
 			return fmt.Errorf("failed to connect to database: %w", connectErr)
 		}
@@ -160,17 +169,50 @@ func (a *App) Init(ctx context.Context) error {
 		return manager.Wait(shutdownCtx)
 	})
 
-	graphqlHandler, err := buildGraphQLHandler(a.cfg, a.logger, manager, graphqlMetrics, securityMetrics, queryExecutor, availableRoles)
+	txSessions, err := buildTransactionRegistry(a.cfg, a.logger, queryExecutor)
+	if err != nil {
+		return fmt.Errorf("failed to initialize interactive transactions: %w", err)
+	}
+	if txSessions != nil {
+		cleanup.push("interactive transactions", func(shutdownCtx context.Context) error {
+			return txSessions.Close(shutdownCtx)
+		})
+	}
+
+	drainer, drainMetricsReg, err := buildDrainController(a.cfg, txSessions)
+	if err != nil {
+		return fmt.Errorf("failed to initialize drain state: %w", err)
+	}
+	if drainMetricsReg != nil {
+		cleanup.push("drain metrics", func(_ context.Context) error {
+			return drainMetricsReg.Unregister()
+		})
+	}
+
+	apiKeys, err := buildAPIKeyStore(a.cfg, a.logger)
+	if err != nil {
+		return fmt.Errorf("failed to load API keys: %w", err)
+	}
+	if apiKeys != nil {
+		cleanup.push("API key store", func(_ context.Context) error {
+			apiKeys.Stop()
+			return nil
+		})
+	}
+
+	graphqlHandler, err := buildGraphQLHandler(a.cfg, a.logger, manager, graphqlMetrics, securityMetrics, queryExecutor, availableRoles, txSessions, drainer, apiKeys)
 	if err != nil {
 		return fmt.Errorf("failed to initialize GraphQL handler: %w", err)
 	}
 
-	adminHandler, err := buildAdminHandler(a.cfg, a.logger, manager, securityMetrics)
+	health := newHealthState(db, manager, drainer, a.cfg.Server.HealthCheckTimeout, a.cfg.Server.TLSMode)
+	health.clientAuth = a.cfg.Server.TLSClientAuth
+	adminHandler, err := buildAdminHandler(a.cfg, a.logger, manager, securityMetrics, queryExecutor, availableRoles, health)
 	if err != nil {
 		return fmt.Errorf("failed to initialize admin handler: %w", err)
 	}
 
-	mux := buildRouter(a.cfg, a.logger, db, graphqlHandler, adminHandler, meterProvider)
+	mux := buildRouter(a.cfg, a.logger, health, graphqlHandler, adminHandler, meterProvider)
 	handler := wrapHTTPHandler(a.cfg, a.logger, mux)
 
 	serverAddr := fmt.Sprintf(":%d", a.cfg.Server.Port)
@@ -181,11 +223,14 @@ func (a *App) Init(ctx context.Context) error {
 	cleanup.push("HTTP server", func(shutdownCtx context.Context) error {
 		return srv.Shutdown(shutdownCtx)
 	})
+	health.setTLSManager(tlsManager)
 	if tlsManager != nil {
 		cleanup.push("TLS manager", func(_ context.Context) error {
 			return tlsManager.Shutdown()
 		})
+		certWatcher.Add(tlsManager.Reloadables()...)
 	}
+	certWatcher.Start()
 
 	a.stateMu.Lock()
 	a.meterProvider = meterProvider
@@ -202,6 +247,8 @@ func (a *App) Init(ctx context.Context) error {
 	a.schemaCancel = schemaCancel
 	a.graphqlHandler = graphqlHandler
 	a.adminHandler = adminHandler
+	a.health = health
+	a.drain = drainer
 	a.mux = mux
 	a.handler = handler
 	a.serverAddr = serverAddr
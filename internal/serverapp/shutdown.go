@@ -46,6 +46,11 @@ func (a *App) Shutdown(ctx context.Context) error {
 	}
 
 	a.shutdownOnce.Do(func() {
+		// Fail readiness and let in-flight mutations commit or roll back
+		// before connections and transactions are torn down. Drain logs
+		// its own outcome; cleanup proceeds either way.
+		_ = a.Drain(ctx)
+
 		a.stateMu.Lock()
 		cleanup := a.cleanup
 		a.started = false
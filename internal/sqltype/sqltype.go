@@ -38,6 +38,20 @@ const (
 	TypeUUID
 	// TypeVector represents TiDB vector values.
 	TypeVector
+	// TypeEmail represents text columns mapped to the Email scalar via configuration.
+	TypeEmail
+	// TypeURL represents text columns mapped to the URL scalar via configuration.
+	TypeURL
+	// TypeIPAddress represents INET6_ATON binary columns mapped via configuration.
+	TypeIPAddress
+	// TypeMoney represents decimal amounts paired with a currency column via configuration.
+	TypeMoney
+	// TypeDuration represents integer seconds or milliseconds mapped via configuration.
+	TypeDuration
+	// TypeTimestamp represents instants exposed as epoch milliseconds via configuration.
+	TypeTimestamp
+	// TypeGeometry represents spatial columns exposed as GeoJSON geometries.
+	TypeGeometry
 )
 
 // MapToGraphQL converts a SQL data type string to its corresponding GraphQL type category.
@@ -75,6 +89,10 @@ func MapToGraphQL(sqlType string) GraphQLType {
 		return TypeSet
 	case "VECTOR":
 		return TypeVector
+	// Spatial Data Types
+	case "GEOMETRY", "POINT", "LINESTRING", "POLYGON", "MULTIPOINT",
+		"MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		return TypeGeometry
 	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB":
 		return TypeBytes
 	// Date and Time Data Types
@@ -122,6 +140,20 @@ func (t GraphQLType) String() string {
 		return "UUID"
 	case TypeVector:
 		return "Vector"
+	case TypeEmail:
+		return "Email"
+	case TypeURL:
+		return "URL"
+	case TypeIPAddress:
+		return "IPAddress"
+	case TypeMoney:
+		return "Money"
+	case TypeDuration:
+		return "Duration"
+	case TypeTimestamp:
+		return "Timestamp"
+	case TypeGeometry:
+		return "GeoJSON"
 	default:
 		return "String"
 	}
@@ -156,6 +188,21 @@ func (t GraphQLType) FilterTypeName() string {
 		return "UUIDFilter"
 	case TypeVector:
 		return "StringFilter"
+	case TypeEmail:
+		return "EmailFilter"
+	case TypeURL:
+		return "URLFilter"
+	case TypeIPAddress:
+		return "IPAddressFilter"
+	case TypeMoney:
+		// Money filters compare the amount column.
+		return "DecimalFilter"
+	case TypeDuration:
+		return "DurationFilter"
+	case TypeTimestamp:
+		return "TimestampFilter"
+	case TypeGeometry:
+		return "GeoJSONFilter"
 	default:
 		// JSON and String both use StringFilter (JSON columns are skipped in WHERE)
 		return "StringFilter"
@@ -168,7 +215,14 @@ func (t GraphQLType) IsNumeric() bool {
 }
 
 // IsComparable returns true if the type can be used with MIN/MAX aggregations.
-// All types except JSON are comparable in SQL.
+// All types except JSON, vectors and geometries are comparable in SQL. Money,
+// Duration and Timestamp values need per-column conversion, so they are left
+// out of aggregates.
 func (t GraphQLType) IsComparable() bool {
-	return t != TypeJSON && t != TypeVector
+	switch t {
+	case TypeJSON, TypeVector, TypeMoney, TypeDuration, TypeTimestamp, TypeGeometry:
+		return false
+	default:
+		return true
+	}
 }
@@ -225,12 +225,20 @@ func TestGraphQLType_UUID(t *testing.T) {
 	assert.True(t, TypeUUID.IsComparable())
 }
 
+func TestMapToGraphQL_SpatialTypes(t *testing.T) {
+	spatialTypes := []string{"GEOMETRY", "point", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION", "geomcollection"}
+
+	for _, sqlType := range spatialTypes {
+		t.Run(sqlType, func(t *testing.T) {
+			assert.Equal(t, TypeGeometry, MapToGraphQL(sqlType))
+			assert.Equal(t, "GeoJSON", MapToGraphQL(sqlType).String())
+			assert.Equal(t, "GeoJSONFilter", MapToGraphQL(sqlType).FilterTypeName())
+		})
+	}
+}
+
 func TestMapToGraphQL_UnknownTypesDefaultToString(t *testing.T) {
 	unknownTypes := []string{
-		"GEOMETRY",
-		"POINT",
-		"LINESTRING",
-		"POLYGON",
 		"UNKNOWN_TYPE",
 		"",
 	}
@@ -251,9 +259,9 @@ func TestMapToGraphQL_NoFalsePositives(t *testing.T) {
 		expected GraphQLType
 	}{
 		// "POINT" should NOT match "int"
-		{"POINT", TypeString},
+		{"POINT", TypeGeometry},
 		// "MULTIPOINT" should NOT match "int"
-		{"MULTIPOINT", TypeString},
+		{"MULTIPOINT", TypeGeometry},
 		// "TINYINT" SHOULD match int
 		{"TINYINT", TypeInt},
 		{"VECTORIZE", TypeString},
@@ -339,6 +347,13 @@ func TestIsComparable(t *testing.T) {
 		{TypeSet, true},
 		{TypeBytes, true},
 		{TypeUUID, true},
+		{TypeEmail, true},
+		{TypeURL, true},
+		{TypeIPAddress, true},
+		{TypeMoney, false},
+		{TypeDuration, false},
+		{TypeTimestamp, false},
+		{TypeGeometry, false},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,110 @@
+package tlscert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// ClientAuthMode controls client certificate verification on the server.
+type ClientAuthMode string
+
+const (
+	// ClientAuthOff does not ask clients for a certificate.
+	ClientAuthOff ClientAuthMode = "off"
+	// ClientAuthRequest verifies a client certificate when one is presented
+	// but still accepts connections without one.
+	ClientAuthRequest ClientAuthMode = "request"
+	// ClientAuthRequire rejects the handshake unless the client presents a
+	// certificate signed by the client CA bundle.
+	ClientAuthRequire ClientAuthMode = "require"
+)
+
+// Enabled reports whether client certificates are verified at all.
+func (m ClientAuthMode) Enabled() bool {
+	return m == ClientAuthRequest || m == ClientAuthRequire
+}
+
+func (m ClientAuthMode) tlsClientAuth() (tls.ClientAuthType, error) {
+	switch m {
+	case "", ClientAuthOff:
+		return tls.NoClientCert, nil
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven, nil
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert, nil
+	default:
+		return tls.NoClientCert, fmt.Errorf("unsupported client auth mode: %s (valid modes: off, request, require)", m)
+	}
+}
+
+// clientAuthManager adds client certificate verification to the TLS config
+// of the wrapped manager.
+type clientAuthManager struct {
+	Manager
+	clientAuth tls.ClientAuthType
+	mode       ClientAuthMode
+	caFile     string
+	pool       *x509.CertPool
+}
+
+func withClientAuth(manager Manager, cfg Config) (Manager, error) {
+	clientAuth, err := cfg.ClientAuth.tlsClientAuth()
+	if err != nil {
+		return nil, err
+	}
+	if clientAuth == tls.NoClientCert {
+		return manager, nil
+	}
+	if cfg.ClientCAFile == "" {
+		return nil, fmt.Errorf("tls_client_ca_file is required when tls_client_auth=%s", cfg.ClientAuth)
+	}
+	pool, err := LoadCertPool(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client CA file: %w", err)
+	}
+	return &clientAuthManager{
+		Manager:    manager,
+		clientAuth: clientAuth,
+		mode:       cfg.ClientAuth,
+		caFile:     cfg.ClientCAFile,
+		pool:       pool,
+	}, nil
+}
+
+func (m *clientAuthManager) GetTLSConfig() (*tls.Config, error) {
+	tlsConfig, err := m.Manager.GetTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ClientAuth = m.clientAuth
+	tlsConfig.ClientCAs = m.pool
+	return tlsConfig, nil
+}
+
+func (m *clientAuthManager) Description() string {
+	return fmt.Sprintf("%s, client auth=%s (ca=%s)", m.Manager.Description(), m.mode, m.caFile)
+}
+
+func (m *clientAuthManager) Certificates() ([]CertificateInfo, error) {
+	certs, err := m.Manager.Certificates()
+	if err != nil {
+		return certs, err
+	}
+	caCerts, err := ReadCertificateInfo(m.caFile)
+	return append(certs, caCerts...), err
+}
+
+// LoadCertPool reads a PEM bundle into a certificate pool.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,55 @@
+package tlscert
+
+import (
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewManager_ClientAuth(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+	cfg := Config{Mode: CertModeSelfSigned, SelfSignedCertDir: dir}
+
+	manager, err := NewManager(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	tlsConfig, err := manager.GetTLSConfig()
+	if err != nil {
+		t.Fatalf("GetTLSConfig() error = %v", err)
+	}
+	if tlsConfig.ClientAuth != tls.NoClientCert {
+		t.Fatalf("client auth = %v, want none by default", tlsConfig.ClientAuth)
+	}
+
+	cfg.ClientAuth = ClientAuthRequire
+	if _, err := NewManager(cfg, logger); err == nil {
+		t.Fatal("expected error without client CA file")
+	}
+
+	// The self-signed server certificate doubles as a CA bundle here.
+	cfg.ClientCAFile = filepath.Join(dir, "server.crt")
+	manager, err = NewManager(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	tlsConfig, err = manager.GetTLSConfig()
+	if err != nil {
+		t.Fatalf("GetTLSConfig() error = %v", err)
+	}
+	if tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert || tlsConfig.ClientCAs == nil {
+		t.Fatalf("unexpected client auth config: %v", tlsConfig.ClientAuth)
+	}
+	certs, err := manager.Certificates()
+	if err != nil || len(certs) != 2 {
+		t.Fatalf("Certificates() = %d, %v; want server and client CA", len(certs), err)
+	}
+
+	cfg.ClientAuth = "optional"
+	if _, err := NewManager(cfg, logger); err == nil {
+		t.Fatal("expected error for unsupported client auth mode")
+	}
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type fileManager struct {
-	cfg    Config
-	logger *slog.Logger
+	cfg     Config
+	logger  *slog.Logger
+	keyPair *KeyPair
 }
 
 func newFileManager(cfg Config, logger *slog.Logger) (Manager, error) {
@@ -34,14 +35,15 @@ func newFileManager(cfg Config, logger *slog.Logger) (Manager, error) {
 		return nil, fmt.Errorf("insecure key file permissions: %w", err)
 	}
 
-	// Test load certificate to validate it
-	if _, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err != nil {
-		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	keyPair, err := LoadKeyPair("server", cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
 	}
 
 	return &fileManager{
-		cfg:    cfg,
-		logger: logger,
+		cfg:     cfg,
+		logger:  logger,
+		keyPair: keyPair,
 	}, nil
 }
 
@@ -53,17 +55,8 @@ func (m *fileManager) GetTLSConfig() (*tls.Config, error) {
 		CipherSuites: nil,
 	}
 
-	// Load certificate on each connection (allows cert rotation)
-	tlsConfig.GetCertificate = func(clientHello *tls.ClientHelloInfo) (*tls.Certificate, error) {
-		cert, err := tls.LoadX509KeyPair(m.cfg.CertFile, m.cfg.KeyFile)
-		if err != nil {
-			m.logger.Error("failed to reload certificate",
-				slog.String("cert_file", m.cfg.CertFile),
-				slog.String("error", err.Error()))
-			return nil, err
-		}
-		return &cert, nil
-	}
+	// Serve the in-memory pair; a Watcher swaps in rotated files.
+	tlsConfig.GetCertificate = m.keyPair.GetCertificate
 
 	return tlsConfig, nil
 }
@@ -72,6 +65,14 @@ func (m *fileManager) Description() string {
 	return fmt.Sprintf("file-based (cert=%s, key=%s)", m.cfg.CertFile, m.cfg.KeyFile)
 }
 
+func (m *fileManager) Certificates() ([]CertificateInfo, error) {
+	return ReadCertificateInfo(m.cfg.CertFile)
+}
+
+func (m *fileManager) Reloadables() []Reloadable {
+	return []Reloadable{m.keyPair}
+}
+
 func (m *fileManager) Shutdown() error {
 	return nil
 }
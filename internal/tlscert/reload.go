@@ -0,0 +1,358 @@
+package tlscert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Reloadable is certificate material that is kept in memory and swapped in
+// atomically when its files change on disk.
+type Reloadable interface {
+	// Source names the material in logs and metrics, e.g. "server" or "database_ca".
+	Source() string
+	// Reload re-reads the files if they changed since the last load. It reports
+	// whether new material was swapped in; on error the previous material stays active.
+	Reload() (bool, error)
+	// NotAfter returns the earliest expiry of the loaded certificates.
+	NotAfter() time.Time
+}
+
+// fileStamp identifies a file version without reading it. Kubernetes secret
+// volumes rotate by swapping a symlink, which os.Stat follows.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFiles(paths ...string) ([]fileStamp, error) {
+	stamps := make([]fileStamp, len(paths))
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
+
+func sameStamps(a, b []fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// KeyPair is a reloadable certificate and private key.
+type KeyPair struct {
+	source   string
+	certFile string
+	keyFile  string
+
+	mu     sync.Mutex
+	stamps []fileStamp
+
+	current atomic.Pointer[tls.Certificate]
+}
+
+// LoadKeyPair loads a certificate and key. The pair must load successfully.
+func LoadKeyPair(source, certFile, keyFile string) (*KeyPair, error) {
+	kp := &KeyPair{source: source, certFile: certFile, keyFile: keyFile}
+	if _, err := kp.load(); err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
+
+// Source implements Reloadable.
+func (k *KeyPair) Source() string {
+	return k.source
+}
+
+// Certificate returns the active certificate.
+func (k *KeyPair) Certificate() *tls.Certificate {
+	return k.current.Load()
+}
+
+// GetCertificate serves the active certificate to TLS servers.
+func (k *KeyPair) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return k.current.Load(), nil
+}
+
+// GetClientCertificate serves the active certificate to TLS clients.
+func (k *KeyPair) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return k.current.Load(), nil
+}
+
+// Reload implements Reloadable.
+func (k *KeyPair) Reload() (bool, error) {
+	return k.load()
+}
+
+// NotAfter implements Reloadable.
+func (k *KeyPair) NotAfter() time.Time {
+	cert := k.current.Load()
+	if cert == nil || cert.Leaf == nil {
+		return time.Time{}
+	}
+	return cert.Leaf.NotAfter
+}
+
+func (k *KeyPair) load() (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	stamps, err := statFiles(k.certFile, k.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat certificate files: %w", err)
+	}
+	if k.current.Load() != nil && sameStamps(stamps, k.stamps) {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(k.certFile, k.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	if cert.Leaf == nil && len(cert.Certificate) > 0 {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return false, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+	}
+	k.current.Store(&cert)
+	k.stamps = stamps
+	return true, nil
+}
+
+// CAPool is a reloadable CA bundle.
+type CAPool struct {
+	source string
+	path   string
+
+	mu     sync.Mutex
+	stamps []fileStamp
+
+	current  atomic.Pointer[x509.CertPool]
+	notAfter atomic.Pointer[time.Time]
+}
+
+// LoadCAPool loads a PEM CA bundle. The bundle must contain at least one certificate.
+func LoadCAPool(source, path string) (*CAPool, error) {
+	p := &CAPool{source: source, path: path}
+	if _, err := p.load(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Source implements Reloadable.
+func (p *CAPool) Source() string {
+	return p.source
+}
+
+// Pool returns the active certificate pool.
+func (p *CAPool) Pool() *x509.CertPool {
+	return p.current.Load()
+}
+
+// Reload implements Reloadable.
+func (p *CAPool) Reload() (bool, error) {
+	return p.load()
+}
+
+// NotAfter implements Reloadable.
+func (p *CAPool) NotAfter() time.Time {
+	if notAfter := p.notAfter.Load(); notAfter != nil {
+		return *notAfter
+	}
+	return time.Time{}
+}
+
+// VerifyConnection verifies the peer chain against the active pool. When
+// serverName is non-empty the leaf must also match it. Use it with
+// InsecureSkipVerify so rotated CAs apply to connections opened later.
+func (p *CAPool) VerifyConnection(serverName string) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.New("tls: server presented no certificate")
+		}
+		opts := x509.VerifyOptions{
+			Roots:         p.Pool(),
+			DNSName:       serverName,
+			Intermediates: x509.NewCertPool(),
+		}
+		for _, cert := range cs.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		_, err := cs.PeerCertificates[0].Verify(opts)
+		return err
+	}
+}
+
+func (p *CAPool) load() (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stamps, err := statFiles(p.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat CA bundle: %w", err)
+	}
+	if p.current.Load() != nil && sameStamps(stamps, p.stamps) {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	var notAfter time.Time
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse CA certificate: %w", err)
+		}
+		pool.AddCert(cert)
+		if notAfter.IsZero() || cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	if notAfter.IsZero() {
+		return false, fmt.Errorf("no certificates found in %s", p.path)
+	}
+	p.current.Store(pool)
+	p.notAfter.Store(&notAfter)
+	p.stamps = stamps
+	return true, nil
+}
+
+// Watcher polls reloadable material and swaps in new versions.
+type Watcher struct {
+	interval time.Duration
+	logger   *slog.Logger
+	onResult func(source string, err error)
+
+	mu    sync.Mutex
+	items []Reloadable
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+	started  atomic.Bool
+}
+
+// NewWatcher creates a watcher. onResult, when set, is called after every
+// reload attempt that found changed files or failed.
+func NewWatcher(interval time.Duration, logger *slog.Logger, onResult func(source string, err error)) *Watcher {
+	return &Watcher{
+		interval: interval,
+		logger:   logger,
+		onResult: onResult,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Add registers material to watch. It must be called before Start.
+func (w *Watcher) Add(items ...Reloadable) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, item := range items {
+		if item != nil {
+			w.items = append(w.items, item)
+		}
+	}
+}
+
+// Expiries returns the earliest certificate expiry per source.
+func (w *Watcher) Expiries() map[string]time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	expiries := make(map[string]time.Time, len(w.items))
+	for _, item := range w.items {
+		if notAfter := item.NotAfter(); !notAfter.IsZero() {
+			expiries[item.Source()] = notAfter
+		}
+	}
+	return expiries
+}
+
+// Start begins polling. A non-positive interval disables reloading; expiries
+// are still reported.
+func (w *Watcher) Start() {
+	if w.interval <= 0 || !w.started.CompareAndSwap(false, true) {
+		return
+	}
+	go w.loop()
+}
+
+// Stop ends polling and waits for the poll loop to exit. It is safe to call
+// multiple times and without Start.
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	if w.started.Load() {
+		<-w.done
+	}
+}
+
+func (w *Watcher) loop() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// Check reloads every watched item whose files changed.
+func (w *Watcher) Check() {
+	w.mu.Lock()
+	items := append([]Reloadable(nil), w.items...)
+	w.mu.Unlock()
+
+	for _, item := range items {
+		changed, err := item.Reload()
+		if err != nil {
+			if w.logger != nil {
+				w.logger.Error("certificate reload failed; keeping previous certificate",
+					slog.String("source", item.Source()),
+					slog.String("error", err.Error()),
+				)
+			}
+		} else if changed && w.logger != nil {
+			w.logger.Info("certificate reloaded",
+				slog.String("source", item.Source()),
+				slog.Time("not_after", item.NotAfter()),
+			)
+		}
+		if (changed || err != nil) && w.onResult != nil {
+			w.onResult(item.Source(), err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package tlscert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestPair generates a self-signed pair and backdates the files so a
+// rewrite within the same second is still seen as a change.
+func writeTestPair(t *testing.T, dir string, mtime time.Time, hosts ...string) (string, string) {
+	t.Helper()
+	certPath := filepath.Join(dir, "tls.crt")
+	keyPath := filepath.Join(dir, "tls.key")
+	if err := generateSelfSignedCert(certPath, keyPath, hosts); err != nil {
+		t.Fatalf("generateSelfSignedCert() error = %v", err)
+	}
+	for _, path := range []string{certPath, keyPath} {
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("Chtimes() error = %v", err)
+		}
+	}
+	return certPath, keyPath
+}
+
+func TestWatcherReloadsRotatedKeyPair(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestPair(t, dir, time.Now().Add(-time.Hour), "localhost")
+
+	kp, err := LoadKeyPair("server", certPath, keyPath)
+	if err != nil {
+		t.Fatalf("LoadKeyPair() error = %v", err)
+	}
+	first := kp.Certificate()
+
+	var results []error
+	watcher := NewWatcher(time.Minute, nil, func(source string, err error) {
+		if source != "server" {
+			t.Errorf("source = %q, want server", source)
+		}
+		results = append(results, err)
+	})
+	watcher.Add(kp)
+
+	watcher.Check()
+	if len(results) != 0 || kp.Certificate() != first {
+		t.Fatal("unchanged files must not trigger a reload")
+	}
+
+	writeTestPair(t, dir, time.Now(), "localhost")
+	watcher.Check()
+	if len(results) != 1 || results[0] != nil {
+		t.Fatalf("expected one successful reload, got %v", results)
+	}
+	rotated := kp.Certificate()
+	if rotated == first {
+		t.Fatal("expected rotated certificate to be swapped in")
+	}
+	served, _ := kp.GetCertificate(&tls.ClientHelloInfo{})
+	if served != rotated {
+		t.Fatal("GetCertificate must serve the rotated certificate")
+	}
+	if expiry := watcher.Expiries()["server"]; !expiry.Equal(rotated.Leaf.NotAfter) {
+		t.Fatalf("expiry = %v, want %v", expiry, rotated.Leaf.NotAfter)
+	}
+
+	if err := os.WriteFile(certPath, []byte("garbage"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	watcher.Check()
+	if len(results) != 2 || results[1] == nil {
+		t.Fatalf("expected a reload failure, got %v", results)
+	}
+	if kp.Certificate() != rotated {
+		t.Fatal("a failed reload must keep the previous certificate")
+	}
+}
+
+func TestCAPoolVerifyConnection(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestPair(t, dir, time.Now().Add(-time.Hour), "db.internal")
+	kp, err := LoadKeyPair("peer", certPath, keyPath)
+	if err != nil {
+		t.Fatalf("LoadKeyPair() error = %v", err)
+	}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{kp.Certificate().Leaf}}
+
+	pool, err := LoadCAPool("database_ca", certPath)
+	if err != nil {
+		t.Fatalf("LoadCAPool() error = %v", err)
+	}
+	if pool.NotAfter().IsZero() {
+		t.Fatal("expected CA expiry")
+	}
+	if err := pool.VerifyConnection("db.internal")(state); err != nil {
+		t.Fatalf("VerifyConnection() error = %v", err)
+	}
+	if err := pool.VerifyConnection("other.internal")(state); err == nil {
+		t.Fatal("expected hostname mismatch")
+	}
+
+	// Rotate the bundle to an unrelated CA: the old peer no longer verifies.
+	otherDir := t.TempDir()
+	otherCert, _ := writeTestPair(t, otherDir, time.Now(), "db.internal")
+	data, err := os.ReadFile(otherCert)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if err := os.WriteFile(certPath, data, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if changed, err := pool.Reload(); err != nil || !changed {
+		t.Fatalf("Reload() = %v, %v; want changed", changed, err)
+	}
+	if err := pool.VerifyConnection("")(state); err == nil {
+		t.Fatal("expected verification against the rotated bundle to fail")
+	}
+}
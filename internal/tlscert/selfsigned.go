@@ -88,6 +88,15 @@ func (m *selfSignedManager) Description() string {
 	return fmt.Sprintf("self-signed (cert=%s) - DEV ONLY", m.certPath)
 }
 
+func (m *selfSignedManager) Certificates() ([]CertificateInfo, error) {
+	return ReadCertificateInfo(m.certPath)
+}
+
+// Reloadables returns nothing: self-signed certificates are only regenerated at startup.
+func (m *selfSignedManager) Reloadables() []Reloadable {
+	return nil
+}
+
 func (m *selfSignedManager) Shutdown() error {
 	return nil
 }
@@ -3,8 +3,12 @@ package tlscert
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"log/slog"
+	"os"
+	"time"
 )
 
 // CertMode represents the certificate management mode
@@ -26,6 +30,10 @@ type Config struct {
 	// Self-signed mode
 	SelfSignedCertDir string
 	SelfSignedHosts   []string // "localhost", "127.0.0.1", etc.
+
+	// Client certificate verification (either mode)
+	ClientAuth   ClientAuthMode
+	ClientCAFile string
 }
 
 // Manager provides TLS certificate management
@@ -36,21 +44,81 @@ type Manager interface {
 	// Description returns a human-readable description of the cert source
 	Description() string
 
+	// Certificates describes the certificates currently on disk
+	Certificates() ([]CertificateInfo, error)
+
+	// Reloadables returns the material a Watcher should keep current
+	Reloadables() []Reloadable
+
 	// Shutdown performs cleanup (if needed)
 	Shutdown() error
 }
 
 // NewManager creates a certificate manager based on configuration
 func NewManager(cfg Config, logger *slog.Logger) (Manager, error) {
+	var (
+		manager Manager
+		err     error
+	)
 	switch cfg.Mode {
 	case CertModeFile:
-		return newFileManager(cfg, logger)
+		manager, err = newFileManager(cfg, logger)
 	case CertModeSelfSigned:
-		return newSelfSignedManager(cfg, logger)
+		manager, err = newSelfSignedManager(cfg, logger)
 	default:
 		return nil, fmt.Errorf("unsupported TLS certificate mode: %s (valid modes: file, selfsigned)", cfg.Mode)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return withClientAuth(manager, cfg)
 }
 
 // MinTLSVersion is the minimum supported TLS version for the server.
 const MinTLSVersion = tls.VersionTLS13
+
+// CertificateInfo summarizes a certificate for status reporting.
+type CertificateInfo struct {
+	Source    string    `json:"source"`
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	DNSNames  []string  `json:"dnsNames,omitempty"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+// ReadCertificateInfo parses every certificate in a PEM file.
+func ReadCertificateInfo(path string) ([]CertificateInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	var infos []CertificateInfo
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		infos = append(infos, CertificateInfo{
+			Source:    path,
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			DNSNames:  cert.DNSNames,
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no certificate found in %s", path)
+	}
+	return infos, nil
+}
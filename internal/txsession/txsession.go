@@ -0,0 +1,485 @@
+// Package txsession manages interactive transactions that span several HTTP requests.
+// Each session owns one database transaction (and therefore one pooled connection)
+// and is addressed by an opaque token returned from beginTransaction.
+package txsession
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"tidb-graphql/internal/dbexec"
+	"tidb-graphql/internal/logging"
+)
+
+// requestSavepoint is the savepoint that scopes a single mutation request inside
+// a session. Requests against one session are serialized, so a fixed name is safe.
+const requestSavepoint = "tidb_graphql_request"
+
+var (
+	// ErrNotFound is returned for unknown, expired or foreign tokens. Tokens owned by
+	// another subject are reported as not found so their existence is not disclosed.
+	ErrNotFound = errors.New("transaction not found or expired")
+	// ErrBusy is returned when another request is already running inside the transaction.
+	ErrBusy = errors.New("transaction is in use by another request")
+	// ErrLimitExceeded is returned when the per-subject or global session limit is reached.
+	ErrLimitExceeded = errors.New("too many open transactions")
+	// ErrNested is returned when a transaction is begun or ended from a request that
+	// is itself running inside a transaction session.
+	ErrNested = errors.New("request is already running inside a transaction; omit the transaction header")
+	// ErrClosed is returned after the registry has been shut down.
+	ErrClosed = errors.New("transaction registry is closed")
+)
+
+// Config controls session limits and timeouts.
+type Config struct {
+	// Executor begins the underlying database transactions.
+	Executor dbexec.QueryExecutor
+	// IdleTimeout rolls back sessions that have not been used for this long.
+	IdleTimeout time.Duration
+	// MaxLifetime is a hard deadline on each session, measured from begin.
+	MaxLifetime time.Duration
+	// MaxPerSubject caps open sessions per owner.
+	MaxPerSubject int
+	// MaxOpen caps open sessions across all owners.
+	MaxOpen int
+	// OwnerFromCtx identifies the caller. Sessions can only be used by the owner
+	// that began them. When nil, all callers share a single owner.
+	OwnerFromCtx func(context.Context) string
+	Logger       *logging.Logger
+}
+
+type session struct {
+	token     string
+	owner     string
+	tx        dbexec.TxExecutor
+	txCtx     context.Context
+	cancel    context.CancelFunc
+	expiresAt time.Time
+	lastUsed  time.Time
+	busy      bool
+}
+
+// Registry tracks open sessions and rolls back the ones that expire or leak.
+type Registry struct {
+	cfg      Config
+	now      func() time.Time
+	mu       sync.Mutex
+	sessions map[string]*session
+	perOwner map[string]int
+	// pending counts sessions reserved against the limits whose BeginTx is in flight.
+	pending  int
+	closed   bool
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New creates a registry and starts its background reaper.
+func New(cfg Config) (*Registry, error) {
+	if cfg.Executor == nil {
+		return nil, fmt.Errorf("transaction registry requires an executor")
+	}
+	if cfg.IdleTimeout <= 0 || cfg.MaxLifetime <= 0 {
+		return nil, fmt.Errorf("transaction registry requires positive idle timeout and max lifetime")
+	}
+	if cfg.MaxPerSubject <= 0 || cfg.MaxOpen <= 0 {
+		return nil, fmt.Errorf("transaction registry requires positive session limits")
+	}
+	r := &Registry{
+		cfg:      cfg,
+		now:      time.Now,
+		sessions: make(map[string]*session),
+		perOwner: make(map[string]int),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go r.reapLoop(reapInterval(cfg.IdleTimeout))
+	return r, nil
+}
+
+func reapInterval(idle time.Duration) time.Duration {
+	interval := idle / 2
+	if interval > 5*time.Second {
+		interval = 5 * time.Second
+	}
+	if interval < 10*time.Millisecond {
+		interval = 10 * time.Millisecond
+	}
+	return interval
+}
+
+func (r *Registry) owner(ctx context.Context) string {
+	if r.cfg.OwnerFromCtx == nil {
+		return ""
+	}
+	return r.cfg.OwnerFromCtx(ctx)
+}
+
+// Begin opens a new session for the caller and returns its token and hard deadline.
+func (r *Registry) Begin(ctx context.Context) (string, time.Time, error) {
+	if ActiveToken(ctx) != "" {
+		return "", time.Time{}, ErrNested
+	}
+	owner := r.owner(ctx)
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return "", time.Time{}, ErrClosed
+	}
+	if len(r.sessions)+r.pending >= r.cfg.MaxOpen {
+		r.mu.Unlock()
+		return "", time.Time{}, fmt.Errorf("%w: server limit of %d reached", ErrLimitExceeded, r.cfg.MaxOpen)
+	}
+	if r.perOwner[owner] >= r.cfg.MaxPerSubject {
+		r.mu.Unlock()
+		return "", time.Time{}, fmt.Errorf("%w: limit of %d per subject reached", ErrLimitExceeded, r.cfg.MaxPerSubject)
+	}
+	r.perOwner[owner]++
+	r.pending++
+	r.mu.Unlock()
+
+	unreserve := func() {
+		r.mu.Lock()
+		r.pending--
+		r.releaseOwnerLocked(owner)
+		r.mu.Unlock()
+	}
+
+	token, err := newToken()
+	if err != nil {
+		unreserve()
+		return "", time.Time{}, err
+	}
+
+	// The transaction outlives the beginTransaction request, so it must not inherit
+	// that request's cancellation. Its own deadline makes database/sql roll it back
+	// even if the reaper is late.
+	txCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.cfg.MaxLifetime)
+	tx, err := r.cfg.Executor.BeginTx(txCtx)
+	if err != nil {
+		cancel()
+		unreserve()
+		return "", time.Time{}, err
+	}
+
+	now := r.now()
+	s := &session{
+		token:     token,
+		owner:     owner,
+		tx:        tx,
+		txCtx:     txCtx,
+		cancel:    cancel,
+		expiresAt: now.Add(r.cfg.MaxLifetime),
+		lastUsed:  now,
+	}
+
+	r.mu.Lock()
+	r.pending--
+	if r.closed {
+		r.releaseOwnerLocked(owner)
+		r.mu.Unlock()
+		r.finish(s, false)
+		return "", time.Time{}, ErrClosed
+	}
+	r.sessions[token] = s
+	r.mu.Unlock()
+
+	return token, s.expiresAt, nil
+}
+
+// Commit commits the session's transaction and forgets the token.
+func (r *Registry) Commit(ctx context.Context, token string) error {
+	s, err := r.take(ctx, token)
+	if err != nil {
+		return err
+	}
+	return r.finish(s, true)
+}
+
+// Rollback rolls back the session's transaction and forgets the token.
+func (r *Registry) Rollback(ctx context.Context, token string) error {
+	s, err := r.take(ctx, token)
+	if err != nil {
+		return err
+	}
+	return r.finish(s, false)
+}
+
+// take removes an idle session owned by the caller from the registry.
+func (r *Registry) take(ctx context.Context, token string) (*session, error) {
+	if ActiveToken(ctx) != "" {
+		return nil, ErrNested
+	}
+	owner := r.owner(ctx)
+
+	r.mu.Lock()
+	s, err := r.lookupLocked(token, owner)
+	if err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	if s.busy {
+		r.mu.Unlock()
+		return nil, ErrBusy
+	}
+	r.removeLocked(s)
+	r.mu.Unlock()
+	return s, nil
+}
+
+// Acquire leases the session for one request. The caller must call Lease.Release
+// when the request completes; until then other requests for the token get ErrBusy.
+func (r *Registry) Acquire(ctx context.Context, token string) (*Lease, error) {
+	owner := r.owner(ctx)
+
+	r.mu.Lock()
+	s, err := r.lookupLocked(token, owner)
+	if err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	if s.busy {
+		r.mu.Unlock()
+		return nil, ErrBusy
+	}
+	s.busy = true
+	r.mu.Unlock()
+
+	return &Lease{registry: r, session: s}, nil
+}
+
+// lookupLocked returns a live session for the owner, expiring it first if needed.
+func (r *Registry) lookupLocked(token, owner string) (*session, error) {
+	s, ok := r.sessions[token]
+	if !ok || s.owner != owner {
+		return nil, ErrNotFound
+	}
+	if !s.busy && r.expiredLocked(s, r.now()) {
+		r.removeLocked(s)
+		go r.finish(s, false)
+		return nil, ErrNotFound
+	}
+	return s, nil
+}
+
+func (r *Registry) expiredLocked(s *session, now time.Time) bool {
+	return !now.Before(s.expiresAt) || now.Sub(s.lastUsed) >= r.cfg.IdleTimeout
+}
+
+func (r *Registry) removeLocked(s *session) {
+	delete(r.sessions, s.token)
+	r.releaseOwnerLocked(s.owner)
+}
+
+func (r *Registry) releaseOwnerLocked(owner string) {
+	r.perOwner[owner]--
+	if r.perOwner[owner] <= 0 {
+		delete(r.perOwner, owner)
+	}
+}
+
+// finish ends a session that has already been removed from the registry.
+func (r *Registry) finish(s *session, commit bool) error {
+	defer s.cancel()
+	if commit {
+		if err := s.tx.Commit(); err != nil {
+			if errors.Is(err, sql.ErrTxDone) {
+				return ErrNotFound
+			}
+			return err
+		}
+		return nil
+	}
+	if err := s.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	return nil
+}
+
+// Open returns the number of open sessions.
+func (r *Registry) Open() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.sessions)
+}
+
+func (r *Registry) reapLoop(interval time.Duration) {
+	defer close(r.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.reap()
+		}
+	}
+}
+
+// reap rolls back sessions that are past their deadline or idle timeout.
+// Sessions leased by an in-flight request are left for the next pass.
+func (r *Registry) reap() int {
+	now := r.now()
+	var expired []*session
+	r.mu.Lock()
+	for _, s := range r.sessions {
+		if s.busy || !r.expiredLocked(s, now) {
+			continue
+		}
+		r.removeLocked(s)
+		expired = append(expired, s)
+	}
+	r.mu.Unlock()
+
+	for _, s := range expired {
+		if err := r.finish(s, false); err != nil && r.cfg.Logger != nil {
+			r.cfg.Logger.Warn("failed to roll back expired transaction",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	if len(expired) > 0 && r.cfg.Logger != nil {
+		r.cfg.Logger.Info("rolled back expired interactive transactions",
+			slog.Int("count", len(expired)),
+		)
+	}
+	return len(expired)
+}
+
+// Close stops the reaper and rolls back every open session, including ones that
+// are currently leased. It is safe to call multiple times.
+func (r *Registry) Close(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	r.mu.Lock()
+	r.closed = true
+	sessions := make([]*session, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		r.removeLocked(s)
+		sessions = append(sessions, s)
+	}
+	r.mu.Unlock()
+
+	var errs error
+	for _, s := range sessions {
+		errs = errors.Join(errs, r.finish(s, false))
+	}
+	return errs
+}
+
+// Lease is a single request's exclusive use of a session.
+type Lease struct {
+	registry *Registry
+	session  *session
+	once     sync.Once
+}
+
+// Token returns the session token.
+func (l *Lease) Token() string {
+	return l.session.token
+}
+
+// RequestTx returns the executor a request should use. Mutation requests are
+// wrapped in a savepoint so a failed mutation only undoes its own writes; its
+// Commit releases the savepoint and Rollback rolls back to it. For other requests
+// Commit and Rollback are no-ops, leaving the session transaction open.
+func (l *Lease) RequestTx(ctx context.Context, mutation bool) (dbexec.TxExecutor, error) {
+	rt := &requestTx{tx: l.session.tx, ctx: l.session.txCtx}
+	if !mutation {
+		return rt, nil
+	}
+	if _, err := l.session.tx.ExecContext(ctx, "SAVEPOINT "+requestSavepoint); err != nil {
+		return nil, err
+	}
+	rt.savepoint = requestSavepoint
+	return rt, nil
+}
+
+// Release returns the session to the registry. When the request could not restore
+// a known state (failed is true), the whole session is rolled back instead.
+func (l *Lease) Release(failed bool) {
+	l.once.Do(func() {
+		r := l.registry
+		s := l.session
+		r.mu.Lock()
+		s.busy = false
+		s.lastUsed = r.now()
+		_, stillOpen := r.sessions[s.token]
+		if failed && stillOpen {
+			r.removeLocked(s)
+		}
+		r.mu.Unlock()
+		if failed && stillOpen {
+			_ = r.finish(s, false)
+		}
+	})
+}
+
+// requestTx scopes one request inside a session transaction.
+type requestTx struct {
+	tx        dbexec.TxExecutor
+	ctx       context.Context
+	savepoint string
+}
+
+func (t *requestTx) QueryContext(ctx context.Context, query string, args ...any) (dbexec.Rows, error) {
+	return t.tx.QueryContext(ctx, query, args...)
+}
+
+func (t *requestTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+func (t *requestTx) Commit() error {
+	if t.savepoint == "" {
+		return nil
+	}
+	_, err := t.tx.ExecContext(t.ctx, "RELEASE SAVEPOINT "+t.savepoint)
+	return err
+}
+
+func (t *requestTx) Rollback() error {
+	if t.savepoint == "" {
+		return nil
+	}
+	_, err := t.tx.ExecContext(t.ctx, "ROLLBACK TO SAVEPOINT "+t.savepoint)
+	return err
+}
+
+type activeTokenKey struct{}
+
+// WithActiveToken marks ctx as running inside the session identified by token.
+func WithActiveToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, activeTokenKey{}, token)
+}
+
+// ActiveToken returns the session token the request is running inside, if any.
+func ActiveToken(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	token, _ := ctx.Value(activeTokenKey{}).(string)
+	return token
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate transaction token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,276 @@
+package txsession
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+
+	"tidb-graphql/internal/dbexec"
+)
+
+type ownerKey struct{}
+
+func withOwner(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+func newTestRegistry(t *testing.T, cfg Config) (*Registry, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	cfg.Executor = dbexec.NewStandardExecutor(db)
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = time.Hour
+	}
+	if cfg.MaxLifetime == 0 {
+		cfg.MaxLifetime = time.Hour
+	}
+	if cfg.MaxPerSubject == 0 {
+		cfg.MaxPerSubject = 2
+	}
+	if cfg.MaxOpen == 0 {
+		cfg.MaxOpen = 4
+	}
+	cfg.OwnerFromCtx = func(ctx context.Context) string {
+		owner, _ := ctx.Value(ownerKey{}).(string)
+		return owner
+	}
+	registry, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = registry.Close(context.Background()) })
+	return registry, mock
+}
+
+func TestRegistry_MutationRequestUsesSavepointThenCommit(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{})
+	ctx := withOwner(context.Background(), "alice")
+
+	mock.ExpectBegin()
+	token, expiresAt, err := registry.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if token == "" || expiresAt.IsZero() {
+		t.Fatalf("expected token and deadline, got %q %v", token, expiresAt)
+	}
+
+	lease, err := registry.Acquire(ctx, token)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	mock.ExpectExec("SAVEPOINT tidb_graphql_request").WillReturnResult(sqlmock.NewResult(0, 0))
+	tx, err := lease.RequestTx(ctx, true)
+	if err != nil {
+		t.Fatalf("RequestTx() error = %v", err)
+	}
+	mock.ExpectExec("UPDATE t SET v = 1").WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err := tx.ExecContext(ctx, "UPDATE t SET v = 1"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT tidb_graphql_request").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("request Rollback() error = %v", err)
+	}
+	lease.Release(false)
+
+	mock.ExpectCommit()
+	if err := registry.Commit(ctx, token); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if err := registry.Commit(ctx, token); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after commit, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRegistry_QueryRequestDoesNotEndTransaction(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{})
+	ctx := withOwner(context.Background(), "alice")
+
+	mock.ExpectBegin()
+	token, _, err := registry.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	lease, err := registry.Acquire(ctx, token)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	tx, err := lease.RequestTx(ctx, false)
+	if err != nil {
+		t.Fatalf("RequestTx() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("request Commit() error = %v", err)
+	}
+	lease.Release(false)
+	if registry.Open() != 1 {
+		t.Fatalf("expected session to stay open, got %d", registry.Open())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRegistry_AccessRules(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{})
+	alice := withOwner(context.Background(), "alice")
+	bob := withOwner(context.Background(), "bob")
+
+	mock.ExpectBegin()
+	token, _, err := registry.Begin(alice)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	if _, err := registry.Acquire(bob, token); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected foreign owner to get ErrNotFound, got %v", err)
+	}
+	if err := registry.Rollback(bob, token); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected foreign rollback to get ErrNotFound, got %v", err)
+	}
+
+	lease, err := registry.Acquire(alice, token)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if _, err := registry.Acquire(alice, token); !errors.Is(err, ErrBusy) {
+		t.Fatalf("expected concurrent acquire to get ErrBusy, got %v", err)
+	}
+	if err := registry.Commit(alice, token); !errors.Is(err, ErrBusy) {
+		t.Fatalf("expected commit of leased session to get ErrBusy, got %v", err)
+	}
+	if err := registry.Commit(WithActiveToken(alice, token), token); !errors.Is(err, ErrNested) {
+		t.Fatalf("expected commit from inside the session to get ErrNested, got %v", err)
+	}
+	lease.Release(false)
+
+	mock.ExpectRollback()
+	if err := registry.Rollback(alice, token); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRegistry_Limits(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{MaxPerSubject: 1, MaxOpen: 2})
+	alice := withOwner(context.Background(), "alice")
+	bob := withOwner(context.Background(), "bob")
+	carol := withOwner(context.Background(), "carol")
+
+	mock.ExpectBegin()
+	if _, _, err := registry.Begin(alice); err != nil {
+		t.Fatalf("Begin(alice) error = %v", err)
+	}
+	if _, _, err := registry.Begin(alice); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected per-subject limit, got %v", err)
+	}
+	mock.ExpectBegin()
+	if _, _, err := registry.Begin(bob); err != nil {
+		t.Fatalf("Begin(bob) error = %v", err)
+	}
+	if _, _, err := registry.Begin(carol); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected global limit, got %v", err)
+	}
+}
+
+func TestRegistry_FailedBeginReleasesReservation(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{MaxPerSubject: 1})
+	ctx := withOwner(context.Background(), "alice")
+
+	mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+	if _, _, err := registry.Begin(ctx); err == nil {
+		t.Fatal("expected begin error")
+	}
+	mock.ExpectBegin()
+	if _, _, err := registry.Begin(ctx); err != nil {
+		t.Fatalf("expected reservation to be released, got %v", err)
+	}
+}
+
+func TestRegistry_ReapsIdleAndExpiredSessions(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{IdleTimeout: 30 * time.Second, MaxLifetime: time.Minute})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+	ctx := withOwner(context.Background(), "alice")
+
+	mock.ExpectBegin()
+	idle, _, err := registry.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	mock.ExpectBegin()
+	active, _, err := registry.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	now = now.Add(20 * time.Second)
+	lease, err := registry.Acquire(ctx, active)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	lease.Release(false)
+
+	now = now.Add(15 * time.Second)
+	mock.ExpectRollback()
+	if reaped := registry.reap(); reaped != 1 {
+		t.Fatalf("expected idle session to be reaped, got %d", reaped)
+	}
+	if _, err := registry.Acquire(ctx, idle); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected reaped session to be gone, got %v", err)
+	}
+
+	// Keep the remaining session busy past its idle timeout: leased sessions are
+	// never reaped mid-request, but max lifetime still applies afterwards.
+	lease, err = registry.Acquire(ctx, active)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	now = now.Add(time.Minute)
+	if reaped := registry.reap(); reaped != 0 {
+		t.Fatalf("expected leased session to be kept, got %d reaped", reaped)
+	}
+	lease.Release(false)
+	mock.ExpectRollback()
+	if reaped := registry.reap(); reaped != 1 {
+		t.Fatalf("expected expired session to be reaped, got %d", reaped)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestRegistry_CloseRollsBackOpenSessions(t *testing.T) {
+	registry, mock := newTestRegistry(t, Config{})
+	ctx := withOwner(context.Background(), "alice")
+
+	mock.ExpectBegin()
+	if _, _, err := registry.Begin(ctx); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	mock.ExpectRollback()
+	if err := registry.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, _, err := registry.Begin(ctx); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed after close, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
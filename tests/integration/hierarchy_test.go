@@ -0,0 +1,130 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"tidb-graphql/internal/testutil/tidbcloud"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHierarchyFields_SelfReferencingCategories(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testDB := tidbcloud.NewTestDB(t)
+	testDB.LoadSchema(t, "../fixtures/filtering_schema.sql")
+	testDB.LoadFixtures(t, "../fixtures/filtering_seed.sql")
+
+	schema := buildGraphQLSchema(t, testDB)
+
+	query := `
+		{
+			laptops: category(id: "` + nodeIDForTable("categories", 3) + `") {
+				ancestors {
+					depth
+					node { slug }
+				}
+				path { slug }
+			}
+			electronics: category(id: "` + nodeIDForTable("categories", 1) + `") {
+				children: descendants(maxDepth: 1) {
+					depth
+					node { slug }
+				}
+				all: descendants {
+					depth
+					node { slug }
+				}
+			}
+		}
+	`
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Context:       context.Background(),
+	})
+	require.Empty(t, result.Errors, "Query should not return errors")
+
+	data := result.Data.(map[string]interface{})
+	laptops := data["laptops"].(map[string]interface{})
+	assert.Equal(t, []string{"computers", "electronics"}, hierarchyEdgeSlugs(t, laptops["ancestors"]))
+	assert.Equal(t, []int{1, 2}, hierarchyEdgeDepths(t, laptops["ancestors"]))
+
+	var path []string
+	for _, row := range laptops["path"].([]interface{}) {
+		path = append(path, row.(map[string]interface{})["slug"].(string))
+	}
+	assert.Equal(t, []string{"electronics", "computers", "laptops"}, path)
+
+	electronics := data["electronics"].(map[string]interface{})
+	assert.Equal(t, []string{"computers", "phones"}, hierarchyEdgeSlugs(t, electronics["children"]))
+	assert.Equal(t, []string{"computers", "phones", "laptops", "desktops"}, hierarchyEdgeSlugs(t, electronics["all"]))
+	assert.Equal(t, []int{1, 1, 2, 2}, hierarchyEdgeDepths(t, electronics["all"]))
+}
+
+func TestHierarchyFields_StopsOnCycles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	testDB := tidbcloud.NewTestDB(t)
+	testDB.LoadSchema(t, "../fixtures/filtering_schema.sql")
+	testDB.LoadFixtures(t, "../fixtures/filtering_seed.sql")
+
+	// electronics -> computers -> electronics
+	_, err := testDB.DB.Exec("UPDATE categories SET parent_id = 2 WHERE id = 1")
+	require.NoError(t, err)
+
+	schema := buildGraphQLSchema(t, testDB)
+
+	query := `
+		{
+			category(id: "` + nodeIDForTable("categories", 1) + `") {
+				ancestors(maxDepth: 50) {
+					depth
+					node { slug }
+				}
+			}
+		}
+	`
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		Context:       context.Background(),
+	})
+	require.Empty(t, result.Errors, "Query should not return errors")
+
+	category := result.Data.(map[string]interface{})["category"].(map[string]interface{})
+	assert.Equal(t, []string{"computers"}, hierarchyEdgeSlugs(t, category["ancestors"]))
+}
+
+func hierarchyEdgeSlugs(t *testing.T, value interface{}) []string {
+	t.Helper()
+	edges, ok := value.([]interface{})
+	require.True(t, ok, "expected hierarchy edge list, got %T", value)
+	slugs := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		node := edge.(map[string]interface{})["node"].(map[string]interface{})
+		slugs = append(slugs, node["slug"].(string))
+	}
+	return slugs
+}
+
+func hierarchyEdgeDepths(t *testing.T, value interface{}) []int {
+	t.Helper()
+	edges, ok := value.([]interface{})
+	require.True(t, ok, "expected hierarchy edge list, got %T", value)
+	depths := make([]int, 0, len(edges))
+	for _, edge := range edges {
+		depths = append(depths, edge.(map[string]interface{})["depth"].(int))
+	}
+	return depths
+}